@@ -0,0 +1,388 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// tmpExt is the extension of the files being written, which are renamed once complete
+const tmpExt = ".tmp"
+
+// BackupOptions are the options of a chunked backup
+type BackupOptions struct {
+	// Compression is the compression of the chunk files
+	Compression Compression
+	// ChunkSize is the number of the blocks per chunk file
+	ChunkSize uint64
+}
+
+// CreateChunkedBackup fetches the blocks of the range via gRPC and saves them in the compressed
+// chunk files of the backup directory, described by its manifest. If the directory already holds
+// an incomplete backup of the same range, the backup is resumed after its last completed chunk
+func CreateChunkedBackup(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outDir string,
+	opts BackupOptions,
+) (uint64, uint64, error) {
+	if opts.ChunkSize == 0 {
+		return 0, 0, ErrInvalidChunkSize
+	}
+
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		<-signalCh
+		logger.Info("Caught termination signal, shutting down...")
+		cancelFn()
+	}()
+
+	clt := proto.NewSystemClient(conn)
+
+	manifest, err := openManifest(ctx, clt, logger, outDir, from, to, opts)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !manifest.complete() {
+		stream, err := clt.Export(ctx, &proto.ExportRequest{
+			From: manifest.next(),
+			To:   manifest.To,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if err := writeChunks(stream, logger, outDir, manifest); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return manifest.From, manifest.To, nil
+}
+
+// openManifest returns the manifest of the backup being resumed in the directory,
+// or creates the manifest of a new backup
+func openManifest(
+	ctx context.Context,
+	clt proto.SystemClient,
+	logger hclog.Logger,
+	dir string,
+	from uint64,
+	to *uint64,
+	opts BackupOptions,
+) (*Manifest, error) {
+	manifest, err := readManifest(dir)
+	if err == nil {
+		if err := checkResumable(dir, manifest, from, to, opts); err != nil {
+			return nil, err
+		}
+
+		if !manifest.complete() {
+			if err := checkResumedHead(ctx, clt, manifest); err != nil {
+				return nil, err
+			}
+		}
+
+		logger.Info("Resuming backup", "from", manifest.From, "to", manifest.To, "next", manifest.next())
+
+		return manifest, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("%w: %s is not empty and has no manifest", ErrBackupMismatch, dir)
+	}
+
+	reqTo, reqToHash, err := determineTo(ctx, clt, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if from > reqTo {
+		return nil, fmt.Errorf("the beginning height %d is above the latest block %d", from, reqTo)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	manifest = &Manifest{
+		Version:     ManifestVersion,
+		Compression: opts.Compression,
+		ChunkSize:   opts.ChunkSize,
+		From:        from,
+		To:          reqTo,
+		ToHash:      reqToHash,
+		Chunks:      []*Chunk{},
+	}
+
+	if err := writeManifest(dir, manifest); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Wrote manifest to backup", "latest", reqTo, "hash", reqToHash)
+
+	return manifest, nil
+}
+
+// checkResumable checks that the backup in the directory is the requested one, that its chunk files
+// are complete and removes the chunk file which was being written when the backup stopped
+func checkResumable(dir string, manifest *Manifest, from uint64, to *uint64, opts BackupOptions) error {
+	if manifest.From != from || (to != nil && *to != manifest.To) {
+		return fmt.Errorf("%w: blocks %d-%d are backed up", ErrBackupMismatch, manifest.From, manifest.To)
+	}
+
+	if manifest.Compression != opts.Compression || manifest.ChunkSize != opts.ChunkSize {
+		return fmt.Errorf("%w: compression %s, chunk size %d", ErrBackupMismatch, manifest.Compression, manifest.ChunkSize)
+	}
+
+	for _, chunk := range manifest.Chunks {
+		info, err := os.Stat(filepath.Join(dir, chunk.File))
+		if err != nil {
+			return err
+		}
+
+		if uint64(info.Size()) != chunk.Size {
+			return fmt.Errorf("%w: %s has %d bytes, expected %d", ErrChecksumMismatch, chunk.File, info.Size(), chunk.Size)
+		}
+	}
+
+	partials, err := filepath.Glob(filepath.Join(dir, "*"+tmpExt))
+	if err != nil {
+		return err
+	}
+
+	for _, partial := range partials {
+		if err := os.Remove(partial); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkResumedHead checks that the node still has the last block of the backup being resumed,
+// so that the blocks exported now belong to the same chain as the completed chunks
+func checkResumedHead(ctx context.Context, clt proto.SystemClient, manifest *Manifest) error {
+	resp, err := clt.BlockByNumber(ctx, &proto.BlockByNumberRequest{Number: manifest.To})
+	if err != nil {
+		return fmt.Errorf("failed to get the last block %d of the backup: %w", manifest.To, err)
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(resp.Data); err != nil {
+		return err
+	}
+
+	if hash := block.Hash(); hash != manifest.ToHash {
+		return fmt.Errorf("%w: the node has block %s at %d, the backup ends with block %s",
+			ErrBackupMismatch, hash, manifest.To, manifest.ToHash)
+	}
+
+	return nil
+}
+
+// writeChunks writes the blocks of the export stream to the chunk files, and adds every
+// completed chunk to the manifest. The blocks of the chunk being written when the stream
+// fails are dropped, so that the backup can be resumed from the last completed chunk
+func writeChunks(stream proto.System_ExportClient, logger hclog.Logger, dir string, manifest *Manifest) error {
+	writer := &chunkWriter{dir: dir, manifest: manifest}
+	next := manifest.next()
+
+	for next <= manifest.To {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			break
+		}
+
+		if err != nil {
+			return errors.Join(err, writer.abort())
+		}
+
+		blocks := newBlockStream(bytes.NewReader(event.Data))
+
+		for number := event.From; number <= event.To && next <= manifest.To; number++ {
+			size, err := blocks.loadRLPArray()
+			if err != nil {
+				return errors.Join(err, writer.abort())
+			}
+
+			if size == 0 || number != next {
+				return errors.Join(fmt.Errorf("unexpected block %d in the export stream, expected %d", number, next),
+					writer.abort())
+			}
+
+			if err := writer.append(number, blocks.buffer[:size]); err != nil {
+				return errors.Join(err, writer.abort())
+			}
+
+			next++
+		}
+
+		logger.Info(
+			fmt.Sprintf("%d blocks are written", event.To-event.From+1),
+			"next", next,
+			"to", manifest.To,
+			"chunks", len(manifest.Chunks),
+		)
+	}
+
+	if err := writer.abort(); err != nil {
+		return err
+	}
+
+	if !manifest.complete() {
+		return fmt.Errorf("%w: blocks up to %d are backed up, run the backup again to resume",
+			ErrIncompleteBackup, manifest.next()-1)
+	}
+
+	return nil
+}
+
+// chunkWriter writes the blocks to the chunk files of the backup
+type chunkWriter struct {
+	dir      string
+	manifest *Manifest
+
+	// the chunk being written
+	chunk      *Chunk
+	end        uint64
+	file       *os.File
+	hasher     hash.Hash
+	compressor io.WriteCloser
+}
+
+// append writes the RLP encoded block to the current chunk, and completes the chunk with its last block
+func (w *chunkWriter) append(number uint64, data []byte) error {
+	if w.chunk == nil {
+		if err := w.open(number); err != nil {
+			return err
+		}
+	}
+
+	if _, err := w.compressor.Write(data); err != nil {
+		return err
+	}
+
+	w.chunk.To = number
+
+	if number == w.end {
+		return w.finish()
+	}
+
+	return nil
+}
+
+// open starts the chunk file beginning with the given block
+func (w *chunkWriter) open(number uint64) error {
+	chunk := &Chunk{
+		File: fmt.Sprintf("%012d.rlp%s", number, w.manifest.Compression.extension()),
+		From: number,
+	}
+
+	file, err := os.OpenFile(filepath.Join(w.dir, chunk.File+tmpExt), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	hasher := sha256.New()
+
+	compressor, err := w.manifest.Compression.newWriter(io.MultiWriter(file, hasher))
+	if err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	w.chunk = chunk
+	w.end = number + w.manifest.ChunkSize - 1
+
+	if w.end > w.manifest.To {
+		w.end = w.manifest.To
+	}
+
+	w.file = file
+	w.hasher = hasher
+	w.compressor = compressor
+
+	return nil
+}
+
+// finish completes the chunk file and adds the chunk to the manifest
+func (w *chunkWriter) finish() error {
+	defer w.reset()
+
+	path := filepath.Join(w.dir, w.chunk.File)
+
+	if err := w.compressor.Close(); err != nil {
+		return errors.Join(err, w.file.Close())
+	}
+
+	if err := w.file.Sync(); err != nil {
+		return errors.Join(err, w.file.Close())
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(path+tmpExt, path); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	w.chunk.Size = uint64(info.Size())
+	w.chunk.Checksum = types.BytesToHash(w.hasher.Sum(nil))
+	w.manifest.Chunks = append(w.manifest.Chunks, w.chunk)
+
+	return writeManifest(w.dir, w.manifest)
+}
+
+// abort removes the incomplete chunk file, if any
+func (w *chunkWriter) abort() error {
+	if w.chunk == nil {
+		return nil
+	}
+
+	defer w.reset()
+
+	return errors.Join(
+		w.compressor.Close(),
+		w.file.Close(),
+		os.Remove(filepath.Join(w.dir, w.chunk.File+tmpExt)),
+	)
+}
+
+func (w *chunkWriter) reset() {
+	w.chunk = nil
+	w.file = nil
+	w.hasher = nil
+	w.compressor = nil
+}
@@ -0,0 +1,257 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// newTestChain returns the blocks from the genesis to the given number
+func newTestChain(to uint64) []*types.Block {
+	chain := make([]*types.Block, 0, to+1)
+
+	for number := uint64(0); number <= to; number++ {
+		header := &types.Header{Number: number}
+		if number > 0 {
+			header.ParentHash = chain[number-1].Hash()
+		}
+
+		header.ComputeHash()
+
+		chain = append(chain, &types.Block{Header: header})
+	}
+
+	return chain
+}
+
+// exportEvents returns the export events of the blocks, at most two blocks per event
+func exportEvents(blocks []*types.Block) []recvData {
+	recvs := []recvData{}
+
+	for i := 0; i < len(blocks); i += 2 {
+		event := &proto.ExportEvent{From: blocks[i].Number(), To: blocks[i].Number()}
+		event.Data = blocks[i].MarshalRLP()
+
+		if i+1 < len(blocks) {
+			event.To = blocks[i+1].Number()
+			event.Data = append(event.Data, blocks[i+1].MarshalRLP()...)
+		}
+
+		recvs = append(recvs, recvData{event: event})
+	}
+
+	return recvs
+}
+
+// readChunkedBlocks reads the blocks from the chunk files of the backup
+func readChunkedBlocks(t *testing.T, dir string, manifest *Manifest) ([]*types.Block, error) {
+	t.Helper()
+
+	chunks := newChunkReader(dir, manifest)
+	defer chunks.Close()
+
+	stream := newBlockStream(chunks)
+	blocks := []*types.Block{}
+
+	for {
+		block, err := stream.nextBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if block == nil {
+			return blocks, nil
+		}
+
+		blocks = append(blocks, block)
+	}
+}
+
+func Test_writeChunks(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(9)
+
+	for _, compression := range Compressions() {
+		compression := compression
+
+		t.Run(string(compression), func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			opts := BackupOptions{Compression: compression, ChunkSize: 4}
+			manifest := &Manifest{
+				Version:     ManifestVersion,
+				Compression: compression,
+				ChunkSize:   opts.ChunkSize,
+				From:        0,
+				To:          9,
+				ToHash:      chain[9].Hash(),
+				Chunks:      []*Chunk{},
+			}
+
+			// the stream drops in the middle of the second chunk
+			stream := &mockSystemExportClient{recvs: append(exportEvents(chain[:6]), recvData{err: errors.New("dropped")})}
+			require.Error(t, writeChunks(stream, hclog.NewNullLogger(), dir, manifest))
+
+			resumed, err := readManifest(dir)
+			require.NoError(t, err)
+			require.Len(t, resumed.Chunks, 1)
+			require.Equal(t, uint64(4), resumed.next())
+			require.NoError(t, checkResumable(dir, resumed, 0, nil, opts))
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+
+			require.ErrorIs(t, checkResumable(dir, resumed, 1, nil, opts), ErrBackupMismatch)
+
+			// the backup is resumed only if the node still has its last block
+			clt := &systemClientMock{block: &proto.BlockResponse{Data: chain[9].MarshalRLP()}}
+			require.NoError(t, checkResumedHead(context.Background(), clt, resumed))
+
+			clt.block = &proto.BlockResponse{Data: newTestChain(10)[10].MarshalRLP()}
+			require.ErrorIs(t, checkResumedHead(context.Background(), clt, resumed), ErrBackupMismatch)
+
+			require.ErrorIs(t, checkResumable(dir, resumed, 0, nil, BackupOptions{Compression: compression, ChunkSize: 5}),
+				ErrBackupMismatch)
+
+			// the backup is resumed from the first block of the incomplete chunk
+			stream = &mockSystemExportClient{recvs: exportEvents(chain[resumed.next():])}
+			require.NoError(t, writeChunks(stream, hclog.NewNullLogger(), dir, resumed))
+			require.True(t, resumed.complete())
+
+			resumed, err = readManifest(dir)
+			require.NoError(t, err)
+			require.Len(t, resumed.Chunks, 3)
+			require.Equal(t, uint64(8), resumed.Chunks[2].From)
+			require.Equal(t, uint64(9), resumed.Chunks[2].To)
+
+			blocks, err := readChunkedBlocks(t, dir, resumed)
+			require.NoError(t, err)
+			require.Len(t, blocks, len(chain))
+
+			for i, block := range blocks {
+				require.Equal(t, chain[i].Hash(), block.Hash())
+			}
+
+			// a corrupted chunk file is detected
+			path := filepath.Join(dir, resumed.Chunks[1].File)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			data[len(data)-1] ^= 0xff
+			require.NoError(t, os.WriteFile(path, data, 0644))
+
+			_, err = readChunkedBlocks(t, dir, resumed)
+			require.ErrorIs(t, err, ErrChecksumMismatch)
+		})
+	}
+}
+
+func Test_writeChunks_Incomplete(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(5)
+	dir := t.TempDir()
+	manifest := &Manifest{
+		Version:     ManifestVersion,
+		Compression: CompressionNone,
+		ChunkSize:   4,
+		To:          5,
+		Chunks:      []*Chunk{},
+	}
+
+	// the node stops exporting before the last block
+	stream := &mockSystemExportClient{recvs: exportEvents(chain[:5])}
+	require.ErrorIs(t, writeChunks(stream, hclog.NewNullLogger(), dir, manifest), ErrIncompleteBackup)
+	require.Len(t, manifest.Chunks, 1)
+}
+
+func Test_readManifest(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	manifest := &Manifest{
+		Version:     ManifestVersion + 1,
+		Compression: CompressionZstd,
+		ChunkSize:   1,
+	}
+
+	require.NoError(t, writeManifest(dir, manifest))
+
+	_, err := readManifest(dir)
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	manifest.Version = ManifestVersion
+	manifest.Chunks = []*Chunk{{File: "a", From: 0, To: 1}, {File: "b", From: 3, To: 4}}
+	require.NoError(t, writeManifest(dir, manifest))
+
+	_, err = readManifest(dir)
+	require.ErrorIs(t, err, errManifestChunksOrder)
+}
+
+func TestRestoreChain_Chunked(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(6)
+	dir := t.TempDir()
+	manifest := &Manifest{
+		Version:     ManifestVersion,
+		Compression: CompressionZstd,
+		ChunkSize:   3,
+		To:          6,
+		ToHash:      chain[6].Hash(),
+		Chunks:      []*Chunk{},
+	}
+
+	stream := &mockSystemExportClient{recvs: exportEvents(chain)}
+	require.NoError(t, writeChunks(stream, hclog.NewNullLogger(), dir, manifest))
+
+	mock := &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(mock, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, mock.blocks, 6)
+	require.Equal(t, chain[6].Hash(), getLatestBlockFromMockChain(mock).Hash())
+
+	// the backup file of the old format is still restored
+	legacy := filepath.Join(t.TempDir(), "backup")
+	data := (&Metadata{Latest: 6, LatestHash: chain[6].Hash()}).MarshalRLP()
+
+	for _, block := range chain {
+		data = append(data, block.MarshalRLP()...)
+	}
+
+	require.NoError(t, os.WriteFile(legacy, data, 0644))
+
+	mock = &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(mock, legacy, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, mock.blocks, 6)
+
+	// no block of a corrupted chunk file is written
+	path := filepath.Join(dir, manifest.Chunks[1].File)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	data[len(data)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	mock = &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+	err = RestoreChain(mock, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.Len(t, mock.blocks, 2)
+
+	// an incomplete backup is not restored
+	manifest.Chunks = manifest.Chunks[:1]
+	require.NoError(t, writeManifest(dir, manifest))
+	err = RestoreChain(mock, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore))
+	require.ErrorIs(t, err, ErrIncompleteBackup)
+}
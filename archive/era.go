@@ -0,0 +1,813 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/golang/snappy"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+// An Era1 file is an e2store file holding up to 8192 blocks of an epoch, compatible with geth.
+// Every entry begins with its type (2 bytes), the length of its data (4 bytes) and 2 reserved bytes:
+//
+//	Version | CompressedHeader, CompressedBody, CompressedReceipts, TotalDifficulty ... | Accumulator | BlockIndex
+//
+// The headers, the bodies and the receipts are RLP encoded and compressed with framed snappy. The accumulator
+// is the SSZ hash tree root of the hashes and the total difficulties of the blocks, and the block index holds
+// the number of the first block, the offsets of the block entries relative to the index, and the count
+const (
+	// EraMaxBlocks is the number of the blocks of an epoch, the maximal number of the blocks of an Era1 file
+	EraMaxBlocks = 8192
+
+	// EraExt is the extension of the Era1 files
+	EraExt = ".era1"
+
+	eraTypeVersion            uint16 = 0x3265
+	eraTypeCompressedHeader   uint16 = 0x03
+	eraTypeCompressedBody     uint16 = 0x04
+	eraTypeCompressedReceipts uint16 = 0x05
+	eraTypeTotalDifficulty    uint16 = 0x06
+	eraTypeAccumulator        uint16 = 0x07
+	eraTypeBlockIndex         uint16 = 0x3266
+
+	// e2storeHeaderSize is the size of the header of an e2store entry
+	e2storeHeaderSize = 8
+
+	// eraAccumulatorDepth is the depth of the merkle tree of the accumulator, whose leaves are the blocks
+	eraAccumulatorDepth = 13
+)
+
+var (
+	ErrInvalidEra          = errors.New("invalid era1 file")
+	ErrAccumulatorMismatch = errors.New("era1 accumulator mismatch")
+	ErrReceiptsMismatch    = errors.New("receipts don't match the receipts root of the block")
+
+	// eraFileName matches the names of the Era1 files, which end with the beginning of the accumulator root
+	eraFileName = regexp.MustCompile(`-[0-9]{5}-([0-9a-f]{8})\.era1$`)
+)
+
+// CreateEraExport fetches the blocks of the range with their receipts and total difficulties via gRPC,
+// and saves them to the Era1 files of the output directory, one file per epoch of 8192 blocks. The files
+// are named after the chain ID, the epoch and the accumulator root
+func CreateEraExport(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outDir string,
+) (uint64, uint64, error) {
+	if entries, err := os.ReadDir(outDir); err == nil && len(entries) > 0 {
+		return 0, 0, fmt.Errorf("%w: %s is not empty", ErrBackupMismatch, outDir)
+	}
+
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		<-signalCh
+		logger.Info("Caught termination signal, shutting down...")
+		cancelFn()
+	}()
+
+	clt := proto.NewSystemClient(conn)
+
+	status, err := clt.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	reqTo, _, err := determineTo(ctx, clt, to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from > reqTo {
+		return 0, 0, fmt.Errorf("the beginning height %d is above the latest block %d", from, reqTo)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return 0, 0, err
+	}
+
+	stream, err := clt.Export(ctx, &proto.ExportRequest{
+		From:     from,
+		To:       reqTo,
+		Receipts: true,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	writer := &eraFileWriter{dir: outDir, network: fmt.Sprintf("%d", status.Network), logger: logger}
+
+	resFrom, resTo, err := readExportStream(stream, logger, func(block *exportedBlock) error {
+		return writer.append(block)
+	})
+	if err != nil {
+		return 0, 0, errors.Join(err, writer.abort())
+	}
+
+	// the export ends with an incomplete epoch
+	if writer.era != nil {
+		if err := writer.finish(); err != nil {
+			return 0, 0, errors.Join(err, writer.abort())
+		}
+	}
+
+	return resFrom, resTo, nil
+}
+
+// eraFileWriter writes the blocks to the Era1 files of the directory, a file per epoch
+type eraFileWriter struct {
+	dir     string
+	network string
+	logger  hclog.Logger
+
+	// the file being written
+	era   *eraWriter
+	epoch uint64
+	file  *os.File
+	buf   *bufio.Writer
+}
+
+// append writes the block to the Era1 file of its epoch, and completes the file with the last block of the epoch
+func (w *eraFileWriter) append(block *exportedBlock) error {
+	number := block.block.Number()
+
+	if w.era == nil {
+		w.epoch = number / EraMaxBlocks
+
+		file, err := os.OpenFile(w.tmpPath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+
+		w.file = file
+		w.buf = bufio.NewWriter(file)
+		w.era = newEraWriter(w.buf)
+	}
+
+	if err := w.era.add(block.block, block.hash, block.receipts, block.totalDifficulty); err != nil {
+		return err
+	}
+
+	if (number+1)%EraMaxBlocks == 0 {
+		return w.finish()
+	}
+
+	return nil
+}
+
+// finish writes the accumulator and the block index, and renames the file after its accumulator root
+func (w *eraFileWriter) finish() error {
+	root, err := w.era.finish()
+	if err == nil {
+		err = w.buf.Flush()
+	}
+
+	if err == nil {
+		err = w.file.Sync()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	path := filepath.Join(w.dir, eraFile(w.network, w.epoch, root))
+	if err := os.Rename(w.tmpPath(), path); err != nil {
+		return err
+	}
+
+	w.logger.Info("Wrote era1 file", "file", path, "from", w.era.start, "blocks", len(w.era.records))
+
+	w.era = nil
+	w.file = nil
+	w.buf = nil
+
+	return nil
+}
+
+// abort removes the incomplete Era1 file, if any
+func (w *eraFileWriter) abort() error {
+	if w.era == nil {
+		return nil
+	}
+
+	w.era = nil
+
+	return errors.Join(w.file.Close(), os.Remove(w.tmpPath()))
+}
+
+func (w *eraFileWriter) tmpPath() string {
+	return filepath.Join(w.dir, fmt.Sprintf("%s-%05d%s%s", w.network, w.epoch, EraExt, tmpExt))
+}
+
+// eraFile returns the name of the Era1 file of the epoch
+func eraFile(network string, epoch uint64, root types.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s%s", network, epoch, hex.EncodeToString(root[:4]), EraExt)
+}
+
+// eraRecord is the record of a block in the accumulator
+type eraRecord struct {
+	hash            types.Hash
+	totalDifficulty *big.Int
+}
+
+// eraWriter writes the entries of an Era1 file
+type eraWriter struct {
+	w       io.Writer
+	written int64
+
+	start   uint64
+	offsets []int64
+	records []eraRecord
+}
+
+func newEraWriter(w io.Writer) *eraWriter {
+	return &eraWriter{w: w}
+}
+
+// add writes the header, the body, the receipts and the total difficulty of the next block,
+// whose hash is given as computed by the consensus of the chain
+func (w *eraWriter) add(block *types.Block, hash types.Hash, receipts types.Receipts, td *big.Int) error {
+	number := block.Number()
+
+	if len(w.records) == 0 {
+		if err := w.writeEntry(eraTypeVersion, nil); err != nil {
+			return err
+		}
+
+		w.start = number
+	}
+
+	if expected := w.start + uint64(len(w.records)); number != expected {
+		return fmt.Errorf("unexpected block %d in the era1 file, expected %d", number, expected)
+	}
+
+	if len(w.records) == EraMaxBlocks {
+		return fmt.Errorf("era1 file holds %d blocks at most", EraMaxBlocks)
+	}
+
+	if td == nil {
+		return fmt.Errorf("total difficulty of block %d is missing", number)
+	}
+
+	tdBytes, err := eraUint256(td)
+	if err != nil {
+		return err
+	}
+
+	w.offsets = append(w.offsets, w.written)
+	w.records = append(w.records, eraRecord{hash: hash, totalDifficulty: td})
+
+	if err := w.writeCompressed(eraTypeCompressedHeader, block.Header.MarshalRLP()); err != nil {
+		return err
+	}
+
+	if err := w.writeCompressed(eraTypeCompressedBody, marshalGethBody(block)); err != nil {
+		return err
+	}
+
+	if err := w.writeCompressed(eraTypeCompressedReceipts, marshalGethReceipts(receipts)); err != nil {
+		return err
+	}
+
+	return w.writeEntry(eraTypeTotalDifficulty, tdBytes)
+}
+
+// finish writes the accumulator and the block index, and returns the accumulator root
+func (w *eraWriter) finish() (types.Hash, error) {
+	if len(w.records) == 0 {
+		return types.ZeroHash, fmt.Errorf("%w: no block", ErrInvalidEra)
+	}
+
+	root, err := eraAccumulator(w.records)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	if err := w.writeEntry(eraTypeAccumulator, root.Bytes()); err != nil {
+		return types.ZeroHash, err
+	}
+
+	count := len(w.offsets)
+	index := make([]byte, 16+8*count)
+	base := w.written
+
+	binary.LittleEndian.PutUint64(index, w.start)
+
+	for i, offset := range w.offsets {
+		binary.LittleEndian.PutUint64(index[8+8*i:], uint64(offset-base))
+	}
+
+	binary.LittleEndian.PutUint64(index[8+8*count:], uint64(count))
+
+	if err := w.writeEntry(eraTypeBlockIndex, index); err != nil {
+		return types.ZeroHash, err
+	}
+
+	return root, nil
+}
+
+func (w *eraWriter) writeCompressed(typ uint16, data []byte) error {
+	var buf bytes.Buffer
+
+	snappyWriter := snappy.NewBufferedWriter(&buf)
+
+	if _, err := snappyWriter.Write(data); err != nil {
+		return err
+	}
+
+	if err := snappyWriter.Close(); err != nil {
+		return err
+	}
+
+	return w.writeEntry(typ, buf.Bytes())
+}
+
+func (w *eraWriter) writeEntry(typ uint16, data []byte) error {
+	header := make([]byte, e2storeHeaderSize)
+
+	binary.LittleEndian.PutUint16(header, typ)
+	binary.LittleEndian.PutUint32(header[2:], uint32(len(data)))
+
+	if _, err := w.w.Write(header); err != nil {
+		return err
+	}
+
+	if _, err := w.w.Write(data); err != nil {
+		return err
+	}
+
+	w.written += int64(e2storeHeaderSize + len(data))
+
+	return nil
+}
+
+// eraBlock is a block read from an Era1 file
+type eraBlock struct {
+	header          *types.Header
+	body            []byte
+	receipts        []byte
+	totalDifficulty *big.Int
+}
+
+// eraReader reads the entries of an Era1 file in order, and verifies the accumulator
+// and the block index against the blocks once all the blocks are read
+type eraReader struct {
+	r    *bufio.Reader
+	read int64
+
+	start   uint64
+	offsets []int64
+	records []eraRecord
+	root    types.Hash
+}
+
+func newEraReader(r io.Reader) *eraReader {
+	return &eraReader{r: bufio.NewReader(r)}
+}
+
+// next returns the next block of the file, or nil once the accumulator and the block index are verified
+func (r *eraReader) next() (*eraBlock, error) {
+	if r.read == 0 {
+		if _, err := r.readEntry(eraTypeVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	offset := r.read
+
+	typ, data, err := r.readAnyEntry()
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ {
+	case eraTypeCompressedHeader:
+	case eraTypeAccumulator:
+		return nil, r.verify(data)
+	default:
+		return nil, fmt.Errorf("%w: unexpected entry type %#x", ErrInvalidEra, typ)
+	}
+
+	block := &eraBlock{header: &types.Header{}}
+
+	headerData, err := eraDecompress(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := block.header.UnmarshalRLP(headerData); err != nil {
+		return nil, err
+	}
+
+	if block.body, err = r.readCompressedEntry(eraTypeCompressedBody); err != nil {
+		return nil, err
+	}
+
+	if block.receipts, err = r.readCompressedEntry(eraTypeCompressedReceipts); err != nil {
+		return nil, err
+	}
+
+	tdBytes, err := r.readEntry(eraTypeTotalDifficulty)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tdBytes) != 32 {
+		return nil, fmt.Errorf("%w: total difficulty of %d bytes", ErrInvalidEra, len(tdBytes))
+	}
+
+	block.totalDifficulty = new(big.Int).SetBytes(reverseBytes(tdBytes))
+
+	if len(r.records) == 0 {
+		r.start = block.header.Number
+	}
+
+	if expected := r.start + uint64(len(r.records)); block.header.Number != expected {
+		return nil, fmt.Errorf("%w: unexpected block %d, expected %d", ErrInvalidEra, block.header.Number, expected)
+	}
+
+	r.offsets = append(r.offsets, offset)
+	r.records = append(r.records, eraRecord{hash: block.header.Hash, totalDifficulty: block.totalDifficulty})
+
+	return block, nil
+}
+
+// verify checks the accumulator and the block index, which end the file, against the read blocks
+func (r *eraReader) verify(accumulator []byte) error {
+	if len(accumulator) != types.HashLength {
+		return fmt.Errorf("%w: accumulator of %d bytes", ErrInvalidEra, len(accumulator))
+	}
+
+	r.root = types.BytesToHash(accumulator)
+
+	root, err := eraAccumulator(r.records)
+	if err != nil {
+		return err
+	}
+
+	if root != r.root {
+		return fmt.Errorf("%w: expected %s, got %s", ErrAccumulatorMismatch, r.root, root)
+	}
+
+	base := r.read
+
+	index, err := r.readEntry(eraTypeBlockIndex)
+	if err != nil {
+		return err
+	}
+
+	count := len(r.offsets)
+	if len(index) != 16+8*count {
+		return fmt.Errorf("%w: block index of %d bytes for %d blocks", ErrInvalidEra, len(index), count)
+	}
+
+	if start := binary.LittleEndian.Uint64(index); start != r.start {
+		return fmt.Errorf("%w: block index begins with block %d, expected %d", ErrInvalidEra, start, r.start)
+	}
+
+	if n := binary.LittleEndian.Uint64(index[8+8*count:]); n != uint64(count) {
+		return fmt.Errorf("%w: block index counts %d blocks, expected %d", ErrInvalidEra, n, count)
+	}
+
+	for i, offset := range r.offsets {
+		if relative := int64(binary.LittleEndian.Uint64(index[8+8*i:])); base+relative != offset {
+			return fmt.Errorf("%w: wrong offset of block %d in the block index", ErrInvalidEra, r.start+uint64(i))
+		}
+	}
+
+	if _, err := r.r.ReadByte(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected data after the block index", ErrInvalidEra)
+	}
+
+	return nil
+}
+
+func (r *eraReader) readCompressedEntry(typ uint16) ([]byte, error) {
+	data, err := r.readEntry(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return eraDecompress(data)
+}
+
+func (r *eraReader) readEntry(typ uint16) ([]byte, error) {
+	entryType, data, err := r.readAnyEntry()
+	if err != nil {
+		return nil, err
+	}
+
+	if entryType != typ {
+		return nil, fmt.Errorf("%w: unexpected entry type %#x, expected %#x", ErrInvalidEra, entryType, typ)
+	}
+
+	return data, nil
+}
+
+func (r *eraReader) readAnyEntry() (uint16, []byte, error) {
+	header := make([]byte, e2storeHeaderSize)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return 0, nil, fmt.Errorf("%w: %w", ErrInvalidEra, err)
+	}
+
+	if header[6] != 0 || header[7] != 0 {
+		return 0, nil, fmt.Errorf("%w: reserved bytes of the entry header are not zero", ErrInvalidEra)
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint32(header[2:]))
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return 0, nil, fmt.Errorf("%w: %w", ErrInvalidEra, err)
+	}
+
+	r.read += int64(e2storeHeaderSize + len(data))
+
+	return binary.LittleEndian.Uint16(header), data, nil
+}
+
+func eraDecompress(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// eraAccumulator returns the SSZ hash tree root of the records as List[HeaderRecord, 8192],
+// where HeaderRecord is the container of the block hash and the total difficulty as uint256
+func eraAccumulator(records []eraRecord) (types.Hash, error) {
+	if len(records) > EraMaxBlocks {
+		return types.ZeroHash, fmt.Errorf("era1 accumulator holds %d blocks at most", EraMaxBlocks)
+	}
+
+	layer := make([][32]byte, len(records))
+
+	for i, record := range records {
+		td, err := eraUint256(record.totalDifficulty)
+		if err != nil {
+			return types.ZeroHash, err
+		}
+
+		layer[i] = sha256.Sum256(append(record.hash.Bytes(), td...))
+	}
+
+	// zeroHashes[i] is the root of the empty subtree of depth i
+	zeroHashes := make([][32]byte, eraAccumulatorDepth+1)
+	for i := 1; i <= eraAccumulatorDepth; i++ {
+		zeroHashes[i] = sha256.Sum256(append(zeroHashes[i-1][:], zeroHashes[i-1][:]...))
+	}
+
+	root := zeroHashes[eraAccumulatorDepth]
+
+	if len(layer) > 0 {
+		for depth := 0; depth < eraAccumulatorDepth; depth++ {
+			if len(layer)%2 == 1 {
+				layer = append(layer, zeroHashes[depth])
+			}
+
+			parents := make([][32]byte, len(layer)/2)
+			for i := range parents {
+				parents[i] = sha256.Sum256(append(layer[2*i][:], layer[2*i+1][:]...))
+			}
+
+			layer = parents
+		}
+
+		root = layer[0]
+	}
+
+	// the length of the list is mixed in the root
+	length := make([]byte, 32)
+	binary.LittleEndian.PutUint64(length, uint64(len(records)))
+
+	return types.Hash(sha256.Sum256(append(root[:], length...))), nil
+}
+
+// eraUint256 returns the little endian uint256 of the number
+func eraUint256(n *big.Int) ([]byte, error) {
+	if n.Sign() < 0 || n.BitLen() > 256 {
+		return nil, fmt.Errorf("%s doesn't fit in uint256", n)
+	}
+
+	return reverseBytes(n.FillBytes(make([]byte, 32))), nil
+}
+
+func reverseBytes(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[len(b)-1-i] = b[i]
+	}
+
+	return reversed
+}
+
+// eraFiles returns the Era1 files of the path in the order of their blocks, if the path is an Era1 file
+// or a directory of Era1 files
+func eraFiles(path string) ([]string, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+
+	if !info.IsDir() {
+		return []string{path}, filepath.Ext(path) == EraExt
+	}
+
+	paths, err := filepath.Glob(filepath.Join(path, "*"+EraExt))
+	if err != nil || len(paths) == 0 {
+		return nil, false
+	}
+
+	// the names begin with the network and the epoch
+	sort.Strings(paths)
+
+	return paths, true
+}
+
+// restoreEra verifies the Era1 files and writes their blocks to the chain
+func restoreEra(chain blockchainInterface, paths []string, progression *progress.ProgressionWrapper) error {
+	source := &eraSource{paths: paths}
+	defer source.close()
+
+	latest, err := source.latest()
+	if err != nil {
+		return err
+	}
+
+	return writeBlocks(chain, source.nextBlock, latest, progression)
+}
+
+// eraSource returns the blocks of the Era1 files in order. A file is read through and its accumulator
+// and block index are verified before its blocks are returned, and the receipts of every block are
+// checked against the receipts root of its header
+type eraSource struct {
+	paths []string
+
+	file   *os.File
+	reader *eraReader
+	next   *uint64
+}
+
+// latest returns the number of the last block of the Era1 files, read from the block index of the last file
+func (s *eraSource) latest() (uint64, error) {
+	fp, err := os.Open(s.paths[len(s.paths)-1])
+	if err != nil {
+		return 0, err
+	}
+
+	defer fp.Close()
+
+	info, err := fp.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	trailer := make([]byte, 8)
+	if _, err := fp.ReadAt(trailer, info.Size()-8); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidEra, err)
+	}
+
+	count := binary.LittleEndian.Uint64(trailer)
+	if count == 0 || count > EraMaxBlocks {
+		return 0, fmt.Errorf("%w: block index counts %d blocks", ErrInvalidEra, count)
+	}
+
+	start := make([]byte, 8)
+	if _, err := fp.ReadAt(start, info.Size()-int64(16+8*count)); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidEra, err)
+	}
+
+	return binary.LittleEndian.Uint64(start) + count - 1, nil
+}
+
+func (s *eraSource) nextBlock() (*types.Block, error) {
+	for {
+		if s.reader == nil {
+			if len(s.paths) == 0 {
+				return nil, nil
+			}
+
+			if err := s.open(s.paths[0]); err != nil {
+				return nil, err
+			}
+
+			s.paths = s.paths[1:]
+		}
+
+		eb, err := s.reader.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if eb == nil {
+			s.close()
+
+			continue
+		}
+
+		if s.next != nil && eb.header.Number != *s.next {
+			return nil, fmt.Errorf("%w: unexpected block %d, expected %d", ErrInvalidEra, eb.header.Number, *s.next)
+		}
+
+		next := eb.header.Number + 1
+		s.next = &next
+
+		block, err := unmarshalGethBody(eb.header, eb.body)
+		if err != nil {
+			return nil, err
+		}
+
+		receipts, err := unmarshalGethReceipts(eb.receipts)
+		if err != nil {
+			return nil, err
+		}
+
+		if root := buildroot.CalculateReceiptsRoot(receipts); root != eb.header.ReceiptsRoot {
+			return nil, fmt.Errorf("%w: block %d, expected %s, got %s",
+				ErrReceiptsMismatch, eb.header.Number, eb.header.ReceiptsRoot, root)
+		}
+
+		return block, nil
+	}
+}
+
+// open verifies the Era1 file and opens it to read its blocks
+func (s *eraSource) open(path string) error {
+	root, err := verifyEra(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if match := eraFileName.FindStringSubmatch(filepath.Base(path)); match != nil &&
+		match[1] != hex.EncodeToString(root[:4]) {
+		return fmt.Errorf("%w: %s is named after another accumulator root than %s", ErrAccumulatorMismatch, path, root)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	s.file = file
+	s.reader = newEraReader(file)
+
+	return nil
+}
+
+func (s *eraSource) close() {
+	if s.file != nil {
+		s.file.Close()
+	}
+
+	s.file = nil
+	s.reader = nil
+}
+
+// verifyEra reads the Era1 file through, verifies its accumulator and its block index,
+// and returns the accumulator root
+func verifyEra(path string) (types.Hash, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	defer file.Close()
+
+	reader := newEraReader(file)
+
+	for {
+		block, err := reader.next()
+		if err != nil {
+			return types.ZeroHash, err
+		}
+
+		if block == nil {
+			return reader.root, nil
+		}
+	}
+}
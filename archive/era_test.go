@@ -0,0 +1,183 @@
+package archive
+
+import (
+	"encoding/binary"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// writeTestEra writes the blocks with their receipts to the Era1 files of the directory
+func writeTestEra(t *testing.T, dir string, blocks []*types.Block, receipts []types.Receipts) {
+	t.Helper()
+
+	writer := &eraFileWriter{dir: dir, network: "100", logger: hclog.NewNullLogger()}
+	stream := &mockSystemExportClient{recvs: exportReceiptsEvents(blocks, receipts)}
+
+	_, _, err := readExportStream(stream, hclog.NewNullLogger(), writer.append)
+	require.NoError(t, err)
+
+	if writer.era != nil {
+		require.NoError(t, writer.finish())
+	}
+}
+
+func Test_eraWriter(t *testing.T) {
+	t.Parallel()
+
+	chain, receipts := newTestReceiptsChain(EraMaxBlocks + 1)
+	dir := t.TempDir()
+
+	writeTestEra(t, dir, chain, receipts)
+
+	paths, ok := eraFiles(dir)
+	require.True(t, ok)
+	require.Len(t, paths, 2)
+
+	records := make([]eraRecord, 0, len(chain))
+	for i, block := range chain {
+		records = append(records, eraRecord{hash: block.Hash(), totalDifficulty: big.NewInt(int64(i + 1))})
+	}
+
+	// the files of the epochs are named after their accumulator roots
+	for epoch, path := range paths {
+		root, err := verifyEra(path)
+		require.NoError(t, err)
+
+		expected, err := eraAccumulator(records[epoch*EraMaxBlocks : min(len(records), (epoch+1)*EraMaxBlocks)])
+		require.NoError(t, err)
+		require.Equal(t, expected, root)
+		require.Equal(t, eraFile("100", uint64(epoch), root), filepath.Base(path))
+	}
+
+	// the reader returns the blocks of the file
+	file, err := os.Open(paths[1])
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	reader := newEraReader(file)
+
+	for _, number := range []uint64{EraMaxBlocks, EraMaxBlocks + 1} {
+		block, err := reader.next()
+		require.NoError(t, err)
+		require.Equal(t, chain[number].Hash(), block.header.Hash)
+		require.Equal(t, big.NewInt(int64(number+1)), block.totalDifficulty)
+	}
+
+	block, err := reader.next()
+	require.NoError(t, err)
+	require.Nil(t, block)
+}
+
+func Test_eraAccumulator(t *testing.T) {
+	t.Parallel()
+
+	records := []eraRecord{
+		{hash: types.StringToHash("1"), totalDifficulty: big.NewInt(1)},
+		{hash: types.StringToHash("2"), totalDifficulty: big.NewInt(2)},
+	}
+
+	root, err := eraAccumulator(records)
+	require.NoError(t, err)
+
+	// the root depends on the number of the records, the hashes and the total difficulties
+	empty, err := eraAccumulator(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, root, empty)
+
+	single, err := eraAccumulator(records[:1])
+	require.NoError(t, err)
+	require.NotEqual(t, root, single)
+
+	records[1].totalDifficulty = big.NewInt(3)
+
+	changed, err := eraAccumulator(records)
+	require.NoError(t, err)
+	require.NotEqual(t, root, changed)
+
+	_, err = eraAccumulator(make([]eraRecord, EraMaxBlocks+1))
+	require.Error(t, err)
+}
+
+func TestRestoreChain_Era1(t *testing.T) {
+	t.Parallel()
+
+	chain, receipts := newTestReceiptsChain(5)
+	dir := t.TempDir()
+
+	writeTestEra(t, dir, chain, receipts)
+
+	mock := &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(mock, dir, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, mock.blocks, 5)
+	require.Equal(t, chain[5].Hash(), getLatestBlockFromMockChain(mock).Hash())
+
+	// a single file is restored too
+	paths, ok := eraFiles(dir)
+	require.True(t, ok)
+
+	mock = &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(mock, paths[0], progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, mock.blocks, 5)
+}
+
+func TestRestoreChain_Era1Corrupted(t *testing.T) {
+	t.Parallel()
+
+	chain, receipts := newTestReceiptsChain(5)
+	dir := t.TempDir()
+
+	writeTestEra(t, dir, chain, receipts)
+
+	paths, ok := eraFiles(dir)
+	require.True(t, ok)
+
+	data, err := os.ReadFile(paths[0])
+	require.NoError(t, err)
+
+	restore := func(data []byte) error {
+		path := filepath.Join(t.TempDir(), "chain.era1")
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		return RestoreChain(
+			&mockChain{genesis: chain[0], blocks: []*types.Block{}},
+			path,
+			progress.NewProgressionWrapper(progress.ChainSyncRestore),
+		)
+	}
+
+	// the total difficulty of the genesis, the first block entry after the version,
+	// is the fourth entry of the block
+	offset := e2storeHeaderSize
+	for i := 0; i < 3; i++ {
+		offset += e2storeHeaderSize + int(binary.LittleEndian.Uint32(data[offset+2:]))
+	}
+
+	corrupted := append([]byte{}, data...)
+	corrupted[offset+e2storeHeaderSize]++
+	require.ErrorIs(t, restore(corrupted), ErrAccumulatorMismatch)
+
+	// the block index is cut
+	require.ErrorIs(t, restore(data[:len(data)-4]), ErrInvalidEra)
+
+	// the receipts don't match the blocks
+	receipts[3] = receipts[2]
+	dir = t.TempDir()
+
+	writeTestEra(t, dir, chain, receipts)
+
+	err = RestoreChain(
+		&mockChain{genesis: chain[0], blocks: []*types.Block{}},
+		dir,
+		progress.NewProgressionWrapper(progress.ChainSyncRestore),
+	)
+	require.ErrorIs(t, err, ErrReceiptsMismatch)
+}
@@ -0,0 +1,36 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownFormat = errors.New("unknown backup format")
+
+// Format is the format of a backup
+type Format string
+
+const (
+	// FormatChunked is the directory of the compressed chunk files described by a manifest
+	FormatChunked Format = "chunked"
+	// FormatRLP is the file of the concatenated RLP encoded blocks, as exported by geth
+	FormatRLP Format = "rlp"
+	// FormatEra1 is the directory of the Era1 files, holding the blocks, their receipts and accumulator roots
+	FormatEra1 Format = "era1"
+)
+
+// Formats returns the supported backup formats
+func Formats() []Format {
+	return []Format{FormatChunked, FormatRLP, FormatEra1}
+}
+
+// ParseFormat returns the backup format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats() {
+		if string(f) == name {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+}
@@ -0,0 +1,439 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// CreateRLPExport fetches the blocks of the range via gRPC and saves them to the given path as
+// concatenated RLP encoded blocks without metadata, the format of the export and import commands of geth
+func CreateRLPExport(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	from uint64,
+	to *uint64,
+	outPath string,
+) (uint64, uint64, error) {
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	closeAndRemoveFile := func() {
+		if err := fs.Close(); err != nil {
+			logger.Error("an error occurred while closing file", "err", err)
+
+			return
+		}
+
+		if err := os.Remove(outPath); err != nil {
+			logger.Error("an error occurred while removing file", "err", err)
+		}
+	}
+
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		<-signalCh
+		logger.Info("Caught termination signal, shutting down...")
+		cancelFn()
+	}()
+
+	clt := proto.NewSystemClient(conn)
+
+	reqTo, _, err := determineTo(ctx, clt, to)
+	if err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
+	stream, err := clt.Export(ctx, &proto.ExportRequest{
+		From: from,
+		To:   reqTo,
+	})
+	if err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
+	w := bufio.NewWriter(fs)
+
+	resFrom, resTo, err := readExportStream(stream, logger, func(block *exportedBlock) error {
+		_, err := w.Write(marshalGethBlock(block.block))
+
+		return err
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err == nil {
+		err = fs.Sync()
+	}
+
+	if err != nil {
+		closeAndRemoveFile()
+
+		return 0, 0, err
+	}
+
+	if err := fs.Close(); err != nil {
+		return 0, 0, errors.Join(err, os.Remove(outPath))
+	}
+
+	return resFrom, resTo, nil
+}
+
+// exportedBlock is a block of the export stream, with its receipts, its total difficulty and its hash
+// if requested. The hash is the one of the chain, which the consensus may compute in its own way
+type exportedBlock struct {
+	block           *types.Block
+	receipts        types.Receipts
+	totalDifficulty *big.Int
+	hash            types.Hash
+}
+
+// readExportStream calls fn for every block of the export stream in order,
+// and returns the range of the received blocks
+func readExportStream(
+	stream proto.System_ExportClient,
+	logger hclog.Logger,
+	fn func(*exportedBlock) error,
+) (uint64, uint64, error) {
+	var from, to *uint64
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			break
+		}
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if len(event.Receipts) != len(event.TotalDifficulties) || len(event.Receipts) != len(event.Hashes) {
+			return 0, 0, fmt.Errorf("export event has %d receipts, %d total difficulties and %d hashes",
+				len(event.Receipts), len(event.TotalDifficulties), len(event.Hashes))
+		}
+
+		blocks := newBlockStream(bytes.NewReader(event.Data))
+
+		for i := 0; ; i++ {
+			block, err := blocks.nextBlock()
+			if err != nil {
+				return 0, 0, err
+			}
+
+			if block == nil {
+				break
+			}
+
+			if to != nil && block.Number() != *to+1 {
+				return 0, 0, fmt.Errorf("unexpected block %d in the export stream, expected %d", block.Number(), *to+1)
+			}
+
+			exported := &exportedBlock{block: block}
+
+			if len(event.Receipts) > 0 {
+				if i >= len(event.Receipts) {
+					return 0, 0, fmt.Errorf("receipts of block %d are missing in the export stream", block.Number())
+				}
+
+				if err := exported.receipts.UnmarshalStoreRLP(event.Receipts[i]); err != nil {
+					return 0, 0, fmt.Errorf("failed to decode the receipts of block %d: %w", block.Number(), err)
+				}
+
+				exported.totalDifficulty = new(big.Int).SetBytes(event.TotalDifficulties[i])
+				exported.hash = types.BytesToHash(event.Hashes[i])
+			}
+
+			if err := fn(exported); err != nil {
+				return 0, 0, err
+			}
+
+			number := block.Number()
+			if from == nil {
+				from = &number
+			}
+
+			to = &number
+		}
+
+		logger.Info(
+			fmt.Sprintf("%d blocks are written", event.To-event.From+1),
+			"to", event.To,
+			"latest", event.Latest,
+		)
+	}
+
+	if from == nil || to == nil {
+		return 0, 0, errors.New("couldn't get any blocks")
+	}
+
+	return *from, *to, nil
+}
+
+// isRLPExport returns true if the file holds the concatenated blocks of a geth export, which begins
+// with a block rather than with the metadata of a backup file
+func isRLPExport(filePath string) bool {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+
+	defer fp.Close()
+
+	stream := newBlockStream(bufio.NewReader(fp))
+
+	size, err := stream.loadRLPArray()
+	if err != nil || size == 0 {
+		return false
+	}
+
+	var parser fastrlp.Parser
+
+	v, err := parser.Parse(stream.buffer[:size])
+	if err != nil {
+		return false
+	}
+
+	elems, err := v.GetElems()
+
+	// the first field of a block is its header
+	return err == nil && len(elems) > 0 && elems[0].Type() == fastrlp.TypeArray
+}
+
+// restoreRLPExport reads the blocks of the geth export file and writes them to the chain
+func restoreRLPExport(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	stream := newBlockStream(bufio.NewReader(fp))
+
+	// the number of the blocks is not known in advance
+	return writeBlocks(chain, func() (*types.Block, error) {
+		size, err := stream.loadRLPArray()
+		if err != nil || size == 0 {
+			return nil, err
+		}
+
+		return unmarshalGethBlock(stream.buffer[:size])
+	}, 0, progression)
+}
+
+// The blocks and the receipts are encoded as geth does, unlike the blocks of the native backup: a typed
+// transaction or receipt is a single RLP string holding its EIP-2718 envelope, the type followed by the payload
+
+// marshalGethBlock returns the RLP encoded block in the geth format
+func marshalGethBlock(block *types.Block) []byte {
+	return types.MarshalRLPTo(func(a *fastrlp.Arena) *fastrlp.Value {
+		vv := a.NewArray()
+
+		vv.Set(block.Header.MarshalRLPWith(a))
+		vv.Set(marshalGethTxsWith(a, block.Transactions))
+		vv.Set(marshalUnclesWith(a, block.Uncles))
+
+		return vv
+	}, nil)
+}
+
+// marshalGethBody returns the RLP encoded transactions and uncles of the block in the geth format
+func marshalGethBody(block *types.Block) []byte {
+	return types.MarshalRLPTo(func(a *fastrlp.Arena) *fastrlp.Value {
+		vv := a.NewArray()
+
+		vv.Set(marshalGethTxsWith(a, block.Transactions))
+		vv.Set(marshalUnclesWith(a, block.Uncles))
+
+		return vv
+	}, nil)
+}
+
+func marshalGethTxsWith(a *fastrlp.Arena, txs []*types.Transaction) *fastrlp.Value {
+	vv := a.NewArray()
+
+	for _, tx := range txs {
+		if tx.Type == types.LegacyTx {
+			vv.Set(tx.MarshalRLPWith(a))
+		} else {
+			vv.Set(a.NewCopyBytes(tx.MarshalRLP()))
+		}
+	}
+
+	return vv
+}
+
+func marshalUnclesWith(a *fastrlp.Arena, uncles []*types.Header) *fastrlp.Value {
+	vv := a.NewArray()
+
+	for _, uncle := range uncles {
+		vv.Set(uncle.MarshalRLPWith(a))
+	}
+
+	return vv
+}
+
+// marshalGethReceipts returns the RLP encoded receipts in the geth format
+func marshalGethReceipts(receipts types.Receipts) []byte {
+	return types.MarshalRLPTo(func(a *fastrlp.Arena) *fastrlp.Value {
+		vv := a.NewArray()
+
+		for _, receipt := range receipts {
+			if receipt.IsLegacyTx() {
+				vv.Set(receipt.MarshalRLPWith(a))
+			} else {
+				vv.Set(a.NewCopyBytes(receipt.MarshalRLP()))
+			}
+		}
+
+		return vv
+	}, nil)
+}
+
+// unmarshalGethBlock decodes the RLP encoded block in the geth format
+func unmarshalGethBlock(data []byte) (*types.Block, error) {
+	block := &types.Block{}
+
+	err := types.UnmarshalRlp(func(p *fastrlp.Parser, v *fastrlp.Value) error {
+		elems, err := v.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(elems) < 3 {
+			return fmt.Errorf("incorrect number of elements to decode block, expected 3 but found %d", len(elems))
+		}
+
+		block.Header = &types.Header{}
+		if err := block.Header.UnmarshalRLP(elems[0].MarshalTo(nil)); err != nil {
+			return err
+		}
+
+		return unmarshalGethBodyFrom(block, elems[1], elems[2])
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// unmarshalGethBody decodes the RLP encoded transactions and uncles of the block with the given header
+func unmarshalGethBody(header *types.Header, data []byte) (*types.Block, error) {
+	block := &types.Block{Header: header}
+
+	err := types.UnmarshalRlp(func(p *fastrlp.Parser, v *fastrlp.Value) error {
+		elems, err := v.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(elems) < 2 {
+			return fmt.Errorf("incorrect number of elements to decode body, expected 2 but found %d", len(elems))
+		}
+
+		return unmarshalGethBodyFrom(block, elems[0], elems[1])
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func unmarshalGethBodyFrom(block *types.Block, txs *fastrlp.Value, uncles *fastrlp.Value) error {
+	txElems, err := txs.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range txElems {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(gethEnvelope(elem)); err != nil {
+			return err
+		}
+
+		block.Transactions = append(block.Transactions, tx.ComputeHash(block.Number()))
+	}
+
+	uncleElems, err := uncles.GetElems()
+	if err != nil {
+		return err
+	}
+
+	for _, elem := range uncleElems {
+		uncle := &types.Header{}
+		if err := uncle.UnmarshalRLP(elem.MarshalTo(nil)); err != nil {
+			return err
+		}
+
+		block.Uncles = append(block.Uncles, uncle)
+	}
+
+	return nil
+}
+
+// unmarshalGethReceipts decodes the RLP encoded receipts in the geth format
+func unmarshalGethReceipts(data []byte) (types.Receipts, error) {
+	receipts := types.Receipts{}
+
+	err := types.UnmarshalRlp(func(p *fastrlp.Parser, v *fastrlp.Value) error {
+		elems, err := v.GetElems()
+		if err != nil {
+			return err
+		}
+
+		for _, elem := range elems {
+			receipt := &types.Receipt{}
+			if err := receipt.UnmarshalRLP(gethEnvelope(elem)); err != nil {
+				return err
+			}
+
+			receipts = append(receipts, receipt)
+		}
+
+		return nil
+	}, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+// gethEnvelope returns the typed transaction or receipt as its EIP-2718 envelope, and the legacy one as its RLP
+func gethEnvelope(v *fastrlp.Value) []byte {
+	if v.Type() == fastrlp.TypeBytes {
+		return append([]byte{}, v.Raw()...)
+	}
+
+	return v.MarshalTo(nil)
+}
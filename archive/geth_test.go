@@ -0,0 +1,202 @@
+package archive
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/helper/progress"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+)
+
+// newTestReceiptsChain returns the blocks from the genesis to the given number, every block but the genesis
+// holding a legacy and a dynamic fee transaction, and the receipts of the blocks
+func newTestReceiptsChain(to uint64) ([]*types.Block, []types.Receipts) {
+	chain := make([]*types.Block, 0, to+1)
+	receipts := make([]types.Receipts, 0, to+1)
+
+	for number := uint64(0); number <= to; number++ {
+		block := &types.Block{Header: &types.Header{Number: number}}
+		blockReceipts := types.Receipts{}
+
+		if number > 0 {
+			recipient := types.StringToAddress("1")
+
+			block.Header.ParentHash = chain[number-1].Hash()
+			block.Transactions = []*types.Transaction{
+				{
+					Nonce:    number,
+					GasPrice: big.NewInt(1),
+					Gas:      21000,
+					To:       &recipient,
+					Value:    big.NewInt(int64(number)),
+					V:        big.NewInt(27),
+					R:        big.NewInt(1),
+					S:        big.NewInt(2),
+				},
+				{
+					Type:      types.DynamicFeeTx,
+					Nonce:     number,
+					GasTipCap: big.NewInt(1),
+					GasFeeCap: big.NewInt(2),
+					Gas:       21000,
+					To:        &recipient,
+					Value:     big.NewInt(int64(number)),
+					Input:     []byte{0x1},
+					V:         big.NewInt(1),
+					R:         big.NewInt(1),
+					S:         big.NewInt(2),
+					ChainID:   big.NewInt(100),
+				},
+			}
+
+			for i, tx := range block.Transactions {
+				tx.ComputeHash(number)
+
+				receipt := &types.Receipt{
+					TransactionType:   tx.Type,
+					CumulativeGasUsed: uint64(i+1) * 21000,
+					TxHash:            tx.Hash,
+					GasUsed:           21000,
+					Logs: []*types.Log{
+						{Address: recipient, Topics: []types.Hash{tx.Hash}, Data: []byte{byte(i)}},
+					},
+				}
+				receipt.SetStatus(types.ReceiptSuccess)
+
+				blockReceipts = append(blockReceipts, receipt)
+			}
+		}
+
+		block.Header.TxRoot = buildroot.CalculateTransactionsRoot(block.Transactions, number)
+		block.Header.ReceiptsRoot = buildroot.CalculateReceiptsRoot(blockReceipts)
+		block.Header.ComputeHash()
+
+		chain = append(chain, block)
+		receipts = append(receipts, blockReceipts)
+	}
+
+	return chain, receipts
+}
+
+func Test_gethCodec(t *testing.T) {
+	t.Parallel()
+
+	chain, receipts := newTestReceiptsChain(1)
+	block := chain[1]
+
+	data := marshalGethBlock(block)
+
+	// a typed transaction is a string holding its envelope, a legacy one is a list
+	var parser fastrlp.Parser
+
+	v, err := parser.Parse(data)
+	require.NoError(t, err)
+
+	txs, err := v.Get(1).GetElems()
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, fastrlp.TypeArray, txs[0].Type())
+	require.Equal(t, fastrlp.TypeBytes, txs[1].Type())
+
+	decoded, err := unmarshalGethBlock(data)
+	require.NoError(t, err)
+	require.Equal(t, block.Hash(), decoded.Hash())
+	require.Len(t, decoded.Transactions, 2)
+
+	for i, tx := range block.Transactions {
+		require.Equal(t, tx.Type, decoded.Transactions[i].Type)
+		require.Equal(t, tx.Hash, decoded.Transactions[i].Hash)
+	}
+
+	decoded, err = unmarshalGethBody(block.Header, marshalGethBody(block))
+	require.NoError(t, err)
+	require.Equal(t, block.Header.TxRoot, buildroot.CalculateTransactionsRoot(decoded.Transactions, 1))
+
+	decodedReceipts, err := unmarshalGethReceipts(marshalGethReceipts(receipts[1]))
+	require.NoError(t, err)
+	require.Equal(t, block.Header.ReceiptsRoot, buildroot.CalculateReceiptsRoot(decodedReceipts))
+	require.Equal(t, types.DynamicFeeTx, decodedReceipts[1].TransactionType)
+}
+
+func Test_readExportStream(t *testing.T) {
+	t.Parallel()
+
+	chain, receipts := newTestReceiptsChain(4)
+	stream := &mockSystemExportClient{recvs: exportReceiptsEvents(chain, receipts)}
+
+	exported := []*exportedBlock{}
+
+	from, to, err := readExportStream(stream, hclog.NewNullLogger(), func(block *exportedBlock) error {
+		exported = append(exported, block)
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), from)
+	require.Equal(t, uint64(4), to)
+	require.Len(t, exported, 5)
+
+	for i, block := range exported {
+		require.Equal(t, chain[i].Hash(), block.hash)
+		require.Equal(t, big.NewInt(int64(i+1)), block.totalDifficulty)
+		require.Len(t, block.receipts, len(receipts[i]))
+	}
+
+	// the receipts of a block are missing
+	events := exportReceiptsEvents(chain, receipts)
+	events[0].event.Receipts = events[0].event.Receipts[:1]
+
+	_, _, err = readExportStream(&mockSystemExportClient{recvs: events}, hclog.NewNullLogger(),
+		func(*exportedBlock) error {
+			return nil
+		})
+	require.Error(t, err)
+}
+
+func TestRestoreChain_RLP(t *testing.T) {
+	t.Parallel()
+
+	chain, _ := newTestReceiptsChain(5)
+	path := filepath.Join(t.TempDir(), "chain.rlp")
+
+	data := []byte{}
+	for _, block := range chain {
+		data = append(data, marshalGethBlock(block)...)
+	}
+
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	require.True(t, isRLPExport(path))
+
+	mock := &mockChain{genesis: chain[0], blocks: []*types.Block{}}
+	require.NoError(t, RestoreChain(mock, path, progress.NewProgressionWrapper(progress.ChainSyncRestore)))
+	require.Len(t, mock.blocks, 5)
+	require.Equal(t, chain[5].Hash(), getLatestBlockFromMockChain(mock).Hash())
+
+	// a backup file begins with its metadata
+	legacy := filepath.Join(t.TempDir(), "backup")
+	require.NoError(t, os.WriteFile(legacy, (&Metadata{Latest: 5, LatestHash: chain[5].Hash()}).MarshalRLP(), 0644))
+	require.False(t, isRLPExport(legacy))
+}
+
+// exportReceiptsEvents returns the export events of the blocks with their receipts, total difficulties
+// and hashes, at most two blocks per event
+func exportReceiptsEvents(blocks []*types.Block, receipts []types.Receipts) []recvData {
+	recvs := exportEvents(blocks)
+
+	for i, recv := range recvs {
+		for j := 2 * i; j < len(blocks) && j < 2*i+2; j++ {
+			recv.event.Receipts = append(recv.event.Receipts, receipts[j].MarshalStoreRLPTo(nil))
+			recv.event.TotalDifficulties = append(recv.event.TotalDifficulties, big.NewInt(int64(j+1)).Bytes())
+			recv.event.Hashes = append(recv.event.Hashes, blocks[j].Hash().Bytes())
+		}
+	}
+
+	return recvs
+}
@@ -0,0 +1,338 @@
+package archive
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// ManifestVersion is the version of the chunked backup format
+	ManifestVersion = 1
+
+	// ManifestFile is the name of the manifest in the backup directory
+	ManifestFile = "manifest.json"
+
+	// DefaultChunkSize is the default number of the blocks per chunk file
+	DefaultChunkSize = 10000
+)
+
+var (
+	ErrUnknownCompression  = errors.New("unknown compression")
+	ErrUnsupportedVersion  = errors.New("unsupported backup version")
+	ErrChecksumMismatch    = errors.New("chunk checksum mismatch")
+	ErrBackupMismatch      = errors.New("backup directory holds another backup")
+	ErrIncompleteBackup    = errors.New("backup is incomplete")
+	ErrInvalidChunkSize    = errors.New("chunk size must be greater than zero")
+	errManifestChunksOrder = errors.New("chunks of the manifest are not contiguous")
+)
+
+// Compression is the compression of the chunk files
+type Compression string
+
+const (
+	CompressionNone Compression = "none"
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+)
+
+// Compressions returns the supported compressions
+func Compressions() []Compression {
+	return []Compression{CompressionZstd, CompressionGzip, CompressionNone}
+}
+
+// ParseCompression returns the compression with the given name
+func ParseCompression(name string) (Compression, error) {
+	for _, c := range Compressions() {
+		if string(c) == name {
+			return c, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownCompression, name)
+}
+
+// extension returns the extension of the chunk files with the compression
+func (c Compression) extension() string {
+	switch c {
+	case CompressionGzip:
+		return ".gz"
+	case CompressionZstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+// newWriter returns the writer compressing the data written to w
+func (c Compression) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c {
+	case CompressionNone:
+		return nopWriteCloser{w}, nil
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCompression, c)
+	}
+}
+
+// newReader returns the reader decompressing the data read from r
+func (c Compression) newReader(r io.Reader) (io.ReadCloser, error) {
+	switch c {
+	case CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		return gzip.NewReader(r)
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCompression, c)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// Manifest describes the chunked backup in a directory. The blocks from From to To are
+// stored in order in the chunk files, each holding the RLP encoded blocks of its range
+type Manifest struct {
+	Version     uint64      `json:"version"`
+	Compression Compression `json:"compression"`
+	ChunkSize   uint64      `json:"chunkSize"`
+	From        uint64      `json:"from"`
+	To          uint64      `json:"to"`
+	ToHash      types.Hash  `json:"toHash"`
+	Chunks      []*Chunk    `json:"chunks"`
+}
+
+// Chunk is a chunk file of the backup, the checksum is the SHA-256 of the file
+type Chunk struct {
+	File     string     `json:"file"`
+	From     uint64     `json:"from"`
+	To       uint64     `json:"to"`
+	Size     uint64     `json:"size"`
+	Checksum types.Hash `json:"checksum"`
+}
+
+// next returns the number of the first block which is not in the completed chunks
+func (m *Manifest) next() uint64 {
+	if len(m.Chunks) == 0 {
+		return m.From
+	}
+
+	return m.Chunks[len(m.Chunks)-1].To + 1
+}
+
+// complete returns true if the chunks hold all the blocks of the backup
+func (m *Manifest) complete() bool {
+	return m.next() > m.To
+}
+
+// validate checks the version of the manifest and the ranges of its chunks
+func (m *Manifest) validate() error {
+	if m.Version != ManifestVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, m.Version)
+	}
+
+	if _, err := ParseCompression(string(m.Compression)); err != nil {
+		return err
+	}
+
+	next := m.From
+
+	for _, chunk := range m.Chunks {
+		if chunk.From != next || chunk.To < chunk.From {
+			return fmt.Errorf("%w: chunk %s has blocks %d-%d", errManifestChunksOrder, chunk.File, chunk.From, chunk.To)
+		}
+
+		next = chunk.To + 1
+	}
+
+	return nil
+}
+
+// isChunkedBackup returns true if the path is the directory of a chunked backup
+func isChunkedBackup(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
+
+// readManifest reads and validates the manifest in the backup directory
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode the manifest: %w", err)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// writeManifest replaces the manifest in the backup directory at once
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomically(filepath.Join(dir, ManifestFile), data)
+}
+
+// writeFileAtomically writes the file next to the path and renames it, so that
+// the path holds either the previous content or the new one
+func writeFileAtomically(path string, data []byte) error {
+	tmpPath := path + tmpExt
+
+	fs, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fs.Write(data); err != nil {
+		return errors.Join(err, fs.Close())
+	}
+
+	if err := fs.Sync(); err != nil {
+		return errors.Join(err, fs.Close())
+	}
+
+	if err := fs.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// chunkReader reads the decompressed content of the chunk files in order,
+// and verifies the checksum of every chunk file before it is decompressed
+type chunkReader struct {
+	dir         string
+	compression Compression
+	chunks      []*Chunk
+
+	// the chunk being read
+	file         *os.File
+	decompressor io.ReadCloser
+}
+
+func newChunkReader(dir string, manifest *Manifest) *chunkReader {
+	return &chunkReader{
+		dir:         dir,
+		compression: manifest.Compression,
+		chunks:      manifest.Chunks,
+	}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.decompressor == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+
+			if err := r.open(); err != nil {
+				return 0, err
+			}
+		}
+
+		n, err := r.decompressor.Read(p)
+		if !errors.Is(err, io.EOF) {
+			return n, err
+		}
+
+		if err := r.Close(); err != nil {
+			return n, err
+		}
+
+		r.chunks = r.chunks[1:]
+
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// open verifies the checksum of the next chunk file, so that none of its blocks
+// is decoded from a corrupted file, and starts reading it
+func (r *chunkReader) open() error {
+	chunk := r.chunks[0]
+
+	file, err := os.Open(filepath.Join(r.dir, chunk.File))
+	if err != nil {
+		return err
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	if checksum := types.BytesToHash(hasher.Sum(nil)); checksum != chunk.Checksum {
+		return errors.Join(
+			fmt.Errorf("%w: %s has checksum %s, expected %s", ErrChecksumMismatch, chunk.File, checksum, chunk.Checksum),
+			file.Close(),
+		)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return errors.Join(err, file.Close())
+	}
+
+	r.file = file
+
+	r.decompressor, err = r.compression.newReader(file)
+	if err != nil {
+		return errors.Join(err, r.Close())
+	}
+
+	return nil
+}
+
+// Close closes the chunk file being read
+func (r *chunkReader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+
+	var err error
+	if r.decompressor != nil {
+		err = r.decompressor.Close()
+	}
+
+	err = errors.Join(err, r.file.Close())
+
+	r.file = nil
+	r.decompressor = nil
+
+	return err
+}
@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -27,22 +28,59 @@ type blockchainInterface interface {
 	VerifyFinalizedBlock(*types.Block) (*types.FullBlock, error)
 }
 
-// RestoreChain reads blocks from the archive and write to the chain
+// RestoreChain reads blocks from the archive and write to the chain. The archive is either a backup file,
+// the directory of a chunked backup, a file of the concatenated blocks exported by geth, or an Era1 file
+// or a directory of Era1 files, whose accumulators and receipts are verified before the blocks are written
 func RestoreChain(chain blockchainInterface, filePath string, progression *progress.ProgressionWrapper) error {
+	if paths, ok := eraFiles(filePath); ok {
+		return restoreEra(chain, paths, progression)
+	}
+
+	if isChunkedBackup(filePath) {
+		return restoreChunkedBackup(chain, filePath, progression)
+	}
+
+	if isRLPExport(filePath) {
+		return restoreRLPExport(chain, filePath, progression)
+	}
+
 	fp, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
 
+	defer fp.Close()
+
 	blockStream := newBlockStream(fp)
 
 	return importBlocks(chain, blockStream, progression)
 }
 
+// restoreChunkedBackup reads the blocks from the chunk files of the backup directory and writes them to the chain
+func restoreChunkedBackup(chain blockchainInterface, dir string, progression *progress.ProgressionWrapper) error {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if !manifest.complete() {
+		return fmt.Errorf("%w: blocks up to %d of %d are backed up", ErrIncompleteBackup, manifest.next()-1, manifest.To)
+	}
+
+	chunks := newChunkReader(dir, manifest)
+	defer chunks.Close()
+
+	// the chunks are read as a backup file, whose metadata is the last block of the manifest
+	metadata := &Metadata{
+		Latest:     manifest.To,
+		LatestHash: manifest.ToHash,
+	}
+
+	return importBlocks(chain, newBlockStream(io.MultiReader(bytes.NewReader(metadata.MarshalRLP()), chunks)), progression)
+}
+
 // import blocks scans all blocks from stream and write them to chain
 func importBlocks(chain blockchainInterface, blockStream *blockStream, progression *progress.ProgressionWrapper) error {
-	shutdownCh := common.GetTerminationSignalCh()
-
 	metadata, err := blockStream.getMetadata()
 	if err != nil {
 		return err
@@ -58,8 +96,22 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 		return nil
 	}
 
+	return writeBlocks(chain, blockStream.nextBlock, metadata.Latest, progression)
+}
+
+// writeBlocks verifies and writes the blocks returned by next to the chain, until next returns no block.
+// The blocks the chain has already are skipped. If the latest block is not known in advance, zero is given
+// and the goal of the progression follows the written blocks
+func writeBlocks(
+	chain blockchainInterface,
+	next func() (*types.Block, error),
+	latest uint64,
+	progression *progress.ProgressionWrapper,
+) error {
+	shutdownCh := common.GetTerminationSignalCh()
+
 	// skip existing blocks
-	firstBlock, err := consumeCommonBlocks(chain, blockStream, shutdownCh)
+	firstBlock, err := consumeCommonBlocks(chain, next, shutdownCh)
 	if err != nil {
 		return err
 	}
@@ -78,11 +130,17 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 	}()
 
 	// Set the goal
-	progression.UpdateHighestProgression(metadata.Latest)
+	if latest != 0 {
+		progression.UpdateHighestProgression(latest)
+	}
 
 	nextBlock := firstBlock
 
 	for {
+		if latest == 0 {
+			progression.UpdateHighestProgression(nextBlock.Number())
+		}
+
 		if _, err := chain.VerifyFinalizedBlock(nextBlock); err != nil {
 			return err
 		}
@@ -93,7 +151,7 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 
 		progression.UpdateCurrentProgression(nextBlock.Number())
 
-		nextBlock, err = blockStream.nextBlock()
+		nextBlock, err = next()
 		if err != nil {
 			return err
 		}
@@ -116,11 +174,11 @@ func importBlocks(chain blockchainInterface, blockStream *blockStream, progressi
 // returns the first block to be written into chain
 func consumeCommonBlocks(
 	chain blockchainInterface,
-	blockStream *blockStream,
+	next func() (*types.Block, error),
 	shutdownCh <-chan os.Signal,
 ) (*types.Block, error) {
 	for {
-		block, err := blockStream.nextBlock()
+		block, err := next()
 		if err != nil {
 			return nil, err
 		}
@@ -224,7 +282,7 @@ func (b *blockStream) loadRLPArray() (uint64, error) {
 // loadRLPPrefix loads first byte of RLP encoded data from input
 func (b *blockStream) loadRLPPrefix() (byte, error) {
 	buf := b.buffer[:1]
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return 0, err
 	}
 
@@ -246,17 +304,17 @@ func (b *blockStream) loadPrefixSize(offset uint64, prefix byte) (uint64, uint64
 
 		b.reserveCap(offset + payloadSizeSize)
 		payloadSizeBytes := b.buffer[offset : offset+payloadSizeSize]
-		n, err := b.input.Read(payloadSizeBytes)
-
-		if err != nil {
-			return 0, 0, err
-		}
+		n, err := io.ReadFull(b.input, payloadSizeBytes)
 
 		if uint64(n) < payloadSizeSize {
 			// couldn't load required amount of bytes
 			return 0, 0, io.EOF
 		}
 
+		if err != nil {
+			return 0, 0, err
+		}
+
 		payloadSize := new(big.Int).SetBytes(payloadSizeBytes).Int64()
 
 		return payloadSizeSize + 1, uint64(payloadSize), nil
@@ -270,7 +328,7 @@ func (b *blockStream) loadPayload(offset uint64, size uint64) error {
 	b.reserveCap(offset + size)
 	buf := b.buffer[offset : offset+size]
 
-	if _, err := b.input.Read(buf); err != nil {
+	if _, err := io.ReadFull(b.input, buf); err != nil {
 		return err
 	}
 
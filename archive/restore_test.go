@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
 	"testing"
 
@@ -55,6 +56,12 @@ func (m *mockChain) WriteBlock(block *types.Block, _ string) error {
 	return nil
 }
 
+func (m *mockChain) WritePivotBlock(block *types.FullBlock, _ *big.Int, _ []*types.Header) error {
+	m.blocks = append(m.blocks, block.Block)
+
+	return nil
+}
+
 func (m *mockChain) VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error) {
 	return &types.FullBlock{Block: block}, nil
 }
@@ -189,7 +196,7 @@ func Test_consumeCommonBlocks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			osSignal := make(<-chan os.Signal)
-			resultBlock, err := consumeCommonBlocks(tt.chain, tt.blockStream, osSignal)
+			resultBlock, err := consumeCommonBlocks(tt.chain, tt.blockStream.nextBlock, osSignal)
 
 			assert.Equal(t, tt.block, resultBlock)
 			assert.Equal(t, tt.err, err)
@@ -0,0 +1,432 @@
+package archive
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// StateBackupVersion is the version of the state backup format
+	StateBackupVersion = 2
+
+	// stateBackupLogInterval is the number of the state items between the progress logs
+	stateBackupLogInterval = 100000
+
+	// restoreHeadersBatchSize is the number of the headers verified and written at once
+	restoreHeadersBatchSize = 1000
+)
+
+var (
+	// stateBackupMagic is the first field of a state backup, which tells it apart from a backup of blocks
+	stateBackupMagic = []byte("polygon-edge state backup")
+
+	ErrNotStateBackup     = errors.New("file is not a state backup")
+	ErrStateRootMismatch  = errors.New("restored state doesn't match the state root of the block")
+	ErrStateBlockMismatch = errors.New("block of the state backup doesn't match the verified header")
+)
+
+// StateBackupResult describes the state backup
+type StateBackupResult struct {
+	Header  *types.Header
+	Headers uint64
+	Nodes   uint64
+	Codes   uint64
+}
+
+// CreateStateBackup fetches the state of the block via gRPC and saves it to the given path, together
+// with the block and the headers of its ancestors. The file holds the trie nodes of the accounts and of
+// the storages and the contract codes, so that a node restores the state without executing the blocks.
+// The latest block is backed up if the number is zero
+func CreateStateBackup(
+	conn *grpc.ClientConn,
+	logger hclog.Logger,
+	number uint64,
+	outPath string,
+) (*StateBackupResult, error) {
+	// always create new file, throw error if the file exists
+	fs, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	closeAndRemoveFile := func() {
+		if err := fs.Close(); err != nil {
+			logger.Error("an error occurred while closing file", "err", err)
+
+			return
+		}
+
+		if err := os.Remove(outPath); err != nil {
+			logger.Error("an error occurred while removing file", "err", err)
+		}
+	}
+
+	signalCh := common.GetTerminationSignalCh()
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	defer cancelFn()
+
+	go func() {
+		<-signalCh
+		logger.Info("Caught termination signal, shutting down...")
+		cancelFn()
+	}()
+
+	clt := proto.NewSystemClient(conn)
+
+	status, err := clt.GetStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		closeAndRemoveFile()
+
+		return nil, err
+	}
+
+	stream, err := clt.ExportState(ctx, &proto.ExportStateRequest{Number: number})
+	if err != nil {
+		closeAndRemoveFile()
+
+		return nil, err
+	}
+
+	w := bufio.NewWriter(fs)
+
+	result, err := writeStateBackup(stream, logger, w, types.StringToHash(status.Genesis))
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err == nil {
+		err = fs.Sync()
+	}
+
+	if err != nil {
+		closeAndRemoveFile()
+
+		return nil, err
+	}
+
+	if err := fs.Close(); err != nil {
+		return nil, errors.Join(err, os.Remove(outPath))
+	}
+
+	return result, nil
+}
+
+// writeStateBackup writes the block, the headers and the state items of the export stream to the writer,
+// and closes the backup with the number of the written trie nodes and contract codes
+func writeStateBackup(
+	stream proto.System_ExportStateClient,
+	logger hclog.Logger,
+	w io.Writer,
+	genesis types.Hash,
+) (*StateBackupResult, error) {
+	event, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(event.Block) == 0 {
+		return nil, errors.New("expected the block in the first state export event")
+	}
+
+	metadata := &StateMetadata{
+		Version:         StateBackupVersion,
+		Genesis:         genesis,
+		Block:           &types.Block{},
+		Receipts:        types.Receipts{},
+		TotalDifficulty: new(big.Int).SetBytes(event.TotalDifficulty),
+	}
+
+	if err := metadata.Block.UnmarshalRLP(event.Block); err != nil {
+		return nil, fmt.Errorf("failed to decode the block: %w", err)
+	}
+
+	if err := metadata.Receipts.UnmarshalStoreRLP(event.Receipts); err != nil {
+		return nil, fmt.Errorf("failed to decode the receipts: %w", err)
+	}
+
+	if _, err := w.Write(metadata.MarshalRLP()); err != nil {
+		return nil, err
+	}
+
+	header := metadata.Block.Header
+	result := &StateBackupResult{Header: header}
+
+	logger.Info("Wrote metadata to state backup", "number", header.Number, "root", header.StateRoot)
+
+	var buf []byte
+
+	writeItem := func(item *stateItem) error {
+		buf = types.MarshalRLPTo(item.MarshalRLPWith, buf[:0])
+		_, err := w.Write(buf)
+
+		return err
+	}
+
+	for {
+		for _, header := range event.Headers {
+			if err := writeItem(&stateItem{Kind: stateItemHeader, Data: header}); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, node := range event.Nodes {
+			if err := writeItem(&stateItem{Kind: stateItemNode, Data: node}); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, code := range event.Codes {
+			if err := writeItem(&stateItem{Kind: stateItemCode, Data: code}); err != nil {
+				return nil, err
+			}
+		}
+
+		written := result.Nodes + result.Codes
+		result.Headers += uint64(len(event.Headers))
+		result.Nodes += uint64(len(event.Nodes))
+		result.Codes += uint64(len(event.Codes))
+
+		if (result.Nodes+result.Codes)/stateBackupLogInterval != written/stateBackupLogInterval {
+			logger.Info("State items are written", "nodes", result.Nodes, "codes", result.Codes)
+		}
+
+		event, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeItem(&stateItem{Kind: stateItemEnd, Nodes: result.Nodes, Codes: result.Codes}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+type stateChainInterface interface {
+	Genesis() types.Hash
+	Header() *types.Header
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	GetTD(types.Hash) (*big.Int, bool)
+	WritePivotHeaders([]*types.Header) error
+	WritePivotBlock(*types.FullBlock, *big.Int, []*types.Header) error
+}
+
+// IsStateBackup returns true if the file is a state backup
+func IsStateBackup(filePath string) bool {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+
+	defer fp.Close()
+
+	_, err = readStateMetadata(newBlockStream(bufio.NewReader(fp)))
+
+	return err == nil
+}
+
+// RestoreState writes the trie nodes and the contract codes of the state backup to the storage, and
+// writes the block of the state as the head of the empty chain once the state root is verified.
+// The headers up to the block are verified with the consensus and written first, as in a snap sync,
+// so the block has to match the verified header
+func RestoreState(chain stateChainInterface, storage itrie.Storage, filePath string, logger hclog.Logger) error {
+	fp, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+
+	defer fp.Close()
+
+	stream := newBlockStream(bufio.NewReader(fp))
+
+	metadata, err := readStateMetadata(stream)
+	if err != nil {
+		return err
+	}
+
+	if metadata.Version != StateBackupVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, metadata.Version)
+	}
+
+	if metadata.Genesis != chain.Genesis() {
+		return fmt.Errorf(
+			"the genesis of the state backup (%s) does not match blockchain genesis (%s)",
+			metadata.Genesis,
+			chain.Genesis(),
+		)
+	}
+
+	header := metadata.Block.Header
+
+	// the state was restored already
+	if chain.Header().Hash == header.Hash {
+		return nil
+	}
+
+	logger.Info("restoring state", "number", header.Number, "root", header.StateRoot)
+
+	result, err := importState(stream, chain, itrie.NewStateImport(storage))
+	if err != nil {
+		return err
+	}
+
+	if verified, ok := chain.GetHeaderByNumber(header.Number); !ok || verified.Hash != header.Hash {
+		return fmt.Errorf("%w: block %d", ErrStateBlockMismatch, header.Number)
+	}
+
+	td, ok := chain.GetTD(header.Hash)
+	if !ok {
+		return fmt.Errorf("total difficulty of block %d not found", header.Number)
+	}
+
+	root, err := itrie.HashChecker(header.StateRoot.Bytes(), storage)
+	if err != nil {
+		return err
+	}
+
+	if root != header.StateRoot {
+		return fmt.Errorf("%w: expected %s, got %s", ErrStateRootMismatch, header.StateRoot, root)
+	}
+
+	// the hash checker covers the account trie only, the storage tries and the codes are read once more
+	if err := itrie.ExportState(storage, header.StateRoot, func([]byte, bool) error {
+		return nil
+	}); err != nil {
+		return fmt.Errorf("%w: %w", ErrStateRootMismatch, err)
+	}
+
+	logger.Info("state restored",
+		"number", header.Number, "headers", result.Headers, "nodes", result.Nodes, "codes", result.Codes)
+
+	// the ancestors are written with the verified headers
+	return chain.WritePivotBlock(&types.FullBlock{Block: metadata.Block, Receipts: metadata.Receipts}, td, nil)
+}
+
+// readStateMetadata reads the metadata in the beginning of the state backup
+func readStateMetadata(stream *blockStream) (*StateMetadata, error) {
+	size, err := stream.loadRLPArray()
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, ErrNotStateBackup
+	}
+
+	metadata := &StateMetadata{}
+	if err := metadata.UnmarshalRLP(stream.buffer[:size]); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// importState verifies and writes the headers of the stream, and imports the state items until the end
+// of the state backup. It returns the number of the written headers, trie nodes and contract codes
+func importState(
+	stream *blockStream,
+	chain stateChainInterface,
+	imp *itrie.StateImport,
+) (*StateBackupResult, error) {
+	var (
+		result  = &StateBackupResult{}
+		headers = make([]*types.Header, 0, restoreHeadersBatchSize)
+		parser  fastrlp.Parser
+	)
+
+	writeHeaders := func() error {
+		if len(headers) == 0 {
+			return nil
+		}
+
+		if err := chain.WritePivotHeaders(headers); err != nil {
+			return fmt.Errorf("failed to verify headers: %w", err)
+		}
+
+		result.Headers += uint64(len(headers))
+		headers = headers[:0]
+
+		return nil
+	}
+
+	for {
+		size, err := stream.loadRLPArray()
+		if err != nil {
+			return nil, err
+		}
+
+		if size == 0 {
+			return nil, fmt.Errorf("%w: %d nodes and %d codes are backed up",
+				ErrIncompleteBackup, result.Nodes, result.Codes)
+		}
+
+		v, err := parser.Parse(stream.buffer[:size])
+		if err != nil {
+			return nil, err
+		}
+
+		item := &stateItem{}
+		if err := item.UnmarshalRLPFrom(&parser, v); err != nil {
+			return nil, err
+		}
+
+		if item.Kind == stateItemHeader {
+			header := &types.Header{}
+			if err := header.UnmarshalRLP(item.Data); err != nil {
+				return nil, fmt.Errorf("failed to decode the header: %w", err)
+			}
+
+			if headers = append(headers, header); len(headers) == restoreHeadersBatchSize {
+				if err := writeHeaders(); err != nil {
+					return nil, err
+				}
+			}
+
+			continue
+		}
+
+		// the headers precede the state items
+		if err := writeHeaders(); err != nil {
+			return nil, err
+		}
+
+		switch item.Kind {
+		case stateItemNode:
+			result.Nodes++
+		case stateItemCode:
+			result.Codes++
+		case stateItemEnd:
+			if item.Nodes != result.Nodes || item.Codes != result.Codes {
+				return nil, fmt.Errorf("%w: expected %d nodes and %d codes, found %d and %d",
+					ErrIncompleteBackup, item.Nodes, item.Codes, result.Nodes, result.Codes)
+			}
+
+			return result, imp.Commit()
+		}
+
+		if err := imp.Put(item.Data, item.Kind == stateItemCode); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package archive
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/server/proto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockSystemExportStateClient struct {
+	proto.System_ExportStateClient
+	events []*proto.ExportStateEvent
+}
+
+func (m *mockSystemExportStateClient) Recv() (*proto.ExportStateEvent, error) {
+	if len(m.events) == 0 {
+		return nil, io.EOF
+	}
+
+	event := m.events[0]
+	m.events = m.events[1:]
+
+	return event, nil
+}
+
+type mockStateChain struct {
+	genesis *types.Block
+	headers map[uint64]*types.Header
+	blocks  []*types.Block
+}
+
+func newMockStateChain(genesis *types.Block) *mockStateChain {
+	return &mockStateChain{
+		genesis: genesis,
+		headers: map[uint64]*types.Header{0: genesis.Header},
+	}
+}
+
+func (m *mockStateChain) Genesis() types.Hash {
+	return m.genesis.Hash()
+}
+
+func (m *mockStateChain) Header() *types.Header {
+	if l := len(m.blocks); l != 0 {
+		return m.blocks[l-1].Header
+	}
+
+	return m.genesis.Header
+}
+
+func (m *mockStateChain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	header, ok := m.headers[number]
+
+	return header, ok
+}
+
+func (m *mockStateChain) GetTD(hash types.Hash) (*big.Int, bool) {
+	for _, header := range m.headers {
+		if header.Hash == hash {
+			return new(big.Int).SetUint64(header.Number), true
+		}
+	}
+
+	return nil, false
+}
+
+// WritePivotHeaders checks that the headers extend the written ones, in place of the consensus
+func (m *mockStateChain) WritePivotHeaders(headers []*types.Header) error {
+	for _, header := range headers {
+		parent, ok := m.headers[header.Number-1]
+		if !ok || parent.Hash != header.ParentHash {
+			return blockchain.ErrParentHashMismatch
+		}
+
+		m.headers[header.Number] = header
+	}
+
+	return nil
+}
+
+func (m *mockStateChain) WritePivotBlock(block *types.FullBlock, _ *big.Int, _ []*types.Header) error {
+	m.blocks = append(m.blocks, block.Block)
+
+	return nil
+}
+
+// newTestState commits accounts with storage slots, half of them sharing the same code
+func newTestState(t *testing.T) (itrie.Storage, types.Hash) {
+	t.Helper()
+
+	storage := itrie.NewMemoryStorage()
+	code := []byte{0x60, 0x01, 0x60, 0x02, 0x01}
+	objs := make([]*state.Object, 0, 20)
+
+	for i := 0; i < 20; i++ {
+		obj := &state.Object{
+			Address: types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance: big.NewInt(int64(i + 1)),
+			Nonce:   uint64(i),
+			Root:    types.EmptyRootHash,
+		}
+
+		for slot := 0; slot < i; slot++ {
+			obj.Storage = append(obj.Storage, &state.StorageObject{
+				Key: crypto.Keccak256(types.BytesToHash([]byte{byte(slot)}).Bytes()),
+				Val: []byte{byte(slot + 1)},
+			})
+		}
+
+		if i%2 == 0 {
+			obj.Code = code
+			obj.CodeHash = types.BytesToHash(crypto.Keccak256(code))
+			obj.DirtyCode = true
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := itrie.NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	return storage, types.BytesToHash(root)
+}
+
+// exportStateEvents returns the export events of the block, the headers and the state,
+// at most three state items per event
+func exportStateEvents(
+	t *testing.T,
+	storage itrie.Storage,
+	block *types.Block,
+	headers []*types.Header,
+) []*proto.ExportStateEvent {
+	t.Helper()
+
+	events := []*proto.ExportStateEvent{{
+		Block:           block.MarshalRLP(),
+		Receipts:        types.Receipts{}.MarshalStoreRLPTo(nil),
+		TotalDifficulty: big.NewInt(10).Bytes(),
+	}}
+
+	for _, header := range headers {
+		events = append(events, &proto.ExportStateEvent{Headers: [][]byte{header.MarshalRLP()}})
+	}
+
+	events = append(events, &proto.ExportStateEvent{})
+
+	require.NoError(t, itrie.ExportState(storage, block.Header.StateRoot, func(data []byte, code bool) error {
+		event := events[len(events)-1]
+		if len(event.Nodes)+len(event.Codes) == 3 {
+			event = &proto.ExportStateEvent{}
+			events = append(events, event)
+		}
+
+		if code {
+			event.Codes = append(event.Codes, data)
+		} else {
+			event.Nodes = append(event.Nodes, data)
+		}
+
+		return nil
+	}))
+
+	return events
+}
+
+// newTestStateBlock returns the chain up to the block of the state and the headers from the block 1
+// up to the block
+func newTestStateBlock(root types.Hash) ([]*types.Block, *types.Block, []*types.Header) {
+	chain := newTestChain(4)
+
+	header := &types.Header{Number: 5, ParentHash: chain[4].Hash(), StateRoot: root}
+	header.ComputeHash()
+
+	headers := make([]*types.Header, 0, len(chain))
+	for _, block := range chain[1:] {
+		headers = append(headers, block.Header)
+	}
+
+	return chain, &types.Block{Header: header}, append(headers, header)
+}
+
+// writeTestStateBackup writes the state backup of the events to a file and returns its path
+func writeTestStateBackup(t *testing.T, events []*proto.ExportStateEvent, genesis types.Hash) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	stream := &mockSystemExportStateClient{events: events}
+	_, err := writeStateBackup(stream, hclog.NewNullLogger(), &buf, genesis)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "state")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	return path
+}
+
+func TestStateBackup(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+	chain, block, headers := newTestStateBlock(root)
+	header := block.Header
+
+	var buf bytes.Buffer
+
+	stream := &mockSystemExportStateClient{events: exportStateEvents(t, source, block, headers)}
+	result, err := writeStateBackup(stream, hclog.NewNullLogger(), &buf, chain[0].Hash())
+	require.NoError(t, err)
+	require.Equal(t, header.Hash, result.Header.Hash)
+	require.Equal(t, uint64(5), result.Headers)
+	require.Equal(t, uint64(1), result.Codes)
+	require.NotZero(t, result.Nodes)
+
+	path := filepath.Join(t.TempDir(), "state")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	require.True(t, IsStateBackup(path))
+
+	storage := itrie.NewMemoryStorage()
+	mock := newMockStateChain(chain[0])
+	require.NoError(t, RestoreState(mock, storage, path, hclog.NewNullLogger()))
+	require.Len(t, mock.blocks, 1)
+	require.Equal(t, header.Hash, mock.blocks[0].Hash())
+	require.Len(t, mock.headers, 6)
+
+	restored, err := itrie.HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, restored)
+
+	// the state is not restored twice
+	require.NoError(t, RestoreState(mock, storage, path, hclog.NewNullLogger()))
+	require.Len(t, mock.blocks, 1)
+
+	// the state backup of another chain is not restored
+	other := newMockStateChain(chain[1])
+	require.Error(t, RestoreState(other, itrie.NewMemoryStorage(), path, hclog.NewNullLogger()))
+
+	// a truncated state backup is not restored
+	require.NoError(t, os.WriteFile(path, buf.Bytes()[:buf.Len()-20], 0644))
+
+	mock = newMockStateChain(chain[0])
+	require.Error(t, RestoreState(mock, itrie.NewMemoryStorage(), path, hclog.NewNullLogger()))
+	require.Empty(t, mock.blocks)
+
+	// the backup of the blocks is not a state backup
+	legacy := filepath.Join(t.TempDir(), "backup")
+	require.NoError(t, os.WriteFile(legacy, (&Metadata{Latest: 1}).MarshalRLP(), 0644))
+	require.False(t, IsStateBackup(legacy))
+}
+
+func TestRestoreState_UnverifiedBlock(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+	chain, block, headers := newTestStateBlock(root)
+
+	// the forged block has the same parent as the verified one
+	forged := block.Header.Copy()
+	forged.ExtraData = []byte{0x1}
+	forged.ComputeHash()
+
+	// the header which doesn't extend its parent fails the verification
+	broken := headers[2].Copy()
+	broken.ParentHash = types.StringToHash("2")
+	broken.ComputeHash()
+
+	cases := []struct {
+		name    string
+		block   *types.Block
+		headers []*types.Header
+		err     error
+	}{
+		{
+			name:    "forged block",
+			block:   &types.Block{Header: forged},
+			headers: headers,
+			err:     ErrStateBlockMismatch,
+		},
+		{
+			name:    "missing headers",
+			block:   block,
+			headers: headers[:3],
+			err:     ErrStateBlockMismatch,
+		},
+		{
+			name:    "unverified header",
+			block:   block,
+			headers: append(append(append([]*types.Header{}, headers[:2]...), broken), headers[3:]...),
+			err:     blockchain.ErrParentHashMismatch,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeTestStateBackup(t, exportStateEvents(t, source, c.block, c.headers), chain[0].Hash())
+
+			mock := newMockStateChain(chain[0])
+			err := RestoreState(mock, itrie.NewMemoryStorage(), path, hclog.NewNullLogger())
+			require.ErrorIs(t, err, c.err)
+			require.Empty(t, mock.blocks)
+		})
+	}
+}
+
+func TestRestoreState_MissingItem(t *testing.T) {
+	t.Parallel()
+
+	source, root := newTestState(t)
+	chain, block, headers := newTestStateBlock(root)
+
+	cases := []struct {
+		name string
+		drop func(events []*proto.ExportStateEvent)
+	}{
+		{
+			name: "state root",
+			drop: func(events []*proto.ExportStateEvent) {
+				// the state root is the first exported item
+				for _, event := range events {
+					if len(event.Nodes) != 0 {
+						event.Nodes = event.Nodes[1:]
+
+						return
+					}
+				}
+			},
+		},
+		{
+			name: "code",
+			drop: func(events []*proto.ExportStateEvent) {
+				for _, event := range events {
+					event.Codes = nil
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			events := exportStateEvents(t, source, block, headers)
+			c.drop(events)
+
+			path := writeTestStateBackup(t, events, chain[0].Hash())
+
+			mock := newMockStateChain(chain[0])
+			err := RestoreState(mock, itrie.NewMemoryStorage(), path, hclog.NewNullLogger())
+			require.ErrorIs(t, err, ErrStateRootMismatch)
+			require.Empty(t, mock.blocks)
+		})
+	}
+}
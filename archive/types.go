@@ -1,7 +1,9 @@
 package archive
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/umbracle/fastrlp"
@@ -59,3 +61,170 @@ func (m *Metadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	return nil
 }
+
+// StateMetadata is the data stored in the beginning of a state backup,
+// the block whose state is backed up is written as the head once the state is restored
+type StateMetadata struct {
+	Version         uint64
+	Genesis         types.Hash
+	Block           *types.Block
+	Receipts        types.Receipts
+	TotalDifficulty *big.Int
+}
+
+// MarshalRLP returns RLP encoded bytes
+func (m *StateMetadata) MarshalRLP() []byte {
+	return m.MarshalRLPTo(nil)
+}
+
+// MarshalRLPTo sets RLP encoded bytes to given byte slice
+func (m *StateMetadata) MarshalRLPTo(dst []byte) []byte {
+	return types.MarshalRLPTo(m.MarshalRLPWith, dst)
+}
+
+// MarshalRLPWith appends own field into arena for encode
+func (m *StateMetadata) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBytes(stateBackupMagic))
+	vv.Set(arena.NewUint(m.Version))
+	vv.Set(arena.NewBytes(m.Genesis.Bytes()))
+	vv.Set(arena.NewBytes(m.Block.MarshalRLP()))
+	vv.Set(arena.NewBytes(m.Receipts.MarshalStoreRLPTo(nil)))
+	vv.Set(arena.NewBigInt(m.TotalDifficulty))
+
+	return vv
+}
+
+// UnmarshalRLP unmarshals and sets the fields from RLP encoded bytes
+func (m *StateMetadata) UnmarshalRLP(input []byte) error {
+	return types.UnmarshalRlp(m.UnmarshalRLPFrom, input)
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (m *StateMetadata) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 6 {
+		return fmt.Errorf("incorrect number of elements to decode StateMetadata, expected 6 but found %d", len(elems))
+	}
+
+	magic, err := elems[0].Bytes()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(magic, stateBackupMagic) {
+		return ErrNotStateBackup
+	}
+
+	if m.Version, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if err = elems[2].GetHash(m.Genesis[:]); err != nil {
+		return err
+	}
+
+	block, err := elems[3].Bytes()
+	if err != nil {
+		return err
+	}
+
+	m.Block = &types.Block{}
+	if err := m.Block.UnmarshalRLP(block); err != nil {
+		return err
+	}
+
+	receipts, err := elems[4].Bytes()
+	if err != nil {
+		return err
+	}
+
+	m.Receipts = types.Receipts{}
+	if err := m.Receipts.UnmarshalStoreRLP(receipts); err != nil {
+		return err
+	}
+
+	m.TotalDifficulty = new(big.Int)
+
+	return elems[5].GetBigInt(m.TotalDifficulty)
+}
+
+// stateItem is a trie node, a contract code, a header or the end of a state backup
+type stateItem struct {
+	Kind stateItemKind
+	// Data is the trie node, the contract code or the RLP encoded header
+	Data []byte
+	// Nodes and Codes are the number of the items in the backup, set in the end item only
+	Nodes uint64
+	Codes uint64
+}
+
+type stateItemKind uint64
+
+const (
+	stateItemNode stateItemKind = iota
+	stateItemCode
+	stateItemEnd
+	stateItemHeader
+)
+
+// MarshalRLPWith appends own field into arena for encode
+func (s *stateItem) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewUint(uint64(s.Kind)))
+
+	if s.Kind == stateItemEnd {
+		vv.Set(arena.NewUint(s.Nodes))
+		vv.Set(arena.NewUint(s.Codes))
+	} else {
+		vv.Set(arena.NewBytes(s.Data))
+	}
+
+	return vv
+}
+
+// UnmarshalRLPFrom sets the fields from parsed RLP encoded value
+func (s *stateItem) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	if len(elems) < 2 {
+		return fmt.Errorf("incorrect number of elements to decode state item, expected at least 2 but found %d", len(elems))
+	}
+
+	kind, err := elems[0].GetUint64()
+	if err != nil {
+		return err
+	}
+
+	s.Kind = stateItemKind(kind)
+
+	switch s.Kind {
+	case stateItemNode, stateItemCode, stateItemHeader:
+		s.Data, err = elems[1].GetBytes(nil)
+
+		return err
+	case stateItemEnd:
+		if len(elems) < 3 {
+			return fmt.Errorf("incorrect number of elements to decode state end, expected 3 but found %d", len(elems))
+		}
+
+		if s.Nodes, err = elems[1].GetUint64(); err != nil {
+			return err
+		}
+
+		s.Codes, err = elems[2].GetUint64()
+
+		return err
+	default:
+		return fmt.Errorf("unknown state item kind %d", kind)
+	}
+}
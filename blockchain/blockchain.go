@@ -38,6 +38,8 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrNotEmptyChain        = errors.New("the chain is not empty")
+	ErrHistoryPruned        = errors.New("pruned history unavailable")
 )
 
 // Blockchain is a blockchain reference
@@ -91,6 +93,14 @@ type Verifier interface {
 	PreCommitState(block *types.Block, txn *state.Transition) error
 }
 
+// HeadersVerifier is implemented by the consensus which verifies the headers
+// before their parents are written, e.g. while the blocks are downloaded
+type HeadersVerifier interface {
+	// VerifyHeaders verifies the headers following the parents, which are the verified headers
+	// between the written blocks and the headers. Both are ordered from the lowest one
+	VerifyHeaders(parents []*types.Header, headers []*types.Header) error
+}
+
 type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
 }
@@ -590,6 +600,19 @@ func (b *Blockchain) WriteHeadersWithBodies(headers []*types.Header) error {
 	return nil
 }
 
+// VerifyHeaders verifies the headers with the consensus before their blocks are written.
+// The parents are the verified headers between the written blocks and the headers.
+// It returns false if the consensus can verify a header only once its parent is written,
+// the headers are not verified then
+func (b *Blockchain) VerifyHeaders(parents []*types.Header, headers []*types.Header) (bool, error) {
+	verifier, ok := b.consensus.(HeadersVerifier)
+	if !ok {
+		return false, nil
+	}
+
+	return true, verifier.VerifyHeaders(parents, headers)
+}
+
 // VerifyPotentialBlock does the minimal block verification without consulting the
 // consensus layer. Should only be used if consensus checks are done
 // outside the method call
@@ -932,6 +955,152 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 	return nil
 }
 
+// WritePivotHeaders writes the headers of the blocks up to the pivot block of the snap sync,
+// ordered from the lowest one. Every header is verified by the consensus against its written
+// parent, and its total difficulty is computed from the one of the parent. The head of the empty
+// chain is not moved, the pivot block is written once its state is synced
+func (b *Blockchain) WritePivotHeaders(headers []*types.Header) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if b.Header().Number != 0 {
+		return ErrNotEmptyChain
+	}
+
+	for _, header := range headers {
+		if header.Number == 0 {
+			return ErrInvalidBlockSequence
+		}
+
+		parent, ok := b.GetHeaderByNumber(header.Number - 1)
+		if !ok {
+			return ErrParentNotFound
+		}
+
+		if parent.Hash != header.ParentHash {
+			return ErrParentHashMismatch
+		}
+
+		// the header written by a previous sync attempt is verified already
+		if hash, ok := b.db.ReadCanonicalHash(header.Number); ok && hash == header.Hash {
+			continue
+		}
+
+		if err := b.consensus.VerifyHeader(header); err != nil {
+			return fmt.Errorf("failed to verify header %d: %w", header.Number, err)
+		}
+
+		parentTD, ok := b.readTotalDifficulty(parent.Hash)
+		if !ok {
+			return fmt.Errorf("parent of %s (%d) not found", header.Hash.String(), header.Number)
+		}
+
+		td := new(big.Int).Add(parentTD, new(big.Int).SetUint64(header.Difficulty))
+
+		batchWriter := storage.NewBatchWriter(b.db)
+
+		batchWriter.PutHeader(header)
+		batchWriter.PutCanonicalHash(header.Number, header.Hash)
+		batchWriter.PutTotalDifficulty(header.Hash, td)
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return err
+		}
+
+		// update snapshot
+		if err := b.consensus.ProcessHeaders([]*types.Header{header}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WritePivotBlock writes the block whose state was synced from a state snapshot as the head of
+// an empty chain, together with the headers of its ancestors. The ancestors are ordered from the
+// parent of the block downwards. The blocks below the pivot block are left without bodies
+// and receipts, so the pivot block becomes the tail of the chain
+func (b *Blockchain) WritePivotBlock(
+	fblock *types.FullBlock,
+	totalDifficulty *big.Int,
+	ancestors []*types.Header,
+) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if b.Header().Number != 0 {
+		return ErrNotEmptyChain
+	}
+
+	block := fblock.Block
+	header := block.Header
+
+	if hash := buildroot.CalculateTransactionsRoot(block.Transactions, block.Number()); hash != header.TxRoot {
+		return ErrInvalidTxRoot
+	}
+
+	if hash := buildroot.CalculateReceiptsRoot(fblock.Receipts); hash != header.ReceiptsRoot {
+		return ErrInvalidReceiptsRoot
+	}
+
+	batchWriter := storage.NewBatchWriter(b.db)
+
+	td := new(big.Int).Set(totalDifficulty)
+	child := header
+
+	for _, ancestor := range ancestors {
+		if ancestor.Hash != child.ParentHash || ancestor.Number+1 != child.Number {
+			return ErrParentHashMismatch
+		}
+
+		// the genesis is already written
+		if ancestor.Number == 0 {
+			if ancestor.Hash != b.genesis {
+				return ErrParentHashMismatch
+			}
+
+			break
+		}
+
+		td = new(big.Int).Sub(td, new(big.Int).SetUint64(child.Difficulty))
+
+		batchWriter.PutHeader(ancestor)
+		batchWriter.PutCanonicalHash(ancestor.Number, ancestor.Hash)
+		batchWriter.PutTotalDifficulty(ancestor.Hash, td)
+
+		child = ancestor
+	}
+
+	if err := b.writeBody(batchWriter, block); err != nil {
+		return err
+	}
+
+	batchWriter.PutCanonicalHeader(header, totalDifficulty)
+	batchWriter.PutReceipts(header.Hash, fblock.Receipts)
+	batchWriter.PutTailNumber(header.Number)
+
+	if err := b.writeBatchAndUpdate(batchWriter, header, totalDifficulty, true); err != nil {
+		return err
+	}
+
+	evnt := &Event{Source: "snapsync", Type: EventHead}
+	evnt.AddNewHeader(header)
+	evnt.SetDifficulty(totalDifficulty)
+
+	b.dispatchEvent(evnt)
+
+	b.logger.Info("pivot block written", "number", header.Number, "hash", header.Hash)
+
+	return nil
+}
+
+// TailNumber returns the number of the oldest block stored with its body and receipts
+func (b *Blockchain) TailNumber() uint64 {
+	tail, _ := b.db.ReadTailNumber()
+
+	return tail
+}
+
 // GetCachedReceipts retrieves cached receipts for given headerHash
 func (b *Blockchain) GetCachedReceipts(headerHash types.Hash) ([]*types.Receipt, error) {
 	receipts, found := b.receiptsCache.Get(headerHash)
@@ -1521,3 +1521,297 @@ func TestBlockchain_WriteFullBlock(t *testing.T) {
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.CANONICAL, common.EncodeUint64ToBytes(header.Number)))])
 	require.NotNil(t, db[hex.EncodeToHex(getKey(storage.RECEIPTS, header.Hash.Bytes()))])
 }
+
+func TestBlockchain_WritePivotBlock(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeadersWithSeed(NewTestBlockchain(t, nil).Header(), 10, 0)
+	pivot := headers[len(headers)-1]
+
+	ancestors := make([]*types.Header, 0, len(headers)-1)
+	for i := len(headers) - 2; i >= 0; i-- {
+		ancestors = append(ancestors, headers[i])
+	}
+
+	// total difficulty of the pivot block is the sum of the difficulties 0..9
+	td := big.NewInt(45)
+
+	t.Run("invalid ancestors", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		err := b.WritePivotBlock(&types.FullBlock{Block: &types.Block{Header: pivot}}, td, ancestors[1:])
+		require.ErrorIs(t, err, ErrParentHashMismatch)
+		require.Equal(t, uint64(0), b.Header().Number)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		err := b.WritePivotBlock(&types.FullBlock{
+			Block:    &types.Block{Header: pivot},
+			Receipts: []*types.Receipt{{GasUsed: 1}},
+		}, td, ancestors)
+		require.ErrorIs(t, err, ErrInvalidReceiptsRoot)
+	})
+
+	t.Run("pivot block written", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		require.NoError(t, b.WritePivotBlock(&types.FullBlock{Block: &types.Block{Header: pivot}}, td, ancestors))
+
+		require.Equal(t, pivot.Hash, b.Header().Hash)
+		require.Equal(t, pivot.Number, b.TailNumber())
+
+		chainTD, ok := b.GetChainTD()
+		require.True(t, ok)
+		require.Equal(t, td, chainTD)
+
+		// the headers of the ancestors are available for the BLOCKHASH opcode
+		ancestor, ok := b.GetHeaderByNumber(5)
+		require.True(t, ok)
+		require.Equal(t, headers[5].Hash, ancestor.Hash)
+		require.Equal(t, headers[5].Hash, b.GetHashHelper(pivot)(5))
+
+		ancestorTD, ok := b.GetTD(ancestor.Hash)
+		require.True(t, ok)
+		require.Equal(t, big.NewInt(15), ancestorTD)
+
+		// the pivot block is written on an empty chain only
+		require.ErrorIs(t,
+			b.WritePivotBlock(&types.FullBlock{Block: &types.Block{Header: pivot}}, td, ancestors),
+			ErrNotEmptyChain,
+		)
+	})
+}
+
+func TestBlockchain_WritePivotHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeadersWithSeed(NewTestBlockchain(t, nil).Header(), 10, 0)
+	pivot := headers[len(headers)-1]
+
+	t.Run("headers not following the written ones", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTestBlockchain(t, nil)
+
+		require.ErrorIs(t, b.WritePivotHeaders(headers[2:]), ErrParentNotFound)
+		require.ErrorIs(t, b.WritePivotHeaders(headers[:1]), ErrInvalidBlockSequence)
+
+		forked := NewTestHeadersWithSeed(NewTestBlockchain(t, nil).Header(), 4, 1)
+		require.NoError(t, b.WritePivotHeaders(headers[1:3]))
+		require.ErrorIs(t, b.WritePivotHeaders(forked[3:]), ErrParentHashMismatch)
+	})
+
+	t.Run("header rejected by the consensus", func(t *testing.T) {
+		t.Parallel()
+
+		errInvalidSeal := errors.New("invalid seal")
+
+		b := NewTestBlockchain(t, nil)
+		b.SetConsensus(&MockVerifier{verifyHeaderFn: func(header *types.Header) error {
+			if header.Number == 5 {
+				return errInvalidSeal
+			}
+
+			return nil
+		}})
+
+		require.ErrorIs(t, b.WritePivotHeaders(headers[1:]), errInvalidSeal)
+
+		_, ok := b.GetHeaderByNumber(4)
+		require.True(t, ok)
+
+		_, ok = b.GetHeaderByNumber(5)
+		require.False(t, ok)
+	})
+
+	t.Run("headers written", func(t *testing.T) {
+		t.Parallel()
+
+		verified := 0
+
+		b := NewTestBlockchain(t, nil)
+		b.SetConsensus(&MockVerifier{verifyHeaderFn: func(*types.Header) error {
+			verified++
+
+			return nil
+		}})
+
+		require.NoError(t, b.WritePivotHeaders(headers[1:6]))
+		// the headers written already are not verified again
+		require.NoError(t, b.WritePivotHeaders(headers[1:]))
+		require.Equal(t, len(headers)-1, verified)
+
+		// the head of the chain is not moved
+		require.Equal(t, uint64(0), b.Header().Number)
+
+		header, ok := b.GetHeaderByNumber(pivot.Number)
+		require.True(t, ok)
+		require.Equal(t, pivot.Hash, header.Hash)
+
+		// total difficulty of the pivot block adds the difficulties 1..9 to the one of the genesis
+		genesisTD, ok := b.GetTD(headers[0].Hash)
+		require.True(t, ok)
+
+		td, ok := b.GetTD(pivot.Hash)
+		require.True(t, ok)
+		require.Equal(t, new(big.Int).Add(genesisTD, big.NewInt(45)), td)
+
+		require.NoError(t, b.WritePivotBlock(&types.FullBlock{Block: &types.Block{Header: pivot}}, td, nil))
+		require.Equal(t, pivot.Hash, b.Header().Hash)
+		require.Equal(t, headers[5].Hash, b.GetHashHelper(pivot)(5))
+
+		require.ErrorIs(t, b.WritePivotHeaders(headers[1:]), ErrNotEmptyChain)
+	})
+}
+
+func TestBlockchain_PruneHistory(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(20)
+	b := NewTestBlockchain(t, headers)
+
+	txs := make([]*types.Transaction, len(headers))
+	batchWriter := storage.NewBatchWriter(b.db)
+
+	for i, header := range headers[1:] {
+		tx := &types.Transaction{Nonce: uint64(i), Value: big.NewInt(1), GasPrice: big.NewInt(1)}
+		tx.ComputeHash(header.Number)
+		txs[header.Number] = tx
+
+		batchWriter.PutBody(header.Hash, &types.Body{Transactions: []*types.Transaction{tx}})
+		batchWriter.PutReceipts(header.Hash, []*types.Receipt{{TxHash: tx.Hash}})
+		batchWriter.PutTxLookup(tx.Hash, header.Hash)
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+
+	pruned, err := b.PruneHistory(15)
+	require.NoError(t, err)
+	require.Equal(t, uint64(14), pruned)
+	require.Equal(t, uint64(15), b.TailNumber())
+
+	for _, header := range headers[1:] {
+		kept := header.Number >= 15
+
+		// the headers and the canonical hashes are kept
+		canonical, ok := b.GetHeaderByNumber(header.Number)
+		require.True(t, ok)
+		require.Equal(t, header.Hash, canonical.Hash)
+
+		_, ok = b.GetBlockByNumber(header.Number, true)
+		require.Equal(t, kept, ok)
+
+		_, err := b.GetReceiptsByHash(header.Hash)
+		require.Equal(t, kept, err == nil)
+
+		_, ok = b.ReadTxLookup(txs[header.Number].Hash)
+		require.Equal(t, kept, ok)
+
+		if kept {
+			require.NoError(t, b.CheckHistory(header.Number))
+		} else {
+			require.ErrorIs(t, b.CheckHistory(header.Number), ErrHistoryPruned)
+		}
+	}
+
+	require.NoError(t, b.CheckHistory(0))
+
+	// the tail never moves past the head
+	pruned, err = b.PruneHistory(100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), pruned)
+	require.Equal(t, uint64(19), b.TailNumber())
+}
+
+func TestSetHead(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(20)
+	b := NewTestBlockchain(t, headers)
+
+	txs := make([]*types.Transaction, len(headers))
+	batchWriter := storage.NewBatchWriter(b.db)
+
+	for i, header := range headers[1:] {
+		tx := &types.Transaction{Nonce: uint64(i), Value: big.NewInt(1), GasPrice: big.NewInt(1)}
+		tx.ComputeHash(header.Number)
+		txs[header.Number] = tx
+
+		batchWriter.PutBody(header.Hash, &types.Body{Transactions: []*types.Transaction{tx}})
+		batchWriter.PutTxLookup(tx.Hash, header.Hash)
+	}
+
+	require.NoError(t, batchWriter.WriteBatch())
+
+	_, err := SetHead(b.db, 20)
+	require.ErrorIs(t, err, ErrInvalidSetHead)
+
+	removed, err := SetHead(b.db, 12)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), removed)
+
+	head, ok := b.db.ReadHeadNumber()
+	require.True(t, ok)
+	require.Equal(t, uint64(12), head)
+
+	headHash, ok := b.db.ReadHeadHash()
+	require.True(t, ok)
+	require.Equal(t, headers[12].Hash, headHash)
+
+	for _, header := range headers[1:] {
+		kept := header.Number <= 12
+
+		_, ok := b.db.ReadCanonicalHash(header.Number)
+		require.Equal(t, kept, ok)
+
+		_, ok = b.db.ReadTxLookup(txs[header.Number].Hash)
+		require.Equal(t, kept, ok)
+
+		// the removed blocks stay reachable by their hash
+		_, err := b.db.ReadHeader(header.Hash)
+		require.NoError(t, err)
+	}
+
+	removed, err = SetHead(b.db, 12)
+	require.NoError(t, err)
+	require.Zero(t, removed)
+
+	// the new head must have its history
+	_, err = b.PruneHistory(5)
+	require.NoError(t, err)
+
+	_, err = SetHead(b.db, 4)
+	require.ErrorIs(t, err, ErrInvalidSetHead)
+}
+
+func TestBlockchain_Rewind(t *testing.T) {
+	t.Parallel()
+
+	headers := NewTestHeaders(10)
+	b := NewTestBlockchain(t, headers)
+
+	require.ErrorIs(t, b.Rewind(10), ErrInvalidSetHead)
+
+	require.NoError(t, b.Rewind(5))
+	require.Equal(t, headers[5].Hash, b.Header().Hash)
+
+	_, ok := b.GetHeaderByNumber(6)
+	require.False(t, ok)
+
+	// new blocks are built on top of the rewound head
+	fork := AppendNewTestheadersWithSeed(headers[:6], 3, 1)
+	require.NoError(t, b.WriteHeadersWithBodies(fork[6:]))
+	require.Equal(t, fork[8].Hash, b.Header().Hash)
+
+	header, ok := b.GetHeaderByNumber(6)
+	require.True(t, ok)
+	require.Equal(t, fork[6].Hash, header.Hash)
+}
@@ -0,0 +1,76 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+)
+
+// historyPruneBatchSize is the number of the blocks whose history is removed from the storage at once
+const historyPruneBatchSize = 1024
+
+// PruneHistory removes the bodies, the receipts and the transaction lookups of the canonical
+// blocks below the given number, and makes it the tail of the chain. The headers and the
+// canonical hashes are kept. It returns the number of the pruned blocks
+func (b *Blockchain) PruneHistory(tail uint64) (uint64, error) {
+	// the genesis block has no history to prune
+	from := b.TailNumber()
+	if from == 0 {
+		from = 1
+	}
+
+	if head := b.Header().Number; tail > head {
+		tail = head
+	}
+
+	pruned := uint64(0)
+
+	for from < tail {
+		to := from + historyPruneBatchSize
+		if to > tail {
+			to = tail
+		}
+
+		batchWriter := storage.NewBatchWriter(b.db)
+
+		for number := from; number < to; number++ {
+			hash, ok := b.db.ReadCanonicalHash(number)
+			if !ok {
+				continue
+			}
+
+			// the body is missing if the block was pruned before, but the tail was not moved
+			if body, err := b.db.ReadBody(hash); err == nil {
+				for _, tx := range body.Transactions {
+					batchWriter.DeleteTxLookup(tx.Hash)
+				}
+			}
+
+			batchWriter.DeleteBody(hash)
+			batchWriter.DeleteReceipts(hash)
+			b.receiptsCache.Remove(hash)
+		}
+
+		// the tail is moved along with the removal, so that the pruned blocks are never served
+		batchWriter.PutTailNumber(to)
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return pruned, fmt.Errorf("failed to prune the history of blocks %d-%d: %w", from, to-1, err)
+		}
+
+		pruned += to - from
+		from = to
+	}
+
+	return pruned, nil
+}
+
+// CheckHistory returns ErrHistoryPruned if the history of the block, i.e. its body,
+// receipts and transaction lookups, is not stored anymore
+func (b *Blockchain) CheckHistory(number uint64) error {
+	if tail := b.TailNumber(); number > 0 && number < tail {
+		return fmt.Errorf("%w: block %d is below the oldest block %d with history", ErrHistoryPruned, number, tail)
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var ErrInvalidSetHead = errors.New("invalid new head")
+
+// frozenStorage is a storage keeping the oldest blocks in an append-only freezer
+type frozenStorage interface {
+	// Frozen returns the number of the frozen blocks
+	Frozen() uint64
+}
+
+// SetHead rewinds the canonical chain stored in the storage of a stopped node to the given block.
+// The canonical hashes and the transaction lookups of the blocks above it are removed, from the
+// head down, and the head is moved along with the removal, so that an interrupted rewind leaves
+// a consistent chain. The headers, bodies and receipts stay reachable by their hash.
+// It returns the number of the removed blocks
+func SetHead(db storage.Storage, number uint64) (uint64, error) {
+	head, ok := db.ReadHeadNumber()
+	if !ok {
+		return 0, fmt.Errorf("failed to read the head number")
+	}
+
+	if number > head {
+		return 0, fmt.Errorf("%w: block %d is above the head %d", ErrInvalidSetHead, number, head)
+	}
+
+	if tail, ok := db.ReadTailNumber(); ok && number < tail {
+		return 0, fmt.Errorf("%w: the history of block %d is pruned, the oldest block with history is %d",
+			ErrInvalidSetHead, number, tail)
+	}
+
+	if frozen, ok := db.(frozenStorage); ok && number+1 < frozen.Frozen() {
+		return 0, fmt.Errorf("%w: blocks up to %d are frozen", ErrInvalidSetHead, frozen.Frozen()-1)
+	}
+
+	if _, err := readCanonicalHeader(db, number); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidSetHead, err)
+	}
+
+	removed := uint64(0)
+
+	for head > number {
+		from := number + 1
+		if head-number > historyPruneBatchSize {
+			from = head - historyPruneBatchSize + 1
+		}
+
+		batchWriter := storage.NewBatchWriter(db)
+
+		for n := from; n <= head; n++ {
+			hash, ok := db.ReadCanonicalHash(n)
+			if !ok {
+				continue
+			}
+
+			// the body is missing if the history of the block was pruned
+			if body, err := db.ReadBody(hash); err == nil {
+				for _, tx := range body.Transactions {
+					batchWriter.DeleteTxLookup(tx.Hash)
+				}
+			}
+
+			batchWriter.DeleteCanonicalHash(n)
+		}
+
+		newHead, err := readCanonicalHeader(db, from-1)
+		if err != nil {
+			return removed, err
+		}
+
+		batchWriter.PutHeadHash(newHead.Hash)
+		batchWriter.PutHeadNumber(newHead.Number)
+
+		if err := batchWriter.WriteBatch(); err != nil {
+			return removed, fmt.Errorf("failed to remove blocks %d-%d: %w", from, head, err)
+		}
+
+		removed += head - from + 1
+		head = from - 1
+	}
+
+	return removed, nil
+}
+
+// Rewind moves the head of the running chain back to the given block, removing the canonical
+// entries of the blocks above it the same way SetHead does. It is meant for the dev consensus,
+// other consensus engines don't expect the head to move backwards
+func (b *Blockchain) Rewind(number uint64) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if _, err := SetHead(b.db, number); err != nil {
+		return err
+	}
+
+	header, err := readCanonicalHeader(b.db, number)
+	if err != nil {
+		return err
+	}
+
+	difficulty, ok := b.readTotalDifficulty(header.Hash)
+	if !ok {
+		return fmt.Errorf("total difficulty of block %d not found", number)
+	}
+
+	b.setCurrentHeader(header, difficulty)
+
+	return nil
+}
+
+// readCanonicalHeader reads the header of the canonical block with the given number
+func readCanonicalHeader(db storage.Storage, number uint64) (*types.Header, error) {
+	hash, ok := db.ReadCanonicalHash(number)
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := db.ReadHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the header of block %d: %w", number, err)
+	}
+
+	return header, nil
+}
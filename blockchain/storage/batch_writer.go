@@ -45,6 +45,10 @@ func (b *BatchWriter) PutHeadNumber(n uint64) {
 	b.putWithPrefix(HEAD, NUMBER, common.EncodeUint64ToBytes(n))
 }
 
+func (b *BatchWriter) PutTailNumber(n uint64) {
+	b.putWithPrefix(HEAD, TAIL, common.EncodeUint64ToBytes(n))
+}
+
 func (b *BatchWriter) PutReceipts(hash types.Hash, receipts []*types.Receipt) {
 	rr := types.Receipts(receipts)
 
@@ -73,6 +77,22 @@ func (b *BatchWriter) PutForks(forks []types.Hash) {
 	b.putRlp(FORK, EMPTY, &ff)
 }
 
+func (b *BatchWriter) DeleteBody(hash types.Hash) {
+	b.deleteWithPrefix(BODY, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteReceipts(hash types.Hash) {
+	b.deleteWithPrefix(RECEIPTS, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteTxLookup(hash types.Hash) {
+	b.deleteWithPrefix(TX_LOOKUP_PREFIX, hash.Bytes())
+}
+
+func (b *BatchWriter) DeleteCanonicalHash(n uint64) {
+	b.deleteWithPrefix(CANONICAL, common.EncodeUint64ToBytes(n))
+}
+
 func (b *BatchWriter) putRlp(p, k []byte, raw types.RLPMarshaler) {
 	var data []byte
 
@@ -91,6 +111,12 @@ func (b *BatchWriter) putWithPrefix(p, k, data []byte) {
 	b.batch.Put(fullKey, data)
 }
 
+func (b *BatchWriter) deleteWithPrefix(p, k []byte) {
+	fullKey := append(append(make([]byte, 0, len(p)+len(k)), p...), k...)
+
+	b.batch.Delete(fullKey)
+}
+
 func (b *BatchWriter) WriteBatch() error {
 	return b.batch.Write()
 }
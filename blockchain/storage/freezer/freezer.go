@@ -0,0 +1,171 @@
+package freezer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	hashesTable   = "hashes"
+	headersTable  = "headers"
+	bodiesTable   = "bodies"
+	receiptsTable = "receipts"
+)
+
+var (
+	ErrOutOfOrder   = errors.New("frozen block doesn't follow the last frozen block")
+	ErrUnknownTable = errors.New("unknown freezer table")
+)
+
+// Freezer is the append-only store of the old finalized blocks in flat files.
+// The blocks are frozen one after another from the genesis, so the number of a block
+// is the position of its items in every table. An item of a block missing from the
+// key-value database, e.g. the body of a block below the snap sync pivot, is frozen empty
+type Freezer struct {
+	logger hclog.Logger
+
+	lock   sync.Mutex // guards the appends
+	tables map[string]*table
+	frozen atomic.Uint64
+}
+
+// Open opens the freezer in the directory, or creates it if it doesn't exist.
+// The items written to a table only partially before the node stopped are dropped
+func Open(dir string, compress bool, logger hclog.Logger) (*Freezer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	f := &Freezer{
+		logger: logger.Named("freezer"),
+		tables: make(map[string]*table),
+	}
+
+	for _, name := range []string{hashesTable, headersTable, bodiesTable, receiptsTable} {
+		t, err := openTable(dir, name, compress)
+		if err != nil {
+			f.Close()
+
+			return nil, err
+		}
+
+		f.tables[name] = t
+	}
+
+	// the tables are appended one after another, so they may differ by the last block
+	frozen := f.tables[hashesTable].items
+	for _, t := range f.tables {
+		if t.items < frozen {
+			frozen = t.items
+		}
+	}
+
+	if err := f.truncate(frozen); err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	f.frozen.Store(frozen)
+
+	f.logger.Info("opened", "dir", dir, "frozen", frozen)
+
+	return f, nil
+}
+
+// Frozen returns the number of the frozen blocks, the blocks from the genesis up to Frozen()-1
+func (f *Freezer) Frozen() uint64 {
+	return f.frozen.Load()
+}
+
+// Append freezes the block following the last frozen block. The items are the RLP encoded
+// header, body and receipts as stored in the key-value database
+func (f *Freezer) Append(number uint64, hash types.Hash, header, body, receipts []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	frozen := f.frozen.Load()
+	if number != frozen {
+		return fmt.Errorf("%w: expected %d, got %d", ErrOutOfOrder, frozen, number)
+	}
+
+	var hashItem []byte
+	if hash != types.ZeroHash {
+		hashItem = hash.Bytes()
+	}
+
+	items := map[string][]byte{
+		hashesTable:   hashItem,
+		headersTable:  header,
+		bodiesTable:   body,
+		receiptsTable: receipts,
+	}
+
+	for name, item := range items {
+		if err := f.tables[name].append(item); err != nil {
+			// drop the items of the block appended to the other tables
+			if truncateErr := f.truncate(frozen); truncateErr != nil {
+				return errors.Join(err, truncateErr)
+			}
+
+			return fmt.Errorf("failed to append to the %s table: %w", name, err)
+		}
+	}
+
+	f.frozen.Store(frozen + 1)
+
+	return nil
+}
+
+// read returns the item of the frozen block from the table
+func (f *Freezer) read(name string, number uint64) ([]byte, error) {
+	t, ok := f.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTable, name)
+	}
+
+	if number >= f.frozen.Load() {
+		return nil, errOutOfBounds
+	}
+
+	return t.read(number)
+}
+
+// Sync flushes the frozen blocks to the disk
+func (f *Freezer) Sync() error {
+	for _, t := range f.tables {
+		if err := t.sync(); err != nil {
+			return fmt.Errorf("failed to sync the %s table: %w", t.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Close syncs and closes the tables
+func (f *Freezer) Close() error {
+	var errs []error
+
+	for _, t := range f.tables {
+		errs = append(errs, t.sync(), t.close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f *Freezer) truncate(frozen uint64) error {
+	for _, t := range f.tables {
+		if err := t.truncate(frozen); err != nil {
+			return fmt.Errorf("failed to truncate the %s table: %w", t.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package freezer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func openTestFreezer(t *testing.T, dir string, compress bool) *Freezer {
+	t.Helper()
+
+	f, err := Open(dir, compress, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	return f
+}
+
+func testItem(kind string, number uint64) []byte {
+	return bytes.Repeat([]byte(kind), int(number%7)+1)
+}
+
+func appendTestBlocks(t *testing.T, f *Freezer, from, to uint64) {
+	t.Helper()
+
+	for number := from; number < to; number++ {
+		require.NoError(t, f.Append(
+			number,
+			types.BytesToHash([]byte{byte(number + 1)}),
+			testItem("h", number),
+			testItem("b", number),
+			testItem("r", number),
+		))
+	}
+}
+
+func TestFreezer_AppendRead(t *testing.T) {
+	t.Parallel()
+
+	for _, compress := range []bool{false, true} {
+		compress := compress
+
+		t.Run(map[bool]string{false: "raw", true: "compressed"}[compress], func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			f := openTestFreezer(t, dir, compress)
+			appendTestBlocks(t, f, 0, 20)
+			require.Equal(t, uint64(20), f.Frozen())
+
+			// the blocks are appended in order only
+			require.ErrorIs(t, f.Append(30, types.ZeroHash, nil, nil, nil), ErrOutOfOrder)
+			require.NoError(t, f.Close())
+
+			// the frozen blocks are kept after reopening, regardless of the compression option
+			f = openTestFreezer(t, dir, !compress)
+			defer f.Close()
+
+			require.Equal(t, uint64(20), f.Frozen())
+
+			for number := uint64(0); number < 20; number++ {
+				hash, err := f.read(hashesTable, number)
+				require.NoError(t, err)
+				require.Equal(t, types.BytesToHash([]byte{byte(number + 1)}).Bytes(), hash)
+
+				body, err := f.read(bodiesTable, number)
+				require.NoError(t, err)
+				require.Equal(t, testItem("b", number), body)
+			}
+
+			_, err := f.read(headersTable, 20)
+			require.ErrorIs(t, err, errOutOfBounds)
+
+			_, err = f.read("unknown", 0)
+			require.ErrorIs(t, err, ErrUnknownTable)
+
+			_, err = os.Stat(filepath.Join(dir, bodiesTable+map[bool]string{false: rawDataExt, true: compressedDataExt}[compress]))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFreezer_EmptyItems(t *testing.T) {
+	t.Parallel()
+
+	f := openTestFreezer(t, t.TempDir(), true)
+	defer f.Close()
+
+	require.NoError(t, f.Append(0, types.ZeroHash, nil, nil, nil))
+
+	for _, name := range []string{hashesTable, headersTable, bodiesTable, receiptsTable} {
+		item, err := f.read(name, 0)
+		require.NoError(t, err)
+		require.Empty(t, item)
+	}
+}
+
+func TestFreezer_RepairPartialWrites(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	f := openTestFreezer(t, dir, false)
+	appendTestBlocks(t, f, 0, 10)
+	require.NoError(t, f.Close())
+
+	// the node stopped while appending the block 10: the receipts table misses it,
+	// and the data of the headers table is cut in the middle of the item
+	f = openTestFreezer(t, dir, false)
+	for _, name := range []string{hashesTable, headersTable, bodiesTable} {
+		require.NoError(t, f.tables[name].append(testItem(name, 10)))
+	}
+	require.NoError(t, f.Close())
+
+	headersData := filepath.Join(dir, headersTable+rawDataExt)
+
+	stat, err := os.Stat(headersData)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(headersData, stat.Size()-1))
+
+	f = openTestFreezer(t, dir, false)
+	defer f.Close()
+
+	require.Equal(t, uint64(10), f.Frozen())
+
+	for name, table := range f.tables {
+		require.Equal(t, uint64(10), table.items, name)
+	}
+
+	// the block is frozen again after the repair
+	appendTestBlocks(t, f, 10, 11)
+
+	header, err := f.read(headersTable, 10)
+	require.NoError(t, err)
+	require.Equal(t, testItem("h", 10), header)
+}
@@ -0,0 +1,377 @@
+package freezer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// freezeInterval is the interval of the checks for the blocks to freeze
+	freezeInterval = time.Minute
+
+	// freezeBatchSize is the number of the blocks frozen before they are removed from the key-value database
+	freezeBatchSize = 2048
+
+	// progressLogInterval is the minimum interval between the progress logs of the migration
+	progressLogInterval = 8 * time.Second
+)
+
+// Storage is the blockchain storage keeping the finalized blocks which are more than
+// the threshold behind the head in the freezer. The recent blocks, the total difficulties,
+// the forks and the transaction lookups stay in the key-value database.
+// The read methods look up the frozen headers, bodies, receipts and canonical hashes in the freezer.
+// The bodies and the receipts of the blocks below the tail are never served, and if the history
+// is pruned, the blocks are frozen only once their history has been removed
+type Storage struct {
+	storage.Storage // key-value storage of the recent blocks
+
+	logger    hclog.Logger
+	db        storage.KV
+	freezer   *Freezer
+	threshold uint64
+
+	// historyPruned is set if the history of the old blocks is pruned, so that
+	// the pruned bodies and receipts never end up in the append-only freezer
+	historyPruned bool
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewStorage creates the blockchain storage moving the blocks older than the threshold
+// from the key-value database to the freezer once started. If the history is pruned,
+// the blocks are not frozen before the tail moved past them
+func NewStorage(logger hclog.Logger, db storage.KV, freezer *Freezer, threshold uint64, historyPruned bool) *Storage {
+	return &Storage{
+		Storage:       storage.NewKeyValueStorage(logger, db),
+		logger:        logger.Named("freezer"),
+		db:            db,
+		freezer:       freezer,
+		threshold:     threshold,
+		historyPruned: historyPruned,
+		closeCh:       make(chan struct{}),
+	}
+}
+
+// Start starts moving the old blocks to the freezer in the background
+func (s *Storage) Start() {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		if err := s.removeFrozen(); err != nil {
+			s.logger.Error("failed to remove frozen blocks", "err", err)
+		}
+
+		ticker := time.NewTicker(freezeInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.freeze(); err != nil {
+				s.logger.Error("failed to freeze blocks", "err", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-s.closeCh:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the migration and closes the freezer and the key-value database
+func (s *Storage) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closeCh)
+	})
+
+	s.wg.Wait()
+
+	return errors.Join(s.freezer.Close(), s.Storage.Close())
+}
+
+// Frozen returns the number of the frozen blocks
+func (s *Storage) Frozen() uint64 {
+	return s.freezer.Frozen()
+}
+
+// ReadCanonicalHash gets the hash from the number of the canonical chain
+func (s *Storage) ReadCanonicalHash(n uint64) (types.Hash, bool) {
+	if n >= s.freezer.Frozen() {
+		return s.Storage.ReadCanonicalHash(n)
+	}
+
+	data, err := s.freezer.read(hashesTable, n)
+	if err != nil || len(data) == 0 {
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(data), true
+}
+
+// ReadHeader reads the header
+func (s *Storage) ReadHeader(hash types.Hash) (*types.Header, error) {
+	header, err := s.Storage.ReadHeader(hash)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return header, err
+	}
+
+	header = &types.Header{}
+	if err := s.readFrozen(headersTable, hash, header); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// ReadBody reads the body
+func (s *Storage) ReadBody(hash types.Hash) (*types.Body, error) {
+	body, err := s.Storage.ReadBody(hash)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return body, err
+	}
+
+	number, ok := s.readFrozenNumber(hash)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+
+	body = &types.Body{}
+	if err := s.readFrozen(bodiesTable, hash, body); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range body.Transactions {
+		tx.ComputeHash(number)
+	}
+
+	return body, nil
+}
+
+// ReadReceipts reads the receipts
+func (s *Storage) ReadReceipts(hash types.Hash) ([]*types.Receipt, error) {
+	receipts, err := s.Storage.ReadReceipts(hash)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return receipts, err
+	}
+
+	frozen := types.Receipts{}
+	if err := s.readFrozen(receiptsTable, hash, &frozen); err != nil {
+		return nil, err
+	}
+
+	return frozen, nil
+}
+
+// readFrozen decodes the item of the frozen block with the given hash
+func (s *Storage) readFrozen(name string, hash types.Hash, raw types.RLPUnmarshaler) error {
+	number, ok := s.readFrozenNumber(hash)
+	if !ok {
+		return storage.ErrNotFound
+	}
+
+	// the history frozen before it was pruned stays in the freezer, but it is not served
+	if name != headersTable && s.isPruned(number) {
+		return storage.ErrNotFound
+	}
+
+	data, err := s.freezer.read(name, number)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return storage.ErrNotFound
+	}
+
+	if obj, ok := raw.(types.RLPStoreUnmarshaler); ok {
+		return obj.UnmarshalStoreRLP(data)
+	}
+
+	return raw.UnmarshalRLP(data)
+}
+
+// readFrozenNumber returns the number of the frozen block with the given hash
+func (s *Storage) readFrozenNumber(hash types.Hash) (uint64, bool) {
+	data, ok, err := s.db.Get(key(storage.FROZEN_NUMBER, hash.Bytes()))
+	if err != nil || !ok || len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
+// isPruned returns whether the block is below the tail, i.e. its body and receipts have been pruned
+func (s *Storage) isPruned(number uint64) bool {
+	tail, ok := s.ReadTailNumber()
+
+	return ok && number > 0 && number < tail
+}
+
+// freeze moves the canonical blocks which are more than the threshold behind the head to the freezer
+func (s *Storage) freeze() error {
+	head, ok := s.ReadHeadNumber()
+	if !ok || head <= s.threshold {
+		return nil
+	}
+
+	var (
+		limit   = head - s.threshold // the blocks below the limit are frozen
+		first   = s.freezer.Frozen()
+		start   = time.Now()
+		logged  = start
+		written = first
+	)
+
+	// the blocks whose history is still to be pruned are frozen later with their headers only
+	if tail, _ := s.ReadTailNumber(); s.historyPruned && limit > tail {
+		limit = tail
+	}
+
+	if first >= limit {
+		return nil
+	}
+
+	for written < limit {
+		select {
+		case <-s.closeCh:
+			return nil
+		default:
+		}
+
+		to := written + freezeBatchSize
+		if to > limit {
+			to = limit
+		}
+
+		if err := s.freezeRange(written, to); err != nil {
+			return err
+		}
+
+		written = to
+
+		if time.Since(logged) > progressLogInterval {
+			s.logger.Info("freezing blocks", "frozen", written, "target", limit, "elapsed", time.Since(start))
+
+			logged = time.Now()
+		}
+	}
+
+	s.logger.Info("blocks frozen", "from", first, "to", limit-1, "elapsed", time.Since(start))
+
+	return nil
+}
+
+// freezeRange appends the canonical blocks of the range to the freezer, and then removes
+// them from the key-value database. The blocks not removed before the node stopped are
+// removed once the storage is started again
+func (s *Storage) freezeRange(from, to uint64) error {
+	batch := s.db.NewBatch()
+
+	for number := from; number < to; number++ {
+		numberKey := common.EncodeUint64ToBytes(number)
+
+		var hash types.Hash
+
+		if data, ok := s.get(storage.CANONICAL, numberKey); ok {
+			hash = types.BytesToHash(data)
+		}
+
+		// the blocks below the snap sync pivot have no canonical hash, the freezer keeps a gap for them
+		var header, body, receipts []byte
+
+		if hash != types.ZeroHash {
+			header, _ = s.get(storage.HEADER, hash.Bytes())
+			body, _ = s.get(storage.BODY, hash.Bytes())
+			receipts, _ = s.get(storage.RECEIPTS, hash.Bytes())
+		}
+
+		if err := s.freezer.Append(number, hash, header, body, receipts); err != nil {
+			return err
+		}
+
+		if hash == types.ZeroHash {
+			continue
+		}
+
+		batch.Delete(key(storage.CANONICAL, numberKey))
+		batch.Delete(key(storage.HEADER, hash.Bytes()))
+		batch.Delete(key(storage.BODY, hash.Bytes()))
+		batch.Delete(key(storage.RECEIPTS, hash.Bytes()))
+		batch.Put(key(storage.FROZEN_NUMBER, hash.Bytes()), numberKey)
+	}
+
+	// the blocks are removed from the key-value database only once they are safely on the disk
+	if err := s.freezer.Sync(); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// removeFrozen removes the blocks which were frozen, but not removed from the key-value database
+// before the node stopped. They follow the last block removed, so they are looked up from the top
+func (s *Storage) removeFrozen() error {
+	var (
+		batch   = s.db.NewBatch()
+		removed = 0
+	)
+
+	for number := s.freezer.Frozen(); number > 0; number-- {
+		data, err := s.freezer.read(hashesTable, number-1)
+		if err != nil {
+			return err
+		}
+
+		// the blocks below the snap sync pivot have no hash to remove
+		if len(data) == 0 {
+			break
+		}
+
+		hash := types.BytesToHash(data)
+		if _, ok := s.get(storage.FROZEN_NUMBER, hash.Bytes()); ok {
+			break
+		}
+
+		numberKey := common.EncodeUint64ToBytes(number - 1)
+
+		batch.Delete(key(storage.CANONICAL, numberKey))
+		batch.Delete(key(storage.HEADER, hash.Bytes()))
+		batch.Delete(key(storage.BODY, hash.Bytes()))
+		batch.Delete(key(storage.RECEIPTS, hash.Bytes()))
+		batch.Put(key(storage.FROZEN_NUMBER, hash.Bytes()), numberKey)
+
+		removed++
+	}
+
+	if removed == 0 {
+		return nil
+	}
+
+	s.logger.Info("removing frozen blocks from the key-value database", "blocks", removed)
+
+	return batch.Write()
+}
+
+func (s *Storage) get(prefix, k []byte) ([]byte, bool) {
+	data, ok, err := s.db.Get(key(prefix, k))
+	if err != nil {
+		return nil, false
+	}
+
+	return data, ok
+}
+
+func key(prefix, k []byte) []byte {
+	return append(append(make([]byte, 0, len(prefix)+len(k)), prefix...), k...)
+}
@@ -0,0 +1,283 @@
+package freezer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestStorage(t *testing.T, threshold uint64) (*Storage, storage.KV) {
+	t.Helper()
+
+	db := memory.NewMemoryKV()
+
+	return NewStorage(hclog.NewNullLogger(), db, openTestFreezer(t, t.TempDir(), true), threshold, false), db
+}
+
+// pruneTestHistory removes the bodies and the receipts of the blocks below the tail,
+// and moves the tail, as the history pruner does
+func pruneTestHistory(t *testing.T, s storage.Storage, chain []*types.Block, tail uint64) {
+	t.Helper()
+
+	batch := storage.NewBatchWriter(s)
+
+	for _, block := range chain[1:tail] {
+		batch.DeleteBody(block.Hash())
+		batch.DeleteReceipts(block.Hash())
+		batch.DeleteTxLookup(block.Transactions[0].Hash)
+	}
+
+	batch.PutTailNumber(tail)
+
+	require.NoError(t, batch.WriteBatch())
+}
+
+// writeTestChain writes the canonical chain of blocks holding a transaction each.
+// The blocks below the tail have the header only, as after a snap sync
+func writeTestChain(t *testing.T, s storage.Storage, blocks, tail uint64) []*types.Block {
+	t.Helper()
+
+	chain := make([]*types.Block, blocks)
+
+	for number := uint64(0); number < blocks; number++ {
+		header := &types.Header{Number: number, GasLimit: number}
+		header.ComputeHash()
+
+		tx := &types.Transaction{Nonce: number, GasPrice: big.NewInt(1), Value: big.NewInt(1)}
+		tx.ComputeHash(number)
+
+		chain[number] = &types.Block{Header: header, Transactions: []*types.Transaction{tx}}
+
+		batch := storage.NewBatchWriter(s)
+		batch.PutCanonicalHeader(header, big.NewInt(int64(number)))
+
+		if number >= tail {
+			batch.PutBody(header.Hash, chain[number].Body())
+			batch.PutReceipts(header.Hash, []*types.Receipt{{CumulativeGasUsed: number, TxHash: tx.Hash}})
+			batch.PutTxLookup(tx.Hash, header.Hash)
+		}
+
+		require.NoError(t, batch.WriteBatch())
+	}
+
+	return chain
+}
+
+func TestStorage(t *testing.T) {
+	storage.TestStorage(t, func(t *testing.T) (storage.Storage, func()) {
+		t.Helper()
+
+		s, _ := newTestStorage(t, 10)
+
+		return s, func() {
+			require.NoError(t, s.Close())
+		}
+	})
+}
+
+func TestStorage_Freeze(t *testing.T) {
+	t.Parallel()
+
+	s, db := newTestStorage(t, 10)
+	defer s.Close()
+
+	chain := writeTestChain(t, s, 5000, 0)
+
+	require.NoError(t, s.freeze())
+	require.Equal(t, uint64(4989), s.freezer.Frozen())
+
+	for _, block := range chain {
+		hash := block.Hash()
+
+		canonical, ok := s.ReadCanonicalHash(block.Number())
+		require.True(t, ok)
+		require.Equal(t, hash, canonical)
+
+		header, err := s.ReadHeader(hash)
+		require.NoError(t, err)
+		require.Equal(t, block.Header, header)
+
+		body, err := s.ReadBody(hash)
+		require.NoError(t, err)
+		require.Equal(t, block.Transactions[0].Hash, body.Transactions[0].Hash)
+
+		receipts, err := s.ReadReceipts(hash)
+		require.NoError(t, err)
+		require.Equal(t, block.Number(), receipts[0].CumulativeGasUsed)
+
+		lookup, ok := s.ReadTxLookup(block.Transactions[0].Hash)
+		require.True(t, ok)
+		require.Equal(t, hash, lookup)
+
+		// the frozen blocks are removed from the key-value database
+		_, inDB, err := db.Get(key(storage.HEADER, hash.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, block.Number() >= 4989, inDB)
+	}
+
+	// the blocks are frozen again only once the head moves past the threshold
+	require.NoError(t, s.freeze())
+	require.Equal(t, uint64(4989), s.freezer.Frozen())
+
+	_, err := s.ReadHeader(types.StringToHash("1"))
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestStorage_FreezeSnapSynced(t *testing.T) {
+	t.Parallel()
+
+	s, db := newTestStorage(t, 10)
+	defer s.Close()
+
+	chain := writeTestChain(t, s, 100, 50)
+
+	// the blocks below the snap sync pivot ancestors have no canonical hash
+	batch := db.NewBatch()
+	for number := uint64(1); number < 20; number++ {
+		batch.Delete(key(storage.CANONICAL, common.EncodeUint64ToBytes(number)))
+	}
+	require.NoError(t, batch.Write())
+
+	require.NoError(t, s.freeze())
+	require.Equal(t, uint64(89), s.freezer.Frozen())
+
+	_, ok := s.ReadCanonicalHash(10)
+	require.False(t, ok)
+
+	canonical, ok := s.ReadCanonicalHash(30)
+	require.True(t, ok)
+	require.Equal(t, chain[30].Hash(), canonical)
+
+	header, err := s.ReadHeader(chain[30].Hash())
+	require.NoError(t, err)
+	require.Equal(t, chain[30].Header, header)
+
+	_, err = s.ReadBody(chain[30].Hash())
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	body, err := s.ReadBody(chain[60].Hash())
+	require.NoError(t, err)
+	require.Len(t, body.Transactions, 1)
+}
+
+func TestStorage_FreezePrunedHistory(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pruned before frozen", func(t *testing.T) {
+		t.Parallel()
+
+		s, _ := newTestStorage(t, 10)
+		defer s.Close()
+
+		s.historyPruned = true
+
+		chain := writeTestChain(t, s, 200, 0)
+
+		// the blocks are not frozen before their history is pruned
+		require.NoError(t, s.freeze())
+		require.Equal(t, uint64(0), s.freezer.Frozen())
+
+		pruneTestHistory(t, s, chain, 150)
+
+		require.NoError(t, s.freeze())
+		require.Equal(t, uint64(150), s.freezer.Frozen())
+
+		// the pruned history is not frozen
+		for number := uint64(1); number < 150; number++ {
+			for _, name := range []string{bodiesTable, receiptsTable} {
+				data, err := s.freezer.read(name, number)
+				require.NoError(t, err)
+				require.Empty(t, data)
+			}
+		}
+
+		header, err := s.ReadHeader(chain[100].Hash())
+		require.NoError(t, err)
+		require.Equal(t, chain[100].Header, header)
+
+		// the blocks within the history retention are frozen once the tail moves past them
+		pruneTestHistory(t, s, chain, 170)
+
+		require.NoError(t, s.freeze())
+		require.Equal(t, uint64(170), s.freezer.Frozen())
+	})
+
+	t.Run("frozen before pruned", func(t *testing.T) {
+		t.Parallel()
+
+		s, _ := newTestStorage(t, 10)
+		defer s.Close()
+
+		chain := writeTestChain(t, s, 200, 0)
+
+		require.NoError(t, s.freeze())
+		require.Equal(t, uint64(189), s.freezer.Frozen())
+
+		pruneTestHistory(t, s, chain, 150)
+
+		// the frozen history below the tail is not served
+		for _, block := range chain[1:189] {
+			_, err := s.ReadBody(block.Hash())
+			require.Equal(t, block.Number() < 150, errors.Is(err, storage.ErrNotFound))
+
+			_, err = s.ReadReceipts(block.Hash())
+			require.Equal(t, block.Number() < 150, errors.Is(err, storage.ErrNotFound))
+
+			_, err = s.ReadHeader(block.Hash())
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestStorage_RemoveFrozen(t *testing.T) {
+	t.Parallel()
+
+	s, db := newTestStorage(t, 10)
+	defer s.Close()
+
+	chain := writeTestChain(t, s, 100, 0)
+
+	require.NoError(t, s.freezeRange(0, 50))
+
+	// the node stopped after the blocks were frozen, but before they were removed
+	for number := uint64(50); number < 80; number++ {
+		hash := chain[number].Hash()
+
+		header, _ := s.get(storage.HEADER, hash.Bytes())
+		body, _ := s.get(storage.BODY, hash.Bytes())
+		receipts, _ := s.get(storage.RECEIPTS, hash.Bytes())
+
+		require.NoError(t, s.freezer.Append(number, hash, header, body, receipts))
+	}
+
+	require.NoError(t, s.freezer.Sync())
+	require.NoError(t, s.removeFrozen())
+
+	for _, block := range chain {
+		hash := block.Hash()
+
+		_, inDB, err := db.Get(key(storage.HEADER, hash.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, block.Number() >= 80, inDB)
+
+		header, err := s.ReadHeader(hash)
+		require.NoError(t, err)
+		require.Equal(t, block.Header, header)
+
+		canonical, ok := s.ReadCanonicalHash(block.Number())
+		require.True(t, ok)
+		require.Equal(t, hash, canonical)
+	}
+
+	// the freezing continues from the last frozen block
+	require.NoError(t, s.freeze())
+	require.Equal(t, uint64(89), s.freezer.Frozen())
+}
@@ -0,0 +1,246 @@
+package freezer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// indexEntrySize is the size of the end offset of an item in the index file
+	indexEntrySize = 8
+
+	indexExt          = ".idx"
+	rawDataExt        = ".rdat"
+	compressedDataExt = ".cdat"
+)
+
+var (
+	errOutOfBounds = errors.New("item not found in the freezer table")
+)
+
+// table is an append-only flat file of the items of one kind, addressed by their position.
+// The index file holds the end offset of every item in the data file as 8 bytes big endian,
+// so the item n spans the data file from the end offset of the item n-1 to its own end offset
+type table struct {
+	lock sync.RWMutex
+
+	name     string
+	compress bool
+
+	index *os.File
+	data  *os.File
+
+	items uint64 // number of the items in the table
+	size  uint64 // size of the data file
+}
+
+// openTable opens the table in the directory, or creates it if it doesn't exist.
+// The data file of an existing table keeps the compression it was created with
+func openTable(dir, name string, compress bool) (*table, error) {
+	switch {
+	case fileExists(filepath.Join(dir, name+compressedDataExt)):
+		compress = true
+	case fileExists(filepath.Join(dir, name+rawDataExt)):
+		compress = false
+	}
+
+	dataExt := rawDataExt
+	if compress {
+		dataExt = compressedDataExt
+	}
+
+	index, err := os.OpenFile(filepath.Join(dir, name+indexExt), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.OpenFile(filepath.Join(dir, name+dataExt), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		index.Close()
+
+		return nil, err
+	}
+
+	t := &table{
+		name:     name,
+		compress: compress,
+		index:    index,
+		data:     data,
+	}
+
+	if err := t.repair(); err != nil {
+		t.close()
+
+		return nil, fmt.Errorf("failed to repair the %s table: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// repair drops the items which were not completely written before the node stopped
+func (t *table) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+
+	t.items = uint64(indexStat.Size()) / indexEntrySize
+	dataSize := uint64(dataStat.Size())
+
+	// the index entry is written after the data, so only the last entries can point past the data
+	for t.items > 0 {
+		end, err := t.endOffset(t.items - 1)
+		if err != nil {
+			return err
+		}
+
+		if end <= dataSize {
+			break
+		}
+
+		t.items--
+	}
+
+	return t.resize(t.items)
+}
+
+// truncate drops the items from the given position onwards
+func (t *table) truncate(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.resize(items)
+}
+
+// resize cuts the index and data files down to the given number of items
+func (t *table) resize(items uint64) error {
+	size := uint64(0)
+
+	if items > 0 {
+		end, err := t.endOffset(items - 1)
+		if err != nil {
+			return err
+		}
+
+		size = end
+	}
+
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+
+	t.items, t.size = items, size
+
+	return nil
+}
+
+// endOffset reads the end offset of the item from the index file
+func (t *table) endOffset(item uint64) (uint64, error) {
+	buf := make([]byte, indexEntrySize)
+	if _, err := t.index.ReadAt(buf, int64(item*indexEntrySize)); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(buf), nil
+}
+
+// read returns the item at the given position
+func (t *table) read(item uint64) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if item >= t.items {
+		return nil, errOutOfBounds
+	}
+
+	start := uint64(0)
+
+	if item > 0 {
+		var err error
+		if start, err = t.endOffset(item - 1); err != nil {
+			return nil, err
+		}
+	}
+
+	end, err := t.endOffset(item)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, end-start)
+	if _, err := t.data.ReadAt(buf, int64(start)); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if !t.compress || len(buf) == 0 {
+		return buf, nil
+	}
+
+	return snappy.Decode(nil, buf)
+}
+
+// append writes the item at the next position
+func (t *table) append(item []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.compress && len(item) > 0 {
+		item = snappy.Encode(nil, item)
+	}
+
+	if _, err := t.data.WriteAt(item, int64(t.size)); err != nil {
+		return err
+	}
+
+	end := t.size + uint64(len(item))
+
+	buf := make([]byte, indexEntrySize)
+	binary.BigEndian.PutUint64(buf, end)
+
+	if _, err := t.index.WriteAt(buf, int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+
+	t.items++
+	t.size = end
+
+	return nil
+}
+
+// sync flushes the written items to the disk
+func (t *table) sync() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+
+	return t.index.Sync()
+}
+
+func (t *table) close() error {
+	return errors.Join(t.data.Close(), t.index.Close())
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
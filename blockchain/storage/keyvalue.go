@@ -39,12 +39,16 @@ var (
 
 	// TX_LOOKUP_PREFIX is the prefix for transaction lookups
 	TX_LOOKUP_PREFIX = []byte("l")
+
+	// FROZEN_NUMBER is the prefix for the numbers of the blocks moved to the freezer
+	FROZEN_NUMBER = []byte("n")
 )
 
 // Sub-prefixes
 var (
 	HASH   = []byte("hash")
 	NUMBER = []byte("number")
+	TAIL   = []byte("tail")
 	EMPTY  = []byte("empty")
 )
 
@@ -106,6 +110,20 @@ func (s *KeyValueStorage) ReadHeadNumber() (uint64, bool) {
 	return common.EncodeBytesToUint64(data), true
 }
 
+// ReadTailNumber returns the number of the oldest block with the body and the receipts
+func (s *KeyValueStorage) ReadTailNumber() (uint64, bool) {
+	data, ok := s.get(HEAD, TAIL)
+	if !ok {
+		return 0, false
+	}
+
+	if len(data) != 8 {
+		return 0, false
+	}
+
+	return common.EncodeBytesToUint64(data), true
+}
+
 // FORK //
 
 // ReadForks read the current forks
@@ -31,28 +31,43 @@ func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storag
 
 // NewLevelDBStorage creates the new storage reference with leveldb default options
 func NewLevelDBStorage(path string, logger hclog.Logger) (storage.Storage, error) {
-	// Set default options
-	options := &opt.Options{
-		OpenFilesCacheCapacity: DefaultHandles,
-		BlockCacheCapacity:     DefaultCache / 2 * opt.MiB,
-		WriteBuffer:            DefaultCache / 4 * opt.MiB, // Two of these are used internally
+	kv, err := NewLevelDBKV(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return NewLevelDBStorageWithOpt(path, logger, options)
+	return storage.NewKeyValueStorage(logger.Named("leveldb"), kv), nil
 }
 
 // NewLevelDBStorageWithOpt creates the new storage reference with leveldb with custom options
 func NewLevelDBStorageWithOpt(path string, logger hclog.Logger, opts *opt.Options) (storage.Storage, error) {
-	db, err := leveldb.OpenFile(path, opts)
+	kv, err := NewLevelDBKVWithOpt(path, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	kv := &levelDBKV{db}
-
 	return storage.NewKeyValueStorage(logger.Named("leveldb"), kv), nil
 }
 
+// NewLevelDBKV opens the leveldb kv storage with default options
+func NewLevelDBKV(path string) (storage.KV, error) {
+	return NewLevelDBKVWithOpt(path, &opt.Options{
+		OpenFilesCacheCapacity: DefaultHandles,
+		BlockCacheCapacity:     DefaultCache / 2 * opt.MiB,
+		WriteBuffer:            DefaultCache / 4 * opt.MiB, // Two of these are used internally
+	})
+}
+
+// NewLevelDBKVWithOpt opens the leveldb kv storage with custom options
+func NewLevelDBKVWithOpt(path string, opts *opt.Options) (storage.KV, error) {
+	db, err := leveldb.OpenFile(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &levelDBKV{db}, nil
+}
+
 // levelDBKV is the leveldb implementation of the kv storage
 type levelDBKV struct {
 	db *leveldb.DB
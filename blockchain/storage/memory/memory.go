@@ -8,9 +8,12 @@ import (
 
 // NewMemoryStorage creates the new storage reference with inmemory
 func NewMemoryStorage(logger hclog.Logger) (storage.Storage, error) {
-	db := &memoryKV{map[string][]byte{}}
+	return storage.NewKeyValueStorage(logger, NewMemoryKV()), nil
+}
 
-	return storage.NewKeyValueStorage(logger, db), nil
+// NewMemoryKV creates the in memory kv storage
+func NewMemoryKV() storage.KV {
+	return &memoryKV{map[string][]byte{}}
 }
 
 // memoryKV is an in memory implementation of the kv storage
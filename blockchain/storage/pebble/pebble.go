@@ -0,0 +1,121 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	DefaultCache   = int(256)
+	DefaultHandles = int(256)
+
+	mib = 1024 * 1024
+)
+
+// Factory creates a pebble storage
+func Factory(config map[string]interface{}, logger hclog.Logger) (storage.Storage, error) {
+	path, ok := config["path"]
+	if !ok {
+		return nil, fmt.Errorf("path not found")
+	}
+
+	pathStr, ok := path.(string)
+	if !ok {
+		return nil, fmt.Errorf("path is not a string")
+	}
+
+	return NewPebbleStorage(pathStr, logger)
+}
+
+// NewPebbleStorage creates the new storage reference with pebble default options
+func NewPebbleStorage(path string, logger hclog.Logger) (storage.Storage, error) {
+	kv, err := NewPebbleKV(path, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewKeyValueStorage(logger.Named("pebble"), kv), nil
+}
+
+// NewPebbleKV opens the pebble kv storage with the default options
+func NewPebbleKV(path string, logger hclog.Logger) (storage.KV, error) {
+	db, err := OpenDB(path, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleKV{db}, nil
+}
+
+// OpenDB opens the pebble database with the default options
+func OpenDB(path string, logger hclog.Logger) (*pebble.DB, error) {
+	cache := pebble.NewCache(int64(DefaultCache / 2 * mib))
+	defer cache.Unref()
+
+	return pebble.Open(path, &pebble.Options{
+		Cache:        cache,
+		MaxOpenFiles: DefaultHandles,
+		MemTableSize: uint64(DefaultCache / 4 * mib),
+		// the writes are stalled only once 4 memtables are waiting to be flushed
+		MemTableStopWritesThreshold: 4,
+		MaxConcurrentCompactions: func() int {
+			return 4
+		},
+		Logger: &pebbleLogger{logger.Named("pebble")},
+	})
+}
+
+// pebbleLogger writes the pebble logs to hclog
+type pebbleLogger struct {
+	logger hclog.Logger
+}
+
+// Infof logs the pebble event on the debug level, pebble is verbose
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs the pebble error, pebble stops right after it
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.logger.Error(fmt.Sprintf(format, args...))
+}
+
+// pebbleKV is the pebble implementation of the kv storage
+type pebbleKV struct {
+	db *pebble.DB
+}
+
+// Set sets the key-value pair in pebble storage
+func (p *pebbleKV) Set(k []byte, v []byte) error {
+	return p.db.Set(k, v, pebble.Sync)
+}
+
+// Get retrieves the key-value pair in pebble storage
+func (p *pebbleKV) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := p.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	defer closer.Close()
+
+	// the returned data is valid only until the closer is closed
+	return append([]byte{}, data...), true, nil
+}
+
+// Close closes the pebble storage instance
+func (p *pebbleKV) Close() error {
+	return p.db.Close()
+}
+
+func (p *pebbleKV) NewBatch() storage.Batch {
+	return NewBatchPebble(p.db)
+}
@@ -14,6 +14,10 @@ type Storage interface {
 	ReadHeadHash() (types.Hash, bool)
 	ReadHeadNumber() (uint64, bool)
 
+	// ReadTailNumber returns the number of the oldest block stored with its body and receipts.
+	// The blocks below it are missing, e.g. when the node was synced from a state snapshot
+	ReadTailNumber() (uint64, bool)
+
 	ReadForks() ([]types.Hash, error)
 
 	ReadTotalDifficulty(hash types.Hash) (*big.Int, bool)
@@ -34,6 +34,9 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("testHead", func(t *testing.T) {
 		testHead(t, m)
 	})
+	t.Run("testTail", func(t *testing.T) {
+		testTail(t, m)
+	})
 	t.Run("testForks", func(t *testing.T) {
 		testForks(t, m)
 	})
@@ -190,6 +193,25 @@ func testHead(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testTail(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadTailNumber()
+	assert.False(t, ok)
+
+	batch := NewBatchWriter(s)
+	batch.PutTailNumber(100)
+
+	require.NoError(t, batch.WriteBatch())
+
+	tail, ok := s.ReadTailNumber()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), tail)
+}
+
 func testForks(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -453,6 +475,7 @@ func testWriteCanonicalHeader(t *testing.T, m PlaceholderStorage) {
 type readCanonicalHashDelegate func(uint64) (types.Hash, bool)
 type readHeadHashDelegate func() (types.Hash, bool)
 type readHeadNumberDelegate func() (uint64, bool)
+type readTailNumberDelegate func() (uint64, bool)
 type readForksDelegate func() ([]types.Hash, error)
 type readTotalDifficultyDelegate func(types.Hash) (*big.Int, bool)
 type readHeaderDelegate func(types.Hash) (*types.Header, error)
@@ -467,6 +490,7 @@ type MockStorage struct {
 	readCanonicalHashFn   readCanonicalHashDelegate
 	readHeadHashFn        readHeadHashDelegate
 	readHeadNumberFn      readHeadNumberDelegate
+	readTailNumberFn      readTailNumberDelegate
 	readForksFn           readForksDelegate
 	readTotalDifficultyFn readTotalDifficultyDelegate
 	readHeaderFn          readHeaderDelegate
@@ -517,6 +541,18 @@ func (m *MockStorage) HookReadHeadNumber(fn readHeadNumberDelegate) {
 	m.readHeadNumberFn = fn
 }
 
+func (m *MockStorage) ReadTailNumber() (uint64, bool) {
+	if m.readTailNumberFn != nil {
+		return m.readTailNumberFn()
+	}
+
+	return 0, false
+}
+
+func (m *MockStorage) HookReadTailNumber(fn readTailNumberDelegate) {
+	m.readTailNumberFn = fn
+}
+
 func (m *MockStorage) ReadForks() ([]types.Hash, error) {
 	if m.readForksFn != nil {
 		return m.readForksFn()
@@ -61,6 +61,9 @@ type Genesis struct {
 	// Override
 	StateRoot types.Hash
 
+	// Metadata describes the source chain of a genesis dumped from the state of one of its blocks
+	Metadata *GenesisMetadata `json:"metadata,omitempty"`
+
 	// Only for testing
 	Number     uint64     `json:"number"`
 	GasUsed    uint64     `json:"gasUsed"`
@@ -130,6 +133,7 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 		BaseFee            *string                     `json:"baseFee"`
 		BaseFeeEM          *string                     `json:"baseFeeEM"`
 		BaseFeeChangeDenom *string                     `json:"baseFeeChangeDenom"`
+		Metadata           *GenesisMetadata            `json:"metadata,omitempty"`
 	}
 
 	var enc Genesis
@@ -159,6 +163,7 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 	enc.Number = common.EncodeUint64(g.Number)
 	enc.GasUsed = common.EncodeUint64(g.GasUsed)
 	enc.ParentHash = g.ParentHash
+	enc.Metadata = g.Metadata
 
 	return json.Marshal(&enc)
 }
@@ -180,6 +185,7 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		BaseFee            *string                    `json:"baseFee"`
 		BaseFeeEM          *string                    `json:"baseFeeEM"`
 		BaseFeeChangeDenom *string                    `json:"baseFeeChangeDenom"`
+		Metadata           *GenesisMetadata           `json:"metadata"`
 	}
 
 	var dec Genesis
@@ -270,9 +276,19 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		g.ParentHash = *dec.ParentHash
 	}
 
+	g.Metadata = dec.Metadata
+
 	return err
 }
 
+// GenesisMetadata describes the block of the source chain whose state is dumped into the genesis
+type GenesisMetadata struct {
+	SourceChainID     int64      `json:"sourceChainId"`
+	SourceBlockNumber uint64     `json:"sourceBlockNumber"`
+	SourceBlockHash   types.Hash `json:"sourceBlockHash"`
+	SourceStateRoot   types.Hash `json:"sourceStateRoot"`
+}
+
 // Genesis alloc
 
 // GenesisAccount is an account in the state of the genesis block.
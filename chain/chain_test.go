@@ -131,6 +131,26 @@ func TestGenesisX(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `{
+				"gasLimit": "0x11",
+				"metadata": {
+					"sourceChainId": 100,
+					"sourceBlockNumber": 10,
+					"sourceBlockHash": "0x0000000000000000000000000000000000000000000000000000000000000001",
+					"sourceStateRoot": "0x0000000000000000000000000000000000000000000000000000000000000002"
+				}
+			}`,
+			output: &Genesis{
+				GasLimit: 17,
+				Metadata: &GenesisMetadata{
+					SourceChainID:     100,
+					SourceBlockNumber: 10,
+					SourceBlockHash:   hash("1"),
+					SourceStateRoot:   hash("2"),
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
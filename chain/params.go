@@ -20,6 +20,15 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
 
+	// Ordering policy of the transactions in the proposed blocks
+	// (price, fifo or roundrobin). Defaults to price if not set
+	TxOrdering string `json:"txOrdering,omitempty"`
+
+	// RejectUnprotectedTxs makes the nodes reject by default the legacy transactions
+	// signed without the chain ID. It is set in the genesis of the new chains only,
+	// so that the nodes of the existing chains keep accepting them
+	RejectUnprotectedTxs bool `json:"rejectUnprotectedTxs,omitempty"`
+
 	// Access control configuration
 	ContractDeployerAllowList *AddressListConfig `json:"contractDeployerAllowList,omitempty"`
 	ContractDeployerBlockList *AddressListConfig `json:"contractDeployerBlockList,omitempty"`
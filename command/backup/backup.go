@@ -1,16 +1,24 @@
 package backup
 
 import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/archive"
 	"github.com/0xPolygon/polygon-edge/command"
 	"github.com/spf13/cobra"
 
+	"github.com/0xPolygon/polygon-edge/command/backup/state"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 )
 
 func GetCommand() *cobra.Command {
 	backupCmd := &cobra.Command{
-		Use:     "backup",
-		Short:   "Create blockchain backup file by fetching blockchain data from the running node",
+		Use: "backup",
+		Short: "Create blockchain backup by fetching blockchain data from the running node. " +
+			"By default the blocks are written to compressed chunk files in the backup directory, " +
+			"an interrupted backup is resumed by running the command again. " +
+			"The blocks can also be exported to a file of concatenated RLP encoded blocks, as geth does, " +
+			"or to a directory of Era1 files holding the receipts too",
 		PreRunE: runPreRun,
 		Run:     runCommand,
 	}
@@ -20,6 +28,11 @@ func GetCommand() *cobra.Command {
 	setFlags(backupCmd)
 	helper.SetRequiredFlags(backupCmd, params.getRequiredFlags())
 
+	backupCmd.AddCommand(
+		// backup state
+		state.GetCommand(),
+	)
+
 	return backupCmd
 }
 
@@ -28,7 +41,14 @@ func setFlags(cmd *cobra.Command) {
 		&params.out,
 		outFlag,
 		"",
-		"the export path for the backup",
+		"the export directory for the backup, or the export file with the rlp format",
+	)
+
+	cmd.Flags().StringVar(
+		&params.formatRaw,
+		formatFlag,
+		string(archive.FormatChunked),
+		fmt.Sprintf("the format of the backup %v", archive.Formats()),
 	)
 
 	cmd.Flags().StringVar(
@@ -44,6 +64,20 @@ func setFlags(cmd *cobra.Command) {
 		"",
 		"the end height of the chain in backup",
 	)
+
+	cmd.Flags().StringVar(
+		&params.compressionRaw,
+		compressionFlag,
+		string(archive.CompressionZstd),
+		fmt.Sprintf("the compression of the chunk files of the chunked format %v", archive.Compressions()),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chunkSize,
+		chunkSizeFlag,
+		archive.DefaultChunkSize,
+		"the number of the blocks per chunk file of the chunked format",
+	)
 }
 
 func runPreRun(_ *cobra.Command, _ []string) error {
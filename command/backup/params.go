@@ -11,9 +11,12 @@ import (
 )
 
 const (
-	outFlag  = "out"
-	fromFlag = "from"
-	toFlag   = "to"
+	outFlag         = "out"
+	fromFlag        = "from"
+	toFlag          = "to"
+	formatFlag      = "format"
+	compressionFlag = "compression"
+	chunkSizeFlag   = "chunk-size"
 )
 
 var (
@@ -28,6 +31,13 @@ var (
 type backupParams struct {
 	out string
 
+	formatRaw string
+	format    archive.Format
+
+	compressionRaw string
+	compression    archive.Compression
+	chunkSize      uint64
+
 	fromRaw string
 	toRaw   string
 
@@ -41,6 +51,18 @@ type backupParams struct {
 func (p *backupParams) validateFlags() error {
 	var parseErr error
 
+	if p.format, parseErr = archive.ParseFormat(p.formatRaw); parseErr != nil {
+		return parseErr
+	}
+
+	if p.compression, parseErr = archive.ParseCompression(p.compressionRaw); parseErr != nil {
+		return parseErr
+	}
+
+	if p.chunkSize == 0 {
+		return archive.ErrInvalidChunkSize
+	}
+
 	if p.from, parseErr = common.ParseUint64orHex(&p.fromRaw); parseErr != nil {
 		return errDecodeRange
 	}
@@ -76,17 +98,33 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 		return err
 	}
 
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "backup",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	var resFrom, resTo uint64
+
 	// resFrom and resTo represents the range of blocks that can be included in the file
-	resFrom, resTo, err := archive.CreateBackup(
-		connection,
-		hclog.New(&hclog.LoggerOptions{
-			Name:  "backup",
-			Level: hclog.LevelFromString("INFO"),
-		}),
-		p.from,
-		p.to,
-		p.out,
-	)
+	switch p.format {
+	case archive.FormatRLP:
+		resFrom, resTo, err = archive.CreateRLPExport(connection, logger, p.from, p.to, p.out)
+	case archive.FormatEra1:
+		resFrom, resTo, err = archive.CreateEraExport(connection, logger, p.from, p.to, p.out)
+	default:
+		resFrom, resTo, err = archive.CreateChunkedBackup(
+			connection,
+			logger,
+			p.from,
+			p.to,
+			p.out,
+			archive.BackupOptions{
+				Compression: p.compression,
+				ChunkSize:   p.chunkSize,
+			},
+		)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -98,9 +136,16 @@ func (p *backupParams) createBackup(grpcAddress string) error {
 }
 
 func (p *backupParams) getResult() command.CommandResult {
-	return &BackupResult{
-		From: p.resFrom,
-		To:   p.resTo,
-		Out:  p.out,
+	result := &BackupResult{
+		From:   p.resFrom,
+		To:     p.resTo,
+		Out:    p.out,
+		Format: string(p.format),
+	}
+
+	if p.format == archive.FormatChunked {
+		result.Compression = string(p.compression)
 	}
+
+	return result
 }
@@ -8,21 +8,31 @@ import (
 )
 
 type BackupResult struct {
-	From uint64 `json:"from"`
-	To   uint64 `json:"to"`
-	Out  string `json:"out"`
+	From        uint64 `json:"from"`
+	To          uint64 `json:"to"`
+	Out         string `json:"out"`
+	Format      string `json:"format"`
+	Compression string `json:"compression,omitempty"`
 }
 
 func (r *BackupResult) GetOutput() string {
 	var buffer bytes.Buffer
 
 	buffer.WriteString("\n[BACKUP]\n")
-	buffer.WriteString("Exported backup file successfully:\n")
-	buffer.WriteString(helper.FormatKV([]string{
-		fmt.Sprintf("File|%s", r.Out),
+	buffer.WriteString("Exported backup successfully:\n")
+
+	vals := []string{
+		fmt.Sprintf("Out|%s", r.Out),
 		fmt.Sprintf("From|%d", r.From),
 		fmt.Sprintf("To|%d", r.To),
-	}))
+		fmt.Sprintf("Format|%s", r.Format),
+	}
+
+	if r.Compression != "" {
+		vals = append(vals, fmt.Sprintf("Compression|%s", r.Compression))
+	}
+
+	buffer.WriteString(helper.FormatKV(vals))
 
 	return buffer.String()
 }
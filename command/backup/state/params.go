@@ -0,0 +1,89 @@
+package state
+
+import (
+	"errors"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/archive"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	outFlag   = "out"
+	blockFlag = "block"
+)
+
+var (
+	params = &stateParams{}
+)
+
+var (
+	errDecodeBlock = errors.New("unable to decode block number")
+)
+
+type stateParams struct {
+	out string
+
+	blockRaw string
+	block    uint64
+
+	result *archive.StateBackupResult
+}
+
+func (p *stateParams) validateFlags() error {
+	if p.blockRaw == "" {
+		return nil
+	}
+
+	var err error
+
+	if p.block, err = common.ParseUint64orHex(&p.blockRaw); err != nil {
+		return errDecodeBlock
+	}
+
+	return nil
+}
+
+func (p *stateParams) getRequiredFlags() []string {
+	return []string{
+		outFlag,
+	}
+}
+
+func (p *stateParams) createStateBackup(grpcAddress string) error {
+	connection, err := helper.GetGRPCConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return err
+	}
+
+	p.result, err = archive.CreateStateBackup(
+		connection,
+		hclog.New(&hclog.LoggerOptions{
+			Name:  "backup",
+			Level: hclog.LevelFromString("INFO"),
+		}),
+		p.block,
+		p.out,
+	)
+
+	return err
+}
+
+func (p *stateParams) getResult() command.CommandResult {
+	header := p.result.Header
+
+	return &StateResult{
+		Number:    header.Number,
+		Hash:      header.Hash.String(),
+		StateRoot: header.StateRoot.String(),
+		Headers:   p.result.Headers,
+		Nodes:     p.result.Nodes,
+		Codes:     p.result.Codes,
+		Out:       p.out,
+	}
+}
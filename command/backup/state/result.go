@@ -0,0 +1,36 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type StateResult struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"state_root"`
+	Headers   uint64 `json:"headers"`
+	Nodes     uint64 `json:"nodes"`
+	Codes     uint64 `json:"codes"`
+	Out       string `json:"out"`
+}
+
+func (r *StateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[STATE BACKUP]\n")
+	buffer.WriteString("Exported state backup successfully:\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("File|%s", r.Out),
+		fmt.Sprintf("Block|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+		fmt.Sprintf("State Root|%s", r.StateRoot),
+		fmt.Sprintf("Headers|%d", r.Headers),
+		fmt.Sprintf("Trie Nodes|%d", r.Nodes),
+		fmt.Sprintf("Contract Codes|%d", r.Codes),
+	}))
+
+	return buffer.String()
+}
@@ -0,0 +1,57 @@
+package state
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use: "state",
+		Short: "Create the state backup of a block by fetching the state from the running node. " +
+			"The file holds the block, the headers of its ancestors, the trie nodes and the contract codes, " +
+			"and is restored into an empty chain with the server --restore flag",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(stateCmd)
+	helper.SetRequiredFlags(stateCmd, params.getRequiredFlags())
+
+	return stateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.out,
+		outFlag,
+		"",
+		"the export path for the state backup",
+	)
+
+	cmd.Flags().StringVar(
+		&params.blockRaw,
+		blockFlag,
+		"",
+		"the height of the block whose state is backed up, the latest block if omitted",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.createStateBackup(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,44 @@
+package compact
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	compactCmd := &cobra.Command{
+		Use: "compact",
+		Short: "Compacts the blockchain and state databases of the data directory, " +
+			"reclaiming the space of the removed keys. The node must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(compactCmd)
+	helper.SetRequiredFlags(compactCmd, params.getRequiredFlags())
+
+	return compactCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.compact(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,68 @@
+package compact
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &compactParams{}
+)
+
+type compactParams struct {
+	dataDir string
+
+	sizeBefore uint64
+	sizeAfter  uint64
+	elapsed    time.Duration
+}
+
+func (p *compactParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *compactParams) compact() error {
+	logger := hclog.NewNullLogger()
+
+	before, err := dbengine.DataDirSize(p.dataDir)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+
+	if err := dbengine.CompactDataDir(p.dataDir, logger); err != nil {
+		return err
+	}
+
+	p.elapsed = time.Since(start)
+
+	after, err := dbengine.DataDirSize(p.dataDir)
+	if err != nil {
+		return err
+	}
+
+	p.sizeBefore = before
+	p.sizeAfter = after
+
+	return nil
+}
+
+func (p *compactParams) getResult() command.CommandResult {
+	return &DBCompactResult{
+		DataDir:    p.dataDir,
+		SizeBefore: p.sizeBefore,
+		SizeAfter:  p.sizeAfter,
+		Elapsed:    p.elapsed.String(),
+	}
+}
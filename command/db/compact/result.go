@@ -0,0 +1,30 @@
+package compact
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DBCompactResult struct {
+	DataDir    string `json:"data_dir"`
+	SizeBefore uint64 `json:"size_before"`
+	SizeAfter  uint64 `json:"size_after"`
+	Elapsed    string `json:"elapsed"`
+}
+
+func (r *DBCompactResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB COMPACT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data directory|%s", r.DataDir),
+		fmt.Sprintf("Size before (bytes)|%d", r.SizeBefore),
+		fmt.Sprintf("Size after (bytes)|%d", r.SizeAfter),
+		fmt.Sprintf("Elapsed|%s", r.Elapsed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,51 @@
+package convert
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	convertCmd := &cobra.Command{
+		Use: "convert",
+		Short: "Copies the leveldb databases of the data directory into pebble databases " +
+			"in the target data directory. The node must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(convertCmd)
+	helper.SetRequiredFlags(convertCmd, params.getRequiredFlags())
+
+	return convertCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node using leveldb",
+	)
+
+	cmd.Flags().StringVar(
+		&params.targetDataDir,
+		targetDataDirFlag,
+		"",
+		"the data directory the pebble databases are written to. "+
+			"The node is started from it with --db-engine pebble",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.convert(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
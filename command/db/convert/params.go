@@ -0,0 +1,67 @@
+package convert
+
+import (
+	"errors"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+)
+
+const (
+	dataDirFlag       = "data-dir"
+	targetDataDirFlag = "target-data-dir"
+)
+
+var (
+	params = &convertParams{}
+)
+
+var (
+	errSameDataDir = errors.New("target data directory must differ from the data directory")
+)
+
+type convertParams struct {
+	dataDir       string
+	targetDataDir string
+
+	result  *dbengine.ConvertResult
+	elapsed time.Duration
+}
+
+func (p *convertParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+		targetDataDirFlag,
+	}
+}
+
+func (p *convertParams) convert() error {
+	if filepath.Clean(p.dataDir) == filepath.Clean(p.targetDataDir) {
+		return errSameDataDir
+	}
+
+	start := time.Now()
+
+	result, err := dbengine.ConvertDataDir(p.dataDir, p.targetDataDir, hclog.NewNullLogger())
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+	p.elapsed = time.Since(start)
+
+	return nil
+}
+
+func (p *convertParams) getResult() command.CommandResult {
+	return &DBConvertResult{
+		TargetDataDir:  p.targetDataDir,
+		BlockchainKeys: p.result.Blockchain,
+		StateKeys:      p.result.State,
+		Elapsed:        p.elapsed.String(),
+	}
+}
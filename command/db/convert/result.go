@@ -0,0 +1,30 @@
+package convert
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DBConvertResult struct {
+	TargetDataDir  string `json:"target_data_dir"`
+	BlockchainKeys uint64 `json:"blockchain_keys"`
+	StateKeys      uint64 `json:"state_keys"`
+	Elapsed        string `json:"elapsed"`
+}
+
+func (r *DBConvertResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB CONVERT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Target data directory|%s", r.TargetDataDir),
+		fmt.Sprintf("Blockchain keys|%d", r.BlockchainKeys),
+		fmt.Sprintf("State keys|%d", r.StateKeys),
+		fmt.Sprintf("Elapsed|%s", r.Elapsed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
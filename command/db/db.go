@@ -0,0 +1,36 @@
+package db
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/db/compact"
+	"github.com/0xPolygon/polygon-edge/command/db/convert"
+	"github.com/0xPolygon/polygon-edge/command/db/inspect"
+	"github.com/0xPolygon/polygon-edge/command/db/sethead"
+	"github.com/0xPolygon/polygon-edge/command/db/verifystate"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Top level command for managing the node databases. Only accepts subcommands.",
+	}
+
+	registerSubcommands(dbCmd)
+
+	return dbCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// db convert
+		convert.GetCommand(),
+		// db inspect
+		inspect.GetCommand(),
+		// db verify-state
+		verifystate.GetCommand(),
+		// db compact
+		compact.GetCommand(),
+		// db set-head
+		sethead.GetCommand(),
+	)
+}
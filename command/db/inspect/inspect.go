@@ -0,0 +1,44 @@
+package inspect
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	inspectCmd := &cobra.Command{
+		Use: "inspect",
+		Short: "Reports the number and the size of the keys per kind of data in the blockchain " +
+			"and state databases of the data directory. The node must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(inspectCmd)
+	helper.SetRequiredFlags(inspectCmd, params.getRequiredFlags())
+
+	return inspectCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.inspect(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,48 @@
+package inspect
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &inspectParams{}
+)
+
+type inspectParams struct {
+	dataDir string
+
+	result *dbengine.InspectResult
+}
+
+func (p *inspectParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *inspectParams) inspect() error {
+	result, err := dbengine.InspectDataDir(p.dataDir, hclog.NewNullLogger())
+	if err != nil {
+		return err
+	}
+
+	p.result = result
+
+	return nil
+}
+
+func (p *inspectParams) getResult() command.CommandResult {
+	return &DBInspectResult{
+		DataDir:     p.dataDir,
+		Blockchain:  p.result.Blockchain,
+		State:       p.result.State,
+		FreezerSize: p.result.FreezerSize,
+	}
+}
@@ -0,0 +1,69 @@
+package inspect
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+)
+
+type DBInspectResult struct {
+	DataDir     string                  `json:"data_dir"`
+	Blockchain  *dbengine.DatabaseStats `json:"blockchain"`
+	State       *dbengine.DatabaseStats `json:"state"`
+	FreezerSize uint64                  `json:"freezer_size"`
+}
+
+func (r *DBInspectResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB INSPECT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Data directory|%s", r.DataDir),
+		fmt.Sprintf("Freezer size|%s", formatSize(r.FreezerSize)),
+	}))
+	buffer.WriteString("\n")
+
+	writeDatabase(&buffer, "BLOCKCHAIN DATABASE", r.Blockchain)
+	writeDatabase(&buffer, "STATE DATABASE", r.State)
+
+	return buffer.String()
+}
+
+func writeDatabase(buffer *bytes.Buffer, title string, stats *dbengine.DatabaseStats) {
+	buffer.WriteString(fmt.Sprintf("\n[%s]\n", title))
+
+	if stats == nil {
+		buffer.WriteString("No database\n")
+
+		return
+	}
+
+	rows := []string{"Data|Keys|Size"}
+	for _, prefix := range stats.Prefixes {
+		rows = append(rows, fmt.Sprintf("%s|%d|%s", prefix.Name, prefix.Keys, formatSize(prefix.Size)))
+	}
+
+	rows = append(rows, fmt.Sprintf("Total (%s)|%d|%s", stats.Engine, stats.Keys, formatSize(stats.Size)))
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+}
+
+// formatSize formats the size in bytes with a binary unit
+func formatSize(size uint64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.2f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
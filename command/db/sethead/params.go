@@ -0,0 +1,106 @@
+package sethead
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag = "data-dir"
+)
+
+var (
+	params = &setHeadParams{}
+)
+
+var (
+	errStateMissing = errors.New("state of the new head is missing from the state database")
+)
+
+type setHeadParams struct {
+	dataDir string
+	number  uint64
+
+	previousHead uint64
+	removed      uint64
+	head         *types.Header
+}
+
+func (p *setHeadParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *setHeadParams) initRawParams(args []string) error {
+	number, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid block number %q: %w", args[0], err)
+	}
+
+	p.number = number
+
+	return nil
+}
+
+func (p *setHeadParams) setHead() (err error) {
+	blockchainStorage, stateStorage, err := dbengine.OpenExistingStorages(p.dataDir, hclog.NewNullLogger())
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, blockchainStorage.Close(), stateStorage.Close())
+	}()
+
+	previousHead, ok := blockchainStorage.ReadHeadNumber()
+	if !ok {
+		return fmt.Errorf("failed to read the head number")
+	}
+
+	hash, ok := blockchainStorage.ReadCanonicalHash(p.number)
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d not found", p.number)
+	}
+
+	header, err := blockchainStorage.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("failed to read the header of block %d: %w", p.number, err)
+	}
+
+	// the node can't execute the blocks following the new head without its state
+	if _, ok, err := itrie.GetNode(header.StateRoot.Bytes(), stateStorage); err != nil {
+		return err
+	} else if !ok && header.StateRoot != types.EmptyRootHash {
+		return fmt.Errorf("%w: block %d, root %s", errStateMissing, p.number, header.StateRoot)
+	}
+
+	removed, err := blockchain.SetHead(blockchainStorage, p.number)
+	if err != nil {
+		return err
+	}
+
+	p.previousHead = previousHead
+	p.removed = removed
+	p.head = header
+
+	return nil
+}
+
+func (p *setHeadParams) getResult() command.CommandResult {
+	return &DBSetHeadResult{
+		PreviousHead:  p.previousHead,
+		Head:          p.head.Number,
+		HeadHash:      p.head.Hash.String(),
+		RemovedBlocks: p.removed,
+	}
+}
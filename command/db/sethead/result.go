@@ -0,0 +1,30 @@
+package sethead
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DBSetHeadResult struct {
+	PreviousHead  uint64 `json:"previous_head"`
+	Head          uint64 `json:"head"`
+	HeadHash      string `json:"head_hash"`
+	RemovedBlocks uint64 `json:"removed_blocks"`
+}
+
+func (r *DBSetHeadResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB SET HEAD]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Previous head|%d", r.PreviousHead),
+		fmt.Sprintf("Head|%d", r.Head),
+		fmt.Sprintf("Head hash|%s", r.HeadHash),
+		fmt.Sprintf("Removed blocks|%d", r.RemovedBlocks),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
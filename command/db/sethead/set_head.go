@@ -0,0 +1,51 @@
+package sethead
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	setHeadCmd := &cobra.Command{
+		Use: "set-head [number]",
+		Short: "Rewinds the canonical chain to the given block, removing the canonical hashes " +
+			"and the transaction lookups of the blocks above it. The node must be stopped",
+		Args: cobra.ExactArgs(1),
+		Run:  runCommand,
+	}
+
+	setFlags(setHeadCmd)
+	helper.SetRequiredFlags(setHeadCmd, params.getRequiredFlags())
+
+	return setHeadCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+}
+
+func runCommand(cmd *cobra.Command, args []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initRawParams(args); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.setHead(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
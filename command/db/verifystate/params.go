@@ -0,0 +1,107 @@
+package verifystate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag = "data-dir"
+	blockFlag   = "block"
+)
+
+var (
+	params = &verifyStateParams{}
+)
+
+var (
+	errStateRootMissing  = errors.New("state root is missing from the state database")
+	errStateRootMismatch = errors.New("state trie doesn't hash to the state root")
+)
+
+type verifyStateParams struct {
+	dataDir string
+	block   uint64
+
+	// blockSet is false when the head block is verified
+	blockSet bool
+
+	header  *types.Header
+	elapsed time.Duration
+}
+
+func (p *verifyStateParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *verifyStateParams) verify() (err error) {
+	blockchainStorage, stateStorage, err := dbengine.OpenExistingStorages(p.dataDir, hclog.NewNullLogger())
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, blockchainStorage.Close(), stateStorage.Close())
+	}()
+
+	number := p.block
+	if !p.blockSet {
+		head, ok := blockchainStorage.ReadHeadNumber()
+		if !ok {
+			return fmt.Errorf("failed to read the head number")
+		}
+
+		number = head
+	}
+
+	hash, ok := blockchainStorage.ReadCanonicalHash(number)
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := blockchainStorage.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("failed to read the header of block %d: %w", number, err)
+	}
+
+	if _, ok, err := itrie.GetNode(header.StateRoot.Bytes(), stateStorage); err != nil {
+		return err
+	} else if !ok && header.StateRoot != types.EmptyRootHash {
+		return fmt.Errorf("%w: block %d, root %s", errStateRootMissing, number, header.StateRoot)
+	}
+
+	start := time.Now()
+
+	root, err := itrie.HashChecker(header.StateRoot.Bytes(), stateStorage)
+	if err != nil {
+		return fmt.Errorf("failed to hash the state trie of block %d: %w", number, err)
+	}
+
+	if root != header.StateRoot {
+		return fmt.Errorf("%w: block %d, expected %s, got %s", errStateRootMismatch, number, header.StateRoot, root)
+	}
+
+	p.header = header
+	p.elapsed = time.Since(start)
+
+	return nil
+}
+
+func (p *verifyStateParams) getResult() command.CommandResult {
+	return &DBVerifyStateResult{
+		Number:    p.header.Number,
+		Hash:      p.header.Hash.String(),
+		StateRoot: p.header.StateRoot.String(),
+		Elapsed:   p.elapsed.String(),
+	}
+}
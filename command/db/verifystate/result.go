@@ -0,0 +1,30 @@
+package verifystate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DBVerifyStateResult struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	StateRoot string `json:"state_root"`
+	Elapsed   string `json:"elapsed"`
+}
+
+func (r *DBVerifyStateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DB VERIFY STATE]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block number|%d", r.Number),
+		fmt.Sprintf("Block hash|%s", r.Hash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Elapsed|%s", r.Elapsed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
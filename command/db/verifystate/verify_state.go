@@ -0,0 +1,53 @@
+package verifystate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	verifyStateCmd := &cobra.Command{
+		Use: "verify-state",
+		Short: "Verifies that the state trie of a block is complete and hashes to the state root " +
+			"of the block. The node must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(verifyStateCmd)
+	helper.SetRequiredFlags(verifyStateCmd, params.getRequiredFlags())
+
+	return verifyStateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the number of the block whose state is verified, the head block if not set",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	params.blockSet = cmd.Flags().Changed(blockFlag)
+
+	if err := params.verify(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
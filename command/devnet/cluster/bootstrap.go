@@ -0,0 +1,346 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis"
+	cmdHelper "github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/polybftsecrets"
+	rootHelper "github.com/0xPolygon/polygon-edge/command/rootchain/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// InitPolyBFTSecrets generates the secrets of the given number of polybft accounts
+// in the directories with the given prefix, and returns their addresses
+func InitPolyBFTSecrets(binary, dirPrefix string, count int, out io.Writer) ([]types.Address, error) {
+	var secretsOutput bytes.Buffer
+
+	if err := RunCommand(binary, []string{
+		"polybft-secrets",
+		"--" + polybftsecrets.AccountDirFlag, dirPrefix,
+		"--num", strconv.Itoa(count),
+		"--insecure",
+	}, io.MultiWriter(&secretsOutput, out)); err != nil {
+		return nil, fmt.Errorf("failed to initialize secrets: %w", err)
+	}
+
+	return ParseSecretsAddresses(secretsOutput.String()), nil
+}
+
+// Bootstrapper deploys the rootchain contracts of the polybft chain whose genesis is generated,
+// and registers and stakes its genesis validators on them. It is shared by the devnet and
+// the e2e test cluster
+type Bootstrapper struct {
+	// Binary is the polygon-edge binary running the commands
+	Binary string
+	// Dir is the directory holding the secrets of the validators
+	Dir string
+	// ValidatorPrefix is the prefix of the directories of the validators
+	ValidatorPrefix string
+	// GenesisPath is the path of the genesis file, which the rootchain contract addresses are stored to
+	GenesisPath string
+	// RootchainURL is the JSON-RPC endpoint of the rootchain
+	RootchainURL string
+	// ProxyContractsAdmin is the admin of the deployed proxy contracts
+	ProxyContractsAdmin string
+	// StakeAmounts are the initial stakes of the validators, by their order, the default stake otherwise
+	StakeAmounts []*big.Int
+	// Premine are the premined non-validator accounts (format: <address>[:<balance>[:<private key>]]),
+	// which the native root token is minted and premined for if it is not mintable
+	Premine []string
+	// Out receives the output of the commands
+	Out io.Writer
+}
+
+func (b *Bootstrapper) run(args ...string) error {
+	return RunCommand(b.Binary, args, b.Out)
+}
+
+// Bootstrap deploys the stake manager and the rootchain contracts, funds, whitelists, registers
+// and stakes the genesis validators with the given addresses, premines the native root token
+// if it is not mintable, and finalizes the genesis validator set
+func (b *Bootstrapper) Bootstrap(addresses []types.Address) error {
+	if err := b.run(
+		"polybft",
+		"stake-manager-deploy",
+		"--jsonrpc", b.RootchainURL,
+		"--genesis", b.GenesisPath,
+		"--proxy-contracts-admin", b.ProxyContractsAdmin,
+		"--test",
+	); err != nil {
+		return fmt.Errorf("failed to deploy stake manager contract: %w", err)
+	}
+
+	config, err := polybft.LoadPolyBFTConfig(b.GenesisPath)
+	if err != nil {
+		return err
+	}
+
+	if err := b.run(
+		"rootchain",
+		"deploy",
+		"--json-rpc", b.RootchainURL,
+		"--stake-manager", config.Bridge.StakeManagerAddr.String(),
+		"--stake-token", config.Bridge.StakeTokenAddr.String(),
+		"--proxy-contracts-admin", b.ProxyContractsAdmin,
+		"--genesis", b.GenesisPath,
+		"--test",
+	); err != nil {
+		return fmt.Errorf("failed to deploy rootchain contracts: %w", err)
+	}
+
+	// reload the config, the deployment stored the rootchain contract addresses in the genesis
+	if config, err = polybft.LoadPolyBFTConfig(b.GenesisPath); err != nil {
+		return err
+	}
+
+	// the mintable native token is premined in the genesis
+	mintable := config.NativeTokenConfig == nil || config.NativeTokenConfig.IsMintable
+
+	if err := b.fund(addresses, config, mintable); err != nil {
+		return err
+	}
+
+	addressesRaw := make([]string, len(addresses))
+	for i, addr := range addresses {
+		addressesRaw[i] = addr.String()
+	}
+
+	if err := b.run(
+		"polybft",
+		"whitelist-validators",
+		"--addresses", strings.Join(addressesRaw, ","),
+		"--jsonrpc", b.RootchainURL,
+		"--supernet-manager", config.Bridge.CustomSupernetManagerAddr.String(),
+		"--private-key", rootHelper.TestAccountPrivKey,
+	); err != nil {
+		return fmt.Errorf("failed to whitelist genesis validators on supernet manager: %w", err)
+	}
+
+	validatorDirs, err := genesis.GetValidatorKeyFiles(b.Dir, b.ValidatorPrefix)
+	if err != nil {
+		return err
+	}
+
+	if err := b.forEachValidator(validatorDirs, func(_ int, dataDir string) error {
+		if err := b.run(
+			"polybft",
+			"register-validator",
+			"--jsonrpc", b.RootchainURL,
+			"--supernet-manager", config.Bridge.CustomSupernetManagerAddr.String(),
+			"--"+polybftsecrets.AccountDirFlag, dataDir,
+		); err != nil {
+			return fmt.Errorf("failed to register genesis validator on supernet manager: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := b.forEachValidator(validatorDirs, func(i int, dataDir string) error {
+		if err := b.run(
+			"polybft",
+			"stake",
+			"--jsonrpc", b.RootchainURL,
+			"--stake-manager", config.Bridge.StakeManagerAddr.String(),
+			"--"+polybftsecrets.AccountDirFlag, dataDir,
+			"--amount", b.stakeAmount(i).String(),
+			"--supernet-id", strconv.FormatInt(config.SupernetID, 10),
+			"--stake-token", config.Bridge.StakeTokenAddr.String(),
+		); err != nil {
+			return fmt.Errorf("failed to do initial staking for genesis validator on stake manager: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if !mintable {
+		if err := b.premineNativeRootToken(addresses, validatorDirs, config); err != nil {
+			return err
+		}
+	}
+
+	if err := b.run(
+		"polybft",
+		"supernet",
+		"--jsonrpc", b.RootchainURL,
+		"--private-key", rootHelper.TestAccountPrivKey,
+		"--genesis", b.GenesisPath,
+		"--supernet-manager", config.Bridge.CustomSupernetManagerAddr.String(),
+		"--finalize-genesis-set",
+		"--enable-staking",
+	); err != nil {
+		return fmt.Errorf("failed to finalize genesis validators on supernet manager: %w", err)
+	}
+
+	return nil
+}
+
+// fund mints the stake token for the validators on the rootchain, and funds the premined
+// accounts which premine the native root token if it is not mintable
+func (b *Bootstrapper) fund(addresses []types.Address, config polybft.PolyBFTConfig, mintable bool) error {
+	args := []string{
+		"rootchain",
+		"fund",
+		"--json-rpc", b.RootchainURL,
+		"--stake-token", config.Bridge.StakeTokenAddr.String(),
+		"--mint",
+	}
+
+	for _, addr := range addresses {
+		args = append(args, "--addresses", addr.String(), "--amounts", command.DefaultPremineBalance.String())
+	}
+
+	if err := b.run(args...); err != nil {
+		return fmt.Errorf("failed to fund validators on the rootchain: %w", err)
+	}
+
+	if mintable || len(b.Premine) == 0 {
+		return nil
+	}
+
+	// the premined accounts don't need the stake token, only the root token
+	args = []string{
+		"rootchain",
+		"fund",
+		"--json-rpc", b.RootchainURL,
+	}
+
+	for _, premineRaw := range b.Premine {
+		premineInfo, err := cmdHelper.ParsePremineInfo(premineRaw)
+		if err != nil {
+			return err
+		}
+
+		args = append(args, "--addresses", premineInfo.Address.String(),
+			"--amounts", command.DefaultPremineBalance.String())
+	}
+
+	if err := b.run(args...); err != nil {
+		return fmt.Errorf("failed to fund non-validator addresses on root: %w", err)
+	}
+
+	return nil
+}
+
+// premineNativeRootToken mints the native root token for the validators and the premined accounts,
+// which then premine it on the supernet manager
+func (b *Bootstrapper) premineNativeRootToken(
+	addresses []types.Address,
+	validatorDirs []string,
+	config polybft.PolyBFTConfig,
+) error {
+	args := []string{
+		"bridge",
+		"mint-erc20",
+		"--jsonrpc", b.RootchainURL,
+		"--erc20-token", config.Bridge.RootNativeERC20Addr.String(),
+	}
+
+	for _, addr := range addresses {
+		args = append(args, "--addresses", addr.String(), "--amounts", command.DefaultPremineBalance.String())
+	}
+
+	premines := make([]*cmdHelper.PremineInfo, len(b.Premine))
+
+	for i, premineRaw := range b.Premine {
+		premineInfo, err := cmdHelper.ParsePremineInfo(premineRaw)
+		if err != nil {
+			return err
+		}
+
+		premines[i] = premineInfo
+		args = append(args, "--addresses", premineInfo.Address.String(), "--amounts", premineInfo.Amount.String())
+	}
+
+	if err := b.run(args...); err != nil {
+		return fmt.Errorf("failed to mint native root token: %w", err)
+	}
+
+	premine := func(amount *big.Int, account ...string) error {
+		return b.run(append([]string{
+			"rootchain",
+			"premine",
+			"--jsonrpc", b.RootchainURL,
+			"--supernet-manager", config.Bridge.CustomSupernetManagerAddr.String(),
+			"--amount", amount.String(),
+			"--erc20-token", config.Bridge.RootNativeERC20Addr.String(),
+			"--root-erc20-predicate", config.Bridge.RootERC20PredicateAddr.String(),
+		}, account...)...)
+	}
+
+	if err := b.forEachValidator(validatorDirs, func(_ int, dataDir string) error {
+		if err := premine(command.DefaultPremineBalance, "--"+polybftsecrets.AccountDirFlag, dataDir); err != nil {
+			return fmt.Errorf("failed to do premine of native root token for genesis validator: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(context.Background())
+
+	for _, premineInfo := range premines {
+		premineInfo := premineInfo
+
+		g.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				if err := premine(premineInfo.Amount, "--private-key", premineInfo.Key); err != nil {
+					return fmt.Errorf("failed to do premine of native root token for non-validator account %s: %w",
+						premineInfo.Address, err)
+				}
+
+				return nil
+			}
+		})
+	}
+
+	return g.Wait()
+}
+
+// stakeAmount returns the initial stake of the validator with the given index
+func (b *Bootstrapper) stakeAmount(i int) *big.Int {
+	if i < len(b.StakeAmounts) {
+		return b.StakeAmounts[i]
+	}
+
+	return command.DefaultStake
+}
+
+// forEachValidator runs the handler concurrently for the data directory of every validator
+func (b *Bootstrapper) forEachValidator(validatorDirs []string, handler func(i int, dataDir string) error) error {
+	g, ctx := errgroup.WithContext(context.Background())
+
+	for i, dir := range validatorDirs {
+		i, dataDir := i, filepath.Join(b.Dir, dir)
+
+		g.Go(func() error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				return handler(i, dataDir)
+			}
+		})
+	}
+
+	return g.Wait()
+}
@@ -0,0 +1,383 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/command/rootchain/server"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	ConsensusPolyBFT = "polybft"
+	ConsensusIBFT    = "ibft"
+
+	// ValidatorPrefix is the prefix of the validator data directories inside the devnet directory
+	ValidatorPrefix = "test-chain-"
+
+	stateFileName    = "devnet.json"
+	genesisFileName  = "genesis.json"
+	rootchainDirName = "test-rootchain"
+	logsDirName      = "logs"
+	setupLogName     = "setup.log"
+
+	// libp2pPortStart must match the port the genesis command uses for the validator bootnodes
+	libp2pPortStart = 30300
+	hostIP          = "127.0.0.1"
+
+	stopTimeout = 30 * time.Second
+)
+
+var (
+	ErrNotFound       = errors.New("no devnet found in the directory")
+	ErrAlreadyRunning = errors.New("devnet is already running")
+	errConfigMismatch = errors.New("devnet directory was initialized with a different configuration, " +
+		"run devnet down with --destroy first")
+	errNodeExited = errors.New("node exited")
+)
+
+// Config describes the devnet to start
+type Config struct {
+	// Dir is the directory holding the secrets, genesis, data and logs of every node
+	Dir string
+	// Binary is the polygon-edge binary used to run the setup commands and the nodes
+	Binary string
+
+	Consensus     string
+	Validators    int
+	ChainID       uint64
+	BlockGasLimit uint64
+	EpochSize     uint64
+	BlockTime     time.Duration
+	Premine       []string
+
+	// BasePort is the gRPC port of the first node, the JSON-RPC port is the next but one port.
+	// Every following node uses ports shifted by 10
+	BasePort int
+	LogLevel string
+}
+
+// Node is a process started by the devnet
+type Node struct {
+	Name        string `json:"name"`
+	DataDir     string `json:"dataDir,omitempty"`
+	LogFile     string `json:"logFile"`
+	PID         int    `json:"pid"`
+	Libp2pAddr  string `json:"libp2pAddr,omitempty"`
+	GRPCAddr    string `json:"grpcAddr,omitempty"`
+	JSONRPCAddr string `json:"jsonRPCAddr"`
+
+	proc *process
+}
+
+// JSONRPCURL returns the url of the node JSON-RPC endpoint
+func (n *Node) JSONRPCURL() string {
+	return "http://" + n.JSONRPCAddr
+}
+
+// IsRunning checks if the node process is alive
+func (n *Node) IsRunning() bool {
+	if n.proc != nil {
+		return !n.proc.exited()
+	}
+
+	return n.PID != 0 && isAlive(n.PID)
+}
+
+// Devnet is the state of a devnet, persisted in its directory
+// so that it can be inspected and stopped by other invocations
+type Devnet struct {
+	Dir        string  `json:"-"`
+	Consensus  string  `json:"consensus"`
+	Validators int     `json:"validators"`
+	Genesis    string  `json:"genesis"`
+	Rootchain  *Node   `json:"rootchain,omitempty"`
+	Nodes      []*Node `json:"nodes"`
+}
+
+// Load reads the devnet state from the given directory
+func Load(dir string) (*Devnet, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, dir)
+		}
+
+		return nil, err
+	}
+
+	devnet := &Devnet{}
+	if err := json.Unmarshal(data, devnet); err != nil {
+		return nil, fmt.Errorf("failed to parse the devnet state: %w", err)
+	}
+
+	devnet.Dir = dir
+
+	return devnet, nil
+}
+
+func (d *Devnet) save() error {
+	data, err := json.MarshalIndent(d, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	return common.SaveFileSafe(filepath.Join(d.Dir, stateFileName), data, 0660)
+}
+
+// processes returns the nodes followed by the rootchain, in the order they should be stopped
+func (d *Devnet) processes() []*Node {
+	nodes := make([]*Node, 0, len(d.Nodes)+1)
+	for i := len(d.Nodes) - 1; i >= 0; i-- {
+		nodes = append(nodes, d.Nodes[i])
+	}
+
+	if d.Rootchain != nil {
+		nodes = append(nodes, d.Rootchain)
+	}
+
+	return nodes
+}
+
+// IsRunning checks if any process of the devnet is alive
+func (d *Devnet) IsRunning() bool {
+	for _, node := range d.processes() {
+		if node.IsRunning() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Up initializes the devnet on its first start and runs the rootchain (polybft only) and all the validators
+func Up(config *Config) (*Devnet, error) {
+	devnet, err := Load(config.Dir)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if devnet != nil {
+		if devnet.IsRunning() {
+			return nil, fmt.Errorf("%w in %s", ErrAlreadyRunning, config.Dir)
+		}
+
+		if devnet.Consensus != config.Consensus || devnet.Validators != config.Validators {
+			return nil, errConfigMismatch
+		}
+	}
+
+	if err := common.CreateDirSafe(filepath.Join(config.Dir, logsDirName), 0750); err != nil {
+		return nil, err
+	}
+
+	setupLog, err := os.OpenFile(filepath.Join(config.Dir, logsDirName, setupLogName),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+	defer setupLog.Close()
+
+	devnet = &Devnet{
+		Dir:        config.Dir,
+		Consensus:  config.Consensus,
+		Validators: config.Validators,
+		Genesis:    filepath.Join(config.Dir, genesisFileName),
+	}
+
+	if err := devnet.save(); err != nil {
+		return nil, err
+	}
+
+	if err := devnet.start(config, setupLog); err != nil {
+		// do not leave orphan processes behind when the setup fails
+		_ = devnet.stop()
+
+		return nil, err
+	}
+
+	return devnet, nil
+}
+
+func (d *Devnet) start(config *Config, setupLog io.Writer) error {
+	_, err := os.Stat(d.Genesis)
+	initialized := err == nil
+
+	switch config.Consensus {
+	case ConsensusPolyBFT:
+		if err := d.startRootchain(config); err != nil {
+			return err
+		}
+
+		if !initialized {
+			if err := initPolyBFT(config, d.Genesis, d.Rootchain.JSONRPCURL(), setupLog); err != nil {
+				return err
+			}
+		}
+	case ConsensusIBFT:
+		if !initialized {
+			if err := initIBFT(config, d.Genesis, setupLog); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported consensus: %s", config.Consensus)
+	}
+
+	for i := 1; i <= config.Validators; i++ {
+		if err := d.startNode(config, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Devnet) startRootchain(config *Config) error {
+	node := &Node{
+		Name:        "rootchain",
+		DataDir:     filepath.Join(config.Dir, rootchainDirName),
+		LogFile:     filepath.Join(config.Dir, logsDirName, "rootchain.log"),
+		JSONRPCAddr: fmt.Sprintf("%s:%d", hostIP, 8545),
+	}
+
+	d.Rootchain = node
+
+	if err := d.run(config.Binary, node, "rootchain", "server", "--data-dir", node.DataDir); err != nil {
+		return err
+	}
+
+	return server.PingServer(node.proc.done)
+}
+
+func (d *Devnet) startNode(config *Config, index int) error {
+	name := ValidatorPrefix + strconv.Itoa(index)
+	port := config.BasePort + 10*(index-1)
+
+	node := &Node{
+		Name:        name,
+		DataDir:     filepath.Join(config.Dir, name),
+		LogFile:     filepath.Join(config.Dir, logsDirName, name+".log"),
+		Libp2pAddr:  fmt.Sprintf("%s:%d", hostIP, libp2pPortStart+index),
+		GRPCAddr:    fmt.Sprintf("%s:%d", hostIP, port),
+		JSONRPCAddr: fmt.Sprintf("%s:%d", hostIP, port+2),
+	}
+
+	args := []string{
+		"server",
+		"--data-dir", node.DataDir,
+		"--chain", d.Genesis,
+		"--libp2p", node.Libp2pAddr,
+		"--grpc-address", node.GRPCAddr,
+		"--jsonrpc", node.JSONRPCAddr,
+		"--log-level", config.LogLevel,
+	}
+
+	if config.Consensus == ConsensusPolyBFT {
+		args = append(args, "--num-block-confirmations", "2")
+
+		if index == 1 {
+			args = append(args, "--relayer")
+		}
+	}
+
+	d.Nodes = append(d.Nodes, node)
+
+	return d.run(config.Binary, node, args...)
+}
+
+// run starts the node process and records its pid in the devnet state
+func (d *Devnet) run(binary string, node *Node, args ...string) error {
+	proc, err := startProcess(binary, args, node.LogFile)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", node.Name, err)
+	}
+
+	node.PID = proc.cmd.Process.Pid
+	node.proc = proc
+
+	return d.save()
+}
+
+// WaitForBlock waits until every node reaches the given block number
+func (d *Devnet) WaitForBlock(ctx context.Context, block uint64) error {
+	for {
+		reached := true
+
+		for _, node := range d.Nodes {
+			if !node.IsRunning() {
+				return fmt.Errorf("%w: %s, see %s", errNodeExited, node.Name, node.LogFile)
+			}
+
+			number, err := node.BlockNumber()
+			if err != nil || number < block {
+				reached = false
+			}
+		}
+
+		if reached {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("nodes did not reach block %d: %w", block, ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// BlockNumber queries the latest block number of the node
+func (n *Node) BlockNumber() (uint64, error) {
+	client, err := jsonrpc.NewClient(n.JSONRPCURL())
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	return client.Eth().BlockNumber()
+}
+
+// Down stops every process of the devnet in the given directory and optionally removes the directory
+func Down(dir string, destroy bool) (*Devnet, error) {
+	devnet, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := devnet.stop(); err != nil {
+		return nil, err
+	}
+
+	if destroy {
+		return devnet, os.RemoveAll(dir)
+	}
+
+	return devnet, nil
+}
+
+func (d *Devnet) stop() error {
+	for _, node := range d.processes() {
+		if node.PID == 0 {
+			continue
+		}
+
+		if err := stopProcess(node.PID, node.proc, stopTimeout); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", node.Name, err)
+		}
+
+		node.PID = 0
+		node.proc = nil
+	}
+
+	return d.save()
+}
@@ -0,0 +1,86 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestParseSecretsAddresses(t *testing.T) {
+	t.Parallel()
+
+	output := `
+[SECRETS GENERATED]
+Public key (address) = 0x61324166B0202DB1E7502924326262274Fa4358F
+BLS Public key       = 0a7ac0f7c0ed0b4bfe1fb8c3d1d8a7c6b5e3d3c4
+Node ID              = 16Uiu2HAmTkqGixWVxshMbbgtXhTUP8zLCZZ5G1UBd7pRJ1Mkx1ib
+
+[SECRETS GENERATED]
+Public key (address) = 0xE7e8Ab6B0FA1e8f3C8dA2A1Be5eaA9D5a3F8a5C6
+`
+
+	require.Equal(t, []types.Address{
+		types.StringToAddress("0x61324166B0202DB1E7502924326262274Fa4358F"),
+		types.StringToAddress("0xE7e8Ab6B0FA1e8f3C8dA2A1Be5eaA9D5a3F8a5C6"),
+	}, ParseSecretsAddresses(output))
+}
+
+func TestDevnet_Down(t *testing.T) {
+	t.Parallel()
+
+	sleep, err := os.Stat("/bin/sleep")
+	if err != nil || sleep.IsDir() {
+		t.Skip("sleep binary is not available")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, logsDirName), 0750))
+
+	devnet := &Devnet{
+		Dir:        dir,
+		Consensus:  ConsensusIBFT,
+		Validators: 1,
+		Genesis:    filepath.Join(dir, genesisFileName),
+	}
+
+	node := &Node{
+		Name:        ValidatorPrefix + "1",
+		LogFile:     filepath.Join(dir, logsDirName, "node.log"),
+		JSONRPCAddr: "127.0.0.1:10002",
+	}
+	devnet.Nodes = append(devnet.Nodes, node)
+
+	require.NoError(t, devnet.run("/bin/sleep", node, "60"))
+	require.True(t, devnet.IsRunning())
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	require.Equal(t, node.PID, loaded.Nodes[0].PID)
+	require.True(t, loaded.IsRunning())
+
+	_, err = Up(&Config{Dir: dir, Consensus: ConsensusIBFT, Validators: 1})
+	require.ErrorIs(t, err, ErrAlreadyRunning)
+
+	stopped, err := Down(dir, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, stopped.Nodes[0].PID)
+
+	// the process was started by this test, so it is reaped by its own wait
+	select {
+	case <-node.proc.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("node was not stopped")
+	}
+
+	_, err = Down(dir, true)
+	require.NoError(t, err)
+	require.NoDirExists(t, dir)
+
+	_, err = Load(dir)
+	require.ErrorIs(t, err, ErrNotFound)
+}
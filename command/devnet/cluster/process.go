@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// process is a node started by this invocation, reaped in the background
+// so that its exit can be observed while the devnet is starting
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// startProcess starts the binary detached from the terminal session of the caller,
+// so the node keeps running after the command which started it returns
+func startProcess(binary string, args []string, logFile string) (*process, error) {
+	output, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	// the child process holds its own copy of the descriptor
+	defer output.Close()
+
+	cmd := exec.Command(binary, args...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	proc := &process{
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		_ = cmd.Wait()
+
+		close(proc.done)
+	}()
+
+	return proc, nil
+}
+
+// isAlive checks if a process with the given pid exists
+func isAlive(pid int) bool {
+	err := syscall.Kill(pid, syscall.Signal(0))
+
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// stopProcess interrupts the process and kills it if it doesn't exit within the timeout
+func stopProcess(pid int, proc *process, timeout time.Duration) error {
+	running := func() bool {
+		if proc != nil {
+			return !proc.exited()
+		}
+
+		return isAlive(pid)
+	}
+
+	if !running() {
+		return nil
+	}
+
+	if err := syscall.Kill(pid, syscall.SIGINT); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for running() {
+		if time.Now().After(deadline) {
+			if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+				return err
+			}
+
+			deadline = time.Now().Add(timeout)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return nil
+}
+
+// RunCommand runs the binary with the given arguments until it exits.
+// Anything written to the standard error is treated as a failure
+func RunCommand(binary string, args []string, stdout io.Writer) error {
+	var stdErr bytes.Buffer
+
+	cmd := exec.Command(binary, args...)
+	cmd.Stderr = &stdErr
+	cmd.Stdout = stdout
+
+	if err := cmd.Run(); err != nil {
+		if stdErr.Len() > 0 {
+			return fmt.Errorf("failed to execute command: %s", stdErr.String())
+		}
+
+		return fmt.Errorf("failed to execute command: %w", err)
+	}
+
+	if stdErr.Len() > 0 {
+		return fmt.Errorf("error during command execution: %s", stdErr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/0xPolygon/polygon-edge/command/genesis"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// ProxyContractsAdmin is the admin of the proxy contracts deployed by the devnet
+	ProxyContractsAdmin = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+
+	rewardWallet = "0xDEADBEEF:1000000"
+
+	// bootnodeCount is the number of validators used as bootnodes
+	bootnodeCount = 2
+)
+
+var secretsAddressRegex = regexp.MustCompile(`\(address\) = 0x([a-fA-F0-9]+)`)
+
+// ParseSecretsAddresses extracts the account addresses from the output of the secrets commands
+func ParseSecretsAddresses(output string) []types.Address {
+	parsed := secretsAddressRegex.FindAllStringSubmatch(output, -1)
+	result := make([]types.Address, len(parsed))
+
+	for i, v := range parsed {
+		result[i] = types.StringToAddress(v[1])
+	}
+
+	return result
+}
+
+// genesisArgs returns the genesis command arguments shared by all the consensus protocols
+func genesisArgs(config *Config, genesisPath string) ([]string, error) {
+	args := []string{
+		"genesis",
+		"--consensus", config.Consensus,
+		"--validators-path", config.Dir,
+		"--validators-prefix", ValidatorPrefix,
+		"--dir", genesisPath,
+		"--chain-id", strconv.FormatUint(config.ChainID, 10),
+		"--block-gas-limit", strconv.FormatUint(config.BlockGasLimit, 10),
+	}
+
+	for _, premine := range config.Premine {
+		args = append(args, "--premine", premine)
+	}
+
+	validators, err := genesis.ReadValidatorsByPrefix(config.Dir, ValidatorPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(validators) && i < bootnodeCount; i++ {
+		args = append(args, "--bootnode", validators[i].MultiAddr)
+	}
+
+	return args, nil
+}
+
+func initIBFT(config *Config, genesisPath string, out io.Writer) error {
+	if err := RunCommand(config.Binary, []string{
+		"secrets",
+		"init",
+		"--data-dir", filepath.Join(config.Dir, ValidatorPrefix),
+		"--num", strconv.Itoa(config.Validators),
+		"--insecure",
+	}, out); err != nil {
+		return fmt.Errorf("failed to initialize secrets: %w", err)
+	}
+
+	args, err := genesisArgs(config, genesisPath)
+	if err != nil {
+		return err
+	}
+
+	if err := RunCommand(config.Binary, args, out); err != nil {
+		return fmt.Errorf("failed to generate genesis: %w", err)
+	}
+
+	return nil
+}
+
+func initPolyBFT(config *Config, genesisPath, rootchainURL string, out io.Writer) error {
+	addresses, err := InitPolyBFTSecrets(config.Binary, filepath.Join(config.Dir, ValidatorPrefix), config.Validators, out)
+	if err != nil {
+		return err
+	}
+
+	if len(addresses) != config.Validators {
+		return fmt.Errorf("expected %d validator addresses, got %d", config.Validators, len(addresses))
+	}
+
+	args, err := genesisArgs(config, genesisPath)
+	if err != nil {
+		return err
+	}
+
+	args = append(args,
+		"--epoch-size", strconv.FormatUint(config.EpochSize, 10),
+		"--block-time", config.BlockTime.String(),
+		"--premine", types.ZeroAddress.String(),
+		"--reward-wallet", rewardWallet,
+		"--native-token-config", fmt.Sprintf("Polygon:MATIC:18:true:%s", addresses[0]),
+		"--proxy-contracts-admin", ProxyContractsAdmin,
+	)
+
+	if err := RunCommand(config.Binary, args, out); err != nil {
+		return fmt.Errorf("failed to generate genesis: %w", err)
+	}
+
+	b := &Bootstrapper{
+		Binary:              config.Binary,
+		Dir:                 config.Dir,
+		ValidatorPrefix:     ValidatorPrefix,
+		GenesisPath:         genesisPath,
+		RootchainURL:        rootchainURL,
+		ProxyContractsAdmin: ProxyContractsAdmin,
+		Out:                 out,
+	}
+
+	return b.Bootstrap(addresses)
+}
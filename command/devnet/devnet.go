@@ -0,0 +1,31 @@
+package devnet
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/devnet/down"
+	"github.com/0xPolygon/polygon-edge/command/devnet/status"
+	"github.com/0xPolygon/polygon-edge/command/devnet/up"
+)
+
+func GetCommand() *cobra.Command {
+	devnetCmd := &cobra.Command{
+		Use:   "devnet",
+		Short: "Top level command for running a local multi-node network. Only accepts subcommands.",
+	}
+
+	registerSubcommands(devnetCmd)
+
+	return devnetCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// devnet up
+		up.GetCommand(),
+		// devnet down
+		down.GetCommand(),
+		// devnet status
+		status.GetCommand(),
+	)
+}
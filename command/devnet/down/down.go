@@ -0,0 +1,48 @@
+package down
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+func GetCommand() *cobra.Command {
+	downCmd := &cobra.Command{
+		Use:   "down",
+		Short: "Stops all the nodes of a local network started by devnet up",
+		Run:   runCommand,
+	}
+
+	setFlags(downCmd)
+
+	return downCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dir,
+		dirFlag,
+		defaultDir,
+		"the directory of the devnet",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.destroy,
+		destroyFlag,
+		false,
+		"remove the devnet directory with all the secrets, genesis, data and logs",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.down(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,53 @@
+package down
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/devnet/cluster"
+)
+
+const (
+	dirFlag     = "dir"
+	destroyFlag = "destroy"
+
+	defaultDir = "devnet"
+)
+
+var (
+	params = &downParams{}
+)
+
+type downParams struct {
+	dir     string
+	destroy bool
+
+	devnet *cluster.Devnet
+}
+
+func (p *downParams) down() error {
+	devnet, err := cluster.Down(p.dir, p.destroy)
+	if err != nil {
+		return err
+	}
+
+	p.devnet = devnet
+
+	return nil
+}
+
+func (p *downParams) getResult() command.CommandResult {
+	result := &DevnetDownResult{
+		Dir:       p.dir,
+		Destroyed: p.destroy,
+		Stopped:   make([]string, 0, len(p.devnet.Nodes)+1),
+	}
+
+	for _, node := range p.devnet.Nodes {
+		result.Stopped = append(result.Stopped, node.Name)
+	}
+
+	if p.devnet.Rootchain != nil {
+		result.Stopped = append(result.Stopped, p.devnet.Rootchain.Name)
+	}
+
+	return result
+}
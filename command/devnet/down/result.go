@@ -0,0 +1,29 @@
+package down
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type DevnetDownResult struct {
+	Dir       string   `json:"dir"`
+	Stopped   []string `json:"stopped"`
+	Destroyed bool     `json:"destroyed"`
+}
+
+func (r *DevnetDownResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DEVNET DOWN]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("Stopped|%s", strings.Join(r.Stopped, ", ")),
+		fmt.Sprintf("Destroyed|%t", r.Destroyed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
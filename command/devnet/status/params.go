@@ -0,0 +1,68 @@
+package status
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/devnet/cluster"
+)
+
+const (
+	dirFlag = "dir"
+
+	defaultDir = "devnet"
+)
+
+var (
+	params = &statusParams{}
+)
+
+type statusParams struct {
+	dir string
+
+	devnet *cluster.Devnet
+}
+
+func (p *statusParams) status() error {
+	devnet, err := cluster.Load(p.dir)
+	if err != nil {
+		return err
+	}
+
+	p.devnet = devnet
+
+	return nil
+}
+
+func (p *statusParams) getResult() command.CommandResult {
+	result := &DevnetStatusResult{
+		Dir:       p.devnet.Dir,
+		Consensus: p.devnet.Consensus,
+		Genesis:   p.devnet.Genesis,
+		Nodes:     make([]NodeStatus, 0, len(p.devnet.Nodes)+1),
+	}
+
+	nodes := p.devnet.Nodes
+	if p.devnet.Rootchain != nil {
+		nodes = append([]*cluster.Node{p.devnet.Rootchain}, nodes...)
+	}
+
+	for _, node := range nodes {
+		status := NodeStatus{
+			Name:    node.Name,
+			Running: node.IsRunning(),
+			JSONRPC: node.JSONRPCURL(),
+			LogFile: node.LogFile,
+		}
+
+		if status.Running {
+			status.PID = node.PID
+
+			if number, err := node.BlockNumber(); err == nil {
+				status.BlockNumber = &number
+			}
+		}
+
+		result.Nodes = append(result.Nodes, status)
+	}
+
+	return result
+}
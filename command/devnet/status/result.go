@@ -0,0 +1,55 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type NodeStatus struct {
+	Name        string  `json:"name"`
+	Running     bool    `json:"running"`
+	PID         int     `json:"pid,omitempty"`
+	BlockNumber *uint64 `json:"block_number,omitempty"`
+	JSONRPC     string  `json:"jsonrpc"`
+	LogFile     string  `json:"log_file"`
+}
+
+type DevnetStatusResult struct {
+	Dir       string       `json:"dir"`
+	Consensus string       `json:"consensus"`
+	Genesis   string       `json:"genesis"`
+	Nodes     []NodeStatus `json:"nodes"`
+}
+
+func (r *DevnetStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[DEVNET STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("Consensus|%s", r.Consensus),
+		fmt.Sprintf("Genesis|%s", r.Genesis),
+	}))
+	buffer.WriteString("\n")
+
+	rows := make([]string, 0, len(r.Nodes)+1)
+	rows = append(rows, "NAME|RUNNING|PID|BLOCK|JSON-RPC|LOG FILE")
+
+	for _, node := range r.Nodes {
+		block := "-"
+		if node.BlockNumber != nil {
+			block = fmt.Sprintf("%d", *node.BlockNumber)
+		}
+
+		rows = append(rows, fmt.Sprintf("%s|%t|%d|%s|%s|%s",
+			node.Name, node.Running, node.PID, block, node.JSONRPC, node.LogFile))
+	}
+
+	buffer.WriteString("\n[NODES]\n")
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
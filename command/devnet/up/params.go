@@ -0,0 +1,136 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/devnet/cluster"
+)
+
+const (
+	dirFlag           = "dir"
+	validatorsFlag    = "validators"
+	consensusFlag     = "consensus"
+	chainIDFlag       = "chain-id"
+	blockGasLimitFlag = "block-gas-limit"
+	epochSizeFlag     = "epoch-size"
+	blockTimeFlag     = "block-time"
+	premineFlag       = "premine"
+	basePortFlag      = "base-port"
+	logLevelFlag      = "log-level"
+	waitBlockFlag     = "wait-block"
+	timeoutFlag       = "timeout"
+
+	defaultDir = "devnet"
+
+	maxPort = 65535
+)
+
+var (
+	params = &upParams{}
+)
+
+var (
+	errInvalidValidators = errors.New("at least one validator is required")
+	errInvalidConsensus  = fmt.Errorf("consensus must be %s or %s", cluster.ConsensusPolyBFT, cluster.ConsensusIBFT)
+	errInvalidBasePort   = errors.New("base port is out of range for the number of validators")
+)
+
+type upParams struct {
+	dir           string
+	validators    int
+	consensus     string
+	chainID       uint64
+	blockGasLimit uint64
+	epochSize     uint64
+	blockTime     time.Duration
+	premine       []string
+	basePort      int
+	logLevel      string
+	waitBlock     uint64
+	timeout       time.Duration
+
+	devnet *cluster.Devnet
+}
+
+func (p *upParams) validateFlags() error {
+	if p.validators < 1 {
+		return errInvalidValidators
+	}
+
+	if p.consensus != cluster.ConsensusPolyBFT && p.consensus != cluster.ConsensusIBFT {
+		return errInvalidConsensus
+	}
+
+	// the last node uses the JSON-RPC port shifted by 10 for every preceding node
+	if p.basePort < 1 || p.basePort+10*(p.validators-1)+2 > maxPort {
+		return errInvalidBasePort
+	}
+
+	return nil
+}
+
+func (p *upParams) up() error {
+	binary, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	devnet, err := cluster.Up(&cluster.Config{
+		Dir:           p.dir,
+		Binary:        binary,
+		Consensus:     p.consensus,
+		Validators:    p.validators,
+		ChainID:       p.chainID,
+		BlockGasLimit: p.blockGasLimit,
+		EpochSize:     p.epochSize,
+		BlockTime:     p.blockTime,
+		Premine:       p.premine,
+		BasePort:      p.basePort,
+		LogLevel:      p.logLevel,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.devnet = devnet
+
+	if p.waitBlock == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	// the nodes are left running, devnet status shows which of them are stuck
+	return devnet.WaitForBlock(ctx, p.waitBlock)
+}
+
+func (p *upParams) getResult() command.CommandResult {
+	result := &DevnetUpResult{
+		Dir:       p.devnet.Dir,
+		Consensus: p.devnet.Consensus,
+		Genesis:   p.devnet.Genesis,
+		Nodes:     make([]NodeResult, len(p.devnet.Nodes)),
+	}
+
+	if p.devnet.Rootchain != nil {
+		result.Rootchain = p.devnet.Rootchain.JSONRPCURL()
+	}
+
+	for i, node := range p.devnet.Nodes {
+		result.Nodes[i] = NodeResult{
+			Name:    node.Name,
+			PID:     node.PID,
+			JSONRPC: node.JSONRPCURL(),
+			GRPC:    node.GRPCAddr,
+			LogFile: node.LogFile,
+		}
+	}
+
+	return result
+}
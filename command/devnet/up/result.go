@@ -0,0 +1,55 @@
+package up
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type NodeResult struct {
+	Name    string `json:"name"`
+	PID     int    `json:"pid"`
+	JSONRPC string `json:"jsonrpc"`
+	GRPC    string `json:"grpc"`
+	LogFile string `json:"log_file"`
+}
+
+type DevnetUpResult struct {
+	Dir       string       `json:"dir"`
+	Consensus string       `json:"consensus"`
+	Genesis   string       `json:"genesis"`
+	Rootchain string       `json:"rootchain,omitempty"`
+	Nodes     []NodeResult `json:"nodes"`
+}
+
+func (r *DevnetUpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	kv := []string{
+		fmt.Sprintf("Directory|%s", r.Dir),
+		fmt.Sprintf("Consensus|%s", r.Consensus),
+		fmt.Sprintf("Genesis|%s", r.Genesis),
+	}
+
+	if r.Rootchain != "" {
+		kv = append(kv, fmt.Sprintf("Rootchain JSON-RPC|%s", r.Rootchain))
+	}
+
+	buffer.WriteString("\n[DEVNET UP]\n")
+	buffer.WriteString(helper.FormatKV(kv))
+	buffer.WriteString("\n")
+
+	rows := make([]string, 0, len(r.Nodes)+1)
+	rows = append(rows, "NAME|PID|JSON-RPC|GRPC|LOG FILE")
+
+	for _, node := range r.Nodes {
+		rows = append(rows, fmt.Sprintf("%s|%d|%s|%s|%s", node.Name, node.PID, node.JSONRPC, node.GRPC, node.LogFile))
+	}
+
+	buffer.WriteString("\n[NODES]\n")
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
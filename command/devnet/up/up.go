@@ -0,0 +1,129 @@
+package up
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/devnet/cluster"
+)
+
+func GetCommand() *cobra.Command {
+	upCmd := &cobra.Command{
+		Use: "up",
+		Short: "Generates the secrets and genesis of a local network on the first run, " +
+			"starts all the validators in the background and waits for them to produce blocks",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(upCmd)
+
+	return upCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dir,
+		dirFlag,
+		defaultDir,
+		"the directory of the devnet secrets, genesis, data and logs",
+	)
+
+	cmd.Flags().IntVar(
+		&params.validators,
+		validatorsFlag,
+		4,
+		"the number of validators",
+	)
+
+	cmd.Flags().StringVar(
+		&params.consensus,
+		consensusFlag,
+		cluster.ConsensusPolyBFT,
+		fmt.Sprintf("the consensus protocol of the devnet (%s or %s)", cluster.ConsensusPolyBFT, cluster.ConsensusIBFT),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.chainID,
+		chainIDFlag,
+		command.DefaultChainID,
+		"the ID of the chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.blockGasLimit,
+		blockGasLimitFlag,
+		10_000_000,
+		"the maximum amount of gas used by all transactions in a block",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.epochSize,
+		epochSizeFlag,
+		10,
+		"the epoch size of the chain (polybft only)",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.blockTime,
+		blockTimeFlag,
+		2*time.Second,
+		"the predefined period which determines block creation frequency (polybft only)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.premine,
+		premineFlag,
+		[]string{},
+		"the premined accounts and balances (format: <address>[:<balance>]), can be repeated",
+	)
+
+	cmd.Flags().IntVar(
+		&params.basePort,
+		basePortFlag,
+		10000,
+		"the gRPC port of the first node, its JSON-RPC port is the gRPC port + 2 "+
+			"and the ports of every following node are shifted by 10",
+	)
+
+	cmd.Flags().StringVar(
+		&params.logLevel,
+		logLevelFlag,
+		"INFO",
+		"the log level of the nodes",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.waitBlock,
+		waitBlockFlag,
+		1,
+		"the block all the nodes need to reach before the command returns, 0 to not wait",
+	)
+
+	cmd.Flags().DurationVar(
+		&params.timeout,
+		timeoutFlag,
+		5*time.Minute,
+		"the maximum time to wait for the nodes to reach the block",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.up(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
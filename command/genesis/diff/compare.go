@@ -0,0 +1,285 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	sectionGenesis = "genesis"
+	sectionParams  = "params"
+	sectionForks   = "forks"
+	sectionEngine  = "engine"
+	sectionAlloc   = "alloc"
+
+	// missingValue is the value of the settings missing from a genesis
+	missingValue = "<none>"
+)
+
+// nonConsensusPaths are the settings of the genesis which each node may set on its own,
+// so they are left out of the consensus hash
+var nonConsensusPaths = [][]string{
+	{"name"},
+	{"bootnodes"},
+	{"genesis", "metadata"},
+	{"params", "engine", "polybft", "bridge", "jsonRPCEndpoint"},
+	{"params", "engine", "polybft", "blockTrackerPollInterval"},
+}
+
+// Difference is a setting which differs between the genesis files
+type Difference struct {
+	Section string `json:"section"`
+	Path    string `json:"path"`
+	A       string `json:"a"`
+	B       string `json:"b"`
+}
+
+// Compare returns the differences between the genesis files, ordered by section and path
+func Compare(a, b *chain.Chain) ([]*Difference, error) {
+	differences := make([]*Difference, 0)
+
+	sections := []struct {
+		name string
+		a, b interface{}
+	}{
+		{sectionGenesis, headerOf(a.Genesis), headerOf(b.Genesis)},
+		{sectionParams, paramsOf(a.Params), paramsOf(b.Params)},
+		{sectionForks, a.Params.Forks, b.Params.Forks},
+		{sectionEngine, a.Params.Engine, b.Params.Engine},
+	}
+
+	for _, section := range sections {
+		flatA, err := flatten(section.a)
+		if err != nil {
+			return nil, err
+		}
+
+		flatB, err := flatten(section.b)
+		if err != nil {
+			return nil, err
+		}
+
+		differences = append(differences, compareFlat(section.name, flatA, flatB)...)
+	}
+
+	return append(differences, compareAlloc(a.Genesis.Alloc, b.Genesis.Alloc)...), nil
+}
+
+// ConsensusHash returns the hash of the settings of the genesis which all the nodes must agree on
+func ConsensusHash(genesis *chain.Chain) (types.Hash, error) {
+	value, err := toJSONValue(genesis)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	for _, path := range nonConsensusPaths {
+		deletePath(value, path)
+	}
+
+	// the keys of the maps are sorted by the encoding, which makes it canonical
+	data, err := json.Marshal(value)
+	if err != nil {
+		return types.ZeroHash, err
+	}
+
+	return types.BytesToHash(crypto.Keccak256(data)), nil
+}
+
+// headerOf returns the genesis without its alloc, which is compared account by account
+func headerOf(genesis *chain.Genesis) *chain.Genesis {
+	header := *genesis
+	header.Alloc = nil
+
+	return &header
+}
+
+// paramsOf returns the params without the forks and the engine, which are compared in their own sections
+func paramsOf(params *chain.Params) *chain.Params {
+	rest := *params
+	rest.Forks = nil
+	rest.Engine = nil
+
+	return &rest
+}
+
+func compareFlat(section string, a, b map[string]string) []*Difference {
+	paths := make([]string, 0, len(a)+len(b))
+
+	for path := range a {
+		paths = append(paths, path)
+	}
+
+	for path := range b {
+		if _, ok := a[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	differences := make([]*Difference, 0)
+
+	for _, path := range paths {
+		valueA, okA := a[path]
+		valueB, okB := b[path]
+
+		if okA && okB && valueA == valueB {
+			continue
+		}
+
+		if !okA {
+			valueA = missingValue
+		}
+
+		if !okB {
+			valueB = missingValue
+		}
+
+		differences = append(differences, &Difference{Section: section, Path: path, A: valueA, B: valueB})
+	}
+
+	return differences
+}
+
+func compareAlloc(a, b map[types.Address]*chain.GenesisAccount) []*Difference {
+	addrs := make([]types.Address, 0, len(a)+len(b))
+
+	for addr := range a {
+		addrs = append(addrs, addr)
+	}
+
+	for addr := range b {
+		if _, ok := a[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+
+	differences := make([]*Difference, 0)
+
+	for _, addr := range addrs {
+		differences = append(differences,
+			compareFlat(sectionAlloc, flattenAccount(addr, a[addr]), flattenAccount(addr, b[addr]))...)
+	}
+
+	return differences
+}
+
+// flattenAccount returns the fields of the account keyed by their path. The code is represented
+// by its hash, and a missing account has no fields
+func flattenAccount(addr types.Address, account *chain.GenesisAccount) map[string]string {
+	fields := map[string]string{}
+	if account == nil {
+		return fields
+	}
+
+	prefix := addr.String()
+
+	if account.Balance != nil && account.Balance.Sign() != 0 {
+		fields[prefix+".balance"] = account.Balance.String()
+	}
+
+	if account.Nonce != 0 {
+		fields[prefix+".nonce"] = fmt.Sprintf("%d", account.Nonce)
+	}
+
+	if len(account.Code) != 0 {
+		fields[prefix+".codeHash"] = types.BytesToHash(crypto.Keccak256(account.Code)).String()
+	}
+
+	for slot, value := range account.Storage {
+		fields[prefix+".storage."+slot.String()] = value.String()
+	}
+
+	if len(fields) == 0 {
+		// an empty account still differs from a missing one
+		fields[prefix] = "{}"
+	}
+
+	return fields
+}
+
+// flatten returns the leaves of the JSON encoding of the value keyed by their path
+func flatten(v interface{}) (map[string]string, error) {
+	value, err := toJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves := map[string]string{}
+	flattenValue("", value, leaves)
+
+	return leaves, nil
+}
+
+func flattenValue(path string, value interface{}, leaves map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flattenValue(joinPath(path, key), child, leaves)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), child, leaves)
+		}
+	case nil:
+		// unset settings are the same as missing ones
+	default:
+		data, _ := json.Marshal(v)
+		leaves[path] = strings.Trim(string(data), `"`)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+// toJSONValue returns the value decoded from the JSON encoding of v
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// deletePath removes the field at the path of the decoded JSON value, if there is one
+func deletePath(value interface{}, path []string) {
+	for i, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		if i == len(path)-1 {
+			delete(object, key)
+
+			return
+		}
+
+		value = object[key]
+	}
+}
@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestChain() *chain.Chain {
+	return &chain.Chain{
+		Name: "test",
+		Genesis: &chain.Genesis{
+			GasLimit: 5242880,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				types.StringToAddress("1"): {Balance: big.NewInt(100)},
+				types.StringToAddress("2"): {
+					Code:    []byte{0x1},
+					Storage: map[types.Hash]types.Hash{types.StringToHash("1"): types.StringToHash("2")},
+				},
+			},
+		},
+		Params: &chain.Params{
+			ChainID: 100,
+			Forks:   chain.AllForksEnabled.Copy(),
+			Engine: map[string]interface{}{
+				"polybft": map[string]interface{}{
+					"epochSize": 10,
+					"bridge":    map[string]interface{}{"jsonRPCEndpoint": "http://127.0.0.1:8545"},
+				},
+			},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	a, b := newTestChain(), newTestChain()
+
+	differences, err := Compare(a, b)
+	require.NoError(t, err)
+	require.Empty(t, differences)
+
+	b.Genesis.GasLimit = 1000
+	b.Params.Forks.SetFork(chain.LondonFix, chain.NewFork(10))
+	b.Params.Forks.RemoveFork(chain.QuorumCalcAlignment)
+	b.Params.Engine["polybft"].(map[string]interface{})["epochSize"] = 20
+	b.Genesis.Alloc[types.StringToAddress("1")].Balance = big.NewInt(200)
+	b.Genesis.Alloc[types.StringToAddress("2")].Storage[types.StringToHash("1")] = types.StringToHash("3")
+	b.Genesis.Alloc[types.StringToAddress("3")] = &chain.GenesisAccount{}
+
+	differences, err = Compare(a, b)
+	require.NoError(t, err)
+	require.Equal(t, []*Difference{
+		{Section: sectionGenesis, Path: "gasLimit", A: "0x500000", B: "0x3e8"},
+		{Section: sectionForks, Path: "londonfix.block", A: "0", B: "10"},
+		{Section: sectionForks, Path: "quorumcalcalignment.block", A: "0", B: missingValue},
+		{Section: sectionEngine, Path: "polybft.epochSize", A: "10", B: "20"},
+		{
+			Section: sectionAlloc,
+			Path:    types.StringToAddress("1").String() + ".balance",
+			A:       "100",
+			B:       "200",
+		},
+		{
+			Section: sectionAlloc,
+			Path:    types.StringToAddress("2").String() + ".storage." + types.StringToHash("1").String(),
+			A:       types.StringToHash("2").String(),
+			B:       types.StringToHash("3").String(),
+		},
+		{Section: sectionAlloc, Path: types.StringToAddress("3").String(), A: missingValue, B: "{}"},
+	}, differences)
+}
+
+func TestConsensusHash(t *testing.T) {
+	t.Parallel()
+
+	a, b := newTestChain(), newTestChain()
+
+	hashA, err := ConsensusHash(a)
+	require.NoError(t, err)
+
+	// the settings each node sets on its own don't change the hash
+	b.Name = "other"
+	b.Bootnodes = []string{"/ip4/127.0.0.1/tcp/30301/p2p/16Uiu2HAmTN2YAviWyyG4A56Zz8gsVJhmksdojymS4pk54SZqVbGV"}
+	b.Params.Engine["polybft"].(map[string]interface{})["bridge"] = map[string]interface{}{
+		"jsonRPCEndpoint": "http://10.0.0.1:8545",
+	}
+
+	hashB, err := ConsensusHash(b)
+	require.NoError(t, err)
+	require.Equal(t, hashA, hashB)
+
+	b.Params.Forks.SetFork(chain.LondonFix, chain.NewFork(10))
+
+	hashB, err = ConsensusHash(b)
+	require.NoError(t, err)
+	require.NotEqual(t, hashA, hashB)
+}
@@ -0,0 +1,34 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+func GetCommand() *cobra.Command {
+	genesisDiffCmd := &cobra.Command{
+		Use: "diff [GENESIS_A] [GENESIS_B]",
+		Short: "Prints the differences between the params, forks, alloc and engine config of two genesis files, " +
+			"and the hashes of their consensus relevant parts which the nodes can compare",
+		Args: cobra.ExactArgs(2),
+		Run:  runCommand,
+	}
+
+	return genesisDiffCmd
+}
+
+func runCommand(cmd *cobra.Command, args []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	params.initRawParams(args)
+
+	if err := params.diff(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
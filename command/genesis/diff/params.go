@@ -0,0 +1,62 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	params = &diffParams{}
+)
+
+type diffParams struct {
+	genesisPathA string
+	genesisPathB string
+
+	hashA       types.Hash
+	hashB       types.Hash
+	differences []*Difference
+}
+
+func (p *diffParams) initRawParams(args []string) {
+	p.genesisPathA = args[0]
+	p.genesisPathB = args[1]
+}
+
+func (p *diffParams) diff() error {
+	a, err := chain.Import(p.genesisPathA)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.genesisPathA, err)
+	}
+
+	b, err := chain.Import(p.genesisPathB)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.genesisPathB, err)
+	}
+
+	if p.hashA, err = ConsensusHash(a); err != nil {
+		return err
+	}
+
+	if p.hashB, err = ConsensusHash(b); err != nil {
+		return err
+	}
+
+	p.differences, err = Compare(a, b)
+
+	return err
+}
+
+func (p *diffParams) getResult() command.CommandResult {
+	return &GenesisDiffResult{
+		A:              p.genesisPathA,
+		B:              p.genesisPathB,
+		ConsensusHashA: p.hashA.String(),
+		ConsensusHashB: p.hashB.String(),
+		Match:          p.hashA == p.hashB,
+		Differences:    p.differences,
+	}
+}
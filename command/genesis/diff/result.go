@@ -0,0 +1,49 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type GenesisDiffResult struct {
+	A              string        `json:"a"`
+	B              string        `json:"b"`
+	ConsensusHashA string        `json:"consensusHashA"`
+	ConsensusHashB string        `json:"consensusHashB"`
+	Match          bool          `json:"match"`
+	Differences    []*Difference `json:"differences"`
+}
+
+func (r *GenesisDiffResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS DIFF]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("A|%s", r.A),
+		fmt.Sprintf("B|%s", r.B),
+		fmt.Sprintf("Consensus hash A|%s", r.ConsensusHashA),
+		fmt.Sprintf("Consensus hash B|%s", r.ConsensusHashB),
+		fmt.Sprintf("Consensus match|%t", r.Match),
+	}))
+	buffer.WriteString("\n\n")
+
+	if len(r.Differences) == 0 {
+		buffer.WriteString("No differences found\n")
+
+		return buffer.String()
+	}
+
+	rows := make([]string, 0, len(r.Differences)+1)
+	rows = append(rows, "SECTION|PATH|A|B")
+
+	for _, d := range r.Differences {
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s", d.Section, d.Path, d.A, d.B))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,132 @@
+package add
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	genesisForkAddCmd := &cobra.Command{
+		Use: "add",
+		Short: "Schedules a hard fork, or reschedules it, in the genesis file. " +
+			"Refuses the blocks which are already past on the chain behind the given JSON-RPC endpoint",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(genesisForkAddCmd)
+	helper.SetRequiredFlags(genesisForkAddCmd, params.getRequiredFlags())
+
+	return genesisForkAddCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to update",
+	)
+
+	cmd.Flags().StringVar(
+		&params.name,
+		nameFlag,
+		"",
+		"the name of the fork",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the block from which the fork is active",
+	)
+
+	cmd.Flags().StringVar(
+		&params.jsonRPC,
+		rpcFlag,
+		"",
+		"the JSON-RPC endpoint of a node of the running chain, whose head block must be before the fork",
+	)
+
+	// PolyBFT fork params
+	{
+		cmd.Flags().Uint64Var(
+			&params.maxValidatorSetSize,
+			command.MaxValidatorCountFlag,
+			0,
+			"the maximum number of validators in the validator set from the fork",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.epochSize,
+			epochSizeFlag,
+			0,
+			"the epoch size from the fork",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.sprintSize,
+			sprintSizeFlag,
+			0,
+			"the number of blocks included into a sprint from the fork",
+		)
+
+		cmd.Flags().DurationVar(
+			&params.blockTime,
+			blockTimeFlag,
+			0,
+			"the block creation frequency from the fork",
+		)
+
+		cmd.Flags().Uint64Var(
+			&params.blockTimeDrift,
+			blockTimeDriftFlag,
+			0,
+			"the block time drift (in seconds) from the fork",
+		)
+	}
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.paramsSet = map[string]bool{}
+
+	for _, flag := range []string{
+		command.MaxValidatorCountFlag, epochSizeFlag, sprintSizeFlag, blockTimeFlag, blockTimeDriftFlag,
+	} {
+		if cmd.Flags().Changed(flag) {
+			params.paramsSet[flag] = true
+		}
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.checkHead(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.updateGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.overrideGenesisConfig(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,251 @@
+package add
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis/validate"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+const (
+	chainFlag          = "chain"
+	nameFlag           = "name"
+	blockFlag          = "block"
+	rpcFlag            = "rpc"
+	epochSizeFlag      = "epoch-size"
+	sprintSizeFlag     = "sprint-size"
+	blockTimeFlag      = "block-time"
+	blockTimeDriftFlag = "block-time-drift"
+)
+
+var (
+	params = &addParams{}
+)
+
+var (
+	errUnknownFork        = errors.New("fork is not available in this version")
+	errBlockPassed        = errors.New("fork block is already past")
+	errForkActive         = errors.New("fork is already active")
+	errParamsNotSupported = errors.New("fork params are only supported by the polybft consensus")
+	errInvalidSchedule    = errors.New("invalid fork schedule")
+)
+
+type addParams struct {
+	genesisPath string
+	name        string
+	block       uint64
+	jsonRPC     string
+
+	maxValidatorSetSize uint64
+	epochSize           uint64
+	sprintSize          uint64
+	blockTime           time.Duration
+	blockTimeDrift      uint64
+
+	// paramsSet holds the fork params set by the flags
+	paramsSet map[string]bool
+
+	genesisConfig *chain.Chain
+	previousBlock *uint64
+	forkParams    *forkmanager.ForkParams
+	headBlock     *uint64
+}
+
+func (p *addParams) getRequiredFlags() []string {
+	return []string{
+		nameFlag,
+		blockFlag,
+	}
+}
+
+func (p *addParams) initRawParams() error {
+	if _, ok := (*chain.AllForksEnabled)[p.name]; !ok {
+		return fmt.Errorf("%w: %s", errUnknownFork, p.name)
+	}
+
+	cc, err := chain.Import(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chain config from %s: %w", p.genesisPath, err)
+	}
+
+	p.genesisConfig = cc
+
+	return nil
+}
+
+// checkHead refuses the fork if the chain behind the JSON-RPC endpoint is already past its block,
+// or past the block of the fork it reschedules
+func (p *addParams) checkHead() error {
+	if p.jsonRPC == "" {
+		return nil
+	}
+
+	client, err := jsonrpc.NewClient(p.jsonRPC)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", p.jsonRPC, err)
+	}
+
+	defer client.Close()
+
+	head, err := client.Eth().BlockNumber()
+	if err != nil {
+		return fmt.Errorf("failed to get the head block number: %w", err)
+	}
+
+	p.headBlock = &head
+
+	return checkBlocks(p.genesisConfig.Params.Forks, p.name, p.block, head)
+}
+
+// checkBlocks returns an error if the fork or its new block are not ahead of the head block
+func checkBlocks(forks *chain.Forks, name string, block, head uint64) error {
+	if block <= head {
+		return fmt.Errorf("%w: block %d, head block %d", errBlockPassed, block, head)
+	}
+
+	if forks == nil {
+		return nil
+	}
+
+	if fork, ok := (*forks)[name]; ok && fork.Block <= head {
+		return fmt.Errorf("%w: fork %s from block %d, head block %d", errForkActive, name, fork.Block, head)
+	}
+
+	return nil
+}
+
+func (p *addParams) updateGenesisConfig() error {
+	if p.genesisConfig.Params.Forks == nil {
+		p.genesisConfig.Params.Forks = &chain.Forks{}
+	}
+
+	fork := chain.NewFork(p.block)
+
+	if len(p.paramsSet) > 0 {
+		forkParams, err := p.getForkParams()
+		if err != nil {
+			return err
+		}
+
+		fork.Params = forkParams
+		p.forkParams = forkParams
+	}
+
+	if previous, ok := (*p.genesisConfig.Params.Forks)[p.name]; ok {
+		p.previousBlock = &previous.Block
+	}
+
+	p.genesisConfig.Params.Forks.SetFork(p.name, fork)
+
+	// a fork scheduled out of order is refused, since the nodes would disagree on the rules of its blocks
+	for _, finding := range validate.LintForks(p.genesisConfig) {
+		if finding.Severity == validate.SeverityError {
+			return fmt.Errorf("%w: %s", errInvalidSchedule, finding.Message)
+		}
+	}
+
+	return nil
+}
+
+// getForkParams returns the params of the fork. The fork manager replaces all the params at once,
+// so the params which aren't set by the flags keep the values they have at the block of the fork
+func (p *addParams) getForkParams() (*forkmanager.ForkParams, error) {
+	if p.genesisConfig.Params.GetEngine() != polybft.ConsensusName {
+		return nil, errParamsNotSupported
+	}
+
+	config, err := polybft.GetPolyBFTConfig(p.genesisConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	current := &forkmanager.ForkParams{
+		MaxValidatorSetSize: &config.MaxValidatorSetSize,
+		EpochSize:           &config.EpochSize,
+		SprintSize:          &config.SprintSize,
+		BlockTime:           &config.BlockTime,
+		BlockTimeDrift:      &config.BlockTimeDrift,
+	}
+
+	// the params of the latest fork activated before the block override the ones of the config
+	var latest *chain.Fork
+
+	for name, fork := range *p.genesisConfig.Params.Forks {
+		fork := fork
+
+		if name == p.name || fork.Params == nil || fork.Block > p.block {
+			continue
+		}
+
+		if latest == nil || fork.Block > latest.Block {
+			latest = &fork
+		}
+	}
+
+	if latest != nil {
+		current = latest.Params.Copy()
+	}
+
+	if p.paramsSet[command.MaxValidatorCountFlag] {
+		current.MaxValidatorSetSize = &p.maxValidatorSetSize
+	}
+
+	if p.paramsSet[epochSizeFlag] {
+		current.EpochSize = &p.epochSize
+	}
+
+	if p.paramsSet[sprintSizeFlag] {
+		current.SprintSize = &p.sprintSize
+	}
+
+	if p.paramsSet[blockTimeFlag] {
+		current.BlockTime = &common.Duration{Duration: p.blockTime}
+	}
+
+	if p.paramsSet[blockTimeDriftFlag] {
+		current.BlockTimeDrift = &p.blockTimeDrift
+	}
+
+	return current, nil
+}
+
+func (p *addParams) overrideGenesisConfig() error {
+	// Remove the current genesis configuration from disk
+	if err := os.Remove(p.genesisPath); err != nil {
+		return err
+	}
+
+	// Save the new genesis configuration
+	return helper.WriteGenesisConfigToDisk(p.genesisConfig, p.genesisPath)
+}
+
+func (p *addParams) getResult() command.CommandResult {
+	result := &GenesisForkAddResult{
+		Name:  p.name,
+		Block: p.block,
+	}
+
+	if p.previousBlock != nil {
+		result.PreviousBlock = p.previousBlock
+	}
+
+	if p.headBlock != nil {
+		result.HeadBlock = p.headBlock
+	}
+
+	if p.forkParams != nil {
+		result.Params = p.forkParams
+	}
+
+	return result
+}
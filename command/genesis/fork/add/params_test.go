@@ -0,0 +1,107 @@
+package add
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+)
+
+func newTestParams(name string, block uint64) *addParams {
+	forks := chain.AllForksEnabled.Copy()
+	forks.SetFork(chain.LondonFix, chain.NewFork(100))
+
+	return &addParams{
+		name:      name,
+		block:     block,
+		paramsSet: map[string]bool{},
+		genesisConfig: &chain.Chain{
+			Genesis: &chain.Genesis{},
+			Params: &chain.Params{
+				Forks: forks,
+				Engine: map[string]interface{}{
+					polybft.ConsensusName: &polybft.PolyBFTConfig{
+						EpochSize:           10,
+						SprintSize:          5,
+						BlockTime:           common.Duration{Duration: 2 * time.Second},
+						BlockTimeDrift:      10,
+						MaxValidatorSetSize: 100,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCheckBlocks(t *testing.T) {
+	t.Parallel()
+
+	forks := chain.AllForksEnabled.Copy()
+	forks.SetFork(chain.LondonFix, chain.NewFork(100))
+
+	require.NoError(t, checkBlocks(forks, chain.LondonFix, 200, 50))
+	require.ErrorIs(t, checkBlocks(forks, chain.LondonFix, 200, 200), errBlockPassed)
+	require.ErrorIs(t, checkBlocks(forks, chain.LondonFix, 200, 150), errForkActive)
+}
+
+func TestUpdateGenesisConfig(t *testing.T) {
+	t.Parallel()
+
+	p := newTestParams(chain.LondonFix, 200)
+	require.NoError(t, p.updateGenesisConfig())
+	require.Equal(t, chain.NewFork(200), (*p.genesisConfig.Params.Forks)[chain.LondonFix])
+	require.Equal(t, uint64(100), *p.previousBlock)
+
+	// a fork before the forks it follows is refused
+	p = newTestParams(chain.Homestead, 10)
+	require.ErrorIs(t, p.updateGenesisConfig(), errInvalidSchedule)
+}
+
+func TestUpdateGenesisConfig_Params(t *testing.T) {
+	t.Parallel()
+
+	p := newTestParams(chain.QuorumCalcAlignment, 300)
+	p.epochSize = 20
+	p.paramsSet[epochSizeFlag] = true
+
+	require.NoError(t, p.updateGenesisConfig())
+
+	fork := (*p.genesisConfig.Params.Forks)[chain.QuorumCalcAlignment]
+	require.Equal(t, uint64(20), *fork.Params.EpochSize)
+	require.Equal(t, uint64(5), *fork.Params.SprintSize)
+	require.Equal(t, 2*time.Second, fork.Params.BlockTime.Duration)
+
+	// the params which aren't set are the ones of the latest fork with params before the block
+	value := uint64(7)
+	p = newTestParams(chain.TxHashWithType, 400)
+	(*p.genesisConfig.Params.Forks)[chain.LondonFix] = chain.Fork{
+		Block: 100,
+		Params: &forkmanager.ForkParams{
+			MaxValidatorSetSize: &value,
+			EpochSize:           &value,
+			SprintSize:          &value,
+			BlockTime:           &common.Duration{Duration: time.Second},
+			BlockTimeDrift:      &value,
+		},
+	}
+	p.maxValidatorSetSize = 50
+	p.paramsSet[command.MaxValidatorCountFlag] = true
+
+	require.NoError(t, p.updateGenesisConfig())
+
+	fork = (*p.genesisConfig.Params.Forks)[chain.TxHashWithType]
+	require.Equal(t, uint64(50), *fork.Params.MaxValidatorSetSize)
+	require.Equal(t, uint64(7), *fork.Params.SprintSize)
+	require.Equal(t, time.Second, fork.Params.BlockTime.Duration)
+
+	p = newTestParams(chain.TxHashWithType, 400)
+	p.paramsSet[epochSizeFlag] = true
+	p.genesisConfig.Params.Engine = map[string]interface{}{"ibft": map[string]interface{}{}}
+	require.ErrorIs(t, p.updateGenesisConfig(), errParamsNotSupported)
+}
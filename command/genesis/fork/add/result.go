@@ -0,0 +1,51 @@
+package add
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/forkmanager"
+)
+
+type GenesisForkAddResult struct {
+	Name          string                  `json:"name"`
+	Block         uint64                  `json:"block"`
+	PreviousBlock *uint64                 `json:"previousBlock,omitempty"`
+	HeadBlock     *uint64                 `json:"headBlock,omitempty"`
+	Params        *forkmanager.ForkParams `json:"params,omitempty"`
+}
+
+func (r *GenesisForkAddResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS FORK ADD]\n")
+
+	outputs := []string{
+		fmt.Sprintf("Fork|%s", r.Name),
+		fmt.Sprintf("Block|%d", r.Block),
+	}
+
+	if r.PreviousBlock != nil {
+		outputs = append(outputs, fmt.Sprintf("Previous block|%d", *r.PreviousBlock))
+	}
+
+	if r.HeadBlock != nil {
+		outputs = append(outputs, fmt.Sprintf("Head block|%d", *r.HeadBlock))
+	}
+
+	if r.Params != nil {
+		outputs = append(outputs,
+			fmt.Sprintf("Max validator set size|%d", *r.Params.MaxValidatorSetSize),
+			fmt.Sprintf("Epoch size|%d", *r.Params.EpochSize),
+			fmt.Sprintf("Sprint size|%d", *r.Params.SprintSize),
+			fmt.Sprintf("Block time|%s", r.Params.BlockTime.Duration),
+			fmt.Sprintf("Block time drift|%d", *r.Params.BlockTimeDrift),
+		)
+	}
+
+	buffer.WriteString(helper.FormatKV(outputs))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,25 @@
+package fork
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/genesis/fork/add"
+)
+
+func GetCommand() *cobra.Command {
+	forkCmd := &cobra.Command{
+		Use:   "fork",
+		Short: "Top level command for scheduling the hard forks of a genesis. Only accepts subcommands.",
+	}
+
+	registerSubcommands(forkCmd)
+
+	return forkCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// genesis fork add
+		add.GetCommand(),
+	)
+}
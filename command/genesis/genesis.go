@@ -6,10 +6,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/genesis/diff"
+	"github.com/0xPolygon/polygon-edge/command/genesis/fork"
 	"github.com/0xPolygon/polygon-edge/command/genesis/predeploy"
+	"github.com/0xPolygon/polygon-edge/command/genesis/validate"
 	"github.com/0xPolygon/polygon-edge/command/helper"
 	"github.com/0xPolygon/polygon-edge/consensus/ibft"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
 
@@ -27,6 +31,12 @@ func GetCommand() *cobra.Command {
 	genesisCmd.AddCommand(
 		// genesis predeploy
 		predeploy.GetCommand(),
+		// genesis validate
+		validate.GetCommand(),
+		// genesis fork
+		fork.GetCommand(),
+		// genesis diff
+		diff.GetCommand(),
 	)
 
 	return genesisCmd
@@ -117,6 +127,21 @@ func setFlags(cmd *cobra.Command) {
 		"admin for proxy contracts",
 	)
 
+	cmd.Flags().StringVar(
+		&params.txOrdering,
+		txOrderingFlag,
+		string(txpool.PriceOrdering),
+		fmt.Sprintf("the ordering policy of the transactions in the proposed blocks (%s, %s or %s)",
+			txpool.PriceOrdering, txpool.FIFOOrdering, txpool.RoundRobinOrdering),
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rejectUnprotectedTxs,
+		rejectUnprotectedTxsFlag,
+		true,
+		"should the nodes reject by default the legacy transactions signed without the chain ID (pre-EIP-155)",
+	)
+
 	// PoS
 	{
 		cmd.Flags().BoolVar(
@@ -19,6 +19,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts/staking"
 	stakingHelper "github.com/0xPolygon/polygon-edge/helper/staking"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validators"
 )
@@ -39,6 +40,8 @@ const (
 	rewardWalletFlag             = "reward-wallet"
 	blockTrackerPollIntervalFlag = "block-tracker-poll-interval"
 	proxyContractsAdminFlag      = "proxy-contracts-admin"
+	txOrderingFlag               = "tx-ordering"
+	rejectUnprotectedTxsFlag     = "reject-unprotected-txs"
 )
 
 // Legacy flags that need to be preserved for running clients
@@ -136,6 +139,10 @@ type genesisParams struct {
 	blockTrackerPollInterval time.Duration
 
 	proxyContractsAdmin string
+
+	txOrdering string
+
+	rejectUnprotectedTxs bool
 }
 
 func (p *genesisParams) validateFlags() error {
@@ -159,6 +166,10 @@ func (p *genesisParams) validateFlags() error {
 		return err
 	}
 
+	if _, err := txpool.ParseOrderingPolicy(p.txOrdering); err != nil {
+		return err
+	}
+
 	if p.isPolyBFTConsensus() {
 		if err := p.extractNativeTokenMetadata(); err != nil {
 			return err
@@ -412,9 +423,12 @@ func (p *genesisParams) initGenesisConfig() error {
 			GasUsed:    command.DefaultGenesisGasUsed,
 		},
 		Params: &chain.Params{
-			ChainID: int64(p.chainID),
-			Forks:   enabledForks,
-			Engine:  p.consensusEngineConfig,
+			ChainID:    int64(p.chainID),
+			Forks:      enabledForks,
+			Engine:     p.consensusEngineConfig,
+			TxOrdering: p.txOrdering,
+
+			RejectUnprotectedTxs: p.rejectUnprotectedTxs,
 		},
 		Bootnodes: p.bootnodes,
 	}
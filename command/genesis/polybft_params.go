@@ -169,6 +169,9 @@ func (p *genesisParams) generatePolyBftChainConfig(o command.OutputFormatter) er
 			Engine: map[string]interface{}{
 				string(server.PolyBFTConsensus): polyBftConfig,
 			},
+			TxOrdering: p.txOrdering,
+
+			RejectUnprotectedTxs: p.rejectUnprotectedTxs,
 		},
 		Bootnodes: p.bootnodes,
 	}
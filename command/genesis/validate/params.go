@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+const (
+	chainFlag = "chain"
+)
+
+var (
+	params = &validateParams{}
+)
+
+var (
+	errInvalidGenesis = errors.New("genesis is invalid")
+)
+
+type validateParams struct {
+	genesisPath string
+
+	findings []*Finding
+}
+
+func (p *validateParams) validate() error {
+	genesis, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load genesis from %s: %w", p.genesisPath, err)
+	}
+
+	p.findings = Lint(genesis)
+
+	return nil
+}
+
+// getError returns an error if any of the findings is an error
+func (p *validateParams) getError() error {
+	errorsCount := 0
+
+	for _, finding := range p.findings {
+		if finding.Severity == SeverityError {
+			errorsCount++
+		}
+	}
+
+	if errorsCount == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d errors found", errInvalidGenesis, errorsCount)
+}
+
+func (p *validateParams) getResult() command.CommandResult {
+	return &GenesisValidateResult{
+		Genesis:  p.genesisPath,
+		Findings: p.findings,
+	}
+}
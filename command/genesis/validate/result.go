@@ -0,0 +1,38 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type GenesisValidateResult struct {
+	Genesis  string     `json:"genesis"`
+	Findings []*Finding `json:"findings"`
+}
+
+func (r *GenesisValidateResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[GENESIS VALIDATE]\n")
+	buffer.WriteString(fmt.Sprintf("Genesis: %s\n\n", r.Genesis))
+
+	if len(r.Findings) == 0 {
+		buffer.WriteString("No issues found\n")
+
+		return buffer.String()
+	}
+
+	rows := make([]string, 0, len(r.Findings)+1)
+	rows = append(rows, "SEVERITY|RULE|MESSAGE")
+
+	for _, finding := range r.Findings {
+		rows = append(rows, fmt.Sprintf("%s|%s|%s", finding.Severity, finding.Rule, finding.Message))
+	}
+
+	buffer.WriteString(helper.FormatList(rows))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
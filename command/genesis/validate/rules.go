@@ -0,0 +1,526 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// Severity is the severity of a finding. Errors are the findings which prevent the nodes from
+// starting or which fork the network, warnings are the settings which are likely a mistake
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// minFaultTolerantValidators is the smallest validator set which tolerates a faulty validator
+const minFaultTolerantValidators = 4
+
+// Finding is a problem of the genesis reported by a rule
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+// rule checks a part of the genesis and reports its findings
+type rule struct {
+	name  string
+	check func(genesis *chain.Chain, r *reporter)
+}
+
+// reporter collects the findings of the rules
+type reporter struct {
+	rule     string
+	findings []*Finding
+}
+
+func (r *reporter) errorf(format string, args ...interface{}) {
+	r.report(SeverityError, format, args...)
+}
+
+func (r *reporter) warnf(format string, args ...interface{}) {
+	r.report(SeverityWarning, format, args...)
+}
+
+func (r *reporter) report(severity Severity, format string, args ...interface{}) {
+	r.findings = append(r.findings, &Finding{
+		Severity: severity,
+		Rule:     r.rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// forkRules are the checks of the fork schedule
+var forkRules = []rule{
+	{name: "forks", check: checkForks},
+	{name: "fork-order", check: checkForkOrder},
+}
+
+// rules are the checks run on the genesis, in the order of their findings
+var rules = append(append([]rule{
+	{name: "chain-id", check: checkChainID},
+	{name: "engine", check: checkEngine},
+	{name: "gas-limit", check: checkGasLimit},
+	{name: "base-fee", check: checkBaseFee},
+	{name: "tx-ordering", check: checkTxOrdering},
+}, forkRules...), []rule{
+	{name: "burn-contract", check: checkBurnContract},
+	{name: "premine", check: checkPremine},
+	{name: "access-lists", check: checkAccessLists},
+	{name: "polybft", check: checkPolyBFT},
+	{name: "polybft-validators", check: checkPolyBFTValidators},
+}...)
+
+// orderedForks are the forks which build on each other, in the order of their activation
+var orderedForks = []string{
+	chain.Homestead,
+	chain.EIP150,
+	chain.EIP155,
+	chain.EIP158,
+	chain.Byzantium,
+	chain.Constantinople,
+	chain.Petersburg,
+	chain.Istanbul,
+	chain.London,
+}
+
+// Lint runs all the rules on the genesis and returns their findings
+func Lint(genesis *chain.Chain) []*Finding {
+	return lint(genesis, rules)
+}
+
+// LintForks runs the rules of the fork schedule on the genesis and returns their findings
+func LintForks(genesis *chain.Chain) []*Finding {
+	return lint(genesis, forkRules)
+}
+
+func lint(genesis *chain.Chain, rules []rule) []*Finding {
+	findings := make([]*Finding, 0)
+
+	for _, rule := range rules {
+		r := &reporter{rule: rule.name}
+		rule.check(genesis, r)
+
+		findings = append(findings, r.findings...)
+	}
+
+	return findings
+}
+
+func checkChainID(genesis *chain.Chain, r *reporter) {
+	if genesis.Params.ChainID <= 0 {
+		r.errorf("chain ID must be greater than 0, got %d", genesis.Params.ChainID)
+	}
+}
+
+func checkEngine(genesis *chain.Chain, r *reporter) {
+	if len(genesis.Params.Engine) != 1 {
+		r.errorf("expected one consensus engine but found %d", len(genesis.Params.Engine))
+
+		return
+	}
+
+	if engine := genesis.Params.GetEngine(); !server.ConsensusSupported(engine) {
+		r.errorf("consensus engine %s is not supported", engine)
+	}
+}
+
+func checkGasLimit(genesis *chain.Chain, r *reporter) {
+	if genesis.Genesis.GasLimit == 0 {
+		r.errorf("block gas limit must be greater than 0")
+
+		return
+	}
+
+	if genesis.Params.BlockGasTarget > genesis.Genesis.GasLimit {
+		r.warnf("block gas target %d is above the block gas limit %d",
+			genesis.Params.BlockGasTarget, genesis.Genesis.GasLimit)
+	}
+}
+
+func checkBaseFee(genesis *chain.Chain, r *reporter) {
+	if genesis.Params.Forks == nil {
+		return
+	}
+
+	if _, ok := (*genesis.Params.Forks)[chain.London]; !ok {
+		return
+	}
+
+	if genesis.Genesis.BaseFeeChangeDenom == 0 {
+		r.errorf("base fee change denominator must be greater than 0 when the london fork is enabled")
+	}
+
+	if genesis.Genesis.BaseFeeEM == 0 {
+		r.errorf("base fee elasticity multiplier must be greater than 0 when the london fork is enabled")
+	}
+
+	if genesis.Params.Forks.IsActive(chain.London, 0) && genesis.Genesis.BaseFee == 0 {
+		r.warnf("base fee of the genesis block is 0 while the london fork is active from the genesis")
+	}
+}
+
+func checkTxOrdering(genesis *chain.Chain, r *reporter) {
+	if _, err := txpool.ParseOrderingPolicy(genesis.Params.TxOrdering); err != nil {
+		r.errorf("%v", err)
+	}
+}
+
+func checkForks(genesis *chain.Chain, r *reporter) {
+	if genesis.Params.Forks == nil {
+		r.errorf("forks are not defined")
+
+		return
+	}
+
+	for _, name := range sortedForks(genesis.Params.Forks) {
+		fork := (*genesis.Params.Forks)[name]
+
+		if _, ok := (*chain.AllForksEnabled)[name]; !ok {
+			r.errorf("fork %s is not available in this version", name)
+		}
+
+		if fork.Params == nil {
+			continue
+		}
+
+		if genesis.Params.GetEngine() != polybft.ConsensusName {
+			r.warnf("params of fork %s only apply to the %s consensus", name, polybft.ConsensusName)
+		}
+
+		// the fork manager copies the params of the forks, which requires all of them to be set
+		if fork.Params.MaxValidatorSetSize == nil || fork.Params.EpochSize == nil ||
+			fork.Params.SprintSize == nil || fork.Params.BlockTime == nil || fork.Params.BlockTimeDrift == nil {
+			r.errorf("params of fork %s must set maxValidatorSetSize, epochSize, sprintSize, "+
+				"blockTime and blockTimeDrift", name)
+		}
+	}
+}
+
+func checkForkOrder(genesis *chain.Chain, r *reporter) {
+	if genesis.Params.Forks == nil {
+		return
+	}
+
+	var (
+		// latest is the enabled fork with the highest block among the forks preceding the checked one
+		latest  string
+		missing []string
+	)
+
+	for _, name := range orderedForks {
+		fork, ok := (*genesis.Params.Forks)[name]
+		if !ok {
+			missing = append(missing, name)
+
+			continue
+		}
+
+		for _, previous := range missing {
+			r.warnf("fork %s is not enabled, although fork %s which follows it is", previous, name)
+		}
+
+		missing = nil
+
+		if latest == "" {
+			latest = name
+
+			continue
+		}
+
+		if latestFork := (*genesis.Params.Forks)[latest]; fork.Block < latestFork.Block {
+			r.errorf("fork %s at block %d activates before fork %s at block %d, which precedes it",
+				name, fork.Block, latest, latestFork.Block)
+		} else {
+			latest = name
+		}
+	}
+}
+
+func checkBurnContract(genesis *chain.Chain, r *reporter) {
+	burnContract := genesis.Params.BurnContract
+	if len(burnContract) == 0 {
+		return
+	}
+
+	if genesis.Params.Forks == nil {
+		return
+	}
+
+	london, ok := (*genesis.Params.Forks)[chain.London]
+	if !ok {
+		r.warnf("burn contract is set, but the london fork which burns the base fees is not enabled")
+
+		return
+	}
+
+	config, isPolyBFT, err := polyBFTConfig(genesis)
+	if err != nil {
+		return
+	}
+
+	// the base fees of a mintable native token are burned by sending them to the zero address
+	zeroAllowed := isPolyBFT && config.NativeTokenConfig != nil && config.NativeTokenConfig.IsMintable
+
+	blocks := make([]uint64, 0, len(burnContract))
+	for block := range burnContract {
+		blocks = append(blocks, block)
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i] < blocks[j]
+	})
+
+	// the burn contract of the blocks before the first range is the one of the last range
+	if blocks[0] > london.Block {
+		r.errorf("burn contract ranges start at block %d, after the london fork at block %d", blocks[0], london.Block)
+	}
+
+	for i, block := range blocks {
+		addr := burnContract[block]
+
+		switch {
+		case addr == types.ZeroAddress && !zeroAllowed:
+			r.errorf("burn contract from block %d is the zero address", block)
+		case addr != types.ZeroAddress && zeroAllowed:
+			r.errorf("burn contract from block %d must be the zero address for a mintable native token", block)
+		}
+
+		if i > 0 && burnContract[blocks[i-1]] == addr {
+			r.warnf("burn contract ranges from blocks %d and %d overlap, both use %s", blocks[i-1], block, addr)
+		}
+	}
+
+	if !isPolyBFT || zeroAllowed {
+		return
+	}
+
+	if len(burnContract) > 1 {
+		r.errorf("%s consensus deploys a single burn contract, but %d are set", polybft.ConsensusName, len(burnContract))
+
+		return
+	}
+
+	if _, ok := genesis.Genesis.Alloc[burnContract[blocks[0]]]; !ok {
+		r.warnf("burn contract %s is not premined, so it isn't deployed", burnContract[blocks[0]])
+	}
+}
+
+func checkPremine(genesis *chain.Chain, r *reporter) {
+	config, isPolyBFT, err := polyBFTConfig(genesis)
+	if err != nil {
+		return
+	}
+
+	// the reserve account of a non mintable native token is the zero address
+	reserveRequired := isPolyBFT && config.NativeTokenConfig != nil && !config.NativeTokenConfig.IsMintable
+
+	_, zeroPremined := genesis.Genesis.Alloc[types.ZeroAddress]
+
+	switch {
+	case reserveRequired && !zeroPremined:
+		r.errorf("reserve account (zero address) of the non mintable native token must be premined")
+	case !reserveRequired && zeroPremined:
+		r.errorf("zero address is premined")
+	}
+
+	if _, ok := genesis.Genesis.Alloc[contracts.SystemCaller]; ok {
+		r.warnf("system caller %s is premined", contracts.SystemCaller)
+	}
+}
+
+func checkAccessLists(genesis *chain.Chain, r *reporter) {
+	lists := []struct {
+		name    string
+		config  *chain.AddressListConfig
+		isAllow bool
+	}{
+		{"contract deployer allow list", genesis.Params.ContractDeployerAllowList, true},
+		{"contract deployer block list", genesis.Params.ContractDeployerBlockList, false},
+		{"transactions allow list", genesis.Params.TransactionsAllowList, true},
+		{"transactions block list", genesis.Params.TransactionsBlockList, false},
+		{"bridge allow list", genesis.Params.BridgeAllowList, true},
+		{"bridge block list", genesis.Params.BridgeBlockList, false},
+	}
+
+	for i, list := range lists {
+		if list.config == nil {
+			continue
+		}
+
+		if len(list.config.AdminAddresses) == 0 {
+			if list.isAllow && len(list.config.EnabledAddresses) == 0 {
+				r.errorf("%s has neither admins nor enabled addresses, so every address is denied forever", list.name)
+			} else {
+				r.warnf("%s has no admins, so it can't be changed", list.name)
+			}
+		}
+
+		if containsZeroAddress(list.config.AdminAddresses) || containsZeroAddress(list.config.EnabledAddresses) {
+			r.errorf("%s holds the zero address", list.name)
+		}
+
+		// the allow list of a kind is followed by its block list
+		if !list.isAllow && lists[i-1].config != nil {
+			r.warnf("both the %s and the %s are set", lists[i-1].name, list.name)
+		}
+	}
+
+	if genesis.Params.BridgeAllowList != nil || genesis.Params.BridgeBlockList != nil {
+		if config, isPolyBFT, err := polyBFTConfig(genesis); err == nil && (!isPolyBFT || !config.IsBridgeEnabled()) {
+			r.warnf("bridge access lists are set, but the bridge is not enabled")
+		}
+	}
+}
+
+func checkPolyBFT(genesis *chain.Chain, r *reporter) {
+	config, isPolyBFT, err := polyBFTConfig(genesis)
+	if err != nil {
+		r.errorf("invalid %s config: %v", polybft.ConsensusName, err)
+
+		return
+	}
+
+	if !isPolyBFT {
+		return
+	}
+
+	if config.EpochSize < 2 {
+		r.errorf("epoch size must be greater than 1, got %d", config.EpochSize)
+	}
+
+	if config.SprintSize == 0 {
+		r.errorf("sprint size must be greater than 0")
+	} else if config.SprintSize > config.EpochSize {
+		r.warnf("sprint size %d is greater than the epoch size %d", config.SprintSize, config.EpochSize)
+	}
+
+	if config.BlockTime.Duration <= 0 {
+		r.errorf("block time must be greater than 0")
+	}
+
+	if config.NativeTokenConfig == nil {
+		r.errorf("native token config is not set")
+	}
+
+	switch config.ProxyContractsAdmin {
+	case types.ZeroAddress:
+		r.errorf("proxy contracts admin must not be the zero address")
+	case contracts.SystemCaller:
+		r.errorf("proxy contracts admin must not be the system caller")
+	}
+
+	if config.RewardConfig == nil {
+		r.errorf("reward config is not set")
+	} else {
+		if config.RewardConfig.WalletAddress == types.ZeroAddress {
+			r.errorf("reward wallet must not be the zero address")
+		}
+
+		if config.RewardConfig.WalletAmount == nil || config.RewardConfig.WalletAmount.Sign() <= 0 {
+			r.errorf("reward wallet amount must be greater than 0")
+		}
+	}
+
+	if config.IsBridgeEnabled() && config.Bridge.JSONRPCEndpoint == "" {
+		r.warnf("bridge is enabled, but the rootchain JSON-RPC endpoint is not set")
+	}
+}
+
+func checkPolyBFTValidators(genesis *chain.Chain, r *reporter) {
+	config, isPolyBFT, err := polyBFTConfig(genesis)
+	if err != nil || !isPolyBFT {
+		return
+	}
+
+	count := uint64(len(config.InitialValidatorSet))
+
+	if config.MinValidatorSetSize > config.MaxValidatorSetSize {
+		r.errorf("min validator set size %d is greater than the max validator set size %d",
+			config.MinValidatorSetSize, config.MaxValidatorSetSize)
+	}
+
+	if count < config.MinValidatorSetSize {
+		r.errorf("validator set has %d validators, less than the min validator set size %d",
+			count, config.MinValidatorSetSize)
+	}
+
+	if count > config.MaxValidatorSetSize {
+		r.errorf("validator set has %d validators, more than the max validator set size %d",
+			count, config.MaxValidatorSetSize)
+	}
+
+	if count > 0 && count < minFaultTolerantValidators {
+		r.warnf("validator set has %d validators, which don't tolerate a faulty validator", count)
+	}
+
+	seen := make(map[types.Address]struct{}, len(config.InitialValidatorSet))
+
+	for _, validator := range config.InitialValidatorSet {
+		if validator.Address == types.ZeroAddress {
+			r.errorf("validator address must not be the zero address")
+
+			continue
+		}
+
+		if _, ok := seen[validator.Address]; ok {
+			r.errorf("validator %s is in the validator set more than once", validator.Address)
+		}
+
+		seen[validator.Address] = struct{}{}
+
+		if validator.Stake == nil || validator.Stake.Sign() <= 0 {
+			r.errorf("validator %s has no stake", validator.Address)
+		}
+
+		if _, err := validator.UnmarshalBLSPublicKey(); err != nil {
+			r.errorf("validator %s has an invalid BLS public key: %v", validator.Address, err)
+		}
+
+		if validator.MultiAddr == "" {
+			r.warnf("validator %s has no multiaddr", validator.Address)
+		}
+	}
+}
+
+// polyBFTConfig returns the polybft config of the genesis, and false if the genesis is of another consensus
+func polyBFTConfig(genesis *chain.Chain) (polybft.PolyBFTConfig, bool, error) {
+	if _, ok := genesis.Params.Engine[polybft.ConsensusName]; !ok {
+		return polybft.PolyBFTConfig{}, false, nil
+	}
+
+	config, err := polybft.GetPolyBFTConfig(genesis)
+
+	return config, true, err
+}
+
+func containsZeroAddress(addrs []types.Address) bool {
+	for _, addr := range addrs {
+		if addr == types.ZeroAddress {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedForks returns the names of the forks in alphabetical order
+func sortedForks(forks *chain.Forks) []string {
+	names := make([]string, 0, len(*forks))
+	for name := range *forks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
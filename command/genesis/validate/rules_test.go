@@ -0,0 +1,214 @@
+package validate
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft"
+	"github.com/0xPolygon/polygon-edge/consensus/polybft/validator"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestGenesis(t *testing.T, mutate func(*chain.Chain, *polybft.PolyBFTConfig)) *chain.Chain {
+	t.Helper()
+
+	validators := validator.NewTestValidators(t, 4)
+	initialValidators := make([]*validator.GenesisValidator, 0, len(validators.Validators))
+
+	for _, v := range validators.Validators {
+		genesisValidator := v.ParamsValidator()
+		genesisValidator.MultiAddr = "/ip4/127.0.0.1/tcp/30301/p2p/16Uiu2HAmTN2YAviWyyG4A56Zz8gsVJhmksdojymS4pk54SZqVbGV"
+
+		initialValidators = append(initialValidators, genesisValidator)
+	}
+
+	config := &polybft.PolyBFTConfig{
+		InitialValidatorSet: initialValidators,
+		EpochSize:           10,
+		SprintSize:          5,
+		BlockTime:           common.Duration{Duration: 2 * time.Second},
+		NativeTokenConfig:   polybft.DefaultTokenConfig,
+		MinValidatorSetSize: 4,
+		MaxValidatorSetSize: 100,
+		ProxyContractsAdmin: types.StringToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"),
+		RewardConfig: &polybft.RewardsConfig{
+			WalletAddress: types.StringToAddress("0x10"),
+			WalletAmount:  big.NewInt(1000),
+		},
+	}
+
+	burnContract := types.StringToAddress("0x20")
+
+	genesis := &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit:           5242880,
+			BaseFee:            chain.GenesisBaseFee,
+			BaseFeeEM:          chain.GenesisBaseFeeEM,
+			BaseFeeChangeDenom: chain.BaseFeeChangeDenom,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				types.ZeroAddress: {Balance: big.NewInt(1)},
+				burnContract:      {Balance: big.NewInt(0)},
+			},
+		},
+		Params: &chain.Params{
+			ChainID:      100,
+			Forks:        chain.AllForksEnabled.Copy(),
+			BurnContract: map[uint64]types.Address{0: burnContract},
+		},
+	}
+
+	if mutate != nil {
+		mutate(genesis, config)
+	}
+
+	genesis.Params.Engine = map[string]interface{}{polybft.ConsensusName: config}
+
+	return genesis
+}
+
+func TestLint_Valid(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, Lint(newTestGenesis(t, nil)))
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		mutate   func(*chain.Chain, *polybft.PolyBFTConfig)
+		rule     string
+		severity Severity
+	}{
+		{
+			name: "zero chain ID",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.ChainID = 0
+			},
+			rule:     "chain-id",
+			severity: SeverityError,
+		},
+		{
+			name: "fork out of order",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.Forks.SetFork(chain.Istanbul, chain.NewFork(100))
+			},
+			rule:     "fork-order",
+			severity: SeverityError,
+		},
+		{
+			name: "missing preceding fork",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.Forks.RemoveFork(chain.Byzantium)
+			},
+			rule:     "fork-order",
+			severity: SeverityWarning,
+		},
+		{
+			name: "unknown fork",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.Forks.SetFork("unknown", chain.NewFork(0))
+			},
+			rule:     "forks",
+			severity: SeverityError,
+		},
+		{
+			name: "burn contract ranges start after london",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.BurnContract = map[uint64]types.Address{10: types.StringToAddress("0x20")}
+			},
+			rule:     "burn-contract",
+			severity: SeverityError,
+		},
+		{
+			name: "overlapping burn contract ranges",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.BurnContract[10] = types.StringToAddress("0x20")
+			},
+			rule:     "burn-contract",
+			severity: SeverityWarning,
+		},
+		{
+			name: "zero address premined for mintable token",
+			mutate: func(c *chain.Chain, config *polybft.PolyBFTConfig) {
+				config.NativeTokenConfig = &polybft.TokenConfig{Name: "Token", Symbol: "TKN", IsMintable: true}
+				c.Params.BurnContract = map[uint64]types.Address{0: types.ZeroAddress}
+			},
+			rule:     "premine",
+			severity: SeverityError,
+		},
+		{
+			name: "reserve account not premined",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				delete(c.Genesis.Alloc, types.ZeroAddress)
+			},
+			rule:     "premine",
+			severity: SeverityError,
+		},
+		{
+			name: "allow list without admins",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				c.Params.ContractDeployerAllowList = &chain.AddressListConfig{}
+			},
+			rule:     "access-lists",
+			severity: SeverityError,
+		},
+		{
+			name: "allow and block lists",
+			mutate: func(c *chain.Chain, _ *polybft.PolyBFTConfig) {
+				list := &chain.AddressListConfig{AdminAddresses: []types.Address{types.StringToAddress("0x1")}}
+				c.Params.TransactionsAllowList = list
+				c.Params.TransactionsBlockList = list
+			},
+			rule:     "access-lists",
+			severity: SeverityWarning,
+		},
+		{
+			name: "validator set below the min size",
+			mutate: func(_ *chain.Chain, config *polybft.PolyBFTConfig) {
+				config.MinValidatorSetSize = 5
+			},
+			rule:     "polybft-validators",
+			severity: SeverityError,
+		},
+		{
+			name: "duplicate validator",
+			mutate: func(_ *chain.Chain, config *polybft.PolyBFTConfig) {
+				config.InitialValidatorSet = append(config.InitialValidatorSet, config.InitialValidatorSet[0])
+			},
+			rule:     "polybft-validators",
+			severity: SeverityError,
+		},
+		{
+			name: "epoch size",
+			mutate: func(_ *chain.Chain, config *polybft.PolyBFTConfig) {
+				config.EpochSize = 1
+				config.SprintSize = 1
+			},
+			rule:     "polybft",
+			severity: SeverityError,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			found := false
+
+			for _, finding := range Lint(newTestGenesis(t, c.mutate)) {
+				found = found || (finding.Rule == c.rule && finding.Severity == c.severity)
+			}
+
+			require.True(t, found)
+		})
+	}
+}
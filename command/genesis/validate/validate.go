@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+)
+
+func GetCommand() *cobra.Command {
+	genesisValidateCmd := &cobra.Command{
+		Use: "validate",
+		Short: "Checks the genesis file for settings which prevent the nodes from starting or fork the network, " +
+			"and exits with an error if any is found",
+		Run: runCommand,
+	}
+
+	setFlags(genesisValidateCmd)
+
+	return genesisValidateCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		chainFlag,
+		fmt.Sprintf("./%s", command.DefaultGenesisFileName),
+		"the genesis file to validate",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.validate(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	// the findings are written even when errors are found, which fail the command
+	if err := params.getError(); err != nil {
+		outputter.WriteCommandResult(params.getResult())
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -2,6 +2,8 @@ package regenesis
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command/regenesis/dump"
 )
 
 var (
@@ -18,6 +20,7 @@ func GetCommand() *cobra.Command {
 	genesisCMD := RegenesisCMD()
 	genesisCMD.AddCommand(GetRootCMD())
 	genesisCMD.AddCommand(HistoryTestCmd())
+	genesisCMD.AddCommand(dump.GetCommand())
 
 	return genesisCMD
 }
@@ -0,0 +1,116 @@
+package dump
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+func GetCommand() *cobra.Command {
+	dumpCmd := &cobra.Command{
+		Use: "dump",
+		Short: "Dumps the state of a block of a stopped node into the alloc of a new genesis, " +
+			"and verifies that it reproduces the state root of the block",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(dumpCmd)
+	helper.SetRequiredFlags(dumpCmd, params.getRequiredFlags())
+
+	return dumpCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node of the source chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.genesisPath,
+		genesisPathFlag,
+		defaultGenesisPath,
+		"the genesis file of the source chain",
+	)
+
+	cmd.Flags().StringVar(
+		&params.outputPath,
+		outputFlag,
+		defaultOutputPath,
+		"the path of the new genesis file",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.block,
+		blockFlag,
+		0,
+		"the number of the block whose state is dumped, the head block if not set",
+	)
+
+	cmd.Flags().Int64Var(
+		&params.chainID,
+		chainIDFlag,
+		0,
+		"the ID of the new chain, the ID of the source chain if not set",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.exclude,
+		excludeFlag,
+		[]string{},
+		"the addresses of the accounts left out of the new genesis",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.excludeSystemContracts,
+		excludeSystemContractsFlag,
+		false,
+		"leave the system contracts out of the new genesis, so that its genesis deploys them again",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.rewrite,
+		rewriteFlag,
+		[]string{},
+		"moves an account to a new address in the new genesis (format: <old address>:<new address>)",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&params.preimages,
+		preimagesFlag,
+		[]string{},
+		"files with the addresses and the storage slots of the state, one in hex per line, "+
+			"which aren't found in the genesis and the blocks of the source chain",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.storageSlots,
+		storageSlotsFlag,
+		defaultStorageSlots,
+		"the number of leading storage slots of the contracts, and of the entries of the known "+
+			"addresses in the mappings declared at them, which are looked up in the storage",
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	params.blockSet = cmd.Flags().Changed(blockFlag)
+
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.dump(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
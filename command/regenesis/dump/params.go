@@ -0,0 +1,292 @@
+package dump
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag                = "data-dir"
+	genesisPathFlag            = "genesis"
+	outputFlag                 = "output"
+	blockFlag                  = "block"
+	chainIDFlag                = "chain-id"
+	excludeFlag                = "exclude"
+	excludeSystemContractsFlag = "exclude-system-contracts"
+	rewriteFlag                = "rewrite"
+	preimagesFlag              = "preimages"
+	storageSlotsFlag           = "storage-slots"
+
+	defaultGenesisPath  = "./genesis.json"
+	defaultOutputPath   = "./regenesis.json"
+	defaultStorageSlots = 32
+)
+
+var (
+	params = &dumpParams{}
+)
+
+var (
+	errInvalidRewrite = errors.New("invalid rewrite, expected <old address>:<new address>")
+)
+
+type dumpParams struct {
+	dataDir                string
+	genesisPath            string
+	outputPath             string
+	block                  uint64
+	chainID                int64
+	exclude                []string
+	excludeSystemContracts bool
+	rewrite                []string
+	preimages              []string
+	storageSlots           uint64
+
+	// blockSet is false when the state of the head block is dumped
+	blockSet bool
+
+	excludeAddrs []types.Address
+	rewriteAddrs map[types.Address]types.Address
+
+	header      *types.Header
+	accounts    int
+	genesisRoot types.Hash
+}
+
+func (p *dumpParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *dumpParams) validateFlags() error {
+	p.excludeAddrs = make([]types.Address, 0, len(p.exclude))
+
+	for _, raw := range p.exclude {
+		addr, err := parseAddress(raw)
+		if err != nil {
+			return err
+		}
+
+		p.excludeAddrs = append(p.excludeAddrs, addr)
+	}
+
+	p.rewriteAddrs = make(map[types.Address]types.Address, len(p.rewrite))
+
+	for _, raw := range p.rewrite {
+		parts := strings.Split(raw, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("%w: %s", errInvalidRewrite, raw)
+		}
+
+		from, err := parseAddress(parts[0])
+		if err != nil {
+			return err
+		}
+
+		to, err := parseAddress(parts[1])
+		if err != nil {
+			return err
+		}
+
+		if _, ok := p.rewriteAddrs[from]; ok {
+			return fmt.Errorf("%w: %s is rewritten twice", errInvalidRewrite, from)
+		}
+
+		p.rewriteAddrs[from] = to
+	}
+
+	return nil
+}
+
+func parseAddress(raw string) (types.Address, error) {
+	addr := types.StringToAddress(raw)
+	if addr == types.ZeroAddress {
+		return types.ZeroAddress, fmt.Errorf("invalid address %q", raw)
+	}
+
+	return addr, nil
+}
+
+// dump writes the state of the block as the alloc of a new genesis, after checking that the dumped
+// alloc reproduces the state root of the block
+func (p *dumpParams) dump() (err error) {
+	source, err := chain.ImportFromFile(p.genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the genesis of the source chain: %w", err)
+	}
+
+	blockchainStorage, stateStorage, err := dbengine.OpenExistingStorages(p.dataDir, hclog.NewNullLogger())
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = errors.Join(err, blockchainStorage.Close(), stateStorage.Close())
+	}()
+
+	number := p.block
+	if !p.blockSet {
+		head, ok := blockchainStorage.ReadHeadNumber()
+		if !ok {
+			return fmt.Errorf("failed to read the head number")
+		}
+
+		number = head
+	}
+
+	hash, ok := blockchainStorage.ReadCanonicalHash(number)
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d not found", number)
+	}
+
+	header, err := blockchainStorage.ReadHeader(hash)
+	if err != nil {
+		return fmt.Errorf("failed to read the header of block %d: %w", number, err)
+	}
+
+	// the state tries are keyed by the hashes of the addresses and of the slots,
+	// so the accounts and the storage slots are recovered from their known preimages
+	images := newPreimages()
+	images.addGenesis(source.Genesis)
+
+	if err := images.addChain(blockchainStorage, source.Params, number); err != nil {
+		return err
+	}
+
+	for _, path := range p.preimages {
+		if err := images.addFile(path); err != nil {
+			return fmt.Errorf("failed to read the preimages of %s: %w", path, err)
+		}
+	}
+
+	images.addSlots(p.storageSlots)
+
+	alloc, err := dumpState(stateStorage, header.StateRoot, images)
+	if err != nil {
+		return err
+	}
+
+	root, err := genesisRoot(source.Params, alloc)
+	if err != nil {
+		return err
+	}
+
+	if root != header.StateRoot {
+		return fmt.Errorf("%w: block %d, expected %s, got %s", errStateRootMismatch, number, header.StateRoot, root)
+	}
+
+	exclude := p.excludeAddrs
+	if p.excludeSystemContracts {
+		for _, addr := range systemContracts() {
+			if _, ok := alloc[addr]; ok {
+				exclude = append(exclude, addr)
+			}
+		}
+	}
+
+	if err := rewriteAlloc(alloc, exclude, p.rewriteAddrs); err != nil {
+		return err
+	}
+
+	genesis := newGenesis(source, header, alloc, p.chainID)
+
+	if p.genesisRoot, err = genesisRoot(genesis.Params, genesis.Genesis.Alloc); err != nil {
+		return err
+	}
+
+	if err := writeGenesis(p.outputPath, genesis, p.genesisRoot); err != nil {
+		return err
+	}
+
+	p.header = header
+	p.accounts = len(alloc)
+
+	return nil
+}
+
+// newGenesis returns the chain of the source genesis, whose genesis block holds the given alloc
+// and the metadata of the source block
+func newGenesis(
+	source *chain.Chain,
+	header *types.Header,
+	alloc map[types.Address]*chain.GenesisAccount,
+	chainID int64,
+) *chain.Chain {
+	genesis := *source.Genesis
+	genesis.Alloc = alloc
+	genesis.GasLimit = header.GasLimit
+	genesis.StateRoot = types.ZeroHash
+	genesis.Metadata = &chain.GenesisMetadata{
+		SourceChainID:     source.Params.ChainID,
+		SourceBlockNumber: header.Number,
+		SourceBlockHash:   header.Hash,
+		SourceStateRoot:   header.StateRoot,
+	}
+
+	if header.BaseFee != 0 {
+		genesis.BaseFee = header.BaseFee
+	}
+
+	chainParams := *source.Params
+	if chainID != 0 {
+		chainParams.ChainID = chainID
+	}
+
+	return &chain.Chain{
+		Name:      source.Name,
+		Genesis:   &genesis,
+		Params:    &chainParams,
+		Bootnodes: source.Bootnodes,
+	}
+}
+
+// writeGenesis writes the genesis to the file, after checking that the alloc read back from
+// its encoding still has the expected state root
+func writeGenesis(path string, genesis *chain.Chain, root types.Hash) error {
+	data, err := json.MarshalIndent(genesis, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the genesis: %w", err)
+	}
+
+	var decoded *chain.Chain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("failed to decode the genesis: %w", err)
+	}
+
+	decodedRoot, err := genesisRoot(decoded.Params, decoded.Genesis.Alloc)
+	if err != nil {
+		return err
+	}
+
+	if decodedRoot != root {
+		return fmt.Errorf("%w: encoded genesis, expected %s, got %s", errStateRootMismatch, root, decodedRoot)
+	}
+
+	if err := common.SaveFileSafe(path, data, 0660); err != nil {
+		return fmt.Errorf("failed to write genesis: %w", err)
+	}
+
+	return nil
+}
+
+func (p *dumpParams) getResult() command.CommandResult {
+	return &RegenesisDumpResult{
+		Number:      p.header.Number,
+		Hash:        p.header.Hash.String(),
+		StateRoot:   p.header.StateRoot.String(),
+		Accounts:    p.accounts,
+		GenesisRoot: p.genesisRoot.String(),
+		Output:      p.outputPath,
+	}
+}
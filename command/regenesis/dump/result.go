@@ -0,0 +1,34 @@
+package dump
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type RegenesisDumpResult struct {
+	Number      uint64 `json:"number"`
+	Hash        string `json:"hash"`
+	StateRoot   string `json:"state_root"`
+	Accounts    int    `json:"accounts"`
+	GenesisRoot string `json:"genesis_root"`
+	Output      string `json:"output"`
+}
+
+func (r *RegenesisDumpResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[REGENESIS DUMP]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block number|%d", r.Number),
+		fmt.Sprintf("Block hash|%s", r.Hash),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Accounts|%d", r.Accounts),
+		fmt.Sprintf("Genesis state root|%s", r.GenesisRoot),
+		fmt.Sprintf("Genesis file|%s", r.Output),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
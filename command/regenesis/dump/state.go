@@ -0,0 +1,364 @@
+package dump
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/fastrlp"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	errUnresolvedPreimages = errors.New("state holds keys whose preimages are unknown, add them with --preimages")
+	errStateRootMismatch   = errors.New("dumped genesis doesn't reproduce the state root of the block")
+	errAccountNotFound     = errors.New("account is not in the dumped state")
+	errAccountExists       = errors.New("account is in the dumped state already")
+)
+
+// proxySlots are the EIP-1967 slots of the implementation, the admin and the beacon of the proxy contracts
+var proxySlots = []types.Hash{
+	types.StringToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"),
+	types.StringToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"),
+	types.StringToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50"),
+}
+
+// systemContracts returns the addresses of the system contracts which the genesis of a chain deploys
+func systemContracts() []types.Address {
+	addrs := []types.Address{
+		contracts.ChildERC20Contract,
+		contracts.ChildERC721Contract,
+		contracts.ChildERC1155Contract,
+		contracts.RewardTokenContract,
+		contracts.RewardTokenContractV1,
+	}
+
+	for proxy, implementation := range contracts.GetProxyImplementationMapping() {
+		addrs = append(addrs, proxy, implementation)
+	}
+
+	return addrs
+}
+
+// preimages maps the hashes keying the state tries to the addresses of the accounts and to the storage slots
+type preimages struct {
+	addresses map[types.Hash]types.Address
+	slots     map[types.Hash]types.Hash
+}
+
+func newPreimages() *preimages {
+	return &preimages{
+		addresses: map[types.Hash]types.Address{},
+		slots:     map[types.Hash]types.Hash{},
+	}
+}
+
+func (p *preimages) addAddress(addr types.Address) {
+	p.addresses[types.BytesToHash(crypto.Keccak256(addr.Bytes()))] = addr
+}
+
+func (p *preimages) addSlot(slot types.Hash) {
+	p.slots[types.BytesToHash(crypto.Keccak256(slot.Bytes()))] = slot
+}
+
+// addGenesis adds the accounts of the genesis and their storage slots
+func (p *preimages) addGenesis(genesis *chain.Genesis) {
+	for addr, account := range genesis.Alloc {
+		p.addAddress(addr)
+
+		for slot := range account.Storage {
+			p.addSlot(slot)
+		}
+	}
+
+	for _, addr := range systemContracts() {
+		p.addAddress(addr)
+	}
+
+	for _, slot := range proxySlots {
+		p.addSlot(slot)
+	}
+}
+
+// addChain adds the addresses found in the blocks up to the given number: the miners, the senders and
+// the recipients of the transactions, the created contracts, the emitters of the logs and the topics
+// holding an address
+func (p *preimages) addChain(blockchain storage.Storage, params *chain.Params, to uint64) error {
+	for number := uint64(0); number <= to; number++ {
+		hash, ok := blockchain.ReadCanonicalHash(number)
+		if !ok {
+			return fmt.Errorf("canonical hash of block %d not found", number)
+		}
+
+		header, err := blockchain.ReadHeader(hash)
+		if err != nil {
+			return fmt.Errorf("failed to read the header of block %d: %w", number, err)
+		}
+
+		p.addAddress(types.BytesToAddress(header.Miner))
+
+		body, err := blockchain.ReadBody(hash)
+		if err != nil {
+			return fmt.Errorf("failed to read the body of block %d: %w", number, err)
+		}
+
+		signer := crypto.NewSigner(params.Forks.At(number), uint64(params.ChainID))
+
+		for _, tx := range body.Transactions {
+			from := tx.From
+			if from == types.ZeroAddress {
+				if from, err = signer.Sender(tx); err != nil {
+					return fmt.Errorf("failed to recover the sender of transaction %s: %w", tx.Hash, err)
+				}
+			}
+
+			p.addAddress(from)
+
+			if tx.To != nil {
+				p.addAddress(*tx.To)
+			}
+		}
+
+		if len(body.Transactions) == 0 {
+			continue
+		}
+
+		receipts, err := blockchain.ReadReceipts(hash)
+		if err != nil {
+			return fmt.Errorf("failed to read the receipts of block %d: %w", number, err)
+		}
+
+		for _, receipt := range receipts {
+			if receipt.ContractAddress != nil {
+				p.addAddress(*receipt.ContractAddress)
+			}
+
+			for _, log := range receipt.Logs {
+				p.addAddress(log.Address)
+
+				for _, topic := range log.Topics {
+					if addr, ok := topicAddress(topic); ok {
+						p.addAddress(addr)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// addSlots adds the first storage slots, where solidity stores the state variables, and the slots of
+// the entries of the known addresses in the mappings declared at those slots
+func (p *preimages) addSlots(count uint64) {
+	addrs := make([]types.Address, 0, len(p.addresses))
+	for _, addr := range p.addresses {
+		addrs = append(addrs, addr)
+	}
+
+	for i := uint64(0); i < count; i++ {
+		slot := types.BytesToHash(new(big.Int).SetUint64(i).Bytes())
+		p.addSlot(slot)
+
+		for _, addr := range addrs {
+			p.addSlot(types.BytesToHash(crypto.Keccak256(types.BytesToHash(addr.Bytes()).Bytes(), slot.Bytes())))
+		}
+	}
+}
+
+// addFile adds the preimages of the file, which holds an address or a storage slot in hex per line
+func (p *preimages) addFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		raw, err := hex.DecodeHex(line)
+		if err != nil {
+			return fmt.Errorf("invalid preimage %s: %w", line, err)
+		}
+
+		switch len(raw) {
+		case types.AddressLength:
+			p.addAddress(types.BytesToAddress(raw))
+		case types.HashLength:
+			p.addSlot(types.BytesToHash(raw))
+		default:
+			return fmt.Errorf("invalid preimage %s: expected an address or a storage slot", line)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// topicAddress returns the address held by the log topic, which is an address padded with zeros
+func topicAddress(topic types.Hash) (types.Address, bool) {
+	for _, b := range topic[:types.HashLength-types.AddressLength] {
+		if b != 0 {
+			return types.ZeroAddress, false
+		}
+	}
+
+	addr := types.BytesToAddress(topic[types.HashLength-types.AddressLength:])
+
+	return addr, addr != types.ZeroAddress
+}
+
+// dumpState returns the accounts of the state with the given root as the alloc of a genesis, with their
+// balances, nonces, codes and storages. Every account and storage slot must have a known preimage
+func dumpState(
+	storage itrie.Storage,
+	root types.Hash,
+	images *preimages,
+) (map[types.Address]*chain.GenesisAccount, error) {
+	var (
+		alloc              = map[types.Address]*chain.GenesisAccount{}
+		unresolvedAccounts int
+		unresolvedSlots    int
+		parser             fastrlp.Parser
+	)
+
+	err := itrie.WalkLeaves(storage, root, func(key types.Hash, data []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(data); err != nil {
+			return fmt.Errorf("can't parse account %s: %w", key, err)
+		}
+
+		addr, ok := images.addresses[key]
+		if !ok {
+			unresolvedAccounts++
+
+			return nil
+		}
+
+		genesisAccount := &chain.GenesisAccount{
+			Balance: account.Balance,
+			Nonce:   account.Nonce,
+		}
+
+		if genesisAccount.Balance == nil {
+			genesisAccount.Balance = big.NewInt(0)
+		}
+
+		if codeHash := types.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash &&
+			codeHash != types.ZeroHash {
+			code, ok := storage.GetCode(codeHash)
+			if !ok {
+				return fmt.Errorf("code %s of account %s not found", codeHash, addr)
+			}
+
+			genesisAccount.Code = code
+		}
+
+		if err := itrie.WalkLeaves(storage, account.Root, func(key types.Hash, value []byte) error {
+			slot, ok := images.slots[key]
+			if !ok {
+				unresolvedSlots++
+
+				return nil
+			}
+
+			v, err := parser.Parse(value)
+			if err != nil {
+				return err
+			}
+
+			raw, err := v.Bytes()
+			if err != nil {
+				return err
+			}
+
+			if genesisAccount.Storage == nil {
+				genesisAccount.Storage = map[types.Hash]types.Hash{}
+			}
+
+			genesisAccount.Storage[slot] = types.BytesToHash(raw)
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		alloc[addr] = genesisAccount
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if unresolvedAccounts > 0 || unresolvedSlots > 0 {
+		return nil, fmt.Errorf("%w: %d accounts and %d storage slots",
+			errUnresolvedPreimages, unresolvedAccounts, unresolvedSlots)
+	}
+
+	return alloc, nil
+}
+
+// genesisRoot returns the state root of a genesis with the given alloc. The genesis hooks of the consensus
+// are not run, so the root is the one of the alloc only
+func genesisRoot(params *chain.Params, alloc map[types.Address]*chain.GenesisAccount) (types.Hash, error) {
+	executor := state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), hclog.NewNullLogger())
+
+	return executor.WriteGenesis(alloc, types.ZeroHash)
+}
+
+// rewriteAlloc removes the excluded accounts from the alloc and moves the rewritten accounts to their new addresses
+func rewriteAlloc(
+	alloc map[types.Address]*chain.GenesisAccount,
+	exclude []types.Address,
+	rewrite map[types.Address]types.Address,
+) error {
+	for _, addr := range exclude {
+		if _, ok := alloc[addr]; !ok {
+			return fmt.Errorf("%w: %s", errAccountNotFound, addr)
+		}
+
+		delete(alloc, addr)
+	}
+
+	moved := make(map[types.Address]*chain.GenesisAccount, len(rewrite))
+
+	for from := range rewrite {
+		account, ok := alloc[from]
+		if !ok {
+			return fmt.Errorf("%w: %s", errAccountNotFound, from)
+		}
+
+		moved[from] = account
+
+		delete(alloc, from)
+	}
+
+	for from, to := range rewrite {
+		if _, ok := alloc[to]; ok {
+			return fmt.Errorf("%w: %s", errAccountExists, to)
+		}
+
+		alloc[to] = moved[from]
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package dump
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newTestState(t *testing.T) (itrie.Storage, types.Hash, *chain.Genesis) {
+	t.Helper()
+
+	genesis := &chain.Genesis{
+		Alloc: map[types.Address]*chain.GenesisAccount{
+			types.StringToAddress("1"): {
+				Balance: big.NewInt(100),
+				Nonce:   3,
+			},
+			types.StringToAddress("2"): {
+				Balance: big.NewInt(0),
+				Nonce:   1,
+				Code:    []byte{0x60, 0x00},
+				Storage: map[types.Hash]types.Hash{
+					types.StringToHash("1"): types.StringToHash("10"),
+					types.StringToHash("2"): types.StringToHash("20"),
+				},
+			},
+		},
+	}
+
+	storage := itrie.NewMemoryStorage()
+	executor := state.NewExecutor(&chain.Params{Forks: chain.AllForksEnabled},
+		itrie.NewState(storage), hclog.NewNullLogger())
+
+	root, err := executor.WriteGenesis(genesis.Alloc, types.ZeroHash)
+	require.NoError(t, err)
+
+	return storage, root, genesis
+}
+
+func TestDumpState(t *testing.T) {
+	t.Parallel()
+
+	storage, root, genesis := newTestState(t)
+
+	images := newPreimages()
+	images.addGenesis(genesis)
+
+	alloc, err := dumpState(storage, root, images)
+	require.NoError(t, err)
+	require.Equal(t, genesis.Alloc, alloc)
+
+	dumpedRoot, err := genesisRoot(&chain.Params{Forks: chain.AllForksEnabled}, alloc)
+	require.NoError(t, err)
+	require.Equal(t, root, dumpedRoot)
+}
+
+func TestDumpState_UnresolvedPreimages(t *testing.T) {
+	t.Parallel()
+
+	storage, root, _ := newTestState(t)
+
+	images := newPreimages()
+	images.addAddress(types.StringToAddress("2"))
+	images.addSlot(types.StringToHash("1"))
+
+	_, err := dumpState(storage, root, images)
+	require.ErrorIs(t, err, errUnresolvedPreimages)
+	require.ErrorContains(t, err, "1 accounts and 1 storage slots")
+}
+
+func TestRewriteAlloc(t *testing.T) {
+	t.Parallel()
+
+	var (
+		addr1 = types.StringToAddress("1")
+		addr2 = types.StringToAddress("2")
+		addr3 = types.StringToAddress("3")
+	)
+
+	newAlloc := func() map[types.Address]*chain.GenesisAccount {
+		return map[types.Address]*chain.GenesisAccount{
+			addr1: {Nonce: 1},
+			addr2: {Nonce: 2},
+		}
+	}
+
+	alloc := newAlloc()
+	require.NoError(t, rewriteAlloc(alloc, []types.Address{addr1}, map[types.Address]types.Address{addr2: addr3}))
+	require.Equal(t, map[types.Address]*chain.GenesisAccount{addr3: {Nonce: 2}}, alloc)
+
+	// accounts can swap their addresses
+	alloc = newAlloc()
+	require.NoError(t, rewriteAlloc(alloc, nil, map[types.Address]types.Address{addr1: addr2, addr2: addr1}))
+	require.Equal(t, map[types.Address]*chain.GenesisAccount{addr1: {Nonce: 2}, addr2: {Nonce: 1}}, alloc)
+
+	require.ErrorIs(t, rewriteAlloc(newAlloc(), []types.Address{addr3}, nil), errAccountNotFound)
+	require.ErrorIs(t, rewriteAlloc(newAlloc(), nil, map[types.Address]types.Address{addr3: addr1}), errAccountNotFound)
+	require.ErrorIs(t, rewriteAlloc(newAlloc(), nil, map[types.Address]types.Address{addr1: addr2}), errAccountExists)
+}
+
+func TestTopicAddress(t *testing.T) {
+	t.Parallel()
+
+	addr, ok := topicAddress(types.BytesToHash(types.StringToAddress("1").Bytes()))
+	require.True(t, ok)
+	require.Equal(t, types.StringToAddress("1"), addr)
+
+	_, ok = topicAddress(types.StringToHash("0x1000000000000000000000000000000000000000000000000000000000000001"))
+	require.False(t, ok)
+
+	_, ok = topicAddress(types.ZeroHash)
+	require.False(t, ok)
+}
@@ -38,6 +38,28 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64 `json:"concurrent_requests_debug" yaml:"concurrent_requests_debug"`
 	WebSocketReadLimit      uint64 `json:"web_socket_read_limit" yaml:"web_socket_read_limit"`
+	JSONRPCTxPoolAdmin      bool   `json:"json_rpc_txpool_admin" yaml:"json_rpc_txpool_admin"`
+
+	StateScheme             string `json:"state_scheme" yaml:"state_scheme"`
+	StateRetainedRoots      uint64 `json:"state_retained_roots" yaml:"state_retained_roots"`
+	StateCheckpointInterval uint64 `json:"state_checkpoint_interval" yaml:"state_checkpoint_interval"`
+	StateFlatSnapshot       bool   `json:"state_flat_snapshot" yaml:"state_flat_snapshot"`
+
+	SyncMode string `json:"sync_mode" yaml:"sync_mode"`
+
+	DBEngine string `json:"db_engine" yaml:"db_engine"`
+
+	FreezerThreshold   uint64 `json:"freezer_threshold" yaml:"freezer_threshold"`
+	FreezerCompression bool   `json:"freezer_compression" yaml:"freezer_compression"`
+
+	HistoryRetention uint64 `json:"history_retention" yaml:"history_retention"`
+
+	ParallelExecution bool `json:"parallel_execution" yaml:"parallel_execution"`
+
+	ConsoleLog bool `json:"console_log" yaml:"console_log"`
+
+	ForkURL   string `json:"fork_url" yaml:"fork_url"`
+	ForkBlock uint64 `json:"fork_block" yaml:"fork_block"`
 
 	MetricsInterval time.Duration `json:"metrics_interval" yaml:"metrics_interval"`
 }
@@ -63,6 +85,12 @@ type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	MaxSenderTxsPerSec uint64 `json:"max_sender_txs_per_sec" yaml:"max_sender_txs_per_sec"`
+
+	// RejectUnprotectedTxs defaults to the rejectUnprotectedTxs param of the genesis if not set
+	RejectUnprotectedTxs     *bool    `json:"reject_unprotected_txs,omitempty" yaml:"reject_unprotected_txs,omitempty"`
+	AllowUnprotectedLocalTxs bool     `json:"allow_unprotected_local_txs" yaml:"allow_unprotected_local_txs"`
+	UnprotectedTxsAllowlist  []string `json:"unprotected_txs_allowlist" yaml:"unprotected_txs_allowlist"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -97,6 +125,33 @@ const (
 	// DefaultMetricsInterval specifies the time interval after which Prometheus metrics will be generated.
 	// A value of 0 means the metrics are disabled.
 	DefaultMetricsInterval time.Duration = time.Second * 8
+
+	// DefaultStateScheme specifies that the state of every block is kept
+	DefaultStateScheme = "archive"
+
+	// DefaultStateRetainedRoots specifies the number of the most recent blocks
+	// whose state is kept by the full state scheme
+	DefaultStateRetainedRoots uint64 = 128
+
+	// DefaultStateCheckpointInterval specifies the interval of the blocks
+	// whose state is kept forever by the full state scheme
+	DefaultStateCheckpointInterval uint64 = 10000
+
+	// DefaultSyncMode specifies that every block is executed from the genesis
+	DefaultSyncMode = "full"
+
+	// DefaultDBEngine specifies the database engine of the blockchain and state storages
+	DefaultDBEngine = "leveldb"
+
+	// DefaultFreezerThreshold specifies that the blocks are never moved to the freezer
+	DefaultFreezerThreshold uint64 = 0
+
+	// DefaultHistoryRetention specifies that the bodies and the receipts of all the blocks are kept
+	DefaultHistoryRetention uint64 = 0
+
+	// MinHistoryRetention is the minimum number of the most recent blocks whose history is kept,
+	// which covers the fee history and the pivot blocks served to the snap syncing peers
+	MinHistoryRetention uint64 = 128
 )
 
 // DefaultConfig returns the default server configuration
@@ -123,6 +178,11 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			MaxSenderTxsPerSec: 0,
+
+			RejectUnprotectedTxs:     nil,
+			AllowUnprotectedLocalTxs: false,
+			UnprotectedTxsAllowlist:  []string{},
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
@@ -137,6 +197,14 @@ func DefaultConfig() *Config {
 		ConcurrentRequestsDebug:  DefaultConcurrentRequestsDebug,
 		WebSocketReadLimit:       DefaultWebSocketReadLimit,
 		MetricsInterval:          DefaultMetricsInterval,
+		StateScheme:              DefaultStateScheme,
+		StateRetainedRoots:       DefaultStateRetainedRoots,
+		StateCheckpointInterval:  DefaultStateCheckpointInterval,
+		StateFlatSnapshot:        true,
+		SyncMode:                 DefaultSyncMode,
+		DBEngine:                 DefaultDBEngine,
+		FreezerThreshold:         DefaultFreezerThreshold,
+		HistoryRetention:         DefaultHistoryRetention,
 	}
 }
 
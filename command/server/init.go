@@ -7,6 +7,7 @@ import (
 	"net"
 
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 
 	helperCommon "github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/network/common"
@@ -16,6 +17,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 var (
@@ -53,6 +55,31 @@ func (p *serverParams) initRawParams() error {
 		p.initDevMode()
 	}
 
+	if err := p.initUnprotectedTxsAllowlist(); err != nil {
+		return err
+	}
+
+	if err := p.initStateScheme(); err != nil {
+		return err
+	}
+
+	if err := p.initSyncMode(); err != nil {
+		return err
+	}
+
+	if _, err := dbengine.Parse(p.rawConfig.DBEngine); err != nil {
+		return err
+	}
+
+	if retention := p.rawConfig.HistoryRetention; retention > 0 && retention < config.MinHistoryRetention {
+		return fmt.Errorf("%w: %d, expected either 0 or at least %d",
+			errHistoryRetention, retention, config.MinHistoryRetention)
+	}
+
+	if p.rawConfig.ForkURL != "" && !p.isDevConsensus() {
+		return errForkNotDev
+	}
+
 	p.initPeerLimits()
 	p.initLogFileLocation()
 
@@ -61,6 +88,44 @@ func (p *serverParams) initRawParams() error {
 	return p.initAddresses()
 }
 
+func (p *serverParams) initUnprotectedTxsAllowlist() error {
+	p.unprotectedTxsAllowlist = make([]types.Address, 0, len(p.rawConfig.TxPool.UnprotectedTxsAllowlist))
+
+	for _, rawAddr := range p.rawConfig.TxPool.UnprotectedTxsAllowlist {
+		if err := types.IsValidAddress(rawAddr); err != nil {
+			return fmt.Errorf("invalid %s address %s: %w", unprotectedTxsAllowlistFlag, rawAddr, err)
+		}
+
+		p.unprotectedTxsAllowlist = append(p.unprotectedTxsAllowlist, types.StringToAddress(rawAddr))
+	}
+
+	return nil
+}
+
+func (p *serverParams) initStateScheme() error {
+	switch server.StateScheme(p.rawConfig.StateScheme) {
+	case server.ArchiveStateScheme:
+		return nil
+	case server.FullStateScheme:
+		if p.rawConfig.StateRetainedRoots == 0 {
+			return errNoRetainedRoots
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidStateScheme, p.rawConfig.StateScheme)
+	}
+}
+
+func (p *serverParams) initSyncMode() error {
+	switch server.SyncMode(p.rawConfig.SyncMode) {
+	case server.FullSyncMode, server.SnapSyncMode:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", errInvalidSyncMode, p.rawConfig.SyncMode)
+	}
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -138,6 +203,7 @@ func (p *serverParams) initDevConsensusConfig() {
 	p.genesisConfig.Params.Engine = map[string]interface{}{
 		string(server.DevConsensus): map[string]interface{}{
 			"interval": p.devInterval,
+			"autoMine": p.devAutoMine,
 		},
 	}
 }
@@ -6,9 +6,11 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/chain"
 	"github.com/0xPolygon/polygon-edge/command/server/config"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server"
+	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/multiformats/go-multiaddr"
 )
@@ -30,10 +32,15 @@ const (
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	maxSenderTxsPerSecFlag       = "max-sender-txs-per-sec"
+	rejectUnprotectedTxsFlag     = "reject-unprotected-txs"
+	allowUnprotectedLocalTxsFlag = "allow-unprotected-local-txs"
+	unprotectedTxsAllowlistFlag  = "unprotected-txs-allowlist"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
 	devIntervalFlag              = "dev-interval"
+	devAutoMineFlag              = "dev-auto-mine"
 	devFlag                      = "dev"
 	corsOriginFlag               = "access-control-allow-origins"
 	logFileLocationFlag          = "log-to"
@@ -43,8 +50,30 @@ const (
 
 	concurrentRequestsDebugFlag = "concurrent-requests-debug"
 	webSocketReadLimitFlag      = "websocket-read-limit"
+	jsonRPCTxPoolAdminFlag      = "json-rpc-txpool-admin"
 
 	metricsIntervalFlag = "metrics-interval"
+
+	stateSchemeFlag             = "state-scheme"
+	stateRetainedRootsFlag      = "state-retained-roots"
+	stateCheckpointIntervalFlag = "state-checkpoint-interval"
+	stateFlatSnapshotFlag       = "state-flat-snapshot"
+
+	syncModeFlag = "sync-mode"
+
+	dbEngineFlag = "db-engine"
+
+	freezerThresholdFlag   = "freezer-threshold"
+	freezerCompressionFlag = "freezer-compression"
+
+	historyRetentionFlag = "history-retention"
+
+	parallelExecutionFlag = "parallel-execution"
+
+	consoleLogFlag = "console-log"
+
+	forkURLFlag   = "fork-url"
+	forkBlockFlag = "fork-block"
 )
 
 // Flags that are deprecated, but need to be preserved for
@@ -68,7 +97,12 @@ var (
 )
 
 var (
-	errInvalidNATAddress = errors.New("could not parse NAT IP address")
+	errInvalidNATAddress  = errors.New("could not parse NAT IP address")
+	errInvalidStateScheme = errors.New("invalid state scheme, expected either full or archive")
+	errNoRetainedRoots    = errors.New("the number of retained state roots must be greater than zero")
+	errInvalidSyncMode    = errors.New("invalid sync mode, expected either full or snap")
+	errHistoryRetention   = errors.New("history retention is too short")
+	errForkNotDev         = errors.New("forking a chain requires the dev consensus")
 )
 
 type serverParams struct {
@@ -84,6 +118,7 @@ type serverParams struct {
 
 	blockGasTarget uint64
 	devInterval    uint64
+	devAutoMine    bool
 	isDevMode      bool
 
 	ibftBaseTimeoutLegacy uint64
@@ -93,6 +128,9 @@ type serverParams struct {
 
 	logFileLocation string
 
+	rejectUnprotectedTxs    bool
+	unprotectedTxsAllowlist []types.Address
+
 	relayer bool
 }
 
@@ -129,6 +167,16 @@ func (p *serverParams) isDevConsensus() bool {
 	return server.ConsensusType(p.genesisConfig.Params.GetEngine()) == server.DevConsensus
 }
 
+// isRejectUnprotectedTxs returns whether the pool rejects the replay-unprotected transactions,
+// which defaults to the genesis param so that the nodes of the existing chains keep accepting them
+func (p *serverParams) isRejectUnprotectedTxs() bool {
+	if p.rawConfig.TxPool.RejectUnprotectedTxs != nil {
+		return *p.rawConfig.TxPool.RejectUnprotectedTxs
+	}
+
+	return p.genesisConfig.Params.RejectUnprotectedTxs
+}
+
 func (p *serverParams) getRestoreFilePath() *string {
 	if p.rawConfig.RestoreFile != "" {
 		return &p.rawConfig.RestoreFile
@@ -145,6 +193,10 @@ func (p *serverParams) setRawJSONRPCAddress(jsonRPCAddress string) {
 	p.rawConfig.JSONRPCAddr = jsonRPCAddress
 }
 
+func (p *serverParams) setRejectUnprotectedTxs(reject bool) {
+	p.rawConfig.TxPool.RejectUnprotectedTxs = &reject
+}
+
 func (p *serverParams) setJSONLogFormat(jsonLogFormat bool) {
 	p.rawConfig.JSONLogFormat = jsonLogFormat
 }
@@ -159,6 +211,7 @@ func (p *serverParams) generateConfig() *server.Config {
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
 			ConcurrentRequestsDebug:  p.rawConfig.ConcurrentRequestsDebug,
 			WebSocketReadLimit:       p.rawConfig.WebSocketReadLimit,
+			TxPoolAdmin:              p.rawConfig.JSONRPCTxPoolAdmin,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -181,12 +234,37 @@ func (p *serverParams) generateConfig() *server.Config {
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		MaxSenderTxsPerSec: p.rawConfig.TxPool.MaxSenderTxsPerSec,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		LogLevel:           hclog.LevelFromString(p.rawConfig.LogLevel),
 		JSONLogFormat:      p.rawConfig.JSONLogFormat,
 		LogFilePath:        p.logFileLocation,
 
+		RejectUnprotectedTxs:     p.isRejectUnprotectedTxs(),
+		AllowUnprotectedLocalTxs: p.rawConfig.TxPool.AllowUnprotectedLocalTxs,
+		UnprotectedTxsAllowlist:  p.unprotectedTxsAllowlist,
+
+		StateScheme:             server.StateScheme(p.rawConfig.StateScheme),
+		StateRetainedRoots:      p.rawConfig.StateRetainedRoots,
+		StateCheckpointInterval: p.rawConfig.StateCheckpointInterval,
+		StateFlatSnapshot:       p.rawConfig.StateFlatSnapshot,
+
+		SyncMode: server.SyncMode(p.rawConfig.SyncMode),
+		DBEngine: dbengine.Engine(p.rawConfig.DBEngine),
+
+		FreezerThreshold:   p.rawConfig.FreezerThreshold,
+		FreezerCompression: p.rawConfig.FreezerCompression,
+
+		HistoryRetention: p.rawConfig.HistoryRetention,
+
+		ParallelExecution: p.rawConfig.ParallelExecution,
+
+		ConsoleLog: p.rawConfig.ConsoleLog,
+
+		ForkURL:   p.rawConfig.ForkURL,
+		ForkBlock: p.rawConfig.ForkBlock,
+
 		Relayer:               p.relayer,
 		NumBlockConfirmations: p.rawConfig.NumBlockConfirmations,
 		MetricsInterval:       p.rawConfig.MetricsInterval,
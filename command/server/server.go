@@ -115,7 +115,8 @@ func setFlags(cmd *cobra.Command) {
 		&params.rawConfig.RestoreFile,
 		restoreFlag,
 		"",
-		"the path to the archive blockchain data to restore on initialization",
+		"the path to the archive blockchain data, a backup file, the directory of a chunked backup, "+
+			"a geth RLP export, an Era1 file or directory or a state backup file, to restore on initialization",
 	)
 
 	cmd.Flags().BoolVar(
@@ -185,6 +186,36 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxSenderTxsPerSec,
+		maxSenderTxsPerSecFlag,
+		defaultConfig.TxPool.MaxSenderTxsPerSec,
+		"maximum number of incoming transactions accepted per sender per second (0 for unlimited)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rejectUnprotectedTxs,
+		rejectUnprotectedTxsFlag,
+		false,
+		"reject the legacy transactions signed without the chain ID (pre-EIP-155), which can be replayed from other chains "+
+			"(defaults to the rejectUnprotectedTxs param of the genesis, set for the new chains)",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.TxPool.AllowUnprotectedLocalTxs,
+		allowUnprotectedLocalTxsFlag,
+		defaultConfig.TxPool.AllowUnprotectedLocalTxs,
+		"accept the replay-unprotected transactions submitted through the JSON-RPC and GRPC endpoints",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&params.rawConfig.TxPool.UnprotectedTxsAllowlist,
+		unprotectedTxsAllowlistFlag,
+		defaultConfig.TxPool.UnprotectedTxsAllowlist,
+		"the sender addresses whose replay-unprotected transactions are accepted "+
+			"(e.g. the deployer of a deterministic deployment proxy)",
+	)
+
 	cmd.Flags().StringArrayVar(
 		&params.rawConfig.CorsAllowedOrigins,
 		corsOriginFlag,
@@ -242,6 +273,13 @@ func setFlags(cmd *cobra.Command) {
 		"maximum size in bytes for a message read from the peer by websocket",
 	)
 
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCTxPoolAdmin,
+		jsonRPCTxPoolAdminFlag,
+		defaultConfig.JSONRPCTxPoolAdmin,
+		"enable the txpool_drop* JSON-RPC methods, which remove transactions from the pool",
+	)
+
 	cmd.Flags().DurationVar(
 		&params.rawConfig.MetricsInterval,
 		metricsIntervalFlag,
@@ -249,6 +287,108 @@ func setFlags(cmd *cobra.Command) {
 		"the interval (in seconds) at which special metrics are generated. a value of zero means the metrics are disabled",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.StateScheme,
+		stateSchemeFlag,
+		defaultConfig.StateScheme,
+		"the state kept by the node: \"archive\" keeps the state of every block, "+
+			"\"full\" keeps only the recent and the checkpoint blocks' state",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateRetainedRoots,
+		stateRetainedRootsFlag,
+		defaultConfig.StateRetainedRoots,
+		"the number of the most recent blocks whose state is kept with the full state scheme",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.StateCheckpointInterval,
+		stateCheckpointIntervalFlag,
+		defaultConfig.StateCheckpointInterval,
+		"the interval of the blocks whose state is kept forever with the full state scheme, "+
+			"value of 0 keeps only the genesis state",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.StateFlatSnapshot,
+		stateFlatSnapshotFlag,
+		defaultConfig.StateFlatSnapshot,
+		"serve the reads of the recent states from a flat snapshot instead of the trie, "+
+			"a missing or outdated flat snapshot is regenerated in the background on startup",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.SyncMode,
+		syncModeFlag,
+		defaultConfig.SyncMode,
+		"the way a node with an empty chain catches up: \"full\" executes every block from the genesis, "+
+			"\"snap\" downloads the state of a recent block from the peers and executes the blocks after it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.DBEngine,
+		dbEngineFlag,
+		defaultConfig.DBEngine,
+		"the database engine of the blockchain and state storages, either \"leveldb\" or \"pebble\". "+
+			"A leveldb data directory is converted to pebble with the \"db convert\" command",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.FreezerThreshold,
+		freezerThresholdFlag,
+		defaultConfig.FreezerThreshold,
+		"the number of the blocks behind the head after which the blocks are moved from the database "+
+			"to the append-only freezer in flat files, 0 keeps all the blocks in the database",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.FreezerCompression,
+		freezerCompressionFlag,
+		false,
+		"compress the blocks moved to the freezer, the existing freezer keeps its compression",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.HistoryRetention,
+		historyRetentionFlag,
+		defaultConfig.HistoryRetention,
+		fmt.Sprintf("the number of the most recent blocks whose bodies, receipts and transaction lookups are kept, "+
+			"the older ones are removed in the background (minimum %d), 0 keeps the whole history",
+			config.MinHistoryRetention),
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ParallelExecution,
+		parallelExecutionFlag,
+		false,
+		"execute the transactions of the imported blocks optimistically in parallel, "+
+			"re-executing the transactions which conflict with the previous ones",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.ConsoleLog,
+		consoleLogFlag,
+		false,
+		"enable the Hardhat console.log precompile, printing the messages of the executed transactions "+
+			"to the node log and adding them to the transaction traces (always enabled with the dev consensus)",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.ForkURL,
+		forkURLFlag,
+		"",
+		"the JSON-RPC endpoint of the chain to fork, the accounts, storage and code missing from the local state "+
+			"are read from it and the new blocks are built locally (requires the dev consensus)",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.ForkBlock,
+		forkBlockFlag,
+		0,
+		fmt.Sprintf("the block of the forked chain whose state is read, 0 for the latest block (requires %s)", forkURLFlag),
+	)
+
 	setLegacyFlags(cmd)
 
 	setDevFlags(cmd)
@@ -286,6 +426,15 @@ func setDevFlags(cmd *cobra.Command) {
 	)
 
 	_ = cmd.Flags().MarkHidden(devIntervalFlag)
+
+	cmd.Flags().BoolVar(
+		&params.devAutoMine,
+		devAutoMineFlag,
+		false,
+		"should the client seal a block for every new transaction in dev mode (default false)",
+	)
+
+	_ = cmd.Flags().MarkHidden(devAutoMineFlag)
 }
 
 func runPreRun(cmd *cobra.Command, _ []string) error {
@@ -295,6 +444,10 @@ func runPreRun(cmd *cobra.Command, _ []string) error {
 	params.setRawJSONRPCAddress(helper.GetJSONRPCAddress(cmd))
 	params.setJSONLogFormat(helper.GetJSONLogFormat(cmd))
 
+	if cmd.Flags().Changed(rejectUnprotectedTxsFlag) {
+		params.setRejectUnprotectedTxs(params.rejectUnprotectedTxs)
+	}
+
 	// Check if the config file has been specified
 	// Config file settings will override JSON-RPC and GRPC address values
 	if isConfigFileSpecified(cmd) {
@@ -0,0 +1,92 @@
+package rebuild
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	dataDirFlag  = "data-dir"
+	dbEngineFlag = "db-engine"
+)
+
+var (
+	params = &rebuildParams{}
+)
+
+var (
+	errHeadNotFound = errors.New("head block not found, the blockchain is empty")
+)
+
+type rebuildParams struct {
+	dataDir  string
+	dbEngine string
+
+	header  *types.Header
+	elapsed time.Duration
+}
+
+func (p *rebuildParams) getRequiredFlags() []string {
+	return []string{
+		dataDirFlag,
+	}
+}
+
+func (p *rebuildParams) rebuildSnapshot() error {
+	if err := p.readHeadHeader(); err != nil {
+		return err
+	}
+
+	trieStorage, err := dbengine.OpenStateStorage(dbengine.Engine(p.dbEngine), p.dataDir, hclog.NewNullLogger())
+	if err != nil {
+		return fmt.Errorf("failed to open the state storage: %w", err)
+	}
+
+	defer trieStorage.Close()
+
+	start := time.Now()
+
+	if err := itrie.RebuildFlatSnapshot(trieStorage, p.header.StateRoot); err != nil {
+		return fmt.Errorf("failed to rebuild the flat state snapshot: %w", err)
+	}
+
+	p.elapsed = time.Since(start)
+
+	return nil
+}
+
+func (p *rebuildParams) readHeadHeader() error {
+	chainStorage, err := dbengine.OpenBlockchainStorage(dbengine.Engine(p.dbEngine), p.dataDir, hclog.NewNullLogger())
+	if err != nil {
+		return fmt.Errorf("failed to open the blockchain storage: %w", err)
+	}
+
+	defer chainStorage.Close()
+
+	headHash, ok := chainStorage.ReadHeadHash()
+	if !ok {
+		return errHeadNotFound
+	}
+
+	if p.header, err = chainStorage.ReadHeader(headHash); err != nil {
+		return fmt.Errorf("failed to read the head header: %w", err)
+	}
+
+	return nil
+}
+
+func (p *rebuildParams) getResult() command.CommandResult {
+	return &SnapshotRebuildResult{
+		Number:    p.header.Number,
+		StateRoot: p.header.StateRoot.String(),
+		Elapsed:   p.elapsed.String(),
+	}
+}
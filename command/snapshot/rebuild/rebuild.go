@@ -0,0 +1,51 @@
+package rebuild
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	rebuildCmd := &cobra.Command{
+		Use: "rebuild",
+		Short: "Regenerates the flat state snapshot from the state trie of the head block. " +
+			"The node must be stopped",
+		Run: runCommand,
+	}
+
+	setFlags(rebuildCmd)
+	helper.SetRequiredFlags(rebuildCmd, params.getRequiredFlags())
+
+	return rebuildCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.dataDir,
+		dataDirFlag,
+		"",
+		"the data directory of the stopped node",
+	)
+
+	cmd.Flags().StringVar(
+		&params.dbEngine,
+		dbEngineFlag,
+		string(dbengine.LevelDB),
+		"the database engine of the node, either \"leveldb\" or \"pebble\"",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.rebuildSnapshot(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,28 @@
+package rebuild
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type SnapshotRebuildResult struct {
+	Number    uint64 `json:"number"`
+	StateRoot string `json:"state_root"`
+	Elapsed   string `json:"elapsed"`
+}
+
+func (r *SnapshotRebuildResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SNAPSHOT REBUILD]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Block number|%d", r.Number),
+		fmt.Sprintf("State root|%s", r.StateRoot),
+		fmt.Sprintf("Elapsed|%s", r.Elapsed),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
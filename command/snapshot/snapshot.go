@@ -0,0 +1,24 @@
+package snapshot
+
+import (
+	"github.com/0xPolygon/polygon-edge/command/snapshot/rebuild"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Top level command for managing the flat state snapshot. Only accepts subcommands.",
+	}
+
+	registerSubcommands(snapshotCmd)
+
+	return snapshotCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// snapshot rebuild
+		rebuild.GetCommand(),
+	)
+}
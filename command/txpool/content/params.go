@@ -0,0 +1,79 @@
+package content
+
+import (
+	"context"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+)
+
+var (
+	params = &contentParams{}
+)
+
+const (
+	accountFlag = "account"
+)
+
+type contentParams struct {
+	account string
+
+	txPoolClient txpoolOp.TxnPoolOperatorClient
+
+	content *txpoolOp.ContentFromResp
+}
+
+func (p *contentParams) getRequiredFlags() []string {
+	return []string{
+		accountFlag,
+	}
+}
+
+func (p *contentParams) initTxPoolClient(grpcAddress string) error {
+	txPoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txPoolClient = txPoolClient
+
+	return nil
+}
+
+func (p *contentParams) getContent() error {
+	content, err := p.txPoolClient.ContentFrom(
+		context.Background(),
+		&txpoolOp.AccountReq{
+			Address: p.account,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	p.content = content
+
+	return nil
+}
+
+func (p *contentParams) getResult() command.CommandResult {
+	toContentTxs := func(poolTxns []*txpoolOp.PoolTxn) []ContentTx {
+		txs := make([]ContentTx, len(poolTxns))
+
+		for i, poolTxn := range poolTxns {
+			txs[i] = ContentTx{
+				Hash:  poolTxn.Hash,
+				Nonce: poolTxn.Nonce,
+			}
+		}
+
+		return txs
+	}
+
+	return &TxPoolContentResult{
+		Account: p.account,
+		Pending: toContentTxs(p.content.Pending),
+		Queued:  toContentTxs(p.content.Queued),
+	}
+}
@@ -0,0 +1,55 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type ContentTx struct {
+	Hash  string `json:"hash"`
+	Nonce uint64 `json:"nonce"`
+}
+
+type TxPoolContentResult struct {
+	Account string      `json:"account"`
+	Pending []ContentTx `json:"pending"`
+	Queued  []ContentTx `json:"queued"`
+}
+
+func (r *TxPoolContentResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	formatTxs := func(txs []ContentTx) string {
+		rows := make([]string, len(txs)+1)
+		rows[0] = "Nonce|Hash"
+
+		for i, tx := range txs {
+			rows[i+1] = fmt.Sprintf("%d|%s", tx.Nonce, tx.Hash)
+		}
+
+		return helper.FormatList(rows)
+	}
+
+	buffer.WriteString("\n[TXPOOL CONTENT]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Account|%s", r.Account),
+		fmt.Sprintf("Pending transactions|%d", len(r.Pending)),
+		fmt.Sprintf("Queued transactions|%d", len(r.Queued)),
+	}))
+
+	if len(r.Pending) > 0 {
+		buffer.WriteString("\n\n[PENDING TRANSACTIONS]\n")
+		buffer.WriteString(formatTxs(r.Pending))
+	}
+
+	if len(r.Queued) > 0 {
+		buffer.WriteString("\n\n[QUEUED TRANSACTIONS]\n")
+		buffer.WriteString(formatTxs(r.Queued))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
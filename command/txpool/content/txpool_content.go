@@ -0,0 +1,48 @@
+package content
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolContentCmd := &cobra.Command{
+		Use:   "content",
+		Short: "Returns the pending and queued transactions of an account in the transaction pool",
+		Run:   runCommand,
+	}
+
+	setFlags(txPoolContentCmd)
+	helper.SetRequiredFlags(txPoolContentCmd, params.getRequiredFlags())
+
+	return txPoolContentCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.account,
+		accountFlag,
+		"",
+		"the address of the account",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.getContent(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
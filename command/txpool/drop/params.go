@@ -0,0 +1,108 @@
+package drop
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	txpoolOp "github.com/0xPolygon/polygon-edge/txpool/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	params = &dropParams{}
+)
+
+var (
+	errInvalidTarget = errors.New("exactly one of the tx, account or all flags is required")
+)
+
+const (
+	txFlag      = "tx"
+	accountFlag = "account"
+	allFlag     = "all"
+)
+
+type dropParams struct {
+	txHash  string
+	account string
+	all     bool
+
+	txPoolClient txpoolOp.TxnPoolOperatorClient
+
+	droppedTxs []string
+}
+
+func (p *dropParams) validateFlags() error {
+	targets := 0
+
+	if p.txHash != "" {
+		targets++
+	}
+
+	if p.account != "" {
+		targets++
+	}
+
+	if p.all {
+		targets++
+	}
+
+	if targets != 1 {
+		return errInvalidTarget
+	}
+
+	return nil
+}
+
+func (p *dropParams) initTxPoolClient(grpcAddress string) error {
+	txPoolClient, err := helper.GetTxPoolClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	p.txPoolClient = txPoolClient
+
+	return nil
+}
+
+func (p *dropParams) dropTxs() error {
+	var (
+		resp *txpoolOp.DropResp
+		err  error
+	)
+
+	switch {
+	case p.txHash != "":
+		resp, err = p.txPoolClient.DropTx(
+			context.Background(),
+			&txpoolOp.DropTxReq{
+				Hash: p.txHash,
+			},
+		)
+	case p.account != "":
+		resp, err = p.txPoolClient.DropAccount(
+			context.Background(),
+			&txpoolOp.AccountReq{
+				Address: p.account,
+			},
+		)
+	default:
+		resp, err = p.txPoolClient.Clear(context.Background(), &empty.Empty{})
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.droppedTxs = resp.TxHashes
+
+	return nil
+}
+
+func (p *dropParams) getResult() command.CommandResult {
+	return &TxPoolDropResult{
+		TxHashes: p.droppedTxs,
+	}
+}
@@ -0,0 +1,30 @@
+package drop
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/command/helper"
+)
+
+type TxPoolDropResult struct {
+	TxHashes []string `json:"tx_hashes"`
+}
+
+func (r *TxPoolDropResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[TXPOOL DROP]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Dropped transactions|%d", len(r.TxHashes)),
+	}))
+
+	if len(r.TxHashes) > 0 {
+		buffer.WriteString("\n\n[LIST OF DROPPED TRANSACTIONS]\n")
+		buffer.WriteString(helper.FormatList(r.TxHashes))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,69 @@
+package drop
+
+import (
+	"github.com/0xPolygon/polygon-edge/command"
+	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	txPoolDropCmd := &cobra.Command{
+		Use: "drop",
+		Short: "Removes a transaction, together with the subsequent transactions of its sender, " +
+			"all the transactions of an account, or all the transactions from the transaction pool",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(txPoolDropCmd)
+
+	return txPoolDropCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.txHash,
+		txFlag,
+		"",
+		"the hash of the transaction to drop",
+	)
+
+	cmd.Flags().StringVar(
+		&params.account,
+		accountFlag,
+		"",
+		"the address of the account whose transactions are dropped",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.all,
+		allFlag,
+		false,
+		"drop all the transactions from the pool",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive(txFlag, accountFlag, allFlag)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.validateFlags()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.initTxPoolClient(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	if err := params.dropTxs(); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
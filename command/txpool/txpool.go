@@ -2,6 +2,8 @@ package txpool
 
 import (
 	"github.com/0xPolygon/polygon-edge/command/helper"
+	"github.com/0xPolygon/polygon-edge/command/txpool/content"
+	"github.com/0xPolygon/polygon-edge/command/txpool/drop"
 	"github.com/0xPolygon/polygon-edge/command/txpool/status"
 	"github.com/0xPolygon/polygon-edge/command/txpool/subscribe"
 	"github.com/spf13/cobra"
@@ -26,5 +28,9 @@ func registerSubcommands(baseCmd *cobra.Command) {
 		status.GetCommand(),
 		// txpool subscribe
 		subscribe.GetCommand(),
+		// txpool content
+		content.GetCommand(),
+		// txpool drop
+		drop.GetCommand(),
 	)
 }
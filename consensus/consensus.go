@@ -11,6 +11,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -86,6 +87,7 @@ type Params struct {
 
 	NumBlockConfirmations uint64
 	MetricsInterval       time.Duration
+	SnapSync              *syncer.SnapSyncConfig
 }
 
 // Factory is the factory function to create a discovery consensus
@@ -0,0 +1,344 @@
+package dev
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	ErrInvalidTimestamp       = errors.New("timestamp must be greater than the timestamp of the latest block")
+	ErrAccountNotImpersonated = errors.New("account is not impersonated")
+)
+
+// blockOverride is the state override applied at the end of a sealed block
+type blockOverride struct {
+	hash     types.Hash
+	override types.StateOverride
+}
+
+// snapshot is a chain state the node can be reverted to
+type snapshot struct {
+	id         uint64
+	number     uint64
+	timeOffset int64
+}
+
+// cheats holds the state of the Hardhat and Anvil compatible node controls.
+// It is guarded by the lock of the dev consensus
+type cheats struct {
+	// timeOffset is added to the wall clock to get the timestamp of the next block
+	timeOffset int64
+	// timestamp is the exact timestamp of the next block if it is not zero
+	timestamp uint64
+
+	// override is applied at the end of the next block
+	override types.StateOverride
+
+	impersonated map[types.Address]struct{}
+	// pendingTxs are the transactions sent on behalf of the impersonated accounts
+	pendingTxs []*types.Transaction
+
+	snapshots      []snapshot
+	nextSnapshotID uint64
+}
+
+func newCheats() *cheats {
+	return &cheats{
+		override:     types.StateOverride{},
+		impersonated: map[types.Address]struct{}{},
+	}
+}
+
+// nextTimestamp returns the timestamp of the block following the parent. The block timestamps
+// are strictly increasing, even if the blocks are sealed within the same second
+func (c *cheats) nextTimestamp(parent *types.Header, now time.Time) uint64 {
+	if c.timestamp != 0 {
+		timestamp := c.timestamp
+
+		// the following blocks continue from the set timestamp
+		c.timestamp = 0
+		c.timeOffset = int64(timestamp) - now.Unix()
+
+		return timestamp
+	}
+
+	timestamp := now.Unix() + c.timeOffset
+	if timestamp <= int64(parent.Timestamp) {
+		return parent.Timestamp + 1
+	}
+
+	return uint64(timestamp)
+}
+
+// overrideAccount updates the pending override of the given account
+func (c *cheats) overrideAccount(addr types.Address, update func(account *types.OverrideAccount)) {
+	account := c.override[addr]
+	update(&account)
+	c.override[addr] = account
+}
+
+// sealed clears the override and the impersonated transactions once they are part of a block
+func (c *cheats) sealed(withTxs bool) {
+	c.override = types.StateOverride{}
+
+	if withTxs {
+		c.pendingTxs = nil
+	}
+}
+
+// Mine seals a new block immediately. A non-zero timestamp is used as the block timestamp
+func (d *Dev) Mine(timestamp uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	parent := d.blockchain.Header()
+
+	if timestamp != 0 {
+		if timestamp <= parent.Timestamp {
+			return fmt.Errorf("%w: %d", ErrInvalidTimestamp, parent.Timestamp)
+		}
+
+		d.cheats.timestamp = timestamp
+	}
+
+	return d.writeNewBlock(parent, true)
+}
+
+// IncreaseTime moves the clock of the following blocks forward by the given number of seconds.
+// Returns the total time offset in seconds
+func (d *Dev) IncreaseTime(seconds uint64) int64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.cheats.timeOffset += int64(seconds)
+
+	return d.cheats.timeOffset
+}
+
+// SetNextBlockTimestamp sets the exact timestamp of the next block
+func (d *Dev) SetNextBlockTimestamp(timestamp uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if head := d.blockchain.Header(); timestamp <= head.Timestamp {
+		return fmt.Errorf("%w: %d", ErrInvalidTimestamp, head.Timestamp)
+	}
+
+	d.cheats.timestamp = timestamp
+
+	return nil
+}
+
+// SetAutoMine switches the on-demand sealing of a block for every new transaction
+func (d *Dev) SetAutoMine(enabled bool) {
+	d.autoMine.Store(enabled)
+}
+
+// AutoMine returns if a block is sealed for every new transaction
+func (d *Dev) AutoMine() bool {
+	return d.autoMine.Load()
+}
+
+// Snapshot records the current chain state and returns its id for Revert
+func (d *Dev) Snapshot() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	id := d.cheats.nextSnapshotID
+	d.cheats.nextSnapshotID++
+
+	d.cheats.snapshots = append(d.cheats.snapshots, snapshot{
+		id:         id,
+		number:     d.blockchain.Header().Number,
+		timeOffset: d.cheats.timeOffset,
+	})
+
+	return id
+}
+
+// Revert rewinds the chain to the snapshot with the given id. The snapshot and all the later
+// ones are removed, as are the transactions in the pool. Returns false if the snapshot is unknown
+func (d *Dev) Revert(id uint64) (bool, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	index := -1
+
+	for i, snapshot := range d.cheats.snapshots {
+		if snapshot.id == id {
+			index = i
+
+			break
+		}
+	}
+
+	if index < 0 {
+		return false, nil
+	}
+
+	snapshot := d.cheats.snapshots[index]
+
+	if d.blockchain.Header().Number > snapshot.number {
+		if err := d.blockchain.Rewind(snapshot.number); err != nil {
+			return false, err
+		}
+	}
+
+	d.cheats.snapshots = d.cheats.snapshots[:index]
+	d.cheats.timeOffset = snapshot.timeOffset
+	d.cheats.timestamp = 0
+	d.cheats.override = types.StateOverride{}
+	d.cheats.pendingTxs = nil
+
+	// the pool expects the nonces of the reverted blocks
+	d.txpool.ResyncAccounts()
+
+	return true, nil
+}
+
+// SetBalance sets the balance of the account in a new block
+func (d *Dev) SetBalance(addr types.Address, balance *big.Int) error {
+	return d.setAccount(addr, func(account *types.OverrideAccount) {
+		account.Balance = new(big.Int).Set(balance)
+	})
+}
+
+// SetCode sets the code of the account in a new block
+func (d *Dev) SetCode(addr types.Address, code []byte) error {
+	return d.setAccount(addr, func(account *types.OverrideAccount) {
+		account.Code = code
+	})
+}
+
+// SetStorageAt sets a storage slot of the account in a new block.
+// As with any empty account, the storage of an account without balance, nonce and code is cleared
+func (d *Dev) SetStorageAt(addr types.Address, slot, value types.Hash) error {
+	return d.setAccount(addr, func(account *types.OverrideAccount) {
+		if account.StateDiff == nil {
+			account.StateDiff = map[types.Hash]types.Hash{}
+		}
+
+		account.StateDiff[slot] = value
+	})
+}
+
+// SetNonce sets the nonce of the account in a new block
+func (d *Dev) SetNonce(addr types.Address, nonce uint64) error {
+	if err := d.setAccount(addr, func(account *types.OverrideAccount) {
+		account.Nonce = &nonce
+	}); err != nil {
+		return err
+	}
+
+	// the pool expects the previous nonce of the account
+	d.txpool.ResyncAccounts(addr)
+
+	return nil
+}
+
+// setAccount overrides the account and seals a block without transactions,
+// so that the change is visible in the latest state right away
+func (d *Dev) setAccount(addr types.Address, update func(account *types.OverrideAccount)) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.cheats.overrideAccount(addr, update)
+
+	return d.writeNewBlock(d.blockchain.Header(), false)
+}
+
+// ImpersonateAccount allows sending unsigned transactions on behalf of the account
+func (d *Dev) ImpersonateAccount(addr types.Address) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.cheats.impersonated[addr] = struct{}{}
+}
+
+// StopImpersonatingAccount stops accepting unsigned transactions of the account
+func (d *Dev) StopImpersonatingAccount(addr types.Address) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	delete(d.cheats.impersonated, addr)
+}
+
+// IsImpersonated checks if unsigned transactions of the account are accepted
+func (d *Dev) IsImpersonated(addr types.Address) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	_, ok := d.cheats.impersonated[addr]
+
+	return ok
+}
+
+// SendImpersonatedTransaction queues the unsigned transaction of an impersonated account
+// for the next block, which is sealed right away in the auto-mine mode. If fillNonce is set,
+// the transaction follows the transactions of the account which are not sealed yet.
+// The transaction gets the signature of the sender and its hash
+func (d *Dev) SendImpersonatedTransaction(tx *types.Transaction, fillNonce bool) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if _, ok := d.cheats.impersonated[tx.From]; !ok {
+		return fmt.Errorf("%w: %s", ErrAccountNotImpersonated, tx.From)
+	}
+
+	if fillNonce {
+		tx.Nonce = d.nextImpersonatedNonce(tx.From)
+	}
+
+	tx.V, tx.R, tx.S = impersonatedSignature(tx.From)
+	tx.ComputeHash(d.blockchain.Header().Number)
+
+	d.cheats.pendingTxs = append(d.cheats.pendingTxs, tx)
+
+	if !d.autoMine.Load() {
+		return nil
+	}
+
+	return d.writeNewBlock(d.blockchain.Header(), true)
+}
+
+// nextImpersonatedNonce returns the nonce of the next transaction of the impersonated account,
+// following its transactions in the pool and its queued impersonated transactions
+func (d *Dev) nextImpersonatedNonce(addr types.Address) uint64 {
+	nonce := d.txpool.GetNonce(addr)
+
+	for _, tx := range d.cheats.pendingTxs {
+		if tx.From == addr && tx.Nonce >= nonce {
+			nonce = tx.Nonce + 1
+		}
+	}
+
+	return nonce
+}
+
+// impersonatedSignature returns the signature values of the unsigned transactions of the account.
+// As in Anvil, the sender is the R value, so that the same transactions of different
+// impersonated accounts don't share the hash
+func impersonatedSignature(from types.Address) (v, r, s *big.Int) {
+	return big.NewInt(0), new(big.Int).SetBytes(from.Bytes()), big.NewInt(1)
+}
+
+// writeImpersonatedTransactions applies the pending transactions of the impersonated accounts
+func (d *Dev) writeImpersonatedTransactions(transition transitionInterface) []*types.Transaction {
+	successful := make([]*types.Transaction, 0, len(d.cheats.pendingTxs))
+
+	for _, tx := range d.cheats.pendingTxs {
+		if err := transition.Write(tx); err != nil {
+			d.logger.Warn("failed to apply impersonated transaction", "hash", tx.Hash, "from", tx.From, "err", err)
+
+			continue
+		}
+
+		successful = append(successful, tx)
+	}
+
+	return successful
+}
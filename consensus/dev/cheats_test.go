@@ -0,0 +1,142 @@
+package dev
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestCheats_NextTimestamp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_000, 0)
+
+	cases := []struct {
+		name       string
+		timeOffset int64
+		timestamp  uint64
+		parent     uint64
+		expected   uint64
+	}{
+		{"wall clock", 0, 0, 900, 1_000},
+		{"time offset", 50, 0, 900, 1_050},
+		{"same second as the parent", 0, 0, 1_000, 1_001},
+		{"parent ahead of the clock", 0, 0, 1_200, 1_201},
+		{"exact timestamp", 50, 2_000, 900, 2_000},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			cheats := &cheats{timeOffset: c.timeOffset, timestamp: c.timestamp}
+
+			assert.Equal(t, c.expected, cheats.nextTimestamp(&types.Header{Timestamp: c.parent}, now))
+		})
+	}
+
+	// the blocks following the exact timestamp continue from it
+	cheats := &cheats{timestamp: 2_000}
+
+	assert.Equal(t, uint64(2_000), cheats.nextTimestamp(&types.Header{Timestamp: 900}, now))
+	assert.Zero(t, cheats.timestamp)
+	assert.Equal(t, uint64(2_010), cheats.nextTimestamp(&types.Header{Timestamp: 2_000}, now.Add(10*time.Second)))
+}
+
+func TestDev_Revert(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDev(t)
+
+	id := d.Snapshot()
+	d.IncreaseTime(100)
+
+	d.addTx(t, 0)
+	require.NoError(t, d.Mine(0))
+	require.Equal(t, uint64(1), d.blockchain.Header().Number)
+	require.Equal(t, uint64(1), d.account(t, d.addr).Nonce)
+
+	// the pool follows the transaction of the reverted block
+	d.addTx(t, 1)
+	require.Equal(t, uint64(1), d.txpool.Length())
+
+	reverted, err := d.Revert(id)
+	require.NoError(t, err)
+	require.True(t, reverted)
+
+	assert.Equal(t, uint64(0), d.blockchain.Header().Number)
+	assert.Equal(t, int64(0), d.cheats.timeOffset)
+
+	// the pool expects the nonce of the reverted chain again
+	assert.Equal(t, uint64(0), d.txpool.GetNonce(d.addr))
+	assert.Zero(t, d.txpool.Length())
+
+	// the snapshot is removed
+	reverted, err = d.Revert(id)
+	require.NoError(t, err)
+	assert.False(t, reverted)
+}
+
+func TestDev_SendImpersonatedTransaction(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDev(t)
+	d.SetAutoMine(false)
+
+	first := types.StringToAddress("0x10")
+	second := types.StringToAddress("0x20")
+	to := types.StringToAddress("0x30")
+
+	newTx := func(from types.Address) *types.Transaction {
+		return &types.Transaction{From: from, To: &to, Value: big.NewInt(0), Gas: 21000, GasPrice: big.NewInt(0)}
+	}
+
+	require.ErrorIs(t, d.SendImpersonatedTransaction(newTx(first), true), ErrAccountNotImpersonated)
+
+	d.ImpersonateAccount(first)
+	d.ImpersonateAccount(second)
+
+	// the transactions sent before the next block get the following nonces
+	tx1, tx2 := newTx(first), newTx(first)
+
+	require.NoError(t, d.SendImpersonatedTransaction(tx1, true))
+	require.NoError(t, d.SendImpersonatedTransaction(tx2, true))
+
+	assert.Equal(t, uint64(0), tx1.Nonce)
+	assert.Equal(t, uint64(1), tx2.Nonce)
+
+	// the same transaction of another sender has a different hash
+	tx3 := newTx(second)
+
+	require.NoError(t, d.SendImpersonatedTransaction(tx3, true))
+	assert.Equal(t, uint64(0), tx3.Nonce)
+	assert.NotEqual(t, tx1.Hash, tx3.Hash)
+
+	require.NoError(t, d.Mine(0))
+
+	block, ok := d.blockchain.GetBlockByNumber(1, true)
+	require.True(t, ok)
+	require.Len(t, block.Transactions, 3)
+	assert.Equal(t, uint64(2), d.account(t, first).Nonce)
+	assert.Equal(t, uint64(1), d.account(t, second).Nonce)
+
+	// the sealed transactions are followed
+	tx4 := newTx(first)
+
+	require.NoError(t, d.SendImpersonatedTransaction(tx4, true))
+	assert.Equal(t, uint64(2), tx4.Nonce)
+
+	// the explicit nonce is kept
+	tx5 := newTx(second)
+	tx5.Nonce = 5
+
+	require.NoError(t, d.SendImpersonatedTransaction(tx5, false))
+	assert.Equal(t, uint64(5), tx5.Nonce)
+}
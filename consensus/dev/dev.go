@@ -1,7 +1,10 @@
 package dev
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
@@ -9,26 +12,38 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 )
 
 const (
 	devConsensus = "dev-consensus"
+
+	// KeyInterval is the engine config key of the block sealing interval in seconds
+	KeyInterval = "interval"
+	// KeyAutoMine is the engine config key of the on-demand sealing mode,
+	// which seals a block for every new transaction
+	KeyAutoMine = "autoMine"
 )
 
 // Dev consensus protocol seals any new transaction immediately
 type Dev struct {
 	logger hclog.Logger
 
-	notifyCh chan struct{}
-	closeCh  chan struct{}
+	closeCh chan struct{}
 
 	interval uint64
+	autoMine atomic.Bool
 	txpool   *txpool.TxPool
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	// lock serializes the block sealing with the cheat codes changing the chain
+	lock     sync.Mutex
+	cheats   *cheats
+	override *blockOverride
 }
 
 // Factory implements the base factory method
@@ -39,14 +54,14 @@ func Factory(
 
 	d := &Dev{
 		logger:     logger,
-		notifyCh:   make(chan struct{}),
 		closeCh:    make(chan struct{}),
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
+		cheats:     newCheats(),
 	}
 
-	rawInterval, ok := params.Config.Config["interval"]
+	rawInterval, ok := params.Config.Config[KeyInterval]
 	if ok {
 		interval, ok := rawInterval.(uint64)
 		if !ok {
@@ -56,6 +71,21 @@ func Factory(
 		d.interval = interval
 	}
 
+	rawAutoMine, ok := params.Config.Config[KeyAutoMine]
+	if ok {
+		autoMine, ok := rawAutoMine.(bool)
+		if !ok {
+			return nil, fmt.Errorf("autoMine expected bool")
+		}
+
+		d.autoMine.Store(autoMine)
+	}
+
+	// without an explicit interval the auto-mine mode seals blocks only on demand
+	if d.interval == 0 && !d.autoMine.Load() {
+		d.interval = 1
+	}
+
 	return d, nil
 }
 
@@ -73,38 +103,56 @@ func (d *Dev) Start() error {
 	return nil
 }
 
-func (d *Dev) nextNotify() chan struct{} {
-	if d.interval == 0 {
-		d.interval = 1
+func (d *Dev) run() {
+	d.logger.Info("consensus started", "interval", d.interval, "autoMine", d.autoMine.Load())
+
+	var tickerCh <-chan time.Time
+
+	if d.interval > 0 {
+		ticker := time.NewTicker(time.Duration(d.interval) * time.Second)
+		defer ticker.Stop()
+
+		tickerCh = ticker.C
 	}
 
-	go func() {
-		<-time.After(time.Duration(d.interval) * time.Second)
-		d.notifyCh <- struct{}{}
-	}()
+	// a promoted transaction is ready to be sealed
+	promotedCh, unsubscribe, err := d.txpool.TxPoolSubscribe(&proto.SubscribeRequest{
+		Types: []proto.EventType{proto.EventType_PROMOTED},
+	})
+	if err != nil {
+		d.logger.Error("failed to subscribe to the txpool events", "err", err)
 
-	return d.notifyCh
-}
+		return
+	}
 
-func (d *Dev) run() {
-	d.logger.Info("consensus started")
+	defer unsubscribe()
 
 	for {
-		// wait until there is a new txn
 		select {
-		case <-d.nextNotify():
+		case <-tickerCh:
+		case <-promotedCh:
+			// the transactions of the earlier events may be sealed already
+			if !d.autoMine.Load() || d.txpool.Length() == 0 {
+				continue
+			}
 		case <-d.closeCh:
 			return
 		}
 
-		// There are new transactions in the pool, try to seal them
-		header := d.blockchain.Header()
-		if err := d.writeNewBlock(header); err != nil {
+		if err := d.mine(); err != nil {
 			d.logger.Error("failed to mine block", "err", err)
 		}
 	}
 }
 
+// mine seals a new block with the transactions from the pool on top of the current head
+func (d *Dev) mine() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.writeNewBlock(d.blockchain.Header(), true)
+}
+
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
 }
@@ -127,7 +175,12 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 		}
 
 		if err := transition.Write(tx); err != nil {
-			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+			if errors.Is(err, state.ErrStateUnavailable) {
+				// the block is not sealed, the transaction is applied again in the next one
+				d.txpool.Demote(tx)
+
+				break
+			} else if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 				break
 			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
 				d.txpool.Demote(tx)
@@ -150,15 +203,16 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 }
 
 // writeNewBLock generates a new block based on transactions from the pool,
-// and writes them to the blockchain
-func (d *Dev) writeNewBlock(parent *types.Header) error {
+// and writes them to the blockchain. The pending state overrides are applied
+// after the transactions. It must be called with the lock held
+func (d *Dev) writeNewBlock(parent *types.Header, withTxs bool) error {
 	// Generate the base block
 	num := parent.Number
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     num + 1,
 		GasLimit:   parent.GasLimit, // Inherit from parent for now, will need to adjust dynamically later.
-		Timestamp:  uint64(time.Now().UTC().Unix()),
+		Timestamp:  d.cheats.nextTimestamp(parent, time.Now().UTC()),
 	}
 
 	// calculate gas limit based on parent header
@@ -181,7 +235,18 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
-	txns := d.writeTransactions(gasLimit, transition)
+	var txns []*types.Transaction
+
+	if withTxs {
+		// the transactions of the impersonated accounts are not signed and bypass the pool
+		txns = d.writeImpersonatedTransactions(transition)
+		txns = append(txns, d.writeTransactions(gasLimit, transition)...)
+	}
+
+	override := d.cheats.override
+	if err := transition.WithStateOverride(override); err != nil {
+		return err
+	}
 
 	// Commit the changes
 	_, root, err := transition.Commit()
@@ -201,6 +266,12 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		Receipts: transition.Receipts(),
 	})
 
+	// the verification executes the block again and applies the override in PreCommitState
+	d.override = &blockOverride{hash: block.Hash(), override: override}
+	defer func() {
+		d.override = nil
+	}()
+
 	if _, err := d.blockchain.VerifyFinalizedBlock(block); err != nil {
 		return err
 	}
@@ -210,6 +281,8 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
+	d.cheats.sealed(withTxs)
+
 	// after the block has been written we reset the txpool so that
 	// the old transactions are removed
 	d.txpool.ResetWithHeaders(block.Header)
@@ -224,6 +297,12 @@ func (d *Dev) VerifyHeader(header *types.Header) error {
 	return nil
 }
 
+// VerifyHeaders implements the blockchain.HeadersVerifier interface
+func (d *Dev) VerifyHeaders(parents []*types.Header, headers []*types.Header) error {
+	// All blocks are valid
+	return nil
+}
+
 func (d *Dev) ProcessHeaders(headers []*types.Header) error {
 	return nil
 }
@@ -233,7 +312,12 @@ func (d *Dev) GetBlockCreator(header *types.Header) (types.Address, error) {
 }
 
 // PreCommitState a hook to be called before finalizing state transition on inserting block
-func (d *Dev) PreCommitState(_ *types.Block, _ *state.Transition) error {
+func (d *Dev) PreCommitState(block *types.Block, txn *state.Transition) error {
+	// the override of a block sealed by this node is not part of the block itself
+	if override := d.override; override != nil && override.hash == block.Hash() {
+		return txn.WithStateOverride(override.override)
+	}
+
 	return nil
 }
 
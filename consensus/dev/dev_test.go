@@ -0,0 +1,217 @@
+package dev
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/consensus"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/txpool"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const testChainID = 100
+
+var testBalance = big.NewInt(1_000_000_000_000_000_000)
+
+// testDev is the dev consensus sealing the blocks of an in-memory chain
+type testDev struct {
+	*Dev
+
+	state  state.State
+	signer crypto.TxSigner
+	key    *ecdsa.PrivateKey
+	// addr is the account of the key, premined in the genesis
+	addr types.Address
+}
+
+// txpoolStore reads the pool accounts from the state of the chain
+type txpoolStore struct {
+	*blockchain.Blockchain
+	state state.State
+}
+
+func (s *txpoolStore) GetNonce(root types.Hash, addr types.Address) uint64 {
+	account, err := s.account(root, addr)
+	if err != nil || account == nil {
+		return 0
+	}
+
+	return account.Nonce
+}
+
+func (s *txpoolStore) GetBalance(root types.Hash, addr types.Address) (*big.Int, error) {
+	account, err := s.account(root, addr)
+	if err != nil || account == nil {
+		return big.NewInt(0), err
+	}
+
+	return account.Balance, nil
+}
+
+func (s *txpoolStore) account(root types.Hash, addr types.Address) (*state.Account, error) {
+	snap, err := s.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return snap.GetAccount(addr)
+}
+
+// newTestDev creates the dev consensus on top of a chain with the genesis block only,
+// sealing the blocks on demand
+func newTestDev(t *testing.T) *testDev {
+	t.Helper()
+
+	key, err := crypto.GenerateECDSAKey()
+	require.NoError(t, err)
+
+	addr := crypto.PubKeyToAddress(&key.PublicKey)
+	logger := hclog.NewNullLogger()
+
+	forks := chain.AllForksEnabled.Copy().RemoveFork(chain.London)
+	config := &chain.Chain{
+		Genesis: &chain.Genesis{
+			GasLimit: 5_000_000,
+			Alloc: map[types.Address]*chain.GenesisAccount{
+				addr: {Balance: testBalance},
+			},
+		},
+		Params: &chain.Params{
+			ChainID: testChainID,
+			Forks:   forks,
+		},
+	}
+
+	st := itrie.NewState(itrie.NewMemoryStorage())
+	executor := state.NewExecutor(config.Params, st, logger)
+
+	config.Genesis.StateRoot, err = executor.WriteGenesis(config.Genesis.Alloc, types.ZeroHash)
+	require.NoError(t, err)
+
+	db, err := memory.NewMemoryStorage(nil)
+	require.NoError(t, err)
+
+	signer := crypto.NewSigner(forks.At(0), testChainID)
+
+	bc, err := blockchain.NewBlockchain(logger, db, config, nil, executor, signer)
+	require.NoError(t, err)
+
+	executor.GetHash = bc.GetHashHelper
+
+	pool, err := txpool.NewTxPool(logger, forks, &txpoolStore{Blockchain: bc, state: st}, nil, nil,
+		&txpool.Config{
+			MaxSlots:           4096,
+			MaxAccountEnqueued: 128,
+			ChainID:            big.NewInt(testChainID),
+		})
+	require.NoError(t, err)
+
+	pool.SetSigner(signer)
+	pool.Start()
+	t.Cleanup(pool.Close)
+
+	engine, err := Factory(&consensus.Params{
+		Config:     &consensus.Config{Params: config.Params, Config: map[string]interface{}{KeyAutoMine: true}},
+		TxPool:     pool,
+		Blockchain: bc,
+		Executor:   executor,
+		Logger:     logger,
+	})
+	require.NoError(t, err)
+
+	d, ok := engine.(*Dev)
+	require.True(t, ok)
+
+	bc.SetConsensus(d)
+	require.NoError(t, bc.ComputeGenesis())
+	require.NoError(t, d.Initialize())
+
+	return &testDev{Dev: d, state: st, signer: signer, key: key, addr: addr}
+}
+
+// account returns the account in the state of the latest block
+func (d *testDev) account(t *testing.T, addr types.Address) *state.Account {
+	t.Helper()
+
+	snap, err := d.state.NewSnapshotAt(d.blockchain.Header().StateRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr)
+	require.NoError(t, err)
+
+	return account
+}
+
+// addTx adds the transfer of the premined account with the given nonce to the pool,
+// and waits until it is promoted
+func (d *testDev) addTx(t *testing.T, nonce uint64) *types.Transaction {
+	t.Helper()
+
+	to := types.StringToAddress("0x1")
+
+	tx, err := d.signer.SignTx(&types.Transaction{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    big.NewInt(1),
+		Gas:      21000,
+		GasPrice: big.NewInt(1),
+	}, d.key)
+	require.NoError(t, err)
+
+	require.NoError(t, d.txpool.AddTx(tx))
+	require.Eventually(t, func() bool {
+		return d.txpool.GetNonce(d.addr) == nonce+1
+	}, time.Second, 10*time.Millisecond)
+
+	return tx
+}
+
+func TestDev_PreCommitState(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDev(t)
+	addr := types.StringToAddress("0x2")
+
+	// the block is verified with the override which isn't part of it
+	require.NoError(t, d.SetBalance(addr, big.NewInt(100)))
+	require.NoError(t, d.SetCode(addr, []byte{0x1}))
+
+	head := d.blockchain.Header()
+	assert.Equal(t, uint64(2), head.Number)
+
+	account := d.account(t, addr)
+	require.NotNil(t, account)
+	assert.Equal(t, big.NewInt(100), account.Balance)
+
+	// the override is not applied to the other blocks
+	transition, err := d.executor.BeginTxn(head.StateRoot, &types.Header{Number: 3}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	d.override = &blockOverride{
+		hash:     types.StringToHash("0x1"),
+		override: types.StateOverride{addr: {Balance: big.NewInt(200)}},
+	}
+
+	block := &types.Block{Header: &types.Header{Number: 3}}
+	block.Header.ComputeHash()
+
+	require.NoError(t, d.PreCommitState(block, transition))
+	assert.Equal(t, big.NewInt(100), transition.GetBalance(addr))
+
+	d.override.hash = block.Hash()
+
+	require.NoError(t, d.PreCommitState(block, transition))
+	assert.Equal(t, big.NewInt(200), transition.GetBalance(addr))
+}
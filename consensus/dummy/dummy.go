@@ -52,6 +52,12 @@ func (d *Dummy) VerifyHeader(header *types.Header) error {
 	return nil
 }
 
+// VerifyHeaders implements the blockchain.HeadersVerifier interface
+func (d *Dummy) VerifyHeaders(parents []*types.Header, headers []*types.Header) error {
+	// All blocks are valid
+	return nil
+}
+
 func (d *Dummy) ProcessHeaders(headers []*types.Header) error {
 	return nil
 }
@@ -205,6 +205,9 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	writeCtx, cancelFn := context.WithDeadline(context.Background(), potentialTimestamp)
 	defer cancelFn()
 
+	// the state of the pending transactions is loaded while they are written one by one
+	stopPrefetch := transition.Prefetch(i.pendingTxs())
+
 	txs := i.writeTransactions(
 		writeCtx,
 		gasLimit,
@@ -212,6 +215,8 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 		transition,
 	)
 
+	stopPrefetch()
+
 	// provide dummy block instance to the PreCommitState
 	// (for the IBFT consensus, it is correct to have just a header, as only it is used)
 	if err := i.PreCommitState(&types.Block{Header: header}, transition); err != nil {
@@ -250,6 +255,18 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	return block, nil
 }
 
+// pendingTxs returns the promoted transactions of the pool, which are candidates for the block
+func (i *backendIBFT) pendingTxs() []*types.Transaction {
+	promoted, _ := i.txpool.GetTxs(false)
+
+	txs := []*types.Transaction{}
+	for _, accountTxs := range promoted {
+		txs = append(txs, accountTxs...)
+	}
+
+	return txs
+}
+
 // calcHeaderTimestamp calculates the new block timestamp, based
 // on the block time and parent timestamp
 func (i *backendIBFT) calcHeaderTimestamp(parentUnix uint64, currentTime time.Time) time.Time {
@@ -290,6 +307,8 @@ type txExeResult struct {
 
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
+	WriteBundle(txs []*types.Transaction) error
+	TotalGas() uint64
 }
 
 func (i *backendIBFT) writeTransactions(
@@ -308,6 +327,7 @@ func (i *backendIBFT) writeTransactions(
 		successful = 0
 		failed     = 0
 		skipped    = 0
+		bundles    = 0
 	)
 
 	defer func() {
@@ -316,10 +336,21 @@ func (i *backendIBFT) writeTransactions(
 			"successful", successful,
 			"failed", failed,
 			"skipped", skipped,
+			"bundles", bundles,
 			"remaining", i.txpool.Length(),
 		)
 	}()
 
+	// bundles have precedence over the regular pool transactions
+	executed, bundles = i.writeBundles(writeCtx, blockNumber, gasLimit, transition)
+	successful += len(executed)
+
+	select {
+	case <-writeCtx.Done():
+		return
+	default:
+	}
+
 	i.txpool.Prepare()
 
 write:
@@ -395,6 +426,63 @@ func (i *backendIBFT) writeTransaction(
 	return &txExeResult{tx, success}, true
 }
 
+// writeBundles writes the bundles which target the given block, until the block gas is exhausted
+// or the write context is done. Returns the written transactions and the number of written bundles
+func (i *backendIBFT) writeBundles(
+	writeCtx context.Context,
+	blockNumber,
+	gasLimit uint64,
+	transition transitionInterface,
+) ([]*types.Transaction, int) {
+	var (
+		executed = make([]*types.Transaction, 0)
+		written  = 0
+	)
+
+	for _, bundle := range i.txpool.Bundles(blockNumber) {
+		select {
+		case <-writeCtx.Done():
+			return executed, written
+		default:
+		}
+
+		if transition.TotalGas()+state.TxGas > gasLimit {
+			// no other bundle can fit into the block
+			break
+		}
+
+		if txs, ok := i.writeBundle(bundle, transition, gasLimit-transition.TotalGas()); ok {
+			executed = append(executed, txs...)
+			written++
+		}
+	}
+
+	return executed, written
+}
+
+// writeBundle writes all the bundle transactions atomically,
+// returns false if the bundle could not be included
+func (i *backendIBFT) writeBundle(
+	bundle *types.Bundle,
+	transition transitionInterface,
+	availableGas uint64,
+) ([]*types.Transaction, bool) {
+	if bundle.Gas() > availableGas {
+		i.logger.Debug("bundle exceeds the available block gas", "hash", bundle.Hash,
+			"gas", bundle.Gas(), "available", availableGas)
+
+		return nil, false
+	}
+
+	if err := transition.WriteBundle(bundle.Txs); err != nil {
+		i.logger.Debug("failed to write bundle", "hash", bundle.Hash, "err", err)
+
+		return nil, false
+	}
+
+	return bundle.Txs, true
+}
+
 // extractCommittedSeals extracts CommittedSeals from header
 func (i *backendIBFT) extractCommittedSeals(
 	header *types.Header,
@@ -1,10 +1,16 @@
 package ibft
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 // TestIBFTBackend_CalculateHeaderTimestamp verifies that the header timestamp
@@ -111,3 +117,145 @@ func TestIBFTBackend_RoundUpTime(t *testing.T) {
 		})
 	}
 }
+
+type mockBundlesTxPool struct {
+	txPoolInterface
+	bundles []*types.Bundle
+}
+
+func (m *mockBundlesTxPool) Bundles(uint64) []*types.Bundle {
+	return m.bundles
+}
+
+type mockTransition struct {
+	totalGas uint64
+	written  [][]*types.Transaction
+	failing  map[types.Hash]bool
+}
+
+func (m *mockTransition) Write(txn *types.Transaction) error {
+	return nil
+}
+
+func (m *mockTransition) WriteBundle(txs []*types.Transaction) error {
+	for _, tx := range txs {
+		if m.failing[tx.Hash] {
+			return errors.New("bundle transaction failed")
+		}
+	}
+
+	for _, tx := range txs {
+		m.totalGas += tx.Gas
+	}
+
+	m.written = append(m.written, txs)
+
+	return nil
+}
+
+func (m *mockTransition) TotalGas() uint64 {
+	return m.totalGas
+}
+
+func newTestBundle(gas ...uint64) *types.Bundle {
+	bundle := &types.Bundle{}
+
+	for _, g := range gas {
+		bundle.Txs = append(bundle.Txs, &types.Transaction{
+			Gas:  g,
+			Hash: types.BytesToHash([]byte(fmt.Sprintf("%d-%d", g, len(bundle.Txs)))),
+		})
+	}
+
+	return bundle.ComputeHash()
+}
+
+// TestIBFTBackend_WriteBundle verifies that the bundles are written atomically
+// and only if they fit into the available block gas
+func TestIBFTBackend_WriteBundle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bundle exceeding the available gas is not written", func(t *testing.T) {
+		t.Parallel()
+
+		i := &backendIBFT{logger: hclog.NewNullLogger()}
+		transition := &mockTransition{}
+
+		txs, ok := i.writeBundle(newTestBundle(30_000, 30_000), transition, 50_000)
+		assert.False(t, ok)
+		assert.Nil(t, txs)
+		assert.Empty(t, transition.written)
+	})
+
+	t.Run("failing bundle is not written", func(t *testing.T) {
+		t.Parallel()
+
+		bundle := newTestBundle(21_000, 21_000)
+		i := &backendIBFT{logger: hclog.NewNullLogger()}
+		transition := &mockTransition{failing: map[types.Hash]bool{bundle.Txs[1].Hash: true}}
+
+		txs, ok := i.writeBundle(bundle, transition, 100_000)
+		assert.False(t, ok)
+		assert.Nil(t, txs)
+		assert.Empty(t, transition.written)
+	})
+
+	t.Run("bundle is written", func(t *testing.T) {
+		t.Parallel()
+
+		bundle := newTestBundle(21_000, 21_000)
+		i := &backendIBFT{logger: hclog.NewNullLogger()}
+		transition := &mockTransition{}
+
+		txs, ok := i.writeBundle(bundle, transition, 100_000)
+		assert.True(t, ok)
+		assert.Equal(t, bundle.Txs, txs)
+		assert.Equal(t, [][]*types.Transaction{bundle.Txs}, transition.written)
+	})
+}
+
+// TestIBFTBackend_WriteBundles verifies that the bundles are written
+// until the block gas is exhausted or the write context is done
+func TestIBFTBackend_WriteBundles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bundles are written while they fit into the block", func(t *testing.T) {
+		t.Parallel()
+
+		bundles := []*types.Bundle{
+			newTestBundle(40_000, 40_000),
+			newTestBundle(30_000), // does not fit after the first one
+			newTestBundle(21_000),
+			newTestBundle(21_000), // no gas left for any other bundle
+		}
+
+		i := &backendIBFT{
+			logger: hclog.NewNullLogger(),
+			txpool: &mockBundlesTxPool{bundles: bundles},
+		}
+		transition := &mockTransition{}
+
+		txs, written := i.writeBundles(context.Background(), 1, 101_000, transition)
+		assert.Equal(t, 2, written)
+		assert.Equal(t, append(bundles[0].Txs, bundles[2].Txs...), txs)
+		assert.Equal(t, uint64(101_000), transition.TotalGas())
+	})
+
+	t.Run("no bundles are written after the write context is done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		i := &backendIBFT{
+			logger: hclog.NewNullLogger(),
+			txpool: &mockBundlesTxPool{bundles: []*types.Bundle{newTestBundle(21_000)}},
+		}
+		transition := &mockTransition{}
+
+		txs, written := i.writeBundles(ctx, 1, 100_000, transition)
+		assert.Equal(t, 0, written)
+		assert.Empty(t, txs)
+		assert.Empty(t, transition.written)
+	})
+}
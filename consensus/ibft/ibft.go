@@ -50,6 +50,8 @@ type txPoolInterface interface {
 	Demote(tx *types.Transaction)
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
+	Bundles(blockNumber uint64) []*types.Bundle
+	GetTxs(inclQueued bool) (promoted, enqueued map[types.Address][]*types.Transaction)
 }
 
 type forkManagerInterface interface {
@@ -149,6 +151,7 @@ func Factory(params *consensus.Params) (consensus.Consensus, error) {
 			params.Network,
 			params.Blockchain,
 			time.Duration(params.BlockTime)*3*time.Second,
+			params.SnapSync,
 		),
 		secretsManager: params.SecretsManager,
 		Grpc:           params.Grpc,
@@ -155,10 +155,36 @@ func (b *BlockBuilder) WriteTx(tx *types.Transaction) error {
 	return nil
 }
 
+// WriteBundle applies given transactions to the state atomically.
+// If any of the transactions fails, none of them is included in the block.
+func (b *BlockBuilder) WriteBundle(txs []*types.Transaction) error {
+	for _, tx := range txs {
+		if tx.Gas > b.params.GasLimit {
+			return txpool.ErrBlockLimitExceeded
+		}
+	}
+
+	if err := b.state.WriteBundle(txs); err != nil {
+		return err
+	}
+
+	b.txns = append(b.txns, txs...)
+
+	return nil
+}
+
 // Fill fills the block with transactions from the txpool
 func (b *BlockBuilder) Fill() {
 	blockTimer := time.NewTimer(b.params.BlockTime)
 
+	// the state of the pending transactions is loaded while they are written one by one
+	defer b.state.Prefetch(b.pendingTxs())()
+
+	// bundles have precedence over the regular pool transactions
+	if expired := b.writeBundles(blockTimer); expired {
+		return
+	}
+
 	b.params.TxPool.Prepare()
 write:
 	for {
@@ -184,11 +210,54 @@ write:
 	<-blockTimer.C
 }
 
+// pendingTxs returns the executable transactions of the txpool
+func (b *BlockBuilder) pendingTxs() []*types.Transaction {
+	promoted, _ := b.params.TxPool.GetTxs(false)
+
+	txs := []*types.Transaction{}
+	for _, accountTxs := range promoted {
+		txs = append(txs, accountTxs...)
+	}
+
+	return txs
+}
+
 // Receipts returns the collection of transaction receipts for given block
 func (b *BlockBuilder) Receipts() []*types.Receipt {
 	return b.state.Receipts()
 }
 
+// writeBundles writes the bundles which target the block being built,
+// until the block gas is exhausted. Returns true if the block timer expired in the meantime
+func (b *BlockBuilder) writeBundles(blockTimer *time.Timer) bool {
+	for _, bundle := range b.params.TxPool.Bundles(b.header.Number) {
+		select {
+		case <-blockTimer.C:
+			return true
+		default:
+		}
+
+		remainingGas := b.params.GasLimit - b.state.TotalGas()
+		if remainingGas < state.TxGas {
+			// no other bundle can fit into the block
+			return false
+		}
+
+		if bundle.Gas() > remainingGas {
+			b.params.Logger.Debug("Fill bundle skipped, not enough gas left",
+				"hash", bundle.Hash, "gas", bundle.Gas(), "remaining", remainingGas)
+
+			continue
+		}
+
+		if err := b.WriteBundle(bundle.Txs); err != nil {
+			b.params.Logger.Debug("Fill bundle error", "hash", bundle.Hash, "err", err)
+		}
+	}
+
+	return false
+}
+
 func (b *BlockBuilder) writeTxPoolTransaction(tx *types.Transaction) (bool, error) {
 	if tx == nil {
 		return true, nil
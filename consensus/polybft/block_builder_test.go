@@ -76,6 +76,9 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 
 	txPool := &txPoolMock{}
 	txPool.On("Prepare").Once()
+	txPool.On("Bundles", uint64(1)).Return([]*types.Bundle{}).Once()
+
+	pending := map[types.Address][]*types.Transaction{}
 
 	for i, acc := range accounts {
 		receiver := types.Address(acc.Ecdsa.Address())
@@ -102,6 +105,8 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 		// all tx until the fifth will be retrieved from the pool
 		if i <= 4 {
 			txPool.On("Peek").Return(tx).Once()
+
+			pending[receiver] = append(pending[receiver], tx)
 		}
 
 		// first two and fourth will be added to the block, third will be demoted
@@ -112,6 +117,8 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 		}
 	}
 
+	txPool.On("GetTxs", false).Return(pending, map[types.Address][]*types.Transaction{}).Once()
+
 	bb := NewBlockBuilder(&BlockBuilderParams{
 		BlockTime: time.Millisecond * 100,
 		Parent:    parentHeader,
@@ -158,3 +165,111 @@ func TestBlockBuilder_BuildBlockTxOneFailedTxAndOneTakesTooMuchGas(t *testing.T)
 	assert.False(t, fb.Block.Header.LogsBloom.IsLogInBloom(
 		&types.Log{Address: types.StringToAddress("111177779999")}))
 }
+
+func TestBlockBuilder_BuildBlockWithBundles(t *testing.T) {
+	t.Parallel()
+
+	const (
+		amount        = 1_000
+		gasPrice      = 1_000
+		gasLimit      = 21000
+		blockGasLimit = 21000 * 10
+		chainID       = 100
+	)
+
+	accounts := [3]*wallet.Account{}
+
+	for i := range accounts {
+		accounts[i] = generateTestAccount(t)
+	}
+
+	forks := &chain.Forks{}
+	logger := hclog.NewNullLogger()
+	signer := crypto.NewSigner(forks.At(0), chainID)
+
+	mchain := &chain.Chain{
+		Params: &chain.Params{
+			ChainID: chainID,
+			Forks:   forks,
+		},
+	}
+
+	executor := state.NewExecutor(mchain.Params, itrie.NewState(itrie.NewMemoryStorage()), logger)
+	executor.GetHash = func(header *types.Header) func(i uint64) types.Hash {
+		return func(i uint64) (res types.Hash) {
+			return types.BytesToHash(common.EncodeUint64ToBytes(i))
+		}
+	}
+
+	// the last account has no funds, so its transactions fail
+	hash, err := executor.WriteGenesis(map[types.Address]*chain.GenesisAccount{
+		types.Address(accounts[0].Ecdsa.Address()): {Balance: ethgo.Ether(1)},
+		types.Address(accounts[1].Ecdsa.Address()): {Balance: ethgo.Ether(1)},
+	}, types.ZeroHash)
+	require.NoError(t, err)
+
+	signTx := func(acc *wallet.Account, nonce uint64) *types.Transaction {
+		t.Helper()
+
+		privateKey, err := acc.GetEcdsaPrivateKey()
+		require.NoError(t, err)
+
+		receiver := types.Address(accounts[0].Ecdsa.Address())
+
+		tx, err := signer.SignTx(&types.Transaction{
+			Value:    big.NewInt(amount),
+			GasPrice: big.NewInt(gasPrice),
+			Gas:      gasLimit,
+			Nonce:    nonce,
+			To:       &receiver,
+		}, privateKey)
+		require.NoError(t, err)
+
+		return tx
+	}
+
+	validBundle := &types.Bundle{
+		Txs: []*types.Transaction{signTx(accounts[1], 0), signTx(accounts[1], 1)},
+	}
+	failingBundle := &types.Bundle{
+		Txs: []*types.Transaction{signTx(accounts[0], 0), signTx(accounts[2], 0)},
+	}
+	// does not fit into the gas left after the valid bundle
+	oversizedBundle := &types.Bundle{}
+	for nonce := uint64(2); nonce < 11; nonce++ {
+		oversizedBundle.Txs = append(oversizedBundle.Txs, signTx(accounts[1], nonce))
+	}
+
+	txPool := &txPoolMock{}
+	txPool.On("Bundles", uint64(1)).Return([]*types.Bundle{failingBundle, validBundle, oversizedBundle}).Once()
+	txPool.On("Prepare").Once()
+	txPool.On("Peek").Return((*types.Transaction)(nil)).Once()
+	txPool.On("GetTxs", false).
+		Return(map[types.Address][]*types.Transaction{}, map[types.Address][]*types.Transaction{}).Once()
+
+	bb := NewBlockBuilder(&BlockBuilderParams{
+		BlockTime: time.Millisecond * 100,
+		Parent:    &types.Header{StateRoot: hash, GasLimit: 1_000_000_000_000_000},
+		Coinbase:  types.ZeroAddress,
+		Executor:  executor,
+		GasLimit:  blockGasLimit,
+		TxPool:    txPool,
+		Logger:    logger,
+	})
+
+	require.NoError(t, bb.Reset())
+
+	bb.Fill()
+
+	_, err = bb.Build(nil)
+	require.NoError(t, err)
+
+	txPool.AssertExpectations(t)
+
+	// only the transactions of the valid bundle are included
+	require.Equal(t, validBundle.Txs, bb.txns)
+	require.Len(t, bb.Receipts(), 2)
+
+	// the first transaction of the failing bundle is reverted
+	require.Equal(t, uint64(0), bb.GetState().Txn().GetNonce(types.Address(accounts[0].Ecdsa.Address())))
+}
@@ -95,6 +95,8 @@ func (p *blockchainWrapper) ProcessBlock(parent *types.Header, block *types.Bloc
 		return nil, err
 	}
 
+	defer transition.Prefetch(block.Transactions)()
+
 	// apply transactions from block
 	for _, tx := range block.Transactions {
 		if err = transition.Write(tx); err != nil {
@@ -48,6 +48,8 @@ type txPoolInterface interface {
 	Demote(*types.Transaction)
 	SetSealing(bool)
 	ResetWithHeaders(...*types.Header)
+	Bundles(uint64) []*types.Bundle
+	GetTxs(inclQueued bool) (map[types.Address][]*types.Transaction, map[types.Address][]*types.Transaction)
 }
 
 // epochMetadata is the static info for epoch currently being processed
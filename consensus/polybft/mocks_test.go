@@ -359,6 +359,21 @@ func (tp *txPoolMock) ResetWithHeaders(values ...*types.Header) {
 	tp.Called(values)
 }
 
+func (tp *txPoolMock) GetTxs(inclQueued bool) (
+	map[types.Address][]*types.Transaction,
+	map[types.Address][]*types.Transaction,
+) {
+	args := tp.Called(inclQueued)
+
+	return args[0].(map[types.Address][]*types.Transaction), args[1].(map[types.Address][]*types.Transaction) //nolint
+}
+
+func (tp *txPoolMock) Bundles(blockNumber uint64) []*types.Bundle {
+	args := tp.Called(blockNumber)
+
+	return args[0].([]*types.Bundle) //nolint
+}
+
 var _ syncer.Syncer = (*syncerMock)(nil)
 
 type syncerMock struct {
@@ -441,6 +441,7 @@ func (p *Polybft) Initialize() error {
 		p.config.Network,
 		p.config.Blockchain,
 		time.Duration(p.config.BlockTime)*3*time.Second,
+		p.config.SnapSync,
 	)
 
 	// set blockchain backend
@@ -709,6 +710,41 @@ func (p *Polybft) VerifyHeader(header *types.Header) error {
 	return p.verifyHeaderImpl(parent, header, p.consensusConfig.BlockTimeDrift, nil)
 }
 
+// VerifyHeaders verifies the headers following the verified parents before their blocks are written.
+// The validators of the headers which are not written yet are computed from the preceding headers
+func (p *Polybft) VerifyHeaders(parents []*types.Header, headers []*types.Header) error {
+	parents = append(parents[:len(parents):len(parents)], headers...)
+	offset := len(parents) - len(headers)
+
+	for i, header := range headers {
+		// Short circuit if the header is known
+		if _, ok := p.blockchain.GetHeaderByHash(header.Hash); ok {
+			continue
+		}
+
+		var parent *types.Header
+
+		if offset+i > 0 {
+			parent = parents[offset+i-1]
+		} else if parent, _ = p.blockchain.GetHeaderByHash(header.ParentHash); parent == nil {
+			return fmt.Errorf(
+				"unable to get parent header by hash for block number %d",
+				header.Number,
+			)
+		}
+
+		if parent.Hash != header.ParentHash {
+			return fmt.Errorf("header %d doesn't follow its parent", header.Number)
+		}
+
+		if err := p.verifyHeaderImpl(parent, header, p.consensusConfig.BlockTimeDrift, parents[:offset+i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *Polybft) verifyHeaderImpl(parent, header *types.Header, blockTimeDrift uint64, parents []*types.Header) error {
 	// validate header fields
 	if err := validateHeaderFields(parent, header, blockTimeDrift); err != nil {
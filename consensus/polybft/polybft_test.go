@@ -199,6 +199,135 @@ func TestPolybft_VerifyHeader(t *testing.T) {
 	assert.NoError(t, polybft.VerifyHeader(currentHeader))
 }
 
+func TestPolybft_VerifyHeaders(t *testing.T) {
+	t.Parallel()
+
+	const epochSize = uint64(10)
+
+	validators := validator.NewTestValidators(t, 6)
+	validatorSet := validators.GetPublicIdentities()
+	accounts := validators.GetPrivateIdentities()
+
+	// the validator set changes at the end of the first epoch
+	validatorSetParent, validatorSetCurrent := validatorSet[:len(validatorSet)-1], validatorSet[1:]
+	accountSetParent, accountSetCurrent := accounts[:len(accounts)-1], accounts[1:]
+
+	checkpoint := &CheckpointData{
+		EpochNumber:           1,
+		CurrentValidatorsHash: types.StringToHash("Foo"),
+		NextValidatorsHash:    types.StringToHash("Bar"),
+	}
+
+	// newHeader creates the header following the parent, committed by the given accounts
+	newHeader := func(parent *types.Header, delta *validator.ValidatorSetDelta, parentSignature *Signature,
+		committedAccounts []*wallet.Account) *types.Header {
+		header := &types.Header{
+			Number:     parent.Number + 1,
+			ParentHash: parent.Hash,
+			Timestamp:  parent.Timestamp + 1,
+			MixHash:    PolyBFTMixDigest,
+			Difficulty: 1,
+		}
+
+		extra := &Extra{Validators: delta, Parent: parentSignature, Checkpoint: checkpoint, Committed: &Signature{}}
+
+		header.ExtraData = extra.MarshalRLPTo(nil)
+		header.ComputeHash()
+
+		checkpointHash, err := checkpoint.Hash(0, header.Number, header.Hash)
+		require.NoError(t, err)
+
+		extra.Committed = createSignature(t, committedAccounts, checkpointHash, signer.DomainCheckpointManager)
+		header.ExtraData = extra.MarshalRLPTo(nil)
+
+		return header
+	}
+
+	headersMap := &testHeadersMap{}
+
+	genesisDelta, err := validator.CreateValidatorSetDelta(nil, validatorSetParent)
+	require.NoError(t, err)
+
+	genesis := &types.Header{
+		Number:    0,
+		Timestamp: uint64(time.Now().UTC().Unix()) - epochSize,
+		ExtraData: (&Extra{Validators: genesisDelta, Checkpoint: &CheckpointData{}}).MarshalRLPTo(nil),
+	}
+	genesis.ComputeHash()
+	headersMap.addHeader(genesis)
+
+	sameDelta, err := validator.CreateValidatorSetDelta(validatorSetParent, validatorSetParent)
+	require.NoError(t, err)
+
+	// the blocks of the first epoch are written up to the block 8
+	headers := []*types.Header{genesis}
+
+	for i := uint64(1); i <= epochSize+1; i++ {
+		parent := headers[len(headers)-1]
+
+		var (
+			delta           = sameDelta
+			committed       = accountSetParent
+			parentSignature *Signature
+		)
+
+		if i > 1 {
+			parentExtra, err := GetIbftExtra(parent.ExtraData)
+			require.NoError(t, err)
+
+			parentSignature = parentExtra.Committed
+		}
+
+		switch i {
+		case epochSize:
+			delta, err = validator.CreateValidatorSetDelta(validatorSetParent, validatorSetCurrent)
+			require.NoError(t, err)
+		case epochSize + 1:
+			delta, err = validator.CreateValidatorSetDelta(validatorSetCurrent, validatorSetCurrent)
+			require.NoError(t, err)
+
+			committed = accountSetCurrent
+		}
+
+		headers = append(headers, newHeader(parent, delta, parentSignature, committed))
+
+		if i < epochSize-1 {
+			headersMap.addHeader(headers[i])
+		}
+	}
+
+	blockchainMock := new(blockchainMock)
+	blockchainMock.On("GetHeaderByNumber", mock.Anything).Return(headersMap.getHeader)
+	blockchainMock.On("GetHeaderByHash", mock.Anything).Return(headersMap.getHeaderByHash)
+
+	newPolybft := func() *Polybft {
+		return &Polybft{
+			closeCh:         make(chan struct{}),
+			logger:          hclog.NewNullLogger(),
+			consensusConfig: &PolyBFTConfig{EpochSize: epochSize, SprintSize: 5, BlockTimeDrift: 10},
+			blockchain:      blockchainMock,
+			validatorsCache: newValidatorsSnapshotCache(hclog.NewNullLogger(), newTestState(t), blockchainMock),
+		}
+	}
+
+	// the headers following the written ones are verified, including the change of the validator set
+	require.NoError(t, newPolybft().VerifyHeaders(nil, headers[epochSize-1:]))
+
+	// the headers have to follow a written one
+	require.ErrorContains(t, newPolybft().VerifyHeaders(nil, headers[epochSize:]), "unable to get parent header")
+
+	// the header committed by the validators of the previous epoch is rejected
+	lastExtra, err := GetIbftExtra(headers[epochSize+1].ExtraData)
+	require.NoError(t, err)
+
+	forged := newHeader(headers[epochSize], lastExtra.Validators, lastExtra.Parent, accountSetParent)
+
+	require.ErrorContains(t,
+		newPolybft().VerifyHeaders(headers[epochSize-1:epochSize+1], []*types.Header{forged}),
+		"failed to verify signatures for block 11",
+	)
+}
+
 func TestPolybft_Close(t *testing.T) {
 	t.Parallel()
 
@@ -78,12 +78,18 @@ func (v *validatorsSnapshotCache) GetSnapshot(
 		v.lock.Unlock()
 	}()
 
-	_, extra, err := getBlockData(blockNumber, v.blockchain)
+	// the headers which are not written yet are looked up in the parents
+	chain := v.blockchain
+	if len(parents) > 0 {
+		chain = &parentsBackend{blockchainBackend: v.blockchain, parents: parents}
+	}
+
+	_, extra, err := getBlockData(blockNumber, chain)
 	if err != nil {
 		return nil, err
 	}
 
-	isEpochEndingBlock, err := isEpochEndingBlock(blockNumber, extra, v.blockchain)
+	isEpochEndingBlock, err := isEpochEndingBlock(blockNumber, extra, chain)
 	if err != nil && !errors.Is(err, blockchain.ErrNoBlock) {
 		// if there is no block after given block, we assume its not epoch ending block
 		// but, it's a regular use case, and we should not stop the snapshot calculation
@@ -132,7 +138,7 @@ func (v *validatorsSnapshotCache) GetSnapshot(
 
 	// Create the snapshot for the desired block (epoch) by incrementally applying deltas to the latest stored snapshot
 	for latestValidatorSnapshot.Epoch < epochToGetSnapshot {
-		nextEpochEndBlockNumber, err := v.getNextEpochEndingBlock(latestValidatorSnapshot.EpochEndingBlock)
+		nextEpochEndBlockNumber, err := v.getNextEpochEndingBlock(latestValidatorSnapshot.EpochEndingBlock, chain)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get the epoch ending block for epoch: %d. Error: %w",
 				latestValidatorSnapshot.Epoch+1, err)
@@ -328,10 +334,11 @@ func (v *validatorsSnapshotCache) getLastCachedSnapshot(currentEpoch uint64,
 
 // getNextEpochEndingBlock gets the epoch ending block of a newer epoch
 // It start checking the blocks from the provided epoch ending block of the previous epoch
-func (v *validatorsSnapshotCache) getNextEpochEndingBlock(latestEpochEndingBlock uint64) (uint64, error) {
+func (v *validatorsSnapshotCache) getNextEpochEndingBlock(
+	latestEpochEndingBlock uint64, chain blockchainBackend) (uint64, error) {
 	blockNumber := latestEpochEndingBlock + 1 // get next block
 
-	_, extra, err := getBlockData(blockNumber, v.blockchain)
+	_, extra, err := getBlockData(blockNumber, chain)
 	if err != nil {
 		return 0, err
 	}
@@ -342,7 +349,7 @@ func (v *validatorsSnapshotCache) getNextEpochEndingBlock(latestEpochEndingBlock
 	for startEpoch == epoch {
 		blockNumber++
 
-		_, extra, err = getBlockData(blockNumber, v.blockchain)
+		_, extra, err = getBlockData(blockNumber, chain)
 		if err != nil {
 			if errors.Is(err, blockchain.ErrNoBlock) {
 				return blockNumber - 1, nil
@@ -356,3 +363,21 @@ func (v *validatorsSnapshotCache) getNextEpochEndingBlock(latestEpochEndingBlock
 
 	return blockNumber - 1, nil
 }
+
+// parentsBackend looks up the headers which are not written to the chain yet in the given parents
+type parentsBackend struct {
+	blockchainBackend
+
+	parents []*types.Header
+}
+
+// GetHeaderByNumber returns the header of the parents, or the written one
+func (b *parentsBackend) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	for _, header := range b.parents {
+		if header.Number == number {
+			return header, true
+		}
+	}
+
+	return b.blockchainBackend.GetHeaderByNumber(number)
+}
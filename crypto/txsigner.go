@@ -47,6 +47,27 @@ func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	return signer
 }
 
+// IsReplayProtected returns false if the transaction is a legacy transaction
+// signed without the chain ID (before EIP155), so it can be replayed on any chain
+func IsReplayProtected(tx *types.Transaction) bool {
+	if tx.Type != types.LegacyTx {
+		return true
+	}
+
+	return isProtectedV(tx.V)
+}
+
+// isProtectedV returns false if the v value conforms to an earlier standard (before EIP155)
+func isProtectedV(v *big.Int) bool {
+	if v != nil && v.BitLen() <= 8 {
+		vv := v.Uint64()
+
+		return vv != 27 && vv != 28
+	}
+
+	return true
+}
+
 // encodeSignature generates a signature value based on the R, S and V value
 func encodeSignature(R, S, V *big.Int, isHomestead bool) ([]byte, error) {
 	if !ValidateSignatureValues(V, R, S, isHomestead) {
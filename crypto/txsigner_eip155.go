@@ -3,7 +3,6 @@ package crypto
 import (
 	"crypto/ecdsa"
 	"math/big"
-	"math/bits"
 
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -28,22 +27,16 @@ func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
 
 // Sender returns the transaction sender
 func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
-	protected := true
-
 	// Check if v value conforms to an earlier standard (before EIP155)
+	if !isProtectedV(tx.V) {
+		return (&FrontierSigner{}).Sender(tx)
+	}
+
 	bigV := big.NewInt(0)
 	if tx.V != nil {
 		bigV.SetBytes(tx.V.Bytes())
 	}
 
-	if vv := bigV.Uint64(); bits.Len(uint(vv)) <= 8 {
-		protected = vv != 27 && vv != 28
-	}
-
-	if !protected {
-		return (&FrontierSigner{}).Sender(tx)
-	}
-
 	// Reverse the V calculation to find the original V in the range [0, 1]
 	// v = CHAIN_ID * 2 + 35 + {0, 1}
 	mulOperand := big.NewInt(0).Mul(big.NewInt(int64(e.chainID)), big.NewInt(2))
@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEIP155Signer_Sender(t *testing.T) {
@@ -135,3 +136,38 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestIsReplayProtected(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateECDSAKey()
+	require.NoError(t, err)
+
+	toAddress := types.StringToAddress("1")
+	newTx := func(txType types.TxType) *types.Transaction {
+		return &types.Transaction{
+			Type:     txType,
+			To:       &toAddress,
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(0),
+		}
+	}
+
+	unprotectedTx, err := NewFrontierSigner(true).SignTx(newTx(types.LegacyTx), key)
+	require.NoError(t, err)
+	assert.False(t, IsReplayProtected(unprotectedTx))
+
+	protectedTx, err := NewEIP155Signer(100, true).SignTx(newTx(types.LegacyTx), key)
+	require.NoError(t, err)
+	assert.True(t, IsReplayProtected(protectedTx))
+
+	// the signer recovers the sender of both
+	sender, err := NewEIP155Signer(100, true).Sender(unprotectedTx)
+	require.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), sender)
+
+	// chain ID is always part of the dynamic fee transaction hash
+	dynamicFeeTx := newTx(types.DynamicFeeTx)
+	dynamicFeeTx.V = big.NewInt(1)
+	assert.True(t, IsReplayProtected(dynamicFeeTx))
+}
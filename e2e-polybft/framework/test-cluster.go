@@ -1,22 +1,20 @@
 package framework
 
 import (
-	"bytes"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	devnetCluster "github.com/0xPolygon/polygon-edge/command/devnet/cluster"
 	"github.com/0xPolygon/polygon-edge/command/genesis"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft"
 	"github.com/0xPolygon/polygon-edge/consensus/polybft/contractsapi"
@@ -606,11 +604,7 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 				strings.Join(sliceAddressToSliceString(cluster.Config.BridgeBlockListEnabled), ","))
 		}
 
-		proxyAdminAddr := cluster.Config.ProxyContractsAdmin
-		if proxyAdminAddr == "" {
-			proxyAdminAddr = ProxyContractAdminAddr
-		}
-		args = append(args, "--proxy-contracts-admin", proxyAdminAddr)
+		args = append(args, "--proxy-contracts-admin", cluster.Config.GetProxyContractsAdmin())
 
 		// run genesis command with all the arguments
 		err = cluster.cmdRun(args...)
@@ -621,45 +615,20 @@ func NewTestCluster(t *testing.T, validatorsCount int, opts ...ClusterOption) *T
 	cluster.Bridge, err = NewTestBridge(t, cluster.Config)
 	require.NoError(t, err)
 
-	// deploy stake manager contract
-	err = cluster.Bridge.deployStakeManager(genesisPath)
-	require.NoError(t, err)
-
-	// deploy rootchain contracts
-	err = cluster.Bridge.deployRootchainContracts(genesisPath)
-	require.NoError(t, err)
-
-	polybftConfig, err := polybft.LoadPolyBFTConfig(genesisPath)
-	require.NoError(t, err)
-
-	tokenConfig, err := polybft.ParseRawTokenConfig(cluster.Config.NativeTokenConfigRaw)
-	require.NoError(t, err)
-
-	// fund addresses on the rootchain
-	err = cluster.Bridge.fundAddressesOnRoot(tokenConfig, polybftConfig)
-	require.NoError(t, err)
-
-	// whitelist genesis validators on the rootchain
-	err = cluster.Bridge.whitelistValidators(addresses, polybftConfig)
-	require.NoError(t, err)
-
-	// register genesis validators on the rootchain
-	err = cluster.Bridge.registerGenesisValidators(polybftConfig)
-	require.NoError(t, err)
-
-	// do initial staking for genesis validators on the rootchain
-	err = cluster.Bridge.initialStakingOfGenesisValidators(polybftConfig)
-	require.NoError(t, err)
-
-	// add premine if token is non-mintable
-	err = cluster.Bridge.mintNativeRootToken(addresses, tokenConfig, polybftConfig)
-	require.NoError(t, err)
-
-	err = cluster.Bridge.premineNativeRootToken(tokenConfig, polybftConfig)
-	require.NoError(t, err)
+	// deploy the rootchain contracts and register the genesis validators on them
+	bootstrapper := &devnetCluster.Bootstrapper{
+		Binary:              config.Binary,
+		Dir:                 config.TmpDir,
+		ValidatorPrefix:     config.ValidatorPrefix,
+		GenesisPath:         genesisPath,
+		RootchainURL:        cluster.Bridge.JSONRPCAddr(),
+		ProxyContractsAdmin: config.GetProxyContractsAdmin(),
+		StakeAmounts:        config.StakeAmounts,
+		Premine:             config.Premine,
+		Out:                 config.GetStdout("bridge"),
+	}
 
-	// finalize genesis validators on the rootchain
-	err = cluster.Bridge.finalizeGenesis(genesisPath, polybftConfig)
+	err = bootstrapper.Bootstrap(addresses)
 	require.NoError(t, err)
 
 	for i := 1; i <= int(cluster.Config.ValidatorSetSize); i++ {
@@ -832,25 +801,7 @@ func (c *TestCluster) getOpenPort() int64 {
 
 // runCommand executes command with given arguments
 func runCommand(binary string, args []string, stdout io.Writer) error {
-	var stdErr bytes.Buffer
-
-	cmd := exec.Command(binary, args...)
-	cmd.Stderr = &stdErr
-	cmd.Stdout = stdout
-
-	if err := cmd.Run(); err != nil {
-		if stdErr.Len() > 0 {
-			return fmt.Errorf("failed to execute command: %s", stdErr.String())
-		}
-
-		return fmt.Errorf("failed to execute command: %w", err)
-	}
-
-	if stdErr.Len() > 0 {
-		return fmt.Errorf("error during command execution: %s", stdErr.String())
-	}
-
-	return nil
+	return devnetCluster.RunCommand(binary, args, stdout)
 }
 
 // RunEdgeCommand - calls a command line edge function
@@ -861,29 +812,8 @@ func RunEdgeCommand(args []string, stdout io.Writer) error {
 // InitSecrets initializes account(s) secrets with given prefix.
 // (secrets are being stored in the temp directory created by given e2e test execution)
 func (c *TestCluster) InitSecrets(prefix string, count int) ([]types.Address, error) {
-	var b bytes.Buffer
-
-	args := []string{
-		"polybft-secrets",
-		"--data-dir", path.Join(c.Config.TmpDir, prefix),
-		"--num", strconv.Itoa(count),
-		"--insecure",
-	}
-	stdOut := c.Config.GetStdout("polybft-secrets", &b)
-
-	if err := runCommand(c.Config.Binary, args, stdOut); err != nil {
-		return nil, err
-	}
-
-	re := regexp.MustCompile("\\(address\\) = 0x([a-fA-F0-9]+)")
-	parsed := re.FindAllStringSubmatch(b.String(), -1)
-	result := make([]types.Address, len(parsed))
-
-	for i, v := range parsed {
-		result[i] = types.StringToAddress(v[1])
-	}
-
-	return result, nil
+	return devnetCluster.InitPolyBFTSecrets(c.Config.Binary, path.Join(c.Config.TmpDir, prefix), count,
+		c.Config.GetStdout("polybft-secrets"))
 }
 
 func (c *TestCluster) ExistsCode(t *testing.T, addr ethgo.Address) bool {
@@ -0,0 +1,185 @@
+package dbengine
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	pebblestorage "github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+)
+
+// convertBatchSize is the size in bytes of the key-value pairs written to the target database at once
+const convertBatchSize = 64 * 1024 * 1024
+
+var (
+	ErrNotLevelDB     = errors.New("source directory doesn't hold a leveldb database")
+	ErrTargetNotEmpty = errors.New("target directory already holds a database")
+)
+
+// ConvertResult is the number of the key-value pairs copied into each database
+type ConvertResult struct {
+	Blockchain uint64
+	State      uint64
+}
+
+// ConvertDataDir copies the leveldb blockchain and state databases of the data directory
+// into new pebble databases in the target data directory
+func ConvertDataDir(dataDir, targetDataDir string, logger hclog.Logger) (*ConvertResult, error) {
+	var (
+		result = &ConvertResult{}
+		err    error
+	)
+
+	result.Blockchain, err = ConvertLevelDBToPebble(
+		filepath.Join(dataDir, BlockchainDir),
+		filepath.Join(targetDataDir, BlockchainDir),
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the blockchain database: %w", err)
+	}
+
+	result.State, err = ConvertLevelDBToPebble(
+		filepath.Join(dataDir, StateDir),
+		filepath.Join(targetDataDir, StateDir),
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the state database: %w", err)
+	}
+
+	// the freezer doesn't depend on the engine, its flat files are copied as they are
+	if err := copyDir(filepath.Join(dataDir, FreezerDir), filepath.Join(targetDataDir, FreezerDir)); err != nil {
+		return nil, fmt.Errorf("failed to copy the freezer: %w", err)
+	}
+
+	return result, nil
+}
+
+// copyDir copies the files of the directory, if it exists, into the target directory
+func copyDir(source, target string) error {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err := os.MkdirAll(target, 0700); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		if err := copyFile(filepath.Join(source, entry.Name()), filepath.Join(target, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(source, target string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return errors.Join(err, dst.Close())
+	}
+
+	return dst.Close()
+}
+
+// ConvertLevelDBToPebble copies all the key-value pairs of the leveldb database
+// into a new pebble database and returns the number of the copied pairs
+func ConvertLevelDBToPebble(source, target string, logger hclog.Logger) (uint64, error) {
+	if engine, ok, err := Detect(source); err != nil {
+		return 0, err
+	} else if !ok || engine != LevelDB {
+		return 0, fmt.Errorf("%w: %s", ErrNotLevelDB, source)
+	}
+
+	if _, ok, err := Detect(target); err != nil {
+		return 0, err
+	} else if ok {
+		return 0, fmt.Errorf("%w: %s", ErrTargetNotEmpty, target)
+	}
+
+	src, err := leveldb.OpenFile(source, &opt.Options{ReadOnly: true, ErrorIfMissing: true})
+	if err != nil {
+		return 0, err
+	}
+
+	defer src.Close()
+
+	dst, err := pebblestorage.OpenDB(target, logger)
+	if err != nil {
+		return 0, err
+	}
+
+	iter := src.NewIterator(nil, nil)
+	defer iter.Release()
+
+	var (
+		copied uint64
+		batch  = dst.NewBatch()
+	)
+
+	for iter.Next() {
+		if err := batch.Set(iter.Key(), iter.Value(), nil); err != nil {
+			return 0, errors.Join(err, dst.Close())
+		}
+
+		copied++
+
+		if batch.Len() >= convertBatchSize {
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return 0, errors.Join(err, dst.Close())
+			}
+
+			logger.Info("copying key-value pairs", "target", target, "copied", copied)
+
+			batch = dst.NewBatch()
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return 0, errors.Join(err, dst.Close())
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return 0, errors.Join(err, dst.Close())
+	}
+
+	// the copied pairs are written to the sorted tables, instead of being replayed from the log on open
+	if err := dst.Flush(); err != nil {
+		return 0, errors.Join(err, dst.Close())
+	}
+
+	if err := dst.Close(); err != nil {
+		return 0, err
+	}
+
+	return copied, nil
+}
@@ -0,0 +1,184 @@
+package dbengine
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+// Engine is the key-value database engine of the blockchain and state storages
+type Engine string
+
+const (
+	LevelDB Engine = "leveldb"
+	Pebble  Engine = "pebble"
+)
+
+const (
+	// BlockchainDir is the directory of the blockchain storage in the data directory
+	BlockchainDir = "blockchain"
+
+	// StateDir is the directory of the state storage in the data directory
+	StateDir = "trie"
+
+	// FreezerDir is the directory of the freezer of the old blocks in the data directory
+	FreezerDir = "freezer"
+)
+
+var (
+	ErrUnknownEngine  = errors.New("unknown database engine")
+	ErrEngineMismatch = errors.New("database was created by another engine")
+)
+
+// Engines returns the supported database engines
+func Engines() []Engine {
+	return []Engine{LevelDB, Pebble}
+}
+
+// Parse returns the database engine with the given name
+func Parse(name string) (Engine, error) {
+	for _, engine := range Engines() {
+		if string(engine) == name {
+			return engine, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownEngine, name)
+}
+
+// Detect returns the engine of the database in the given directory,
+// and false if the directory doesn't hold a database
+func Detect(path string) (Engine, bool, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	hasCurrent := false
+
+	for _, entry := range entries {
+		// pebble keeps its options next to the manifest, leveldb doesn't
+		if strings.HasPrefix(entry.Name(), "OPTIONS-") {
+			return Pebble, true, nil
+		}
+
+		if entry.Name() == "CURRENT" {
+			hasCurrent = true
+		}
+	}
+
+	if hasCurrent {
+		return LevelDB, true, nil
+	}
+
+	return "", false, nil
+}
+
+// OpenBlockchainStorage opens the blockchain storage in the data directory
+func OpenBlockchainStorage(engine Engine, dataDir string, logger hclog.Logger) (storage.Storage, error) {
+	path := filepath.Join(dataDir, BlockchainDir)
+
+	if err := checkEngine(engine, path); err != nil {
+		return nil, err
+	}
+
+	switch engine {
+	case LevelDB:
+		return leveldb.NewLevelDBStorage(path, logger)
+	case Pebble:
+		return pebble.NewPebbleStorage(path, logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+}
+
+// OpenFreezerStorage opens the blockchain storage in the data directory which moves
+// the blocks more than the threshold behind the head to the freezer once started.
+// If the history is pruned, the blocks are frozen only once their history has been removed
+func OpenFreezerStorage(
+	engine Engine,
+	dataDir string,
+	threshold uint64,
+	compress bool,
+	historyPruned bool,
+	logger hclog.Logger,
+) (*freezer.Storage, error) {
+	path := filepath.Join(dataDir, BlockchainDir)
+
+	if err := checkEngine(engine, path); err != nil {
+		return nil, err
+	}
+
+	var (
+		kv  storage.KV
+		err error
+	)
+
+	switch engine {
+	case LevelDB:
+		kv, err = leveldb.NewLevelDBKV(path)
+	case Pebble:
+		kv, err = pebble.NewPebbleKV(path, logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := freezer.Open(filepath.Join(dataDir, FreezerDir), compress, logger)
+	if err != nil {
+		return nil, errors.Join(err, kv.Close())
+	}
+
+	return freezer.NewStorage(logger.Named(string(engine)), kv, f, threshold, historyPruned), nil
+}
+
+// OpenStateStorage opens the state storage in the data directory
+func OpenStateStorage(engine Engine, dataDir string, logger hclog.Logger) (itrie.Storage, error) {
+	path := filepath.Join(dataDir, StateDir)
+
+	if err := checkEngine(engine, path); err != nil {
+		return nil, err
+	}
+
+	switch engine {
+	case LevelDB:
+		return itrie.NewLevelDBStorage(path, logger)
+	case Pebble:
+		return itrie.NewPebbleStorage(path, logger)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+}
+
+// checkEngine makes sure the database in the directory, if any, was created by the given engine.
+// An engine opening the database of another one could take it for an empty database
+func checkEngine(engine Engine, path string) error {
+	detected, ok, err := Detect(path)
+	if err != nil {
+		return err
+	}
+
+	if ok && detected != engine {
+		return fmt.Errorf("%w: %s holds a %s database, but the %s engine is selected",
+			ErrEngineMismatch, path, detected, engine)
+	}
+
+	return nil
+}
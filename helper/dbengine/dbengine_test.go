@@ -0,0 +1,170 @@
+package dbengine
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// newLevelDBDataDir creates the data directory holding a block and the state of an account
+func newLevelDBDataDir(t *testing.T) (string, *types.Header) {
+	t.Helper()
+
+	dataDir := t.TempDir()
+
+	stateStorage, err := OpenStateStorage(LevelDB, dataDir, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	_, root, err := itrie.NewState(stateStorage).NewSnapshot().Commit([]*state.Object{{
+		Address:  types.StringToAddress("1"),
+		Balance:  big.NewInt(100),
+		CodeHash: types.EmptyCodeHash,
+		Root:     types.EmptyRootHash,
+	}})
+	require.NoError(t, err)
+	require.NoError(t, stateStorage.Close())
+
+	chainStorage, err := OpenBlockchainStorage(LevelDB, dataDir, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	header := &types.Header{Number: 1, StateRoot: types.BytesToHash(root)}
+	header.ComputeHash()
+
+	batch := storage.NewBatchWriter(chainStorage)
+	batch.PutCanonicalHeader(header, big.NewInt(1))
+	require.NoError(t, batch.WriteBatch())
+	require.NoError(t, chainStorage.Close())
+
+	return dataDir, header
+}
+
+func TestConvertDataDir(t *testing.T) {
+	t.Parallel()
+
+	dataDir, header := newLevelDBDataDir(t)
+	targetDataDir := t.TempDir()
+
+	result, err := ConvertDataDir(dataDir, targetDataDir, hclog.NewNullLogger())
+	require.NoError(t, err)
+	require.NotZero(t, result.Blockchain)
+	require.NotZero(t, result.State)
+
+	for _, dir := range []string{BlockchainDir, StateDir} {
+		engine, ok, err := Detect(filepath.Join(targetDataDir, dir))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, Pebble, engine)
+	}
+
+	// the converted databases can't be opened by leveldb, nor the source ones by pebble
+	_, err = OpenBlockchainStorage(LevelDB, targetDataDir, hclog.NewNullLogger())
+	require.ErrorIs(t, err, ErrEngineMismatch)
+
+	_, err = OpenStateStorage(Pebble, dataDir, hclog.NewNullLogger())
+	require.ErrorIs(t, err, ErrEngineMismatch)
+
+	chainStorage, err := OpenBlockchainStorage(Pebble, targetDataDir, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	headHash, ok := chainStorage.ReadHeadHash()
+	require.True(t, ok)
+	require.Equal(t, header.Hash, headHash)
+
+	stored, err := chainStorage.ReadHeader(headHash)
+	require.NoError(t, err)
+	require.Equal(t, header, stored)
+	require.NoError(t, chainStorage.Close())
+
+	stateStorage, err := OpenStateStorage(Pebble, targetDataDir, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	snap, err := itrie.NewState(stateStorage).NewSnapshotAt(header.StateRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.StringToAddress("1"))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), account.Balance)
+	require.NoError(t, stateStorage.Close())
+
+	// the converted databases are not overwritten
+	_, err = ConvertDataDir(dataDir, targetDataDir, hclog.NewNullLogger())
+	require.ErrorIs(t, err, ErrTargetNotEmpty)
+}
+
+func TestConvertLevelDBToPebble_NotLevelDB(t *testing.T) {
+	t.Parallel()
+
+	_, err := ConvertLevelDBToPebble(t.TempDir(), t.TempDir(), hclog.NewNullLogger())
+	require.ErrorIs(t, err, ErrNotLevelDB)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, engine := range Engines() {
+		parsed, err := Parse(string(engine))
+		require.NoError(t, err)
+		require.Equal(t, engine, parsed)
+	}
+
+	_, err := Parse("rocksdb")
+	require.ErrorIs(t, err, ErrUnknownEngine)
+}
+
+func TestInspectDataDir(t *testing.T) {
+	t.Parallel()
+
+	dataDir, header := newLevelDBDataDir(t)
+	pebbleDataDir := t.TempDir()
+
+	_, err := ConvertDataDir(dataDir, pebbleDataDir, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	for dir, engine := range map[string]Engine{dataDir: LevelDB, pebbleDataDir: Pebble} {
+		require.NoError(t, CompactDataDir(dir, hclog.NewNullLogger()))
+
+		result, err := InspectDataDir(dir, hclog.NewNullLogger())
+		require.NoError(t, err)
+		require.Equal(t, engine, result.Blockchain.Engine)
+		require.Equal(t, engine, result.State.Engine)
+		require.Zero(t, result.FreezerSize)
+
+		prefixes := map[string]PrefixStats{}
+		for _, prefix := range result.Blockchain.Prefixes {
+			prefixes[prefix.Name] = prefix
+		}
+
+		// the canonical header writes the header, the head hash and number,
+		// the canonical hash and the total difficulty
+		require.Equal(t, uint64(5), result.Blockchain.Keys)
+		require.Equal(t, uint64(1), prefixes["headers"].Keys)
+		require.Equal(t, uint64(2), prefixes["head"].Keys)
+		require.Equal(t, uint64(1), prefixes["canonical hashes"].Keys)
+		require.Equal(t, uint64(1), prefixes["total difficulties"].Keys)
+
+		require.Len(t, result.State.Prefixes, 1)
+		require.Equal(t, "trie nodes", result.State.Prefixes[0].Name)
+		require.Equal(t, result.State.Size, result.State.Prefixes[0].Size)
+
+		blockchainStorage, stateStorage, err := OpenExistingStorages(dir, hclog.NewNullLogger())
+		require.NoError(t, err)
+
+		headHash, ok := blockchainStorage.ReadHeadHash()
+		require.True(t, ok)
+		require.Equal(t, header.Hash, headHash)
+
+		require.NoError(t, blockchainStorage.Close())
+		require.NoError(t, stateStorage.Close())
+	}
+
+	_, _, err = OpenExistingStorages(t.TempDir(), hclog.NewNullLogger())
+	require.ErrorIs(t, err, ErrNoDatabase)
+}
@@ -0,0 +1,365 @@
+package dbengine
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/freezer"
+	pebblestorage "github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+)
+
+var (
+	ErrNoDatabase = errors.New("data directory doesn't hold a blockchain database")
+)
+
+// blockchainPrefixes are the kinds of the data stored in the blockchain database by their key prefix
+var blockchainPrefixes = []struct {
+	prefix []byte
+	name   string
+}{
+	{storage.HEADER, "headers"},
+	{storage.BODY, "bodies"},
+	{storage.RECEIPTS, "receipts"},
+	{storage.TX_LOOKUP_PREFIX, "transaction lookups"},
+	{storage.CANONICAL, "canonical hashes"},
+	{storage.DIFFICULTY, "total difficulties"},
+	{storage.FROZEN_NUMBER, "frozen block numbers"},
+	{storage.SNAPSHOTS, "snapshots"},
+	{storage.FORK, "forks"},
+	{storage.HEAD, "head"},
+}
+
+// PrefixStats is the number and the size of the key-value pairs of a kind of data
+type PrefixStats struct {
+	Name string `json:"name"`
+	Keys uint64 `json:"keys"`
+	Size uint64 `json:"size"`
+}
+
+// DatabaseStats is the content of a database, the largest kinds of data first
+type DatabaseStats struct {
+	Engine   Engine        `json:"engine"`
+	Keys     uint64        `json:"keys"`
+	Size     uint64        `json:"size"`
+	Prefixes []PrefixStats `json:"prefixes"`
+}
+
+// InspectResult is the content of the databases of a data directory.
+// The stats of a missing database are nil
+type InspectResult struct {
+	Blockchain  *DatabaseStats `json:"blockchain"`
+	State       *DatabaseStats `json:"state"`
+	FreezerSize uint64         `json:"freezer_size"`
+}
+
+// rawDB is a database accessed directly by the engine, regardless of the storage on top of it
+type rawDB interface {
+	// iterate calls fn for every key-value pair, which are valid only until fn returns
+	iterate(fn func(k, v []byte)) error
+	// compact compacts the whole key space
+	compact() error
+	Close() error
+}
+
+type levelDB struct {
+	db *leveldb.DB
+}
+
+func (l *levelDB) iterate(fn func(k, v []byte)) error {
+	iter := l.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		fn(iter.Key(), iter.Value())
+	}
+
+	return iter.Error()
+}
+
+func (l *levelDB) compact() error {
+	return l.db.CompactRange(util.Range{})
+}
+
+func (l *levelDB) Close() error {
+	return l.db.Close()
+}
+
+type pebbleDB struct {
+	db *pebble.DB
+}
+
+func (p *pebbleDB) iterate(fn func(k, v []byte)) error {
+	iter, err := p.db.NewIter(nil)
+	if err != nil {
+		return err
+	}
+
+	for valid := iter.First(); valid; valid = iter.Next() {
+		fn(iter.Key(), iter.Value())
+	}
+
+	return errors.Join(iter.Error(), iter.Close())
+}
+
+func (p *pebbleDB) compact() error {
+	iter, err := p.db.NewIter(nil)
+	if err != nil {
+		return err
+	}
+
+	var first, last []byte
+
+	if iter.First() {
+		first = append(first, iter.Key()...)
+	}
+
+	if iter.Last() {
+		last = append(last, iter.Key()...)
+	}
+
+	if err := errors.Join(iter.Error(), iter.Close()); err != nil {
+		return err
+	}
+
+	if first == nil {
+		return nil
+	}
+
+	// the end of the range is exclusive
+	return p.db.Compact(first, append(last, 0), true)
+}
+
+func (p *pebbleDB) Close() error {
+	return p.db.Close()
+}
+
+// openRaw opens the database in the directory, and returns false if the directory doesn't hold a database
+func openRaw(path string, logger hclog.Logger) (rawDB, Engine, bool, error) {
+	engine, ok, err := Detect(path)
+	if err != nil || !ok {
+		return nil, "", false, err
+	}
+
+	switch engine {
+	case LevelDB:
+		db, err := leveldb.OpenFile(path, &opt.Options{ErrorIfMissing: true})
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		return &levelDB{db: db}, engine, true, nil
+	case Pebble:
+		db, err := pebblestorage.OpenDB(path, logger)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		return &pebbleDB{db: db}, engine, true, nil
+	default:
+		return nil, "", false, fmt.Errorf("%w: %s", ErrUnknownEngine, engine)
+	}
+}
+
+// InspectDataDir reports the number and the size of the key-value pairs per kind of data
+// in the blockchain and state databases of the data directory, and the size of the freezer
+func InspectDataDir(dataDir string, logger hclog.Logger) (*InspectResult, error) {
+	var (
+		result = &InspectResult{}
+		err    error
+	)
+
+	result.Blockchain, err = inspectDB(filepath.Join(dataDir, BlockchainDir), describeBlockchainKey, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the blockchain database: %w", err)
+	}
+
+	result.State, err = inspectDB(filepath.Join(dataDir, StateDir), itrie.DescribeKey, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the state database: %w", err)
+	}
+
+	result.FreezerSize, err = dirSize(filepath.Join(dataDir, FreezerDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect the freezer: %w", err)
+	}
+
+	return result, nil
+}
+
+// inspectDB groups the key-value pairs of the database by the kind of data described by their key
+func inspectDB(path string, describe func(k []byte) string, logger hclog.Logger) (*DatabaseStats, error) {
+	db, engine, ok, err := openRaw(path, logger)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	stats := &DatabaseStats{Engine: engine}
+	prefixes := map[string]*PrefixStats{}
+
+	err = db.iterate(func(k, v []byte) {
+		name := describe(k)
+
+		prefix, ok := prefixes[name]
+		if !ok {
+			prefix = &PrefixStats{Name: name}
+			prefixes[name] = prefix
+		}
+
+		size := uint64(len(k) + len(v))
+
+		prefix.Keys++
+		prefix.Size += size
+		stats.Keys++
+		stats.Size += size
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, prefix := range prefixes {
+		stats.Prefixes = append(stats.Prefixes, *prefix)
+	}
+
+	sort.Slice(stats.Prefixes, func(i, j int) bool {
+		if stats.Prefixes[i].Size != stats.Prefixes[j].Size {
+			return stats.Prefixes[i].Size > stats.Prefixes[j].Size
+		}
+
+		return stats.Prefixes[i].Name < stats.Prefixes[j].Name
+	})
+
+	return stats, nil
+}
+
+// describeBlockchainKey returns the kind of the data stored under the key in the blockchain database
+func describeBlockchainKey(k []byte) string {
+	for _, p := range blockchainPrefixes {
+		if bytes.HasPrefix(k, p.prefix) {
+			return p.name
+		}
+	}
+
+	return "other"
+}
+
+// DataDirSize returns the size on the disk of the blockchain and state databases of the data directory
+func DataDirSize(dataDir string) (uint64, error) {
+	total := uint64(0)
+
+	for _, dir := range []string{BlockchainDir, StateDir} {
+		size, err := dirSize(filepath.Join(dataDir, dir))
+		if err != nil {
+			return 0, err
+		}
+
+		total += size
+	}
+
+	return total, nil
+}
+
+// dirSize returns the total size of the files in the directory, or zero if it doesn't exist
+func dirSize(path string) (uint64, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	size := uint64(0)
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return 0, err
+		}
+
+		if info.Mode().IsRegular() {
+			size += uint64(info.Size())
+		}
+	}
+
+	return size, nil
+}
+
+// CompactDataDir compacts the blockchain and state databases of the data directory
+func CompactDataDir(dataDir string, logger hclog.Logger) error {
+	for _, dir := range []string{BlockchainDir, StateDir} {
+		db, _, ok, err := openRaw(filepath.Join(dataDir, dir), logger)
+		if err != nil {
+			return fmt.Errorf("failed to open the %s database: %w", dir, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		logger.Info("compacting", "database", dir)
+
+		if err := db.compact(); err != nil {
+			return errors.Join(fmt.Errorf("failed to compact the %s database: %w", dir, err), db.Close())
+		}
+
+		if err := db.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OpenExistingStorages opens the blockchain and state storages of the data directory of
+// a stopped node with the engine they were created with. The blockchain storage reads
+// the frozen blocks from the freezer, if there is one, without freezing any other block
+func OpenExistingStorages(dataDir string, logger hclog.Logger) (storage.Storage, itrie.Storage, error) {
+	engine, ok, err := Detect(filepath.Join(dataDir, BlockchainDir))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNoDatabase, dataDir)
+	}
+
+	var blockchainStorage storage.Storage
+
+	if _, err := os.Stat(filepath.Join(dataDir, FreezerDir)); err == nil {
+		// the freezer storage is never started, so the threshold, the compression and the history pruning don't apply
+		var freezerStorage *freezer.Storage
+
+		freezerStorage, err = OpenFreezerStorage(engine, dataDir, 0, false, false, logger)
+		if err == nil {
+			blockchainStorage = freezerStorage
+		}
+	} else {
+		blockchainStorage, err = OpenBlockchainStorage(engine, dataDir, logger)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open the blockchain storage: %w", err)
+	}
+
+	stateStorage, err := OpenStateStorage(engine, dataDir, logger)
+	if err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("failed to open the state storage: %w", err), blockchainStorage.Close())
+	}
+
+	return blockchainStorage, stateStorage, nil
+}
@@ -42,6 +42,9 @@ type debugBlockchainStore interface {
 	// GetBlockByNumber gets a block using the provided height
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 
+	// CheckHistory returns an error if the body and the receipts of the block have been pruned
+	CheckHistory(number uint64) error
+
 	// TraceBlock traces all transactions in the given block
 	TraceBlock(*types.Block, tracer.Tracer) ([]interface{}, error)
 
@@ -103,6 +106,10 @@ func (d *Debug) TraceBlockByNumber(
 
 			block, ok := d.store.GetBlockByNumber(num, true)
 			if !ok {
+				if err := d.store.CheckHistory(num); err != nil {
+					return nil, err
+				}
+
 				return nil, fmt.Errorf("block %d not found", num)
 			}
 
@@ -120,6 +127,12 @@ func (d *Debug) TraceBlockByHash(
 		func() (interface{}, error) {
 			block, ok := d.store.GetBlockByHash(blockHash, true)
 			if !ok {
+				if block != nil {
+					if err := d.store.CheckHistory(block.Number()); err != nil {
+						return nil, err
+					}
+				}
+
 				return nil, fmt.Errorf("block %s not found", blockHash)
 			}
 
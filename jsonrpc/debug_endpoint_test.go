@@ -26,6 +26,7 @@ type debugEndpointMockStore struct {
 	traceCallFn         func(*types.Transaction, *types.Header, tracer.Tracer) (interface{}, error)
 	getNonceFn          func(types.Address) uint64
 	getAccountFn        func(types.Hash, types.Address) (*Account, error)
+	checkHistoryFn      func(uint64) error
 }
 
 func (s *debugEndpointMockStore) Header() *types.Header {
@@ -48,6 +49,14 @@ func (s *debugEndpointMockStore) GetBlockByNumber(num uint64, full bool) (*types
 	return s.getBlockByNumberFn(num, full)
 }
 
+func (s *debugEndpointMockStore) CheckHistory(number uint64) error {
+	if s.checkHistoryFn == nil {
+		return nil
+	}
+
+	return s.checkHistoryFn(number)
+}
+
 func (s *debugEndpointMockStore) TraceBlock(block *types.Block, tracer tracer.Tracer) ([]interface{}, error) {
 	return s.traceBlockFn(block, tracer)
 }
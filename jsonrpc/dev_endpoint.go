@@ -0,0 +1,171 @@
+package jsonrpc
+
+import (
+	"math/big"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// DevStore provides the node controls of the dev consensus,
+// used by the Hardhat and Anvil compatible endpoints
+type DevStore interface {
+	// Mine seals a new block immediately, with the given timestamp if it is not zero
+	Mine(timestamp uint64) error
+
+	// IncreaseTime moves the clock of the following blocks forward and returns the total offset
+	IncreaseTime(seconds uint64) int64
+
+	// SetNextBlockTimestamp sets the exact timestamp of the next block
+	SetNextBlockTimestamp(timestamp uint64) error
+
+	// SetAutoMine switches the sealing of a block for every new transaction
+	SetAutoMine(enabled bool)
+
+	// AutoMine returns if a block is sealed for every new transaction
+	AutoMine() bool
+
+	// Snapshot records the current chain state and returns its id
+	Snapshot() uint64
+
+	// Revert rewinds the chain to the snapshot with the given id
+	Revert(id uint64) (bool, error)
+
+	// SetBalance sets the balance of the account
+	SetBalance(addr types.Address, balance *big.Int) error
+
+	// SetCode sets the code of the account
+	SetCode(addr types.Address, code []byte) error
+
+	// SetStorageAt sets a storage slot of the account
+	SetStorageAt(addr types.Address, slot, value types.Hash) error
+
+	// SetNonce sets the nonce of the account
+	SetNonce(addr types.Address, nonce uint64) error
+
+	// ImpersonateAccount allows sending unsigned transactions on behalf of the account
+	ImpersonateAccount(addr types.Address)
+
+	// StopImpersonatingAccount stops accepting unsigned transactions of the account
+	StopImpersonatingAccount(addr types.Address)
+
+	// IsImpersonated checks if unsigned transactions of the account are accepted
+	IsImpersonated(addr types.Address) bool
+
+	// SendImpersonatedTransaction queues the unsigned transaction of an impersonated account,
+	// assigning its nonce if fillNonce is set, and computes its hash
+	SendImpersonatedTransaction(tx *types.Transaction, fillNonce bool) error
+}
+
+// Evm is the Hardhat compatible evm jsonrpc endpoint, registered only with the dev consensus
+type Evm struct {
+	dev DevStore
+}
+
+// Mine seals a new block, with the given timestamp if it is provided
+func (e *Evm) Mine(timestamp *argUint64) (interface{}, error) {
+	var ts uint64
+	if timestamp != nil {
+		ts = uint64(*timestamp)
+	}
+
+	if err := e.dev.Mine(ts); err != nil {
+		return nil, err
+	}
+
+	return "0x0", nil
+}
+
+// IncreaseTime moves the clock of the following blocks forward by the given number of seconds
+// and returns the total time offset
+func (e *Evm) IncreaseTime(seconds argUint64) (interface{}, error) {
+	return e.dev.IncreaseTime(uint64(seconds)), nil
+}
+
+// SetNextBlockTimestamp sets the exact timestamp of the next block
+func (e *Evm) SetNextBlockTimestamp(timestamp argUint64) (interface{}, error) {
+	if err := e.dev.SetNextBlockTimestamp(uint64(timestamp)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SetAutomine switches the sealing of a block for every new transaction
+func (e *Evm) SetAutomine(enabled bool) (interface{}, error) {
+	e.dev.SetAutoMine(enabled)
+
+	return nil, nil
+}
+
+// Snapshot records the current chain state and returns its id
+func (e *Evm) Snapshot() (interface{}, error) {
+	return argUint64(e.dev.Snapshot()), nil
+}
+
+// Revert rewinds the chain to the snapshot with the given id
+func (e *Evm) Revert(id argUint64) (interface{}, error) {
+	return e.dev.Revert(uint64(id))
+}
+
+// Anvil is the Anvil compatible anvil jsonrpc endpoint, registered only with the dev consensus
+type Anvil struct {
+	dev DevStore
+}
+
+// GetAutomine returns if a block is sealed for every new transaction
+func (a *Anvil) GetAutomine() (interface{}, error) {
+	return a.dev.AutoMine(), nil
+}
+
+// SetBalance sets the balance of the account
+func (a *Anvil) SetBalance(addr types.Address, balance argBig) (interface{}, error) {
+	b := big.Int(balance)
+
+	if err := a.dev.SetBalance(addr, &b); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SetCode sets the code of the account
+func (a *Anvil) SetCode(addr types.Address, code argBytes) (interface{}, error) {
+	if err := a.dev.SetCode(addr, code); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SetStorageAt sets a storage slot of the account
+func (a *Anvil) SetStorageAt(addr types.Address, slot types.Hash, value types.Hash) (interface{}, error) {
+	if err := a.dev.SetStorageAt(addr, slot, value); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// SetNonce sets the nonce of the account
+func (a *Anvil) SetNonce(addr types.Address, nonce argUint64) (interface{}, error) {
+	if err := a.dev.SetNonce(addr, uint64(nonce)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// ImpersonateAccount allows sending transactions on behalf of the account
+// with eth_sendTransaction, without its private key
+func (a *Anvil) ImpersonateAccount(addr types.Address) (interface{}, error) {
+	a.dev.ImpersonateAccount(addr)
+
+	return nil, nil
+}
+
+// StopImpersonatingAccount stops accepting eth_sendTransaction calls of the account
+func (a *Anvil) StopImpersonatingAccount(addr types.Address) (interface{}, error) {
+	a.dev.StopImpersonatingAccount(addr)
+
+	return nil, nil
+}
@@ -0,0 +1,301 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+type mockDevStore struct {
+	mined        []uint64
+	timeOffset   int64
+	timestamp    uint64
+	autoMine     bool
+	snapshots    []uint64
+	balances     map[types.Address]*big.Int
+	codes        map[types.Address][]byte
+	storage      map[types.Address]map[types.Hash]types.Hash
+	nonces       map[types.Address]uint64
+	impersonated map[types.Address]bool
+	sentTxs      []*types.Transaction
+	fillNonces   []bool
+}
+
+func newMockDevStore() *mockDevStore {
+	return &mockDevStore{
+		balances:     map[types.Address]*big.Int{},
+		codes:        map[types.Address][]byte{},
+		storage:      map[types.Address]map[types.Hash]types.Hash{},
+		nonces:       map[types.Address]uint64{},
+		impersonated: map[types.Address]bool{},
+	}
+}
+
+func (m *mockDevStore) Mine(timestamp uint64) error {
+	m.mined = append(m.mined, timestamp)
+
+	return nil
+}
+
+func (m *mockDevStore) IncreaseTime(seconds uint64) int64 {
+	m.timeOffset += int64(seconds)
+
+	return m.timeOffset
+}
+
+func (m *mockDevStore) SetNextBlockTimestamp(timestamp uint64) error {
+	m.timestamp = timestamp
+
+	return nil
+}
+
+func (m *mockDevStore) SetAutoMine(enabled bool) {
+	m.autoMine = enabled
+}
+
+func (m *mockDevStore) AutoMine() bool {
+	return m.autoMine
+}
+
+func (m *mockDevStore) Snapshot() uint64 {
+	m.snapshots = append(m.snapshots, uint64(len(m.snapshots)))
+
+	return uint64(len(m.snapshots) - 1)
+}
+
+func (m *mockDevStore) Revert(id uint64) (bool, error) {
+	if id >= uint64(len(m.snapshots)) {
+		return false, nil
+	}
+
+	m.snapshots = m.snapshots[:id]
+
+	return true, nil
+}
+
+func (m *mockDevStore) SetBalance(addr types.Address, balance *big.Int) error {
+	m.balances[addr] = balance
+
+	return nil
+}
+
+func (m *mockDevStore) SetCode(addr types.Address, code []byte) error {
+	m.codes[addr] = code
+
+	return nil
+}
+
+func (m *mockDevStore) SetStorageAt(addr types.Address, slot, value types.Hash) error {
+	if m.storage[addr] == nil {
+		m.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	m.storage[addr][slot] = value
+
+	return nil
+}
+
+func (m *mockDevStore) SetNonce(addr types.Address, nonce uint64) error {
+	m.nonces[addr] = nonce
+
+	return nil
+}
+
+func (m *mockDevStore) ImpersonateAccount(addr types.Address) {
+	m.impersonated[addr] = true
+}
+
+func (m *mockDevStore) StopImpersonatingAccount(addr types.Address) {
+	delete(m.impersonated, addr)
+}
+
+func (m *mockDevStore) IsImpersonated(addr types.Address) bool {
+	return m.impersonated[addr]
+}
+
+func (m *mockDevStore) SendImpersonatedTransaction(tx *types.Transaction, fillNonce bool) error {
+	tx.ComputeHash(0)
+
+	m.sentTxs = append(m.sentTxs, tx)
+	m.fillNonces = append(m.fillNonces, fillNonce)
+
+	return nil
+}
+
+func TestDevEndpoints_Registration(t *testing.T) {
+	t.Parallel()
+
+	withoutDev := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{})
+
+	resp, err := withoutDev.Handle([]byte(`{"method": "evm_snapshot", "params": []}`))
+	require.NoError(t, err)
+
+	var id string
+	require.Error(t, expectJSONResult(resp, &id))
+
+	withDev := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+		devStore: newMockDevStore(),
+	})
+
+	resp, err = withDev.Handle([]byte(`{"method": "evm_snapshot", "params": []}`))
+	require.NoError(t, err)
+	require.NoError(t, expectJSONResult(resp, &id))
+	assert.Equal(t, "0x0", id)
+}
+
+func TestDevEndpoints_Evm(t *testing.T) {
+	t.Parallel()
+
+	dev := newMockDevStore()
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+		devStore: dev,
+	})
+
+	call := func(req string, result interface{}) {
+		t.Helper()
+
+		resp, err := dispatcher.Handle([]byte(req))
+		require.NoError(t, err)
+		require.NoError(t, expectJSONResult(resp, result))
+	}
+
+	var mined string
+
+	call(`{"method": "evm_mine", "params": []}`, &mined)
+	call(`{"method": "evm_mine", "params": ["0x64"]}`, &mined)
+	assert.Equal(t, "0x0", mined)
+	assert.Equal(t, []uint64{0, 100}, dev.mined)
+
+	var offset int64
+
+	call(`{"method": "evm_increaseTime", "params": [60]}`, &offset)
+	call(`{"method": "evm_increaseTime", "params": ["0x3c"]}`, &offset)
+	assert.Equal(t, int64(120), offset)
+
+	var empty interface{}
+
+	call(`{"method": "evm_setNextBlockTimestamp", "params": [1700000000]}`, &empty)
+	assert.Equal(t, uint64(1700000000), dev.timestamp)
+
+	call(`{"method": "evm_setAutomine", "params": [true]}`, &empty)
+	assert.True(t, dev.autoMine)
+
+	var (
+		first, second string
+		reverted      bool
+	)
+
+	call(`{"method": "evm_snapshot", "params": []}`, &first)
+	call(`{"method": "evm_snapshot", "params": []}`, &second)
+	assert.Equal(t, "0x1", second)
+
+	call(`{"method": "evm_revert", "params": ["`+first+`"]}`, &reverted)
+	assert.True(t, reverted)
+
+	call(`{"method": "evm_revert", "params": ["`+second+`"]}`, &reverted)
+	assert.False(t, reverted)
+}
+
+func TestDevEndpoints_Anvil(t *testing.T) {
+	t.Parallel()
+
+	dev := newMockDevStore()
+	dispatcher := newTestDispatcher(t, hclog.NewNullLogger(), newMockStore(), &dispatcherParams{
+		devStore: dev,
+	})
+
+	call := func(req string, result interface{}) {
+		t.Helper()
+
+		resp, err := dispatcher.Handle([]byte(req))
+		require.NoError(t, err)
+		require.NoError(t, expectJSONResult(resp, result))
+	}
+
+	addr := types.StringToAddress("0x1")
+
+	var empty interface{}
+
+	call(`{"method": "anvil_setBalance", "params": ["`+addr.String()+`", "0xde0b6b3a7640000"]}`, &empty)
+	assert.Equal(t, oneEther, dev.balances[addr])
+
+	call(`{"method": "anvil_setCode", "params": ["`+addr.String()+`", "0x6001"]}`, &empty)
+	assert.Equal(t, []byte{0x60, 0x01}, dev.codes[addr])
+
+	var ok bool
+
+	call(`{"method": "anvil_setStorageAt", "params": ["`+addr.String()+`", "0x1", "0x2a"]}`, &ok)
+	assert.True(t, ok)
+	assert.Equal(t, types.StringToHash("0x2a"), dev.storage[addr][types.StringToHash("0x1")])
+
+	call(`{"method": "anvil_setNonce", "params": ["`+addr.String()+`", "0x5"]}`, &empty)
+	assert.Equal(t, uint64(5), dev.nonces[addr])
+
+	call(`{"method": "anvil_impersonateAccount", "params": ["`+addr.String()+`"]}`, &empty)
+	assert.True(t, dev.impersonated[addr])
+
+	call(`{"method": "anvil_getAutomine", "params": []}`, &ok)
+	assert.False(t, ok)
+
+	call(`{"method": "anvil_stopImpersonatingAccount", "params": ["`+addr.String()+`"]}`, &empty)
+	assert.False(t, dev.impersonated[addr])
+}
+
+func TestEth_SendTransaction_Impersonated(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	store.add(&types.Block{Header: &types.Header{Number: 1}})
+
+	dev := newMockDevStore()
+	eth := newTestEthEndpoint(store)
+
+	from := types.StringToAddress("0x1")
+	to := types.StringToAddress("0x2")
+	args := &txnArgs{
+		From:     &from,
+		To:       &to,
+		Nonce:    toArgUint64Ptr(0),
+		Gas:      toArgUint64Ptr(21000),
+		GasPrice: toArgBytesPtr(big.NewInt(1).Bytes()),
+		Value:    toArgBytesPtr(oneEther.Bytes()),
+	}
+
+	// eth_sendTransaction is rejected without the dev consensus
+	_, err := eth.SendTransaction(args)
+	require.Error(t, err)
+
+	eth.dev = dev
+
+	// and for the accounts which are not impersonated
+	_, err = eth.SendTransaction(args)
+	require.Error(t, err)
+
+	dev.ImpersonateAccount(from)
+
+	hash, err := eth.SendTransaction(args)
+	require.NoError(t, err)
+	require.Len(t, dev.sentTxs, 1)
+
+	tx := dev.sentTxs[0]
+	assert.Equal(t, tx.Hash.String(), hash)
+	assert.Equal(t, from, tx.From)
+	assert.Equal(t, &to, tx.To)
+	assert.Equal(t, uint64(21000), tx.Gas)
+	assert.Equal(t, oneEther, tx.Value)
+	// the explicit nonce is kept
+	assert.False(t, dev.fillNonces[0])
+
+	// the dev consensus assigns the missing one
+	args.Nonce = nil
+
+	_, err = eth.SendTransaction(args)
+	require.NoError(t, err)
+	require.Len(t, dev.fillNonces, 2)
+	assert.True(t, dev.fillNonces[1])
+}
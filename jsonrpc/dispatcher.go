@@ -12,6 +12,8 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-hclog"
 )
@@ -39,6 +41,8 @@ type endpoints struct {
 	TxPool *TxPool
 	Bridge *Bridge
 	Debug  *Debug
+	Evm    *Evm
+	Anvil  *Anvil
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -61,6 +65,9 @@ type dispatcherParams struct {
 	blockRangeLimit         uint64
 
 	concurrentRequestsDebug uint64
+	txPoolAdmin             bool
+
+	devStore DevStore
 }
 
 func (dp dispatcherParams) isExceedingBatchLengthLimit(value uint64) bool {
@@ -96,6 +103,7 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		d.params.chainID,
 		d.filterManager,
 		d.params.priceLimit,
+		d.params.devStore,
 	}
 	d.endpoints.Net = &Net{
 		store,
@@ -105,7 +113,8 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		d.params.chainName,
 	}
 	d.endpoints.TxPool = &TxPool{
-		store,
+		store: store,
+		admin: d.params.txPoolAdmin,
 	}
 	d.endpoints.Bridge = &Bridge{
 		store,
@@ -134,7 +143,23 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) error {
 		return err
 	}
 
-	return d.registerService("debug", d.endpoints.Debug)
+	if err = d.registerService("debug", d.endpoints.Debug); err != nil {
+		return err
+	}
+
+	// the node controls are exposed only by the dev consensus
+	if d.params.devStore == nil {
+		return nil
+	}
+
+	d.endpoints.Evm = &Evm{d.params.devStore}
+	d.endpoints.Anvil = &Anvil{d.params.devStore}
+
+	if err = d.registerService("evm", d.endpoints.Evm); err != nil {
+		return err
+	}
+
+	return d.registerService("anvil", d.endpoints.Anvil)
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -440,6 +465,20 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 			}
 		}
 
+		// keep the error codes defined by the endpoints
+		var rpcErr Error
+		if errors.As(err, &rpcErr) {
+			return data, rpcErr
+		}
+
+		if errors.Is(err, state.ErrStatePruned) {
+			return data, NewStatePrunedError(err.Error())
+		}
+
+		if errors.Is(err, blockchain.ErrHistoryPruned) {
+			return data, NewHistoryPrunedError(err.Error())
+		}
+
 		return data, NewInvalidRequestError(err.Error())
 	}
 
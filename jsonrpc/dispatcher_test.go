@@ -2,12 +2,15 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
@@ -236,6 +239,56 @@ func (m *mockService) Filter(f LogQuery) (interface{}, error) {
 	return nil, nil
 }
 
+func (m *mockService) Fail(typed bool) (interface{}, error) {
+	if typed {
+		return nil, NewUnprotectedTxError("unprotected tx")
+	}
+
+	return nil, errors.New("plain error")
+}
+
+func (m *mockService) Pruned() (interface{}, error) {
+	return nil, fmt.Errorf("unable to get snapshot: %w", state.ErrStatePruned)
+}
+
+func (m *mockService) PrunedHistory() (interface{}, error) {
+	return nil, fmt.Errorf("%w: block 1 is below the oldest block 10 with history", blockchain.ErrHistoryPruned)
+}
+
+func TestDispatcher_EndpointErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(t,
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{},
+	)
+
+	require.NoError(t, dispatcher.registerService("mock", &mockService{}))
+
+	// the error codes defined by the endpoints are kept
+	_, err := dispatcher.handleReq(Request{Method: "mock_fail", Params: []byte(`[true]`)})
+	require.Error(t, err)
+	assert.Equal(t, -32003, err.ErrorCode())
+	assert.Equal(t, "unprotected tx", err.Error())
+
+	_, err = dispatcher.handleReq(Request{Method: "mock_fail", Params: []byte(`[false]`)})
+	require.Error(t, err)
+	assert.Equal(t, -32600, err.ErrorCode())
+
+	// the pruned historical state is reported with its own error code
+	_, err = dispatcher.handleReq(Request{Method: "mock_pruned", Params: []byte(`[]`)})
+	require.Error(t, err)
+	assert.Equal(t, -32000, err.ErrorCode())
+	assert.Contains(t, err.Error(), state.ErrStatePruned.Error())
+
+	// the pruned history is reported with the error code of the history expiry
+	_, err = dispatcher.handleReq(Request{Method: "mock_prunedHistory", Params: []byte(`[]`)})
+	require.Error(t, err)
+	assert.Equal(t, 4444, err.ErrorCode())
+	assert.Contains(t, err.Error(), blockchain.ErrHistoryPruned.Error())
+}
+
 func TestDispatcherFuncDecode(t *testing.T) {
 	t.Parallel()
 
@@ -76,6 +76,48 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+// unprotectedTxError is returned when a replay-unprotected (pre-EIP-155)
+// transaction is rejected by the transaction pool
+type unprotectedTxError struct {
+	err string
+}
+
+func (e *unprotectedTxError) Error() string {
+	return e.err
+}
+
+func (e *unprotectedTxError) ErrorCode() int {
+	return -32003
+}
+
+// statePrunedError is returned when the requested historical state
+// has been removed by the state pruning
+type statePrunedError struct {
+	err string
+}
+
+func (e *statePrunedError) Error() string {
+	return e.err
+}
+
+func (e *statePrunedError) ErrorCode() int {
+	return -32000
+}
+
+// historyPrunedError is returned when the requested block body or receipts
+// have been removed by the history expiry
+type historyPrunedError struct {
+	err string
+}
+
+func (e *historyPrunedError) Error() string {
+	return e.err
+}
+
+func (e *historyPrunedError) ErrorCode() int {
+	return 4444
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
@@ -86,6 +128,18 @@ func NewInvalidParamsError(msg string) *invalidParamsError {
 	return &invalidParamsError{msg}
 }
 
+func NewUnprotectedTxError(msg string) *unprotectedTxError {
+	return &unprotectedTxError{msg}
+}
+
+func NewStatePrunedError(msg string) *statePrunedError {
+	return &statePrunedError{msg}
+}
+
+func NewHistoryPrunedError(msg string) *historyPrunedError {
+	return &historyPrunedError{msg}
+}
+
 func NewInternalError(msg string) *internalError {
 	return &internalError{msg}
 }
@@ -2,8 +2,10 @@ package jsonrpc
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
@@ -13,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_Block_GetBlockByNumber(t *testing.T) {
@@ -68,6 +71,74 @@ func TestEth_Block_GetBlockByHash(t *testing.T) {
 	assert.Nil(t, res)
 }
 
+func TestEth_Block_PrunedHistory(t *testing.T) {
+	store := &mockBlockStore{tail: 5}
+	for i := 0; i < 10; i++ {
+		block := newTestBlock(uint64(i), types.BytesToHash([]byte{byte(i + 1)}))
+		tx := &types.Transaction{
+			Nonce:    uint64(i),
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(1),
+			V:        big.NewInt(1),
+			R:        big.NewInt(1),
+			S:        big.NewInt(1),
+		}
+		tx.ComputeHash(uint64(i))
+
+		block.Transactions = []*types.Transaction{tx}
+
+		store.add(block)
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetBlockByNumber(BlockNumber(2), false)
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+	assert.Nil(t, res)
+
+	res, err = eth.GetBlockByHash(store.blocks[3].Hash(), false)
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+	assert.Nil(t, res)
+
+	res, err = eth.GetBlockTransactionCountByNumber(BlockNumber(4))
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+	assert.Nil(t, res)
+
+	// the transactions which are not found may belong to the pruned blocks
+	prunedTx := store.blocks[3].Transactions[0].Hash
+
+	res, err = eth.GetTransactionByHash(prunedTx)
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+	assert.Nil(t, res)
+
+	res, err = eth.GetTransactionReceipt(prunedTx)
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+	assert.Nil(t, res)
+
+	res, err = eth.GetTransactionByHash(store.blocks[7].Transactions[0].Hash)
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+
+	// the unknown transactions are not reported as pruned while the whole history is kept
+	store.tail = 0
+
+	res, err = eth.GetTransactionReceipt(hash3)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+
+	// the blocks from the tail, and the genesis, are served
+	for _, number := range []BlockNumber{0, 5, 9} {
+		res, err = eth.GetBlockByNumber(number, false)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	}
+
+	// the blocks above the head are not pruned
+	res, err = eth.GetBlockByNumber(BlockNumber(50), false)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
 func TestEth_Block_BlockNumber(t *testing.T) {
 	store := &mockBlockStore{}
 	store.add(&types.Block{
@@ -440,6 +511,90 @@ func TestEth_Call(t *testing.T) {
 	})
 }
 
+func TestEth_CallBundle(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	parent := newTestBlock(100, hash1)
+	parent.Header.GasLimit = 5_000_000
+	parent.Header.BaseFee = 10
+	parent.Header.Miner = addr2.Bytes()
+	parent.Header.Timestamp = uint64(time.Now().Add(time.Hour).Unix())
+	store.add(parent)
+	store.bundleResults = []*runtime.ExecutionResult{
+		{GasUsed: 21000, ReturnValue: []byte{0x1}},
+		{GasUsed: 30000, Err: runtime.ErrExecutionReverted},
+		{GasUsed: 40000, Err: runtime.ErrOutOfGas},
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	txs := make([]argBytes, len(store.bundleResults))
+
+	for i := range txs {
+		txn := &types.Transaction{
+			From:  addr0,
+			To:    &addr1,
+			Nonce: uint64(i),
+			V:     big.NewInt(1),
+		}
+
+		txs[i] = txn.MarshalRLP()
+	}
+
+	res, err := eth.CallBundle(&bundleArgs{Txs: txs}, BlockNumberOrHash{})
+	require.NoError(t, err)
+
+	result, ok := res.(*callBundleResult)
+	require.True(t, ok)
+
+	expectedTxs := make([]*types.Transaction, len(txs))
+
+	for i, raw := range txs {
+		expectedTxs[i] = &types.Transaction{}
+		require.NoError(t, expectedTxs[i].UnmarshalRLP(raw))
+		expectedTxs[i].ComputeHash(101)
+	}
+
+	// the bundle is simulated in the child block of the state block
+	assert.Equal(t, parent.Header, store.bundleParent)
+	assert.Equal(t, hash1, store.bundleHeader.ParentHash)
+	assert.Equal(t, uint64(101), store.bundleHeader.Number)
+	assert.Equal(t, parent.Header.Timestamp+1, store.bundleHeader.Timestamp)
+	assert.Equal(t, uint64(5_000_000), store.bundleHeader.GasLimit)
+	assert.Equal(t, uint64(11), store.bundleHeader.BaseFee)
+	assert.Equal(t, addr2.Bytes(), store.bundleHeader.Miner)
+
+	expectedBundle := &types.Bundle{Txs: expectedTxs}
+
+	assert.Equal(t, expectedBundle.ComputeHash().Hash, result.BundleHash)
+	assert.Equal(t, argUint64(100), result.StateBlockNumber)
+	assert.Equal(t, argUint64(91000), result.TotalGasUsed)
+	require.Len(t, result.Results, 3)
+
+	// successful transaction
+	assert.Equal(t, expectedTxs[0].Hash, result.Results[0].TxHash)
+	assert.Equal(t, argUint64(21000), result.Results[0].GasUsed)
+	assert.Equal(t, argBytes{0x1}, result.Results[0].ReturnValue)
+	assert.Empty(t, result.Results[0].Error)
+	assert.Empty(t, result.Results[0].Revert)
+
+	// reverted transaction
+	assert.Equal(t, expectedTxs[1].Hash, result.Results[1].TxHash)
+	assert.Equal(t, argUint64(30000), result.Results[1].GasUsed)
+	assert.Equal(t, runtime.ErrExecutionReverted.Error(), result.Results[1].Revert)
+	assert.Empty(t, result.Results[1].Error)
+
+	// failed transaction
+	assert.Equal(t, expectedTxs[2].Hash, result.Results[2].TxHash)
+	assert.Equal(t, argUint64(40000), result.Results[2].GasUsed)
+	assert.Equal(t, runtime.ErrOutOfGas.Error(), result.Results[2].Error)
+	assert.Empty(t, result.Results[2].Revert)
+
+	_, err = eth.CallBundle(&bundleArgs{}, BlockNumberOrHash{})
+	assert.Error(t, err)
+}
+
 type testStore interface {
 	ethStore
 }
@@ -456,6 +611,10 @@ type mockBlockStore struct {
 	returnValue     []byte
 	forksInTime     chain.ForksInTime
 	baseFee         uint64
+	bundleResults   []*runtime.ExecutionResult
+	bundleParent    *types.Header
+	bundleHeader    *types.Header
+	tail            uint64
 
 	maxPriorityFeePerGasFn func() (*big.Int, error)
 }
@@ -568,7 +727,7 @@ func (m *mockBlockStore) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, e
 func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Number() == blockNumber {
-			return b, true
+			return m.withHistory(b, full)
 		}
 	}
 
@@ -578,19 +737,41 @@ func (m *mockBlockStore) GetBlockByNumber(blockNumber uint64, full bool) (*types
 func (m *mockBlockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	for _, b := range m.blocks {
 		if b.Hash() == hash {
-			return b, true
+			return m.withHistory(b, full)
 		}
 	}
 
 	return nil, false
 }
 
+// withHistory returns the header only of the block whose body has been pruned, as the blockchain does
+func (m *mockBlockStore) withHistory(b *types.Block, full bool) (*types.Block, bool) {
+	if full && m.CheckHistory(b.Number()) != nil {
+		return &types.Block{Header: b.Header}, false
+	}
+
+	return b, true
+}
+
+func (m *mockBlockStore) CheckHistory(number uint64) error {
+	if number > 0 && number < m.tail {
+		return blockchain.ErrHistoryPruned
+	}
+
+	return nil
+}
+
 func (m *mockBlockStore) Header() *types.Header {
 	return m.blocks[len(m.blocks)-1].Header
 }
 
 func (m *mockBlockStore) ReadTxLookup(txnHash types.Hash) (types.Hash, bool) {
 	for _, block := range m.blocks {
+		// the lookups are pruned along with the history
+		if m.CheckHistory(block.Number()) != nil {
+			continue
+		}
+
 		for _, txn := range block.Transactions {
 			if txn.Hash == txnHash {
 				return block.Hash(), true
@@ -628,7 +809,52 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
-func (m *mockBlockStore) ApplyTxn(_ *types.Header, _ *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockBlockStore) CalculateGasLimit(number uint64) (uint64, error) {
+	parent, ok := m.GetBlockByNumber(number-1, false)
+	if !ok {
+		return 0, fmt.Errorf("parent of block %d not found", number)
+	}
+
+	return parent.Header.GasLimit, nil
+}
+
+func (m *mockBlockStore) CalculateBaseFee(parent *types.Header) uint64 {
+	return parent.BaseFee + 1
+}
+
+func (m *mockBlockStore) ApplyBundle(
+	parent *types.Header,
+	header *types.Header,
+	txs []*types.Transaction,
+) ([]*types.Receipt, []*runtime.ExecutionResult, error) {
+	m.bundleParent, m.bundleHeader = parent, header
+
+	receipts := make([]*types.Receipt, len(txs))
+	totalGas := uint64(0)
+
+	for i, tx := range txs {
+		tx.ComputeHash(header.Number)
+
+		result := m.bundleResults[i]
+		totalGas += result.GasUsed
+
+		receipts[i] = &types.Receipt{
+			TxHash:            tx.Hash,
+			GasUsed:           result.GasUsed,
+			CumulativeGasUsed: totalGas,
+		}
+
+		if result.Failed() {
+			receipts[i].SetStatus(types.ReceiptFailed)
+		} else {
+			receipts[i].SetStatus(types.ReceiptSuccess)
+		}
+	}
+
+	return receipts, m.bundleResults, nil
+}
+
+func (m *mockBlockStore) ApplyTxn(_ *types.Header, _ *types.Transaction, _ types.StateOverride, _, _ bool) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{
 		Err:         m.ethCallError,
 		ReturnValue: m.returnValue,
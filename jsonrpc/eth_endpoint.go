@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/hashicorp/go-hclog"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/state"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
@@ -29,6 +31,9 @@ type ethTxPoolStore interface {
 
 	// GetBaseFee returns the current base fee of TxPool
 	GetBaseFee() uint64
+
+	// AddBundle adds a new bundle of transactions to the tx pool
+	AddBundle(bundle *types.Bundle) error
 }
 
 type Account struct {
@@ -62,17 +67,36 @@ type ethBlockchainStore interface {
 	// GetReceiptsByHash returns the receipts for a block hash
 	GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error)
 
+	// CheckHistory returns an error if the body and the receipts of the block have been pruned
+	CheckHistory(number uint64) error
+
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
-	// ApplyTxn applies a transaction object to the blockchain
+	// CalculateGasLimit returns the gas limit of the block with the given number
+	CalculateGasLimit(number uint64) (uint64, error)
+
+	// CalculateBaseFee returns the base fee of the child block of the parent
+	CalculateBaseFee(parent *types.Header) uint64
+
+	// ApplyTxn applies a transaction object to the blockchain.
+	// The console.log messages of the execution are printed only if console is set
 	ApplyTxn(
 		header *types.Header,
 		txn *types.Transaction,
 		override types.StateOverride,
 		nonPayable bool,
+		console bool,
 	) (*runtime.ExecutionResult, error)
 
+	// ApplyBundle writes the bundle transactions in order in the given child block
+	// on top of the parent block state and returns their receipts and execution results
+	ApplyBundle(
+		parent *types.Header,
+		header *types.Header,
+		txs []*types.Transaction,
+	) ([]*types.Receipt, []*runtime.ExecutionResult, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -98,6 +122,7 @@ type Eth struct {
 	chainID       uint64
 	filterManager *FilterManager
 	priceLimit    uint64
+	dev           DevStore
 }
 
 var (
@@ -135,7 +160,7 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 
 	block, ok := e.store.GetBlockByNumber(num, true)
 	if !ok {
-		return nil, nil
+		return nil, e.store.CheckHistory(num)
 	}
 
 	if err := e.filterExtra(block); err != nil {
@@ -149,6 +174,11 @@ func (e *Eth) GetBlockByNumber(number BlockNumber, fullTx bool) (interface{}, er
 func (e *Eth) GetBlockByHash(hash types.Hash, fullTx bool) (interface{}, error) {
 	block, ok := e.store.GetBlockByHash(hash, true)
 	if !ok {
+		// the header of a block with pruned history is still there
+		if block != nil {
+			return nil, e.store.CheckHistory(block.Number())
+		}
+
 		return nil, nil
 	}
 
@@ -185,7 +215,7 @@ func (e *Eth) GetBlockTransactionCountByNumber(number BlockNumber) (interface{},
 	block, ok := e.store.GetBlockByNumber(num, true)
 
 	if !ok {
-		return nil, nil
+		return nil, e.store.CheckHistory(num)
 	}
 
 	return *common.EncodeUint64(uint64(len(block.Transactions))), nil
@@ -210,16 +240,195 @@ func (e *Eth) SendRawTransaction(buf argBytes) (interface{}, error) {
 
 	// tx hash will be calculated inside e.store.AddTx
 	if err := e.store.AddTx(tx); err != nil {
+		if errors.Is(err, txpool.ErrUnprotectedTx) {
+			return nil, NewUnprotectedTxError(err.Error())
+		}
+
 		return nil, err
 	}
 
 	return tx.Hash.String(), nil
 }
 
+// SendBundle adds a bundle of raw transactions, which are included
+// in a block atomically and in the given order, or not at all
+func (e *Eth) SendBundle(args *bundleArgs) (interface{}, error) {
+	bundle, err := decodeBundle(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.MinBlock != nil {
+		bundle.MinBlock = uint64(*args.MinBlock)
+	}
+
+	if args.MaxBlock != nil {
+		bundle.MaxBlock = uint64(*args.MaxBlock)
+	}
+
+	// bundle hash will be calculated inside e.store.AddBundle
+	if err := e.store.AddBundle(bundle); err != nil {
+		return nil, err
+	}
+
+	return &sendBundleResult{BundleHash: bundle.Hash}, nil
+}
+
+// CallBundle simulates the execution of a bundle of raw transactions
+// in the next block on top of the state of the given block
+func (e *Eth) CallBundle(args *bundleArgs, filter BlockNumberOrHash) (interface{}, error) {
+	parent, err := GetHeaderFromBlockNumberOrHash(filter, e.store)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := decodeBundle(args)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := e.childHeader(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, execResults, err := e.store.ApplyBundle(parent, header, bundle.Txs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &callBundleResult{
+		BundleHash:       bundle.ComputeHash().Hash,
+		StateBlockNumber: argUint64(parent.Number),
+		Results:          make([]callBundleTxResult, len(receipts)),
+	}
+
+	for i, receipt := range receipts {
+		tx, execResult := bundle.Txs[i], execResults[i]
+		txResult := callBundleTxResult{
+			TxHash:      receipt.TxHash,
+			FromAddress: tx.From,
+			GasUsed:     argUint64(receipt.GasUsed),
+			ReturnValue: execResult.ReturnValue,
+		}
+
+		if *receipt.Status == types.ReceiptFailed {
+			if execResult.Reverted() {
+				txResult.Revert = constructErrorFromRevert(execResult).Error()
+			} else {
+				txResult.Error = execResult.Err.Error()
+			}
+		}
+
+		result.Results[i] = txResult
+	}
+
+	if len(receipts) > 0 {
+		result.TotalGasUsed = argUint64(receipts[len(receipts)-1].CumulativeGasUsed)
+	}
+
+	return result, nil
+}
+
+// childHeader returns the header of the block built on top of the parent,
+// the same way the block builder does
+func (e *Eth) childHeader(parent *types.Header) (*types.Header, error) {
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Miner:      parent.Miner,
+		Timestamp:  uint64(time.Now().UTC().Unix()),
+		BaseFee:    e.store.CalculateBaseFee(parent),
+	}
+
+	if header.Timestamp <= parent.Timestamp {
+		header.Timestamp = parent.Timestamp + 1
+	}
+
+	gasLimit, err := e.store.CalculateGasLimit(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	header.GasLimit = gasLimit
+
+	return header, nil
+}
+
+// decodeBundle decodes raw bundle transactions
+func decodeBundle(args *bundleArgs) (*types.Bundle, error) {
+	if args == nil || len(args.Txs) == 0 {
+		return nil, errors.New("bundle has no transactions")
+	}
+
+	bundle := &types.Bundle{
+		Txs: make([]*types.Transaction, len(args.Txs)),
+	}
+
+	for i, raw := range args.Txs {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, fmt.Errorf("failed to decode bundle transaction %d: %w", i, err)
+		}
+
+		bundle.Txs[i] = tx
+	}
+
+	return bundle, nil
+}
+
 // SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
-func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
-	return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
-		" use eth_sendRawTransaction instead")
+func (e *Eth) SendTransaction(arg *txnArgs) (interface{}, error) {
+	// the dev consensus accepts unsigned transactions of the impersonated accounts
+	if e.dev == nil || arg == nil || arg.From == nil || !e.dev.IsImpersonated(*arg.From) {
+		return nil, fmt.Errorf("request calls to eth_sendTransaction method are not supported," +
+			" use eth_sendRawTransaction instead")
+	}
+
+	return e.sendImpersonatedTransaction(arg)
+}
+
+// sendImpersonatedTransaction fills the missing fields of the unsigned transaction
+// and hands it to the dev consensus
+func (e *Eth) sendImpersonatedTransaction(arg *txnArgs) (interface{}, error) {
+	header := e.store.Header()
+
+	if arg.Gas == nil {
+		gas, err := e.EstimateGas(arg, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		estimated, ok := gas.(argUint64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected gas estimation result: %v", gas)
+		}
+
+		arg.Gas = &estimated
+	}
+
+	// the pool doesn't know the impersonated transactions not sealed yet,
+	// the dev consensus assigns the missing nonce
+	fillNonce := arg.Nonce == nil
+
+	tx, err := DecodeTxn(arg, header.Number, e.store, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.fillTransactionGasPrice(tx); err != nil {
+		return nil, err
+	}
+
+	if tx.Type == types.DynamicFeeTx {
+		tx.ChainID = new(big.Int).SetUint64(e.chainID)
+	}
+
+	if err := e.dev.SendImpersonatedTransaction(tx, fillNonce); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
 }
 
 // GetTransactionByHash returns a transaction by its hash.
@@ -228,18 +437,22 @@ func (e *Eth) SendTransaction(_ *txnArgs) (interface{}, error) {
 func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	// findSealedTx is a helper method for checking the world state
 	// for the transaction with the provided hash
-	findSealedTx := func() *transaction {
+	findSealedTx := func() (*transaction, error) {
 		// Check the chain state for the transaction
 		blockHash, ok := e.store.ReadTxLookup(hash)
 		if !ok {
 			// Block not found in storage
-			return nil
+			return nil, nil
 		}
 
 		block, ok := e.store.GetBlockByHash(blockHash, true)
 		if !ok {
-			// Block receipts not found in storage
-			return nil
+			// the header of a block with pruned history is still there
+			if block != nil {
+				return nil, e.store.CheckHistory(block.Number())
+			}
+
+			return nil, nil
 		}
 
 		// Find the transaction within the block
@@ -251,10 +464,10 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 				argUintPtr(block.Number()),
 				argHashPtr(block.Hash()),
 				&idx,
-			)
+			), nil
 		}
 
-		return nil
+		return nil, nil
 	}
 
 	// findPendingTx is a helper method for checking the TxPool
@@ -270,8 +483,8 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 	}
 
 	// 1. Check the chain state for the txn
-	if resultTxn := findSealedTx(); resultTxn != nil {
-		return resultTxn, nil
+	if resultTxn, err := findSealedTx(); resultTxn != nil || err != nil {
+		return resultTxn, err
 	}
 
 	// 2. Check the TxPool for the txn
@@ -284,7 +497,17 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 		fmt.Sprintf("Transaction with hash [%s] not found", hash),
 	)
 
-	return nil, nil
+	return nil, e.checkTxHistory(hash)
+}
+
+// checkTxHistory returns ErrHistoryPruned for the transaction which is not found once the history
+// of the chain has been pruned, since the lookups of the pruned transactions are removed as well
+func (e *Eth) checkTxHistory(hash types.Hash) error {
+	if err := e.store.CheckHistory(1); err != nil {
+		return fmt.Errorf("transaction %s not found: %w", hash, err)
+	}
+
+	return nil
 }
 
 // GetTransactionReceipt returns a transaction receipt by his hash
@@ -292,11 +515,16 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	blockHash, ok := e.store.ReadTxLookup(hash)
 	if !ok {
 		// txn not found
-		return nil, nil
+		return nil, e.checkTxHistory(hash)
 	}
 
 	block, ok := e.store.GetBlockByHash(blockHash, true)
 	if !ok {
+		// the header of a block with pruned history is still there
+		if block != nil {
+			return nil, e.store.CheckHistory(block.Number())
+		}
+
 		// block not found
 		e.logger.Warn(
 			fmt.Sprintf("Block with hash [%s] not found", blockHash.String()),
@@ -485,7 +713,7 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash, apiOverride *stateOve
 	}
 
 	// The return value of the execution is saved in the transition (returnValue field)
-	result, err := e.store.ApplyTxn(header, transaction, override, true)
+	result, err := e.store.ApplyTxn(header, transaction, override, true, true)
 	if err != nil {
 		return nil, err
 	}
@@ -635,7 +863,8 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 
 		transaction.Gas = gas
 
-		result, applyErr := e.store.ApplyTxn(header, transaction, nil, true)
+		// the estimation executes the transaction many times, its console.log messages are not printed
+		result, applyErr := e.store.ApplyTxn(header, transaction, nil, true, false)
 
 		if result != nil {
 			data = []byte(hex.EncodeToString(result.ReturnValue))
@@ -700,6 +700,10 @@ func TestEth_EstimateGas_GasLimit(t *testing.T) {
 				// Make sure the estimate is correct
 				assert.Equal(t, argUint64(testCase.intrinsicGasCost), estimate)
 			}
+
+			// Make sure the console.log messages of the estimation are not printed
+			assert.NotEmpty(t, store.consoles)
+			assert.NotContains(t, store.consoles, true)
 		})
 	}
 }
@@ -833,6 +837,8 @@ type mockSpecialStore struct {
 	block   *types.Block
 
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+	// consoles records if the console.log messages of the applied transactions are printed
+	consoles []bool
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -902,7 +908,9 @@ func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime
 	return chain.AllForksEnabled.At(0)
 }
 
-func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, _ types.StateOverride, _ bool) (*runtime.ExecutionResult, error) {
+func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction, _ types.StateOverride, _, console bool) (*runtime.ExecutionResult, error) {
+	m.consoles = append(m.consoles, console)
+
 	if m.applyTxnHook != nil {
 		return m.applyTxnHook(header, txn)
 	}
@@ -4,8 +4,10 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
@@ -29,6 +31,24 @@ func TestEth_TxnPool_SendRawTransaction(t *testing.T) {
 	}
 }
 
+func TestEth_TxnPool_SendRawTransaction_Unprotected(t *testing.T) {
+	store := &mockStoreTxn{addTxErr: txpool.ErrUnprotectedTx}
+	eth := newTestEthEndpoint(store)
+
+	txn := &types.Transaction{
+		From: addr0,
+		V:    big.NewInt(27),
+	}
+
+	_, err := eth.SendRawTransaction(txn.MarshalRLP())
+
+	var rpcErr Error
+
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, -32003, rpcErr.ErrorCode())
+	assert.Equal(t, txpool.ErrUnprotectedTx.Error(), rpcErr.Error())
+}
+
 func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	store := &mockStoreTxn{}
 	store.AddAccount(addr0)
@@ -46,13 +66,59 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
 }
 
+func TestEth_TxnPool_SendBundle(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	txs := make([]argBytes, 2)
+
+	for i := range txs {
+		txn := &types.Transaction{
+			From:  addr0,
+			Nonce: uint64(i),
+			V:     big.NewInt(1),
+		}
+
+		txs[i] = txn.MarshalRLP()
+	}
+
+	minBlock, maxBlock := argUint64(10), argUint64(12)
+
+	res, err := eth.SendBundle(&bundleArgs{Txs: txs, MinBlock: &minBlock, MaxBlock: &maxBlock})
+	assert.NoError(t, err)
+
+	assert.Len(t, store.bundle.Txs, 2)
+	assert.Equal(t, uint64(10), store.bundle.MinBlock)
+	assert.Equal(t, uint64(12), store.bundle.MaxBlock)
+	assert.Equal(t, store.bundle.Hash, res.(*sendBundleResult).BundleHash) //nolint:forcetypeassert
+
+	_, err = eth.SendBundle(&bundleArgs{})
+	assert.Error(t, err)
+}
+
 type mockStoreTxn struct {
 	ethStore
 	accounts map[types.Address]*mockAccount
 	txn      *types.Transaction
+	bundle   *types.Bundle
+	addTxErr error
+}
+
+func (m *mockStoreTxn) AddBundle(bundle *types.Bundle) error {
+	for _, tx := range bundle.Txs {
+		tx.ComputeHash(1)
+	}
+
+	m.bundle = bundle.ComputeHash()
+
+	return nil
 }
 
 func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
+	if m.addTxErr != nil {
+		return m.addTxErr
+	}
+
 	m.txn = tx
 
 	tx.ComputeHash(1)
@@ -308,6 +308,9 @@ type filterManagerStore interface {
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 
+	// CheckHistory returns an error if the body and the receipts of the block have been pruned
+	CheckHistory(number uint64) error
+
 	// TxPoolSubscribe subscribes for tx pool events
 	TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto.TxPoolEvent, func(), error)
 }
@@ -531,6 +534,10 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 		return nil, ErrBlockRangeTooHigh
 	}
 
+	if err := f.store.CheckHistory(from); err != nil {
+		return nil, err
+	}
+
 	logs := make([]*Log, 0)
 
 	for i := from; i <= to; i++ {
@@ -561,6 +568,12 @@ func (f *FilterManager) GetLogsForQuery(query *LogQuery) ([]*Log, error) {
 		// BlockHash is set -> fetch logs from this block only
 		block, ok := f.store.GetBlockByHash(*query.BlockHash, true)
 		if !ok {
+			if block != nil {
+				if err := f.store.CheckHistory(block.Number()); err != nil {
+					return nil, err
+				}
+			}
+
 			return nil, ErrBlockNotFound
 		}
 
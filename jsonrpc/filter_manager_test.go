@@ -154,6 +154,32 @@ func Test_GetLogsForQuery(t *testing.T) {
 	}
 }
 
+func Test_GetLogsForQuery_PrunedHistory(t *testing.T) {
+	t.Parallel()
+
+	store := &mockBlockStore{tail: 3}
+	store.setupLogs()
+
+	for i := 0; i < 5; i++ {
+		store.add(newTestBlock(uint64(i), types.StringToHash(strconv.Itoa(i))))
+	}
+
+	f := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer f.Close()
+
+	_, err := f.GetLogsForQuery(&LogQuery{fromBlock: 1, toBlock: 4})
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+
+	prunedHash := store.blocks[2].Hash()
+
+	_, err = f.GetLogsForQuery(&LogQuery{BlockHash: &prunedHash})
+	assert.ErrorIs(t, err, blockchain.ErrHistoryPruned)
+
+	logs, err := f.GetLogsForQuery(&LogQuery{fromBlock: 3, toBlock: 4})
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+}
+
 func Test_getLogsFromBlock(t *testing.T) {
 	t.Parallel()
 
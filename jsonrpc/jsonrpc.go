@@ -71,6 +71,10 @@ type Config struct {
 
 	ConcurrentRequestsDebug uint64
 	WebSocketReadLimit      uint64
+	TxPoolAdmin             bool
+
+	// DevStore enables the Hardhat and Anvil compatible evm and anvil endpoints
+	DevStore DevStore
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -85,6 +89,8 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 			jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 			blockRangeLimit:         config.BlockRangeLimit,
 			concurrentRequestsDebug: config.ConcurrentRequestsDebug,
+			txPoolAdmin:             config.TxPoolAdmin,
+			devStore:                config.DevStore,
 		},
 	)
 
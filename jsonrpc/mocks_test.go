@@ -181,6 +181,10 @@ func (m *mockStore) GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
 	return &types.Block{Header: header}, header != nil
 }
 
+func (m *mockStore) CheckHistory(uint64) error {
+	return nil
+}
+
 func (m *mockStore) GetTxs(inclQueued bool) (
 	map[types.Address][]*types.Transaction,
 	map[types.Address][]*types.Transaction,
@@ -192,6 +196,22 @@ func (m *mockStore) GetCapacity() (uint64, uint64) {
 	return 0, 0
 }
 
+func (m *mockStore) GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction) {
+	return nil, nil
+}
+
+func (m *mockStore) RemoveTx(hash types.Hash) ([]*types.Transaction, error) {
+	return nil, nil
+}
+
+func (m *mockStore) RemoveAccount(addr types.Address) ([]*types.Transaction, error) {
+	return nil, nil
+}
+
+func (m *mockStore) RemoveAll() []*types.Transaction {
+	return nil
+}
+
 func (m *mockStore) GenerateExitProof(exitID uint64) (types.Proof, error) {
 	hash := types.BytesToHash([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 
@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -17,11 +18,28 @@ type txPoolStore interface {
 
 	// GetBaseFee returns current base fee
 	GetBaseFee() uint64
+
+	// GetAccountTxs gets pending and queued transactions of the given account, ordered by nonce
+	GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction)
+
+	// RemoveTx removes the transaction and the subsequent transactions of its sender from the pool
+	RemoveTx(hash types.Hash) ([]*types.Transaction, error)
+
+	// RemoveAccount removes all the transactions of the given account from the pool
+	RemoveAccount(addr types.Address) ([]*types.Transaction, error)
+
+	// RemoveAll removes all the transactions from the pool
+	RemoveAll() []*types.Transaction
 }
 
+var ErrTxPoolAdminDisabled = errors.New("txpool admin methods are disabled")
+
 // TxPool is the txpool jsonrpc endpoint
 type TxPool struct {
 	store txPoolStore
+
+	// admin enables the methods which modify the pool content
+	admin bool
 }
 
 type ContentResponse struct {
@@ -36,6 +54,11 @@ type InspectResponse struct {
 	MaxCapacity     uint64                       `json:"maxCapacity"`
 }
 
+type ContentFromResponse struct {
+	Pending map[uint64]*transaction `json:"pending"`
+	Queued  map[uint64]*transaction `json:"queued"`
+}
+
 type StatusResponse struct {
 	Pending uint64 `json:"pending"`
 	Queued  uint64 `json:"queued"`
@@ -125,3 +148,75 @@ func (t *TxPool) Status() (interface{}, error) {
 
 	return resp, nil
 }
+
+// Create response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-txpool#txpool-contentfrom.
+func (t *TxPool) ContentFrom(addr types.Address) (interface{}, error) {
+	convertTxs := func(txs []*types.Transaction) map[uint64]*transaction {
+		result := make(map[uint64]*transaction, len(txs))
+
+		for _, tx := range txs {
+			result[tx.Nonce] = toTransaction(tx, nil, &types.ZeroHash, nil)
+		}
+
+		return result
+	}
+
+	pendingTxs, queuedTxs := t.store.GetAccountTxs(addr)
+	resp := ContentFromResponse{
+		Pending: convertTxs(pendingTxs),
+		Queued:  convertTxs(queuedTxs),
+	}
+
+	return resp, nil
+}
+
+// DropTransaction removes the transaction, and the subsequent transactions of its sender,
+// from the pool. Returns the hashes of the removed transactions
+func (t *TxPool) DropTransaction(hash types.Hash) (interface{}, error) {
+	if !t.admin {
+		return nil, ErrTxPoolAdminDisabled
+	}
+
+	removed, err := t.store.RemoveTx(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTxHashes(removed), nil
+}
+
+// DropAccount removes all the transactions of the account from the pool.
+// Returns the hashes of the removed transactions
+func (t *TxPool) DropAccount(addr types.Address) (interface{}, error) {
+	if !t.admin {
+		return nil, ErrTxPoolAdminDisabled
+	}
+
+	removed, err := t.store.RemoveAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTxHashes(removed), nil
+}
+
+// DropAll removes all the transactions from the pool.
+// Returns the hashes of the removed transactions
+func (t *TxPool) DropAll() (interface{}, error) {
+	if !t.admin {
+		return nil, ErrTxPoolAdminDisabled
+	}
+
+	return toTxHashes(t.store.RemoveAll()), nil
+}
+
+func toTxHashes(txs []*types.Transaction) []types.Hash {
+	hashes := make([]types.Hash, len(txs))
+
+	for i, tx := range txs {
+		hashes[i] = tx.Hash
+	}
+
+	return hashes
+}
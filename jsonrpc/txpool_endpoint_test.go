@@ -1,6 +1,7 @@
 package jsonrpc
 
 import (
+	"errors"
 	"math/big"
 	"strconv"
 	"testing"
@@ -17,7 +18,7 @@ func TestContentEndpoint(t *testing.T) {
 		t.Parallel()
 
 		mockStore := newMockTxPoolStore()
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Content()
 		//nolint:forcetypeassert
@@ -36,7 +37,7 @@ func TestContentEndpoint(t *testing.T) {
 		testTx1 := newTestTransaction(2, address1)
 		testTx2 := newTestDynamicFeeTransaction(3, address1)
 		mockStore.pending[address1] = []*types.Transaction{testTx1, testTx2}
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Content()
 		//nolint:forcetypeassert
@@ -84,7 +85,7 @@ func TestContentEndpoint(t *testing.T) {
 		testTx2 := newTestDynamicFeeTransaction(1, address2)
 		mockStore.queued[address1] = []*types.Transaction{testTx1}
 		mockStore.queued[address2] = []*types.Transaction{testTx2}
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Content()
 		//nolint:forcetypeassert
@@ -139,7 +140,7 @@ func TestContentEndpoint(t *testing.T) {
 		mockStore.pending[address2] = []*types.Transaction{testTx4}
 		mockStore.queued[address1] = []*types.Transaction{testTx3}
 		mockStore.queued[address2] = []*types.Transaction{testTx5}
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Content()
 		//nolint:forcetypeassert
@@ -161,7 +162,7 @@ func TestInspectEndpoint(t *testing.T) {
 
 		mockStore := newMockTxPoolStore()
 		mockStore.maxSlots = 1024
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Inspect()
 		//nolint:forcetypeassert
@@ -182,7 +183,7 @@ func TestInspectEndpoint(t *testing.T) {
 		address1 := types.Address{0x1}
 		testTx := newTestTransaction(2, address1)
 		mockStore.queued[address1] = []*types.Transaction{testTx}
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Inspect()
 		//nolint:forcetypeassert
@@ -205,7 +206,7 @@ func TestInspectEndpoint(t *testing.T) {
 		testTx := newTestTransaction(2, address1)
 		testTx2 := newTestTransaction(3, address1)
 		mockStore.pending[address1] = []*types.Transaction{testTx, testTx2}
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Inspect()
 		//nolint:forcetypeassert
@@ -228,7 +229,7 @@ func TestStatusEndpoint(t *testing.T) {
 		t.Parallel()
 
 		mockStore := newMockTxPoolStore()
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Status()
 		//nolint:forcetypeassert
@@ -253,7 +254,7 @@ func TestStatusEndpoint(t *testing.T) {
 		mockStore.pending[address2] = []*types.Transaction{testTx4}
 		mockStore.queued[address1] = []*types.Transaction{testTx3}
 		mockStore.queued[address2] = []*types.Transaction{testTx5}
-		txPoolEndpoint := &TxPool{mockStore}
+		txPoolEndpoint := &TxPool{store: mockStore}
 
 		result, _ := txPoolEndpoint.Status()
 		//nolint:forcetypeassert
@@ -264,6 +265,116 @@ func TestStatusEndpoint(t *testing.T) {
 	})
 }
 
+func TestContentFromEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mockStore := newMockTxPoolStore()
+	address1 := types.Address{0x1}
+	testTx1 := newTestTransaction(2, address1)
+	testTx2 := newTestTransaction(5, address1)
+	mockStore.pending[address1] = []*types.Transaction{testTx1}
+	mockStore.queued[address1] = []*types.Transaction{testTx2}
+	mockStore.pending[types.Address{0x2}] = []*types.Transaction{newTestTransaction(1, types.Address{0x2})}
+	txPoolEndpoint := &TxPool{store: mockStore}
+
+	result, err := txPoolEndpoint.ContentFrom(address1)
+	assert.NoError(t, err)
+
+	//nolint:forcetypeassert
+	response := result.(ContentFromResponse)
+
+	assert.Equal(t, 1, len(response.Pending))
+	assert.Equal(t, 1, len(response.Queued))
+	assert.Equal(t, testTx1.Hash, response.Pending[testTx1.Nonce].Hash)
+	assert.Equal(t, testTx2.Hash, response.Queued[testTx2.Nonce].Hash)
+}
+
+func TestDropEndpoints(t *testing.T) {
+	t.Parallel()
+
+	address1 := types.Address{0x1}
+	address2 := types.Address{0x2}
+
+	newStore := func() (*mockTxPoolStore, []*types.Transaction) {
+		mockStore := newMockTxPoolStore()
+		txs := []*types.Transaction{
+			newTestTransaction(1, address1),
+			newTestTransaction(2, address1),
+			newTestTransaction(3, address1),
+		}
+
+		for i, tx := range txs {
+			tx.Hash = types.Hash{byte(i + 1)}
+		}
+
+		mockStore.pending[address1] = txs
+		mockStore.queued[address2] = []*types.Transaction{newTestTransaction(7, address2)}
+
+		return mockStore, txs
+	}
+
+	t.Run("admin methods are disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore, txs := newStore()
+		txPoolEndpoint := &TxPool{store: mockStore}
+
+		_, err := txPoolEndpoint.DropTransaction(txs[0].Hash)
+		assert.ErrorIs(t, err, ErrTxPoolAdminDisabled)
+
+		_, err = txPoolEndpoint.DropAccount(address1)
+		assert.ErrorIs(t, err, ErrTxPoolAdminDisabled)
+
+		_, err = txPoolEndpoint.DropAll()
+		assert.ErrorIs(t, err, ErrTxPoolAdminDisabled)
+
+		assert.Len(t, mockStore.pending[address1], 3)
+	})
+
+	t.Run("drop transaction removes the subsequent transactions", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore, txs := newStore()
+		txPoolEndpoint := &TxPool{store: mockStore, admin: true}
+
+		result, err := txPoolEndpoint.DropTransaction(txs[1].Hash)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Hash{txs[1].Hash, txs[2].Hash}, result)
+		assert.Len(t, mockStore.pending[address1], 1)
+
+		_, err = txPoolEndpoint.DropTransaction(types.Hash{0xff})
+		assert.ErrorIs(t, err, errTxNotFound)
+	})
+
+	t.Run("drop account", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore, txs := newStore()
+		txPoolEndpoint := &TxPool{store: mockStore, admin: true}
+
+		result, err := txPoolEndpoint.DropAccount(address1)
+		assert.NoError(t, err)
+		assert.Equal(t, []types.Hash{txs[0].Hash, txs[1].Hash, txs[2].Hash}, result)
+		assert.Empty(t, mockStore.pending[address1])
+		assert.Len(t, mockStore.queued[address2], 1)
+	})
+
+	t.Run("drop all", func(t *testing.T) {
+		t.Parallel()
+
+		mockStore, _ := newStore()
+		txPoolEndpoint := &TxPool{store: mockStore, admin: true}
+
+		result, err := txPoolEndpoint.DropAll()
+		assert.NoError(t, err)
+		assert.Len(t, result, 4)
+		assert.Empty(t, mockStore.pending)
+		assert.Empty(t, mockStore.queued)
+	})
+}
+
+var errTxNotFound = errors.New("transaction not found")
+
 type mockTxPoolStore struct {
 	pending       map[types.Address][]*types.Transaction
 	queued        map[types.Address][]*types.Transaction
@@ -294,6 +405,52 @@ func (s *mockTxPoolStore) GetBaseFee() uint64 {
 	return s.baseFee
 }
 
+func (s *mockTxPoolStore) GetAccountTxs(addr types.Address) ([]*types.Transaction, []*types.Transaction) {
+	return s.pending[addr], s.queued[addr]
+}
+
+func (s *mockTxPoolStore) RemoveTx(hash types.Hash) ([]*types.Transaction, error) {
+	for addr, txs := range s.pending {
+		for i, tx := range txs {
+			if tx.Hash == hash {
+				s.pending[addr] = txs[:i]
+
+				return txs[i:], nil
+			}
+		}
+	}
+
+	return nil, errTxNotFound
+}
+
+func (s *mockTxPoolStore) RemoveAccount(addr types.Address) ([]*types.Transaction, error) {
+	removed := append(s.pending[addr], s.queued[addr]...)
+	if len(removed) == 0 {
+		return nil, errTxNotFound
+	}
+
+	delete(s.pending, addr)
+	delete(s.queued, addr)
+
+	return removed, nil
+}
+
+func (s *mockTxPoolStore) RemoveAll() []*types.Transaction {
+	removed := make([]*types.Transaction, 0)
+
+	for addr := range s.pending {
+		txs, _ := s.RemoveAccount(addr)
+		removed = append(removed, txs...)
+	}
+
+	for addr := range s.queued {
+		txs, _ := s.RemoveAccount(addr)
+		removed = append(removed, txs...)
+	}
+
+	return removed
+}
+
 func newTestTransaction(nonce uint64, from types.Address) *types.Transaction {
 	txn := &types.Transaction{
 		Nonce:    nonce,
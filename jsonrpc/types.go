@@ -420,3 +420,30 @@ func convertToArgUint64SliceSlice(slice [][]uint64) [][]argUint64 {
 
 	return argSlice
 }
+
+// bundleArgs is the bundle argument for the eth_sendBundle and eth_callBundle endpoints
+type bundleArgs struct {
+	Txs      []argBytes `json:"txs"`
+	MinBlock *argUint64 `json:"minBlock"`
+	MaxBlock *argUint64 `json:"maxBlock"`
+}
+
+type sendBundleResult struct {
+	BundleHash types.Hash `json:"bundleHash"`
+}
+
+type callBundleTxResult struct {
+	TxHash      types.Hash    `json:"txHash"`
+	FromAddress types.Address `json:"fromAddress"`
+	GasUsed     argUint64     `json:"gasUsed"`
+	ReturnValue argBytes      `json:"value"`
+	Error       string        `json:"error,omitempty"`
+	Revert      string        `json:"revert,omitempty"`
+}
+
+type callBundleResult struct {
+	BundleHash       types.Hash           `json:"bundleHash"`
+	StateBlockNumber argUint64            `json:"stateBlockNumber"`
+	TotalGasUsed     argUint64            `json:"totalGasUsed"`
+	Results          []callBundleTxResult `json:"results"`
+}
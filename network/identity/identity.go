@@ -19,6 +19,7 @@ const PeerID = "peerID"
 var (
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrNoAvailableSlots = errors.New("no available Slots")
+	ErrPeerBanned       = errors.New("peer is banned")
 )
 
 // networkingServer defines the base communication interface between
@@ -52,6 +53,9 @@ type networkingServer interface {
 
 	// HasFreeConnectionSlot checks if there are available outbound connection slots [Thread safe]
 	HasFreeConnectionSlot(direction network.Direction) bool
+
+	// IsBanned checks if the peer is temporarily banned [Thread safe]
+	IsBanned(peerID peer.ID) bool
 }
 
 // IdentityService is a networking service used to handle peer handshaking.
@@ -93,6 +97,12 @@ func (i *IdentityService) GetNotifyBundle() *network.NotifyBundle {
 				return
 			}
 
+			if i.baseServer.IsBanned(peerID) {
+				i.disconnectFromPeer(peerID, ErrPeerBanned.Error())
+
+				return
+			}
+
 			if !i.baseServer.HasFreeConnectionSlot(conn.Stat().Direction) {
 				i.disconnectFromPeer(peerID, ErrNoAvailableSlots.Error())
 
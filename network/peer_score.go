@@ -0,0 +1,169 @@
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// DefaultPeerBanThreshold is the misbehaviour score at which a peer gets disconnected and banned
+	DefaultPeerBanThreshold = 100
+
+	// DefaultPeerBanDuration is the time during which a banned peer can not reconnect
+	DefaultPeerBanDuration = 30 * time.Minute
+
+	// DefaultPeerScoreDecayInterval is the time after which the misbehaviour score
+	// of a peer decreases by one point, so that rare honest mistakes don't add up to a ban
+	DefaultPeerScoreDecayInterval = 30 * time.Second
+)
+
+// peerScores keeps track of the misbehaviour score of the connected peers,
+// and of the peers which are temporarily banned
+type peerScores struct {
+	sync.Mutex
+
+	threshold     uint64
+	banDuration   time.Duration
+	decayInterval time.Duration
+
+	scores map[peer.ID]*peerScore // misbehaviour score of each peer
+	banned map[peer.ID]time.Time  // ban expiration time of each banned peer
+
+	now func() time.Time
+}
+
+// peerScore is the misbehaviour score of a peer, decaying over time
+type peerScore struct {
+	value   uint64
+	updated time.Time // time of the last decay
+}
+
+func newPeerScores(threshold uint64, banDuration, decayInterval time.Duration) *peerScores {
+	return &peerScores{
+		threshold:     threshold,
+		banDuration:   banDuration,
+		decayInterval: decayInterval,
+		scores:        make(map[peer.ID]*peerScore),
+		banned:        make(map[peer.ID]time.Time),
+		now:           time.Now,
+	}
+}
+
+// decayedScore returns the score of the peer decreased by one point for every elapsed decay interval,
+// and removes the score once it decays to zero. Returns nil if the peer has no score
+func (ps *peerScores) decayedScore(peerID peer.ID) *peerScore {
+	score, ok := ps.scores[peerID]
+	if !ok {
+		return nil
+	}
+
+	if ps.decayInterval <= 0 {
+		return score
+	}
+
+	elapsed := ps.now().Sub(score.updated)
+	if elapsed < ps.decayInterval {
+		return score
+	}
+
+	decay := uint64(elapsed / ps.decayInterval)
+	if decay >= score.value {
+		delete(ps.scores, peerID)
+
+		return nil
+	}
+
+	score.value -= decay
+	score.updated = score.updated.Add(time.Duration(decay) * ps.decayInterval)
+
+	return score
+}
+
+// penalize increases the misbehaviour score of the peer by the given penalty,
+// and bans the peer if the threshold is reached. Returns true if the peer got banned [Thread safe]
+func (ps *peerScores) penalize(peerID peer.ID, penalty uint64) bool {
+	ps.Lock()
+	defer ps.Unlock()
+
+	score := ps.decayedScore(peerID)
+	if score == nil {
+		score = &peerScore{updated: ps.now()}
+		ps.scores[peerID] = score
+	}
+
+	score.value += penalty
+	if score.value < ps.threshold {
+		return false
+	}
+
+	delete(ps.scores, peerID)
+	ps.banned[peerID] = ps.now().Add(ps.banDuration)
+
+	return true
+}
+
+// isBanned checks if the peer is currently banned,
+// and removes the peer from the banned list if the ban expired [Thread safe]
+func (ps *peerScores) isBanned(peerID peer.ID) bool {
+	ps.Lock()
+	defer ps.Unlock()
+
+	expiration, ok := ps.banned[peerID]
+	if !ok {
+		return false
+	}
+
+	if ps.now().After(expiration) {
+		delete(ps.banned, peerID)
+
+		return false
+	}
+
+	return true
+}
+
+// score returns the current misbehaviour score of the peer [Thread safe]
+func (ps *peerScores) score(peerID peer.ID) uint64 {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if score := ps.decayedScore(peerID); score != nil {
+		return score.value
+	}
+
+	return 0
+}
+
+// reset clears the misbehaviour score of the peer [Thread safe]
+func (ps *peerScores) reset(peerID peer.ID) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	delete(ps.scores, peerID)
+}
+
+// PenalizePeer increases the misbehaviour score of the peer by the given penalty.
+// Once the score reaches the ban threshold the peer is disconnected
+// and can not reconnect until the ban expires
+func (s *Server) PenalizePeer(peerID peer.ID, penalty uint64, reason string) {
+	if peerID == s.host.ID() {
+		return
+	}
+
+	if !s.scores.penalize(peerID, penalty) {
+		return
+	}
+
+	s.logger.Warn("Banning peer", "id", peerID, "reason", reason, "duration", s.scores.banDuration)
+	metrics.IncrCounter([]string{networkMetrics, "banned_peers"}, 1)
+
+	s.DisconnectFromPeer(peerID, "banned: "+reason)
+}
+
+// IsBanned checks if the peer is temporarily banned [Thread safe]
+func (s *Server) IsBanned(peerID peer.ID) bool {
+	return s.scores.isBanned(peerID)
+}
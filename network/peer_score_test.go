@@ -0,0 +1,84 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerScores(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peer1 = peer.ID("peer1")
+		peer2 = peer.ID("peer2")
+	)
+
+	t.Run("peer is banned once the threshold is reached", func(t *testing.T) {
+		t.Parallel()
+
+		scores := newPeerScores(10, time.Hour, time.Hour)
+
+		assert.False(t, scores.penalize(peer1, 4))
+		assert.False(t, scores.penalize(peer1, 5))
+		assert.False(t, scores.penalize(peer2, 9))
+		assert.Equal(t, uint64(9), scores.score(peer1))
+
+		assert.True(t, scores.penalize(peer1, 1))
+		assert.True(t, scores.isBanned(peer1))
+		assert.False(t, scores.isBanned(peer2))
+
+		// the score is cleared once the peer gets banned
+		assert.Equal(t, uint64(0), scores.score(peer1))
+	})
+
+	t.Run("ban expires", func(t *testing.T) {
+		t.Parallel()
+
+		scores := newPeerScores(1, time.Millisecond, time.Hour)
+
+		assert.True(t, scores.penalize(peer1, 1))
+		assert.Eventually(t, func() bool {
+			return !scores.isBanned(peer1)
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("reset clears the score", func(t *testing.T) {
+		t.Parallel()
+
+		scores := newPeerScores(10, time.Hour, time.Hour)
+
+		assert.False(t, scores.penalize(peer1, 5))
+		scores.reset(peer1)
+		assert.Equal(t, uint64(0), scores.score(peer1))
+	})
+
+	t.Run("score decays over time", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Unix(100, 0)
+
+		scores := newPeerScores(10, time.Hour, time.Minute)
+		scores.now = func() time.Time { return now }
+
+		assert.False(t, scores.penalize(peer1, 9))
+
+		now = now.Add(3*time.Minute + 30*time.Second)
+		assert.Equal(t, uint64(6), scores.score(peer1))
+
+		// the partial interval is kept for the next decay
+		now = now.Add(30 * time.Second)
+		assert.Equal(t, uint64(5), scores.score(peer1))
+
+		// the decayed score doesn't reach the threshold anymore
+		assert.False(t, scores.penalize(peer1, 4))
+		assert.Equal(t, uint64(9), scores.score(peer1))
+
+		// the score decayed to zero is removed
+		now = now.Add(time.Hour)
+		assert.Equal(t, uint64(0), scores.score(peer1))
+		assert.NotContains(t, scores.scores, peer1)
+	})
+}
@@ -88,6 +88,8 @@ type Server struct {
 	temporaryDials sync.Map // map of temporary connections; peerID -> bool
 
 	bootnodes *bootnodesWrapper // reference of all bootnodes for the node
+
+	scores *peerScores // misbehaviour scores and bans of the peers
 }
 
 // NewServer returns a new instance of the networking server
@@ -154,6 +156,7 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 			config.MaxInboundPeers,
 			config.MaxOutboundPeers,
 		),
+		scores: newPeerScores(DefaultPeerBanThreshold, DefaultPeerBanDuration, DefaultPeerScoreDecayInterval),
 	}
 
 	// start gossip protocol
@@ -394,7 +397,7 @@ func (s *Server) runDial() {
 
 			peerInfo := tt.GetAddrInfo()
 
-			if s.IsConnected(peerInfo.ID) {
+			if s.IsConnected(peerInfo.ID) || s.IsBanned(peerInfo.ID) {
 				continue
 			}
 
@@ -480,6 +483,9 @@ func (s *Server) removePeer(peerID peer.ID) {
 		return
 	}
 
+	// The misbehaviour score is kept only for the connected peers
+	s.scores.reset(peerID)
+
 	// Emit the event alerting listeners
 	s.emitEvent(peerID, peerEvent.PeerDisconnected)
 }
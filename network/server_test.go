@@ -18,6 +18,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConnLimit_Inbound(t *testing.T) {
@@ -1158,3 +1159,38 @@ func generateRandomPeers(t *testing.T, count int) ([]*randomPeer, error) {
 
 	return randomPeers, nil
 }
+
+func TestPenalizePeer_DisconnectsAndBans(t *testing.T) {
+	servers, createErr := createServers(2, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	if joinErr := JoinAndWait(servers[0], servers[1], DefaultBufferTimeout, DefaultJoinTimeout); joinErr != nil {
+		t.Fatalf("Unable to join servers, %v", joinErr)
+	}
+
+	peerID := servers[1].AddrInfo().ID
+
+	// below the threshold the peer stays connected
+	servers[0].PenalizePeer(peerID, DefaultPeerBanThreshold-1, "test")
+	assert.True(t, servers[0].IsConnected(peerID))
+	assert.False(t, servers[0].IsBanned(peerID))
+
+	servers[0].PenalizePeer(peerID, 1, "test")
+	assert.True(t, servers[0].IsBanned(peerID))
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultJoinTimeout)
+	defer cancel()
+
+	disconnected, err := WaitUntilPeerDisconnectsFrom(ctx, servers[0], peerID)
+	require.NoError(t, err)
+	assert.True(t, disconnected)
+
+	// the banned peer can not reconnect
+	assert.Error(t, JoinAndWait(servers[1], servers[0], time.Second*5, time.Second*5))
+}
@@ -30,6 +30,7 @@ type MockNetworkingServer struct {
 	emitEventFn              emitEventDelegate
 	isTemporaryDialFn        isTemporaryDialDelegate
 	hasFreeConnectionSlotFn  hasFreeConnectionSlotDelegate
+	isBannedFn               isBannedDelegate
 
 	// Discovery Hooks
 	newDiscoveryClientFn       newDiscoveryClientDelegate
@@ -74,6 +75,7 @@ type updatePendingConnCountDelegate func(int64, network.Direction)
 type emitEventDelegate func(*event.PeerEvent)
 type isTemporaryDialDelegate func(peer.ID) bool
 type hasFreeConnectionSlotDelegate func(network.Direction) bool
+type isBannedDelegate func(peer.ID) bool
 
 // Required for Discovery
 type getRandomBootnodeDelegate func() *peer.AddrInfo
@@ -162,6 +164,18 @@ func (m *MockNetworkingServer) HookIsTemporaryDial(fn isTemporaryDialDelegate) {
 	m.isTemporaryDialFn = fn
 }
 
+func (m *MockNetworkingServer) IsBanned(peerID peer.ID) bool {
+	if m.isBannedFn != nil {
+		return m.isBannedFn(peerID)
+	}
+
+	return false
+}
+
+func (m *MockNetworkingServer) HookIsBanned(fn isBannedDelegate) {
+	m.isBannedFn = fn
+}
+
 func (m *MockNetworkingServer) HasFreeConnectionSlot(direction network.Direction) bool {
 	if m.hasFreeConnectionSlotFn != nil {
 		return m.hasFreeConnectionSlotFn(direction)
@@ -7,13 +7,26 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
+	"github.com/0xPolygon/polygon-edge/types"
 )
 
 const DefaultGRPCPort int = 9632
 const DefaultJSONRPCPort int = 8545
 
+// SyncMode defines how a node starting with an empty chain catches up with the network
+type SyncMode string
+
+const (
+	// FullSyncMode executes every block from the genesis
+	FullSyncMode SyncMode = "full"
+	// SnapSyncMode downloads the state of a recent pivot block from the peers
+	// and executes only the blocks following it
+	SnapSyncMode SyncMode = "snap"
+)
+
 // Config is used to parametrize the minimal client
 type Config struct {
 	Chain *chain.Chain
@@ -25,6 +38,11 @@ type Config struct {
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
 	MaxSlots           uint64
+	MaxSenderTxsPerSec uint64
+
+	RejectUnprotectedTxs     bool
+	AllowUnprotectedLocalTxs bool
+	UnprotectedTxsAllowlist  []types.Address
 
 	Telemetry *Telemetry
 	Network   *network.Config
@@ -32,6 +50,27 @@ type Config struct {
 	DataDir     string
 	RestoreFile *string
 
+	StateScheme             StateScheme
+	StateRetainedRoots      uint64
+	StateCheckpointInterval uint64
+	StateFlatSnapshot       bool
+
+	SyncMode SyncMode
+
+	DBEngine dbengine.Engine
+
+	FreezerThreshold   uint64
+	FreezerCompression bool
+
+	HistoryRetention uint64
+
+	ParallelExecution bool
+
+	ConsoleLog bool
+
+	ForkURL   string
+	ForkBlock uint64
+
 	Seal bool
 
 	SecretsManager *secrets.SecretsManagerConfig
@@ -61,4 +100,5 @@ type JSONRPC struct {
 	BlockRangeLimit          uint64
 	ConcurrentRequestsDebug  uint64
 	WebSocketReadLimit       uint64
+	TxPoolAdmin              bool
 }
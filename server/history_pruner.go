@@ -0,0 +1,108 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+)
+
+// historyPruneInterval is the number of the blocks the retention window moves by between the prunings
+const historyPruneInterval = 128
+
+// historyPruner periodically removes the bodies and the receipts of the blocks which are out of the history retention window
+type historyPruner struct {
+	logger     hclog.Logger
+	blockchain *blockchain.Blockchain
+
+	// retention is the number of the most recent blocks whose history is kept
+	retention uint64
+
+	lastPruned uint64
+	pruneCh    chan struct{}
+	closeCh    chan struct{}
+}
+
+func newHistoryPruner(logger hclog.Logger, blockchain *blockchain.Blockchain, retention uint64) *historyPruner {
+	return &historyPruner{
+		logger:     logger.Named("history_pruner"),
+		blockchain: blockchain,
+		retention:  retention,
+		pruneCh:    make(chan struct{}, 1),
+		closeCh:    make(chan struct{}),
+	}
+}
+
+// start runs the pruning on startup, and then every time the retention window moved by historyPruneInterval blocks
+func (p *historyPruner) start() {
+	sub := p.blockchain.SubscribeEvents()
+
+	go func() {
+		defer p.blockchain.UnsubscribeEvents(sub)
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case ev := <-sub.GetEventCh():
+				if ev == nil || len(ev.NewChain) == 0 ||
+					ev.Header().Number < atomic.LoadUint64(&p.lastPruned)+historyPruneInterval {
+					continue
+				}
+
+				// the pruning runs aside, so that the blockchain events are never blocked
+				select {
+				case p.pruneCh <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	go func() {
+		// the history which went out of the window while the node was stopped is pruned right away
+		p.prune()
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case <-p.pruneCh:
+				p.prune()
+			}
+		}
+	}()
+}
+
+func (p *historyPruner) close() {
+	close(p.closeCh)
+}
+
+func (p *historyPruner) prune() {
+	head := p.blockchain.Header().Number
+	atomic.StoreUint64(&p.lastPruned, head)
+
+	if head < p.retention {
+		return
+	}
+
+	start := time.Now()
+	tail := head - p.retention + 1
+
+	pruned, err := p.blockchain.PruneHistory(tail)
+	if err != nil {
+		p.logger.Error("failed to prune the history", "tail", tail, "err", err)
+
+		return
+	}
+
+	if pruned == 0 {
+		return
+	}
+
+	metrics.IncrCounter([]string{"blockchain", "pruned_history_blocks"}, float32(pruned))
+	p.logger.Info("history pruned", "tail", tail, "blocks", pruned, "elapsed", time.Since(start))
+}
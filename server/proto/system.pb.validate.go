@@ -1135,6 +1135,8 @@ func (m *ExportRequest) validate(all bool) error {
 
 	// no validation rules for To
 
+	// no validation rules for Receipts
+
 	if len(errors) > 0 {
 		return ExportRequestMultiError(errors)
 	}
@@ -1320,6 +1322,216 @@ var _ interface {
 	ErrorName() string
 } = ExportEventValidationError{}
 
+// Validate checks the field values on ExportStateRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExportStateRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportStateRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportStateRequestMultiError, or nil if none found.
+func (m *ExportStateRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportStateRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Number
+
+	if len(errors) > 0 {
+		return ExportStateRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportStateRequestMultiError is an error wrapping multiple validation errors
+// returned by ExportStateRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ExportStateRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportStateRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportStateRequestMultiError) AllErrors() []error { return m }
+
+// ExportStateRequestValidationError is the validation error returned by
+// ExportStateRequest.Validate if the designated constraints aren't met.
+type ExportStateRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportStateRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportStateRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportStateRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportStateRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportStateRequestValidationError) ErrorName() string {
+	return "ExportStateRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExportStateRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportStateRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportStateRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportStateRequestValidationError{}
+
+// Validate checks the field values on ExportStateEvent with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ExportStateEvent) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExportStateEvent with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ExportStateEventMultiError, or nil if none found.
+func (m *ExportStateEvent) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExportStateEvent) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Block
+
+	// no validation rules for Receipts
+
+	// no validation rules for TotalDifficulty
+
+	if len(errors) > 0 {
+		return ExportStateEventMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExportStateEventMultiError is an error wrapping multiple validation errors
+// returned by ExportStateEvent.ValidateAll() if the designated constraints
+// aren't met.
+type ExportStateEventMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExportStateEventMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExportStateEventMultiError) AllErrors() []error { return m }
+
+// ExportStateEventValidationError is the validation error returned by
+// ExportStateEvent.Validate if the designated constraints aren't met.
+type ExportStateEventValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExportStateEventValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExportStateEventValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExportStateEventValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExportStateEventValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExportStateEventValidationError) ErrorName() string { return "ExportStateEventValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ExportStateEventValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExportStateEvent.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExportStateEventValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExportStateEventValidationError{}
+
 // Validate checks the field values on BlockchainEvent_Header with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -13,7 +13,6 @@ import (
 	"time"
 
 	"github.com/0xPolygon/polygon-edge/blockchain/storage"
-	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
 	"github.com/0xPolygon/polygon-edge/blockchain/storage/memory"
 	consensusPolyBFT "github.com/0xPolygon/polygon-edge/consensus/polybft"
 	"github.com/0xPolygon/polygon-edge/forkmanager"
@@ -26,16 +25,19 @@ import (
 	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/helper/common"
+	"github.com/0xPolygon/polygon-edge/helper/dbengine"
 	"github.com/0xPolygon/polygon-edge/helper/progress"
 	"github.com/0xPolygon/polygon-edge/jsonrpc"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/secrets"
 	"github.com/0xPolygon/polygon-edge/server/proto"
 	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/state/fork"
 	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
 	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
+	"github.com/0xPolygon/polygon-edge/syncer"
 	"github.com/0xPolygon/polygon-edge/txpool"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/0xPolygon/polygon-edge/validate"
@@ -56,6 +58,8 @@ type Server struct {
 	config       *Config
 	state        state.State
 	stateStorage itrie.Storage
+	// localState is the local state, which the state of a forked chain wraps
+	localState *itrie.State
 
 	consensus consensus.Consensus
 
@@ -88,6 +92,15 @@ type Server struct {
 
 	// gasHelper is providing functions regarding gas and fees
 	gasHelper *gasprice.GasHelper
+
+	// statePruner removes the old state with the full state scheme
+	statePruner *statePruner
+
+	// historyPruner removes the bodies and the receipts out of the history retention window
+	historyPruner *historyPruner
+
+	// forkUpstream reads the state of the forked chain, nil if the chain is not forked
+	forkUpstream *fork.Upstream
 }
 
 // newFileLogger returns logger instance that writes all logs to a specified file.
@@ -196,7 +209,7 @@ func NewServer(config *Config) (*Server, error) {
 	}
 
 	// start blockchain object
-	stateStorage, err := itrie.NewLevelDBStorage(filepath.Join(m.config.DataDir, "trie"), logger)
+	stateStorage, err := dbengine.OpenStateStorage(m.config.DBEngine, m.config.DataDir, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -205,8 +218,37 @@ func NewServer(config *Config) (*Server, error) {
 
 	st := itrie.NewState(stateStorage)
 	m.state = st
+	m.localState = st
+
+	if m.config.StateScheme == FullStateScheme {
+		if err := st.EnablePruning(); err != nil {
+			return nil, err
+		}
+	}
+
+	// a missing flat state snapshot is regenerated once the head block is known
+	if m.config.StateFlatSnapshot {
+		if err := st.EnableFlatSnapshot(); err != nil && !errors.Is(err, itrie.ErrFlatSnapshotMissing) {
+			return nil, err
+		}
+	}
+
+	if m.config.ForkURL != "" {
+		upstream, err := fork.NewUpstream(m.config.ForkURL, m.config.ForkBlock)
+		if err != nil {
+			return nil, err
+		}
+
+		m.forkUpstream = upstream
+		m.state = fork.NewState(st, upstream, logger.Named("fork"))
+
+		logger.Info("forking the remote chain", "url", m.config.ForkURL, "block", upstream.Block())
+	}
 
-	m.executor = state.NewExecutor(config.Chain.Params, st, logger)
+	m.executor = state.NewExecutor(config.Chain.Params, m.state, logger)
+	m.executor.ParallelExecution = config.ParallelExecution
+	m.executor.Console = config.ConsoleLog ||
+		ConsensusType(config.Chain.Params.GetEngine()) == DevConsensus
 
 	// custom write genesis hook per consensus engine
 	engineName := m.config.Chain.Params.GetEngine()
@@ -305,10 +347,7 @@ func NewServer(config *Config) (*Server, error) {
 				return nil, err
 			}
 		} else {
-			db, err = leveldb.NewLevelDBStorage(
-				filepath.Join(m.config.DataDir, "blockchain"),
-				m.logger,
-			)
+			db, err = m.openBlockchainStorage()
 			if err != nil {
 				return nil, err
 			}
@@ -336,6 +375,20 @@ func NewServer(config *Config) (*Server, error) {
 
 	m.executor.GetHash = m.blockchain.GetHashHelper
 
+	if m.config.StateScheme == FullStateScheme {
+		m.statePruner = newStatePruner(
+			logger,
+			st,
+			m.blockchain,
+			m.config.StateRetainedRoots,
+			m.config.StateCheckpointInterval,
+		)
+	}
+
+	if m.config.HistoryRetention > 0 {
+		m.historyPruner = newHistoryPruner(logger, m.blockchain, m.config.HistoryRetention)
+	}
+
 	{
 		hub := &txpoolHub{
 			state:      m.state,
@@ -354,6 +407,13 @@ func NewServer(config *Config) (*Server, error) {
 				PriceLimit:         m.config.PriceLimit,
 				MaxAccountEnqueued: m.config.MaxAccountEnqueued,
 				ChainID:            big.NewInt(m.config.Chain.Params.ChainID),
+				Ordering:           txpool.OrderingPolicy(m.config.Chain.Params.TxOrdering),
+
+				MaxSenderTxsPerSecond: m.config.MaxSenderTxsPerSec,
+
+				RejectUnprotectedTxs:     m.config.RejectUnprotectedTxs,
+				AllowUnprotectedLocalTxs: m.config.AllowUnprotectedLocalTxs,
+				UnprotectedTxsAllowlist:  m.config.UnprotectedTxsAllowlist,
 			},
 		)
 		if err != nil {
@@ -378,6 +438,12 @@ func NewServer(config *Config) (*Server, error) {
 		return nil, err
 	}
 
+	if head := m.blockchain.Header(); m.config.StateFlatSnapshot && !st.HasFlatSnapshot(head.StateRoot) {
+		if err := m.generateFlatSnapshot(st, head); err != nil {
+			return nil, err
+		}
+	}
+
 	// initialize data in consensus layer
 	if err := m.consensus.Initialize(); err != nil {
 		return nil, err
@@ -410,6 +476,14 @@ func NewServer(config *Config) (*Server, error) {
 	m.txpool.SetBaseFee(m.blockchain.Header())
 	m.txpool.Start()
 
+	if m.statePruner != nil {
+		m.statePruner.start()
+	}
+
+	if m.historyPruner != nil {
+		m.historyPruner.start()
+	}
+
 	return m, nil
 }
 
@@ -427,11 +501,39 @@ func unaryInterceptor(
 	return handler(ctx, req)
 }
 
+// openBlockchainStorage opens the blockchain storage in the data directory,
+// which moves the old blocks to the freezer if the freezer threshold is set
+func (s *Server) openBlockchainStorage() (storage.Storage, error) {
+	if s.config.FreezerThreshold == 0 {
+		return dbengine.OpenBlockchainStorage(s.config.DBEngine, s.config.DataDir, s.logger)
+	}
+
+	db, err := dbengine.OpenFreezerStorage(
+		s.config.DBEngine,
+		s.config.DataDir,
+		s.config.FreezerThreshold,
+		s.config.FreezerCompression,
+		s.config.HistoryRetention > 0,
+		s.logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	db.Start()
+
+	return db, nil
+}
+
 func (s *Server) restoreChain() error {
 	if s.config.RestoreFile == nil {
 		return nil
 	}
 
+	if archive.IsStateBackup(*s.config.RestoreFile) {
+		return archive.RestoreState(s.blockchain, s.stateStorage, *s.config.RestoreFile, s.logger)
+	}
+
 	if err := archive.RestoreChain(s.blockchain, *s.config.RestoreFile, s.restoreProgression); err != nil {
 		return err
 	}
@@ -579,6 +681,11 @@ func (s *Server) setupConsensus() error {
 			BlockTime:             uint64(blockTime.Seconds()),
 			NumBlockConfirmations: s.config.NumBlockConfirmations,
 			MetricsInterval:       s.config.MetricsInterval,
+			SnapSync: &syncer.SnapSyncConfig{
+				Enabled:       s.config.SyncMode == SnapSyncMode,
+				PivotDistance: syncer.DefaultSnapSyncPivotDistance,
+				StateStorage:  s.stateStorage,
+			},
 		},
 	)
 
@@ -666,6 +773,11 @@ func (j *jsonRPCHub) GetStorage(stateRoot types.Hash, addr types.Address, slot t
 
 	res := snap.GetStorage(addr, account.Root, slot)
 
+	// the storage slot of a forked chain may not be read
+	if failing, ok := snap.(state.FailingSnapshot); ok && failing.Err() != nil {
+		return nil, failing.Err()
+	}
+
 	return res.Bytes(), nil
 }
 
@@ -688,6 +800,7 @@ func (j *jsonRPCHub) ApplyTxn(
 	txn *types.Transaction,
 	override types.StateOverride,
 	nonPayable bool,
+	console bool,
 ) (result *runtime.ExecutionResult, err error) {
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
 	if err != nil {
@@ -699,6 +812,10 @@ func (j *jsonRPCHub) ApplyTxn(
 		return
 	}
 
+	if !console {
+		transition.SilenceConsole()
+	}
+
 	if override != nil {
 		if err = transition.WithStateOverride(override); err != nil {
 			return
@@ -712,6 +829,40 @@ func (j *jsonRPCHub) ApplyTxn(
 	return
 }
 
+// ApplyBundle writes the bundle transactions in order in the given child block on top of the parent state,
+// through the same path used for the block inclusion, and returns their receipts and execution results.
+// The unsealed child block is credited to the creator of the parent
+func (j *jsonRPCHub) ApplyBundle(
+	parent *types.Header,
+	header *types.Header,
+	txs []*types.Transaction,
+) ([]*types.Receipt, []*runtime.ExecutionResult, error) {
+	blockCreator, err := j.GetConsensus().GetBlockCreator(parent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transition, err := j.BeginTxn(parent.StateRoot, header, blockCreator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the bundle is only simulated
+	transition.SilenceConsole()
+
+	results := make([]*runtime.ExecutionResult, len(txs))
+
+	for idx, tx := range txs {
+		tx.ComputeHash(header.Number)
+
+		if results[idx], err = transition.WriteWithResult(tx); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply bundle transaction %d: %w", idx, err)
+		}
+	}
+
+	return transition.Receipts(), results, nil
+}
+
 // TraceBlock traces all transactions in the given block and returns all results
 func (j *jsonRPCHub) TraceBlock(
 	block *types.Block,
@@ -736,6 +887,9 @@ func (j *jsonRPCHub) TraceBlock(
 		return nil, err
 	}
 
+	// the console.log messages of the sealed transactions were printed when their block was built
+	transition.SilenceConsole()
+
 	transition.SetTracer(tracer)
 
 	results := make([]interface{}, len(block.Transactions))
@@ -780,6 +934,9 @@ func (j *jsonRPCHub) TraceTxn(
 		return nil, err
 	}
 
+	// the console.log messages of the sealed transactions were printed when their block was built
+	transition.SilenceConsole()
+
 	var targetTx *types.Transaction
 
 	for _, tx := range block.Transactions {
@@ -823,6 +980,9 @@ func (j *jsonRPCHub) TraceCall(
 		return nil, err
 	}
 
+	// the call is only simulated, its console.log messages are traced
+	transition.SilenceConsole()
+
 	transition.SetTracer(tracer)
 
 	if _, err := transition.Apply(tx); err != nil {
@@ -873,6 +1033,12 @@ func (s *Server) setupJSONRPC() error {
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
 		ConcurrentRequestsDebug:  s.config.JSONRPC.ConcurrentRequestsDebug,
 		WebSocketReadLimit:       s.config.JSONRPC.WebSocketReadLimit,
+		TxPoolAdmin:              s.config.JSONRPC.TxPoolAdmin,
+	}
+
+	// the dev consensus exposes the node controls of the local development tools
+	if devStore, ok := s.consensus.(jsonrpc.DevStore); ok {
+		conf.DevStore = devStore
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -916,6 +1082,36 @@ func (s *Server) JoinPeer(rawPeerMultiaddr string) error {
 	return s.network.JoinPeer(rawPeerMultiaddr)
 }
 
+// generateFlatSnapshot regenerates the flat state snapshot of the head block in the background.
+// The flat snapshot on disk doesn't match the head block after a crash or after the head is rewound
+func (s *Server) generateFlatSnapshot(st *itrie.State, head *types.Header) error {
+	s.logger.Warn("flat state snapshot does not match the head block, regenerating it in the background. "+
+		"The state is read from the trie in the meantime, which is slower",
+		"block", head.Number, "root", head.StateRoot)
+
+	start := time.Now()
+
+	result, err := st.GenerateFlatSnapshot(head.StateRoot)
+	if err != nil {
+		return fmt.Errorf("failed to regenerate the flat state snapshot: %w", err)
+	}
+
+	go func() {
+		if err := <-result; errors.Is(err, itrie.ErrFlatGenerationAborted) {
+			return
+		} else if err != nil {
+			s.logger.Error("failed to regenerate the flat state snapshot, the state is read from the trie. "+
+				"Run the snapshot rebuild command on the stopped node to regenerate it", "err", err)
+
+			return
+		}
+
+		s.logger.Info("flat state snapshot regenerated", "block", head.Number, "elapsed", time.Since(start))
+	}()
+
+	return nil
+}
+
 // Close closes the Minimal server (blockchain, networking, consensus)
 func (s *Server) Close() {
 	// Close the blockchain layer
@@ -933,11 +1129,33 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Stop the state pruning
+	if s.statePruner != nil {
+		s.statePruner.close()
+	}
+
+	// Stop the history pruning
+	if s.historyPruner != nil {
+		s.historyPruner.close()
+	}
+
+	// Persist the flat state snapshot, so that it is available after the restart
+	if err := s.localState.PersistFlatSnapshot(s.blockchain.Header().StateRoot); err != nil {
+		s.logger.Error("failed to persist the flat state snapshot", "err", err.Error())
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
 	}
 
+	// Close the connection to the forked chain
+	if s.forkUpstream != nil {
+		if err := s.forkUpstream.Close(); err != nil {
+			s.logger.Error("failed to close the connection to the forked chain", "err", err.Error())
+		}
+	}
+
 	if s.prometheusServer != nil {
 		if err := s.prometheusServer.Shutdown(context.Background()); err != nil {
 			s.logger.Error("Prometheus server shutdown error", err)
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// StateScheme defines which state is kept by the node
+type StateScheme string
+
+const (
+	// ArchiveStateScheme keeps the state of every block
+	ArchiveStateScheme StateScheme = "archive"
+	// FullStateScheme keeps the state of the most recent blocks and of the checkpoint blocks
+	FullStateScheme StateScheme = "full"
+)
+
+// statePruner periodically removes the state which is no longer retained
+// when the node runs with the full state scheme
+type statePruner struct {
+	logger     hclog.Logger
+	state      *itrie.State
+	blockchain *blockchain.Blockchain
+
+	// retainedRoots is the number of the most recent blocks whose state is kept
+	retainedRoots uint64
+	// checkpointInterval is the interval of the blocks whose state is kept forever
+	checkpointInterval uint64
+
+	lastPruned uint64
+	pruneCh    chan struct{}
+	closeCh    chan struct{}
+}
+
+func newStatePruner(
+	logger hclog.Logger,
+	state *itrie.State,
+	blockchain *blockchain.Blockchain,
+	retainedRoots uint64,
+	checkpointInterval uint64,
+) *statePruner {
+	return &statePruner{
+		logger:             logger.Named("state_pruner"),
+		state:              state,
+		blockchain:         blockchain,
+		retainedRoots:      retainedRoots,
+		checkpointInterval: checkpointInterval,
+		pruneCh:            make(chan struct{}, 1),
+		closeCh:            make(chan struct{}),
+	}
+}
+
+// start runs the pruning every time the retained window moved by retainedRoots blocks
+func (p *statePruner) start() {
+	atomic.StoreUint64(&p.lastPruned, p.blockchain.Header().Number)
+
+	sub := p.blockchain.SubscribeEvents()
+
+	go func() {
+		defer p.blockchain.UnsubscribeEvents(sub)
+
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case ev := <-sub.GetEventCh():
+				if ev == nil || len(ev.NewChain) == 0 ||
+					ev.Header().Number < atomic.LoadUint64(&p.lastPruned)+p.retainedRoots {
+					continue
+				}
+
+				// the pruning runs aside, so that the blockchain events are never blocked
+				select {
+				case p.pruneCh <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case <-p.closeCh:
+				return
+			case <-p.pruneCh:
+				p.prune()
+			}
+		}
+	}()
+}
+
+func (p *statePruner) close() {
+	close(p.closeCh)
+}
+
+func (p *statePruner) prune() {
+	head := p.blockchain.Header()
+	start := time.Now()
+
+	removed, err := p.state.Prune(p.retainedStateRoots(head.Number))
+	if err != nil {
+		p.logger.Error("failed to prune the state", "block", head.Number, "err", err)
+
+		return
+	}
+
+	atomic.StoreUint64(&p.lastPruned, head.Number)
+
+	metrics.IncrCounter([]string{"state", "pruned_nodes"}, float32(removed))
+	p.logger.Info("state pruned", "block", head.Number, "removed", removed, "elapsed", time.Since(start))
+}
+
+// retainedStateRoots returns the state roots of the most recent blocks,
+// the checkpoint blocks and the genesis block
+func (p *statePruner) retainedStateRoots(head uint64) []types.Hash {
+	numbers := []uint64{0}
+
+	if p.checkpointInterval > 0 {
+		for n := p.checkpointInterval; n <= head; n += p.checkpointInterval {
+			numbers = append(numbers, n)
+		}
+	}
+
+	from := uint64(0)
+	if head >= p.retainedRoots {
+		from = head - p.retainedRoots + 1
+	}
+
+	for n := from; n <= head; n++ {
+		numbers = append(numbers, n)
+	}
+
+	roots := make([]types.Hash, 0, len(numbers))
+
+	for _, n := range numbers {
+		if header, ok := p.blockchain.GetHeaderByNumber(n); ok {
+			roots = append(roots, header.StateRoot)
+		}
+	}
+
+	return roots
+}
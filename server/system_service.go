@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/network/common"
 	"github.com/0xPolygon/polygon-edge/server/proto"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/libp2p/go-libp2p/core/peer"
 	empty "google.golang.org/protobuf/types/known/emptypb"
@@ -37,6 +38,7 @@ func (s *systemService) GetStatus(ctx context.Context, req *empty.Empty) (*proto
 
 	status := &proto.ServerStatus{
 		Network: s.server.chain.Params.ChainID,
+		Genesis: s.server.blockchain.Genesis().String(),
 		Current: &proto.ServerStatus_Block{
 			Number: int64(header.Number),
 			Hash:   header.Hash.String(),
@@ -187,8 +189,8 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	if req.To != 0 {
-		if from >= req.To {
-			return errors.New("to must be greater than from")
+		if from > req.To {
+			return errors.New("to must not be less than from")
 		}
 
 		to = &req.To
@@ -205,6 +207,7 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	}
 
 	writer := newBlockStreamWriter(stream, s.server.blockchain, defaultMaxGRPCPayloadSize)
+	writer.receipts = req.Receipts
 	i := from
 
 	for canLoop(i) {
@@ -227,6 +230,100 @@ func (s *systemService) Export(req *proto.ExportRequest, stream proto.System_Exp
 	return nil
 }
 
+// ExportState streams the block with the given number, the headers of its ancestors and the state
+// of the block, the trie nodes of the accounts and of the storages and the contract codes, in events
+// of bounded size
+func (s *systemService) ExportState(req *proto.ExportStateRequest, stream proto.System_ExportStateServer) error {
+	header := s.server.blockchain.Header()
+	if req.Number != 0 {
+		var ok bool
+
+		if header, ok = s.server.blockchain.GetHeaderByNumber(req.Number); !ok {
+			return fmt.Errorf("block #%d not found", req.Number)
+		}
+	}
+
+	block, ok := s.server.blockchain.GetBlockByHash(header.Hash, true)
+	if !ok {
+		return fmt.Errorf("block #%d not found", header.Number)
+	}
+
+	receipts, err := s.server.blockchain.GetReceiptsByHash(header.Hash)
+	if err != nil {
+		return err
+	}
+
+	td, ok := s.server.blockchain.GetTD(header.Hash)
+	if !ok {
+		return fmt.Errorf("total difficulty of block #%d not found", header.Number)
+	}
+
+	// the state pruner keeps the exported state until the stream ends
+	release := s.server.localState.RetainRoot(header.StateRoot)
+	defer release()
+
+	if header.StateRoot != types.EmptyRootHash {
+		if _, ok, err := itrie.GetNode(header.StateRoot.Bytes(), s.server.stateStorage); err != nil || !ok {
+			return errors.Join(fmt.Errorf("state of block #%d is not available", header.Number), err)
+		}
+	}
+
+	event := &proto.ExportStateEvent{
+		Block:           block.MarshalRLP(),
+		Receipts:        types.Receipts(receipts).MarshalStoreRLPTo(nil),
+		TotalDifficulty: td.Bytes(),
+	}
+	size := uint64(len(event.Block) + len(event.Receipts))
+
+	// the headers let the restoring node verify the block with the consensus
+	for number := uint64(1); number <= header.Number; number++ {
+		ancestor, ok := s.server.blockchain.GetHeaderByNumber(number)
+		if !ok {
+			return fmt.Errorf("header #%d not found", number)
+		}
+
+		data := ancestor.MarshalRLP()
+
+		if size+uint64(len(data)) >= defaultMaxGRPCPayloadSize {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+
+			event = &proto.ExportStateEvent{}
+			size = 0
+		}
+
+		event.Headers = append(event.Headers, data)
+		size += uint64(len(data))
+	}
+
+	err = itrie.ExportState(s.server.stateStorage, header.StateRoot, func(data []byte, code bool) error {
+		if size+uint64(len(data)) >= defaultMaxGRPCPayloadSize {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+
+			event = &proto.ExportStateEvent{}
+			size = 0
+		}
+
+		if code {
+			event.Codes = append(event.Codes, data)
+		} else {
+			event.Nodes = append(event.Nodes, data)
+		}
+
+		size += uint64(len(data))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(event)
+}
+
 const (
 	defaultMaxGRPCPayloadSize uint64 = 512 * 1024 // 4MB
 
@@ -241,6 +338,13 @@ type blockStreamWriter struct {
 	maxPayload  uint64
 	pendingFrom *uint64 // first block height in buffer
 	pendingTo   *uint64 // last block height in buffer
+
+	// receipts is true if the receipts, the total difficulties and the hashes of the blocks are sent too
+	receipts            bool
+	pendingReceipts     [][]byte
+	pendingDifficulties [][]byte
+	pendingHashes       [][]byte
+	pendingSize         int
 }
 
 func newBlockStreamWriter(
@@ -258,7 +362,26 @@ func newBlockStreamWriter(
 
 func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 	data := b.MarshalRLP()
-	if uint64(maxHeaderInfoSize+w.buf.Len()+len(data)) >= w.maxPayload {
+
+	var receipts, td []byte
+
+	if w.receipts {
+		blockReceipts, err := w.blockchain.GetReceiptsByHash(b.Hash())
+		if err != nil {
+			return err
+		}
+
+		difficulty, ok := w.blockchain.GetTD(b.Hash())
+		if !ok {
+			return fmt.Errorf("total difficulty of block #%d not found", b.Number())
+		}
+
+		receipts = types.Receipts(blockReceipts).MarshalStoreRLPTo(nil)
+		td = difficulty.Bytes()
+	}
+
+	size := len(data) + len(receipts) + len(td) + types.HashLength
+	if uint64(maxHeaderInfoSize+w.buf.Len()+w.pendingSize+size) >= w.maxPayload {
 		// send buffered data to client first
 		if err := w.flush(); err != nil {
 			return err
@@ -267,6 +390,13 @@ func (w *blockStreamWriter) appendBlock(b *types.Block) error {
 
 	w.buf.Write(data)
 
+	if w.receipts {
+		w.pendingReceipts = append(w.pendingReceipts, receipts)
+		w.pendingDifficulties = append(w.pendingDifficulties, td)
+		w.pendingHashes = append(w.pendingHashes, b.Hash().Bytes())
+		w.pendingSize += len(receipts) + len(td) + types.HashLength
+	}
+
 	n := b.Number()
 	if w.pendingFrom == nil {
 		w.pendingFrom = &n
@@ -289,10 +419,13 @@ func (w *blockStreamWriter) flush() error {
 	}
 
 	err := w.stream.Send(&proto.ExportEvent{
-		From:   *w.pendingFrom,
-		To:     *w.pendingTo,
-		Latest: w.blockchain.Header().Number,
-		Data:   w.buf.Bytes(),
+		From:              *w.pendingFrom,
+		To:                *w.pendingTo,
+		Latest:            w.blockchain.Header().Number,
+		Data:              w.buf.Bytes(),
+		Receipts:          w.pendingReceipts,
+		TotalDifficulties: w.pendingDifficulties,
+		Hashes:            w.pendingHashes,
 	})
 
 	if err != nil {
@@ -308,4 +441,8 @@ func (w *blockStreamWriter) reset() {
 	w.buf.Reset()
 	w.pendingFrom = nil
 	w.pendingTo = nil
+	w.pendingReceipts = nil
+	w.pendingDifficulties = nil
+	w.pendingHashes = nil
+	w.pendingSize = 0
 }
@@ -41,14 +41,24 @@ type Executor struct {
 
 	PostHook        func(txn *Transition)
 	GenesisPostHook func(*Transition) error
+
+	// ParallelExecution enables the optimistic parallel execution of the block transactions
+	ParallelExecution bool
+
+	// Prefetcher warms the state touched by the transactions before their execution, nil disables it
+	Prefetcher *Prefetcher
+
+	// Console enables the Hardhat console.log precompile, printing the messages to the logger
+	Console bool
 }
 
 // NewExecutor creates a new executor
 func NewExecutor(config *chain.Params, s State, logger hclog.Logger) *Executor {
 	return &Executor{
-		logger: logger,
-		config: config,
-		state:  s,
+		logger:     logger,
+		config:     config,
+		state:      s,
+		Prefetcher: NewPrefetcher(),
 	}
 }
 
@@ -141,11 +151,30 @@ func (e *Executor) ProcessBlock(
 		return nil, err
 	}
 
+	// the console.log messages were printed when the block was built
+	txn.SilenceConsole()
+
+	txs := make([]*types.Transaction, 0, len(block.Transactions))
+
 	for _, t := range block.Transactions {
 		if t.Gas > block.Header.GasLimit {
 			continue
 		}
 
+		txs = append(txs, t)
+	}
+
+	defer txn.Prefetch(txs)()
+
+	if e.ParallelExecution && txn.PostHook == nil {
+		if err = txn.writeParallel(txs); err != nil {
+			return nil, err
+		}
+
+		return txn, nil
+	}
+
+	for _, t := range txs {
 		if err = txn.Write(t); err != nil {
 			return nil, err
 		}
@@ -218,6 +247,11 @@ func (e *Executor) BeginTxn(
 		evm:         evm.NewEVM(),
 		precompiles: precompiled.NewPrecompiled(),
 		PostHook:    e.PostHook,
+		prefetcher:  e.Prefetcher,
+	}
+
+	if e.Console {
+		txn.precompiles.EnableConsole(e.logger.Named("console"))
 	}
 
 	// enable contract deployment allow list (if any)
@@ -269,6 +303,12 @@ type Transition struct {
 
 	PostHook func(t *Transition)
 
+	prefetcher *Prefetcher
+
+	// fees deferred by the speculative transitions of the parallel execution
+	deferFees bool
+	fees      []fee
+
 	// runtimes
 	evm         *evm.EVM
 	precompiles *precompiled.Precompiled
@@ -292,6 +332,12 @@ func NewTransition(config chain.ForksInTime, snap Snapshot, radix *Txn) *Transit
 	}
 }
 
+// SilenceConsole stops printing the console.log messages of the transactions to the logger,
+// for the transactions which are executed again or only simulated. The messages are still traced
+func (t *Transition) SilenceConsole() {
+	t.precompiles.SilenceConsole()
+}
+
 func (t *Transition) WithStateOverride(override types.StateOverride) error {
 	for addr, o := range override {
 		if o.State != nil && o.StateDiff != nil {
@@ -334,17 +380,15 @@ var emptyFrom = types.Address{}
 
 // Write writes another transaction to the executor
 func (t *Transition) Write(txn *types.Transaction) error {
-	var err error
+	_, err := t.WriteWithResult(txn)
 
-	if txn.From == emptyFrom &&
-		(txn.Type == types.LegacyTx || txn.Type == types.DynamicFeeTx) {
-		// Decrypt the from address
-		signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
+	return err
+}
 
-		txn.From, err = signer.Sender(txn)
-		if err != nil {
-			return NewTransitionApplicationError(err, false)
-		}
+// WriteWithResult writes another transaction to the executor and returns its execution result
+func (t *Transition) WriteWithResult(txn *types.Transaction) (*runtime.ExecutionResult, error) {
+	if err := t.recoverSender(txn); err != nil {
+		return nil, err
 	}
 
 	// Make a local copy and apply the transaction
@@ -354,12 +398,43 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	if e != nil {
 		t.logger.Error("failed to apply tx", "err", e)
 
-		return e
+		return nil, e
 	}
 
-	t.totalGas += result.GasUsed
+	if err := t.addReceipt(txn, msg, result, t.state.Logs()); err != nil {
+		return nil, err
+	}
 
-	logs := t.state.Logs()
+	return result, nil
+}
+
+// recoverSender sets the sender of the signed transaction if it is not set yet
+func (t *Transition) recoverSender(txn *types.Transaction) error {
+	if txn.From != emptyFrom ||
+		(txn.Type != types.LegacyTx && txn.Type != types.DynamicFeeTx) {
+		return nil
+	}
+
+	// Decrypt the from address
+	signer := crypto.NewSigner(t.config, uint64(t.ctx.ChainID))
+
+	from, err := signer.Sender(txn)
+	if err != nil {
+		return NewTransitionApplicationError(err, false)
+	}
+
+	txn.From = from
+
+	return nil
+}
+
+// addReceipt appends the receipt of the applied transaction
+func (t *Transition) addReceipt(
+	txn, msg *types.Transaction,
+	result *runtime.ExecutionResult,
+	logs []*types.Log,
+) error {
+	t.totalGas += result.GasUsed
 
 	receipt := &types.Receipt{
 		CumulativeGasUsed: t.totalGas,
@@ -392,6 +467,42 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	return nil
 }
 
+// WriteBundle writes the given transactions atomically and in order.
+// If any of the transactions can not be applied or its execution fails,
+// the transition is reverted to the state before the bundle
+func (t *Transition) WriteBundle(txs []*types.Transaction) error {
+	var (
+		snapshot     = t.state.Snapshot()
+		receiptsSize = len(t.receipts)
+		totalGas     = t.totalGas
+		gasPool      = t.gasPool
+	)
+
+	revert := func(err error) error {
+		if revertErr := t.state.RevertToSnapshot(snapshot); revertErr != nil {
+			return revertErr
+		}
+
+		t.receipts = t.receipts[:receiptsSize]
+		t.totalGas = totalGas
+		t.gasPool = gasPool
+
+		return err
+	}
+
+	for _, txn := range txs {
+		if err := t.Write(txn); err != nil {
+			return revert(err)
+		}
+
+		if receipt := t.receipts[len(t.receipts)-1]; *receipt.Status != types.ReceiptSuccess {
+			return revert(fmt.Errorf("%w: %s", ErrBundleTxFailed, txn.Hash))
+		}
+	}
+
+	return nil
+}
+
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash, error) {
 	objs, err := t.state.Commit(t.config.EIP155)
@@ -399,6 +510,10 @@ func (t *Transition) Commit() (Snapshot, types.Hash, error) {
 		return nil, types.ZeroHash, err
 	}
 
+	if t.prefetcher != nil {
+		t.prefetcher.record(objs)
+	}
+
 	s2, root, err := t.snap.Commit(objs)
 	if err != nil {
 		return nil, types.ZeroHash, err
@@ -430,6 +545,12 @@ func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	s := t.state.Snapshot()
 
 	result, err := t.apply(msg)
+
+	// the execution which read a missing state is not valid, whatever its outcome
+	if snapErr := t.snapshotErr(); snapErr != nil {
+		result, err = nil, snapErr
+	}
+
 	if err != nil {
 		if revertErr := t.state.RevertToSnapshot(s); revertErr != nil {
 			return nil, revertErr
@@ -443,6 +564,17 @@ func (t *Transition) Apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, err
 }
 
+// snapshotErr returns the error of the failed reads of the snapshot, if it can fail
+func (t *Transition) snapshotErr() error {
+	if snap, ok := t.snap.(FailingSnapshot); ok {
+		if err := snap.Err(); err != nil {
+			return fmt.Errorf("%w: %w", ErrStateUnavailable, err)
+		}
+	}
+
+	return nil
+}
+
 // ContextPtr returns reference of context
 // This method is called only by test
 func (t *Transition) ContextPtr() *runtime.TxContext {
@@ -507,6 +639,9 @@ var (
 
 	// ErrNonceUintOverflow is returned if uint64 overflow happens
 	ErrNonceUintOverflow = errors.New("nonce uint64 overflow")
+
+	// ErrBundleTxFailed is returned if the execution of a bundle transaction fails
+	ErrBundleTxFailed = errors.New("bundle transaction execution failed")
 )
 
 type TransitionApplicationError struct {
@@ -608,13 +743,13 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 
 	// Pay the coinbase fee as a miner reward using the calculated effective tip.
 	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), effectiveTip)
-	t.state.AddBalance(t.ctx.Coinbase, coinbaseFee)
+	t.payFee(t.ctx.Coinbase, coinbaseFee)
 
 	// Burn some amount if the london hardfork is applied.
 	// Basically, burn amount is just transferred to the current burn contract.
 	if t.config.London && msg.Type != types.StateTx {
 		burnAmount := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), t.ctx.BaseFee)
-		t.state.AddBalance(t.ctx.BurnContract, burnAmount)
+		t.payFee(t.ctx.BurnContract, burnAmount)
 	}
 
 	// return gas to the pool
@@ -623,6 +758,19 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	return result, nil
 }
 
+// payFee credits the fee of the transaction to the coinbase or the burn contract
+func (t *Transition) payFee(addr types.Address, amount *big.Int) {
+	// the speculative transitions of the parallel execution defer the fees,
+	// otherwise all the transactions would conflict on the coinbase balance
+	if t.deferFees {
+		t.fees = append(t.fees, fee{addr: addr, amount: amount})
+
+		return
+	}
+
+	t.state.AddBalance(addr, amount)
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -0,0 +1,251 @@
+package state
+
+import (
+	"math/big"
+	goruntime "runtime"
+	"sync"
+
+	iradix "github.com/hashicorp/go-immutable-radix"
+
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/addresslist"
+	"github.com/0xPolygon/polygon-edge/state/runtime/evm"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// parallelWindowFactor is the number of the transactions speculated at once per worker
+const parallelWindowFactor = 4
+
+// fee is a fee payment deferred by a speculative transition
+type fee struct {
+	addr   types.Address
+	amount *big.Int
+}
+
+// speculativeResult is the result of a transaction executed speculatively
+// against the state before the transactions of its window
+type speculativeResult struct {
+	msg    *types.Transaction
+	result *runtime.ExecutionResult
+	err    error
+
+	state *Txn
+	logs  []*types.Log
+	fees  []fee
+
+	// console are the console.log messages, printed only if the result is committed
+	console []string
+}
+
+// writeParallel writes the transactions optimistically in parallel, with the same outcome as
+// writing them one after another with Write. The transactions are first executed speculatively
+// against the current state, recording the accounts they read and write. The results are then
+// committed in the order of the transactions: a transaction which read an account written by
+// the transactions committed before it is executed again on top of them.
+// The fees paid to the coinbase and the burn contract are credited at the commit, so that
+// the transactions don't conflict on them
+func (t *Transition) writeParallel(txs []*types.Transaction) error {
+	// the tracer follows the execution of the transactions in order
+	if len(txs) < 2 || t.ctx.Tracer != nil {
+		for _, txn := range txs {
+			if err := t.Write(txn); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	workers := goruntime.NumCPU()
+
+	// the transactions are speculated in windows, so that they are validated
+	// against the writes of the few transactions committed before them in the window
+	window := parallelWindowFactor * workers
+
+	for start := 0; start < len(txs); start += window {
+		end := start + window
+		if end > len(txs) {
+			end = len(txs)
+		}
+
+		if err := t.writeWindow(txs[start:end], workers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeWindow speculates the transactions in parallel against the current state,
+// and then commits them in order
+func (t *Transition) writeWindow(txs []*types.Transaction, workers int) error {
+	var (
+		base    = t.state.txn.CommitOnly()
+		results = make([]*speculativeResult, len(txs))
+		jobs    = make(chan int, len(txs))
+		wg      sync.WaitGroup
+	)
+
+	for i := range txs {
+		jobs <- i
+	}
+
+	close(jobs)
+
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i] = t.speculate(txs[i], base)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	// the accounts written since the speculation
+	t.state.access = newAccessSet()
+	defer func() {
+		t.state.access = nil
+	}()
+
+	for i, txn := range txs {
+		if res := results[i]; t.canCommit(res) {
+			if err := t.commitSpeculative(txn, res); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// the speculative execution is not valid anymore, execute the transaction again
+		if err := t.Write(txn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// speculate executes the transaction against the base state
+func (t *Transition) speculate(txn *types.Transaction, base *iradix.Tree) *speculativeResult {
+	if err := t.recoverSender(txn); err != nil {
+		return &speculativeResult{err: err}
+	}
+
+	spec := t.speculativeTransition(base)
+	msg := txn.Copy()
+
+	result, err := spec.Apply(msg)
+	if err != nil {
+		return &speculativeResult{err: err}
+	}
+
+	return &speculativeResult{
+		msg:     msg,
+		result:  result,
+		state:   spec.state,
+		logs:    spec.state.Logs(),
+		fees:    spec.fees,
+		console: spec.precompiles.ConsoleMessages(),
+	}
+}
+
+// speculativeTransition returns a copy of the transition working on its own radix over the base state
+func (t *Transition) speculativeTransition(base *iradix.Tree) *Transition {
+	spec := &Transition{
+		logger:   t.logger,
+		auxState: t.auxState,
+		snap:     t.snap,
+		config:   t.config,
+		getHash:  t.getHash,
+		ctx:      t.ctx,
+		gasPool:  t.gasPool,
+		state: &Txn{
+			snapshot:  t.state.snapshot,
+			snapshots: []*iradix.Tree{},
+			txn:       base.Txn(),
+			codeCache: t.state.codeCache,
+			access:    newAccessSet(),
+		},
+		deferFees:   true,
+		evm:         evm.NewEVM(),
+		precompiles: t.precompiles.Copy(),
+	}
+
+	// the transaction may be executed again, its console.log messages are printed at the commit
+	spec.precompiles.BufferConsole()
+
+	// the address lists read their state through the transition
+	rebind := func(list *addresslist.AddressList) *addresslist.AddressList {
+		if list == nil {
+			return nil
+		}
+
+		return addresslist.NewAddressList(spec, list.Addr())
+	}
+
+	spec.deploymentAllowList = rebind(t.deploymentAllowList)
+	spec.deploymentBlockList = rebind(t.deploymentBlockList)
+	spec.txnAllowList = rebind(t.txnAllowList)
+	spec.txnBlockList = rebind(t.txnBlockList)
+	spec.bridgeAllowList = rebind(t.bridgeAllowList)
+	spec.bridgeBlockList = rebind(t.bridgeBlockList)
+
+	return spec
+}
+
+// canCommit checks that the speculative execution would be the same on top of the committed transactions
+func (t *Transition) canCommit(res *speculativeResult) bool {
+	if res.err != nil {
+		return false
+	}
+
+	// the gas pool only shrinks, so the speculative execution had at least as much gas available
+	if t.gasPool < res.msg.Gas {
+		return false
+	}
+
+	// the speculative execution didn't see the fees of the previous transactions
+	for _, addr := range []types.Address{t.ctx.Coinbase, t.ctx.BurnContract} {
+		if _, ok := res.state.access.reads[addr]; ok {
+			return false
+		}
+	}
+
+	return !res.state.access.conflicts(t.state.access)
+}
+
+// commitSpeculative applies the accounts written by the speculative execution and appends the receipt
+func (t *Transition) commitSpeculative(txn *types.Transaction, res *speculativeResult) error {
+	if err := t.subGasPool(res.msg.Gas); err != nil {
+		return NewGasLimitReachedTransitionApplicationError(err)
+	}
+
+	t.addGasPool(res.result.GasLeft)
+
+	for addr := range res.state.access.writes {
+		object, ok := res.state.txn.Get(addr.Bytes())
+		if !ok {
+			// the account was created and reverted
+			continue
+		}
+
+		t.state.insertObject(addr, object.(*StateObject)) //nolint:forcetypeassert
+	}
+
+	for _, fee := range res.fees {
+		t.state.AddBalance(fee.addr, fee.amount)
+	}
+
+	t.precompiles.PrintConsole(res.console)
+
+	return t.addReceipt(txn, res.msg, res.result, res.logs)
+}
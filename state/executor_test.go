@@ -1,14 +1,20 @@
 package state
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/contracts"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
 )
@@ -157,3 +163,108 @@ func Test_Transition_checkDynamicFees(t *testing.T) {
 		})
 	}
 }
+
+func TestTransition_WriteBundle(t *testing.T) {
+	t.Parallel()
+
+	receiver := types.Address{0x2}
+
+	newTransition := func() *Transition {
+		state := newStateWithPreState(map[types.Address]*PreState{
+			addr1: {Balance: 1_000_000_000},
+		})
+
+		transition := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+		transition.logger = hclog.NewNullLogger()
+		transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 1_000_000}
+		transition.gasPool = 1_000_000
+
+		return transition
+	}
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     addr1,
+			To:       &receiver,
+			Nonce:    nonce,
+			Value:    big.NewInt(1),
+			Gas:      TxGas,
+			GasPrice: big.NewInt(1),
+		}
+	}
+
+	t.Run("all transactions succeed", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition()
+
+		require.NoError(t, transition.WriteBundle([]*types.Transaction{newTx(0), newTx(1)}))
+		require.Len(t, transition.Receipts(), 2)
+		require.Equal(t, 2*TxGas, transition.TotalGas())
+		require.Equal(t, big.NewInt(2), transition.state.GetBalance(receiver))
+		require.Equal(t, uint64(2), transition.state.GetNonce(addr1))
+	})
+
+	t.Run("failed transaction reverts the whole bundle", func(t *testing.T) {
+		t.Parallel()
+
+		transition := newTransition()
+
+		require.NoError(t, transition.Write(newTx(0)))
+
+		gasPool := transition.gasPool
+
+		// the second transaction has an invalid nonce
+		require.Error(t, transition.WriteBundle([]*types.Transaction{newTx(1), newTx(5)}))
+		require.Len(t, transition.Receipts(), 1)
+		require.Equal(t, TxGas, transition.TotalGas())
+		require.Equal(t, gasPool, transition.gasPool)
+		require.Equal(t, big.NewInt(1), transition.state.GetBalance(receiver))
+		require.Equal(t, uint64(1), transition.state.GetNonce(addr1))
+	})
+}
+
+func TestTransition_WriteParallelConsole(t *testing.T) {
+	t.Parallel()
+
+	var (
+		output  bytes.Buffer
+		senders = []types.Address{{0x10}, {0x20}}
+	)
+
+	state := newStateWithPreState(map[types.Address]*PreState{
+		senders[0]: {Balance: 1_000_000_000},
+		senders[1]: {Balance: 1_000_000_000},
+	})
+
+	transition := NewTransition(chain.AllForksEnabled.At(0), state, newTxn(state))
+	transition.logger = hclog.NewNullLogger()
+	transition.ctx = runtime.TxContext{BaseFee: big.NewInt(0), GasLimit: 10_000_000}
+	transition.gasPool = 10_000_000
+	transition.precompiles.EnableConsole(hclog.New(&hclog.LoggerOptions{Output: &output}))
+
+	// the transactions of the same sender conflict and are executed again
+	txs := make([]*types.Transaction, 6)
+
+	for i := range txs {
+		input, err := abi.MustNewType("tuple(string)").Encode([]interface{}{fmt.Sprintf("message %d", i)})
+		require.NoError(t, err)
+
+		txs[i] = &types.Transaction{
+			From:     senders[i%2],
+			To:       &contracts.ConsolePrecompile,
+			Nonce:    uint64(i / 2),
+			Input:    append(ethgo.Keccak256([]byte("log(string)"))[:4], input...),
+			Gas:      100_000,
+			GasPrice: big.NewInt(1),
+		}
+	}
+
+	require.NoError(t, transition.writeParallel(txs))
+	require.Len(t, transition.Receipts(), len(txs))
+
+	// the messages of the discarded speculative executions are not printed
+	for i := range txs {
+		require.Equal(t, 1, strings.Count(output.String(), fmt.Sprintf("message %d\n", i)), output.String())
+	}
+}
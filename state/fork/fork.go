@@ -0,0 +1,325 @@
+// Package fork implements the state of a dev node forked from a remote chain.
+// The accounts, storage slots and code which are missing from the local state
+// are read from the remote chain at the fork block and cached,
+// while the new blocks are built locally on top of them
+package fork
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var _ state.State = (*State)(nil)
+
+// LocalState is the state the blocks of the forked node are committed to
+type LocalState interface {
+	state.State
+
+	// SetCode stores the code by its hash
+	SetCode(hash types.Hash, code []byte) error
+}
+
+// State is the state of a node forked from a remote chain.
+//
+// The local state takes precedence over the remote one. The accounts deleted and
+// the storage slots cleared by the local blocks are tracked in memory by the local
+// state root, so that their remote values are not read again. As the tracking is not
+// persisted, a forked node is meant to run on a fresh data directory
+type State struct {
+	local    LocalState
+	upstream *Upstream
+	logger   hclog.Logger
+
+	lock sync.RWMutex
+	// accounts caches the remote accounts, nil if the account doesn't exist
+	accounts map[types.Address]*remoteAccount
+	// storage caches the remote storage slots
+	storage map[types.Address]map[types.Hash]types.Hash
+
+	// changes are the local changes hiding the remote state, by the local state root
+	changes map[types.Hash]*changes
+}
+
+// changes are the accounts deleted and the storage slots cleared by a local block
+type changes struct {
+	// parent are the changes of the earlier blocks, nil if there are none
+	parent *changes
+
+	destroyed map[types.Address]struct{}
+	cleared   map[types.Address]map[types.Hash]struct{}
+}
+
+// isDestroyed checks if the account was deleted by the local blocks
+func (c *changes) isDestroyed(addr types.Address) bool {
+	for ; c != nil; c = c.parent {
+		if _, ok := c.destroyed[addr]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isCleared checks if the storage slot was cleared, or its account deleted, by the local blocks
+func (c *changes) isCleared(addr types.Address, key types.Hash) bool {
+	for ; c != nil; c = c.parent {
+		if _, ok := c.destroyed[addr]; ok {
+			return true
+		}
+
+		if _, ok := c.cleared[addr][key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewState creates the state forked from the chain of the upstream
+func NewState(local LocalState, upstream *Upstream, logger hclog.Logger) *State {
+	return &State{
+		local:    local,
+		upstream: upstream,
+		logger:   logger,
+		accounts: map[types.Address]*remoteAccount{},
+		storage:  map[types.Address]map[types.Hash]types.Hash{},
+		changes:  map[types.Hash]*changes{},
+	}
+}
+
+// NewSnapshot implements the state.State interface
+func (s *State) NewSnapshot() state.Snapshot {
+	return &snapshot{Snapshot: s.local.NewSnapshot(), state: s}
+}
+
+// NewSnapshotAt implements the state.State interface
+func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
+	snap, err := s.local.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	changes := s.changes[root]
+	s.lock.RUnlock()
+
+	return &snapshot{Snapshot: snap, state: s, changes: changes}, nil
+}
+
+// GetCode implements the state.State interface. The code of the remote accounts
+// is stored locally once the account is read
+func (s *State) GetCode(hash types.Hash) ([]byte, bool) {
+	return s.local.GetCode(hash)
+}
+
+// remoteAccount returns the account of the forked chain, nil if it doesn't exist
+func (s *State) remoteAccount(addr types.Address) (*remoteAccount, error) {
+	s.lock.RLock()
+	account, ok := s.accounts[addr]
+	s.lock.RUnlock()
+
+	if ok {
+		return account, nil
+	}
+
+	account, err := s.upstream.account(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.empty() {
+		account = nil
+	} else if len(account.code) > 0 {
+		if err := s.local.SetCode(account.codeHash, account.code); err != nil {
+			return nil, err
+		}
+	}
+
+	s.lock.Lock()
+	s.accounts[addr] = account
+	s.lock.Unlock()
+
+	return account, nil
+}
+
+// remoteStorage returns the storage slot of the forked chain
+func (s *State) remoteStorage(addr types.Address, key types.Hash) (types.Hash, error) {
+	s.lock.RLock()
+	value, cached := s.storage[addr][key]
+	s.lock.RUnlock()
+
+	if cached {
+		return value, nil
+	}
+
+	// only the contracts have storage
+	account, err := s.remoteAccount(addr)
+	if err != nil || account == nil || len(account.code) == 0 {
+		return types.ZeroHash, err
+	}
+
+	if value, err = s.upstream.storage(addr, key); err != nil {
+		return types.ZeroHash, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.storage[addr] == nil {
+		s.storage[addr] = map[types.Hash]types.Hash{}
+	}
+
+	s.storage[addr][key] = value
+
+	return value, nil
+}
+
+// track records the accounts deleted and the storage slots cleared by the objects committed
+// on top of the parent changes, as the changes of the committed root
+func (s *State) track(parent *changes, root types.Hash, objs []*state.Object) *changes {
+	c := &changes{
+		parent:    parent,
+		destroyed: map[types.Address]struct{}{},
+		cleared:   map[types.Address]map[types.Hash]struct{}{},
+	}
+
+	for _, obj := range objs {
+		if obj.Deleted {
+			c.destroyed[obj.Address] = struct{}{}
+
+			continue
+		}
+
+		for _, entry := range obj.Storage {
+			if !entry.Deleted {
+				continue
+			}
+
+			if c.cleared[obj.Address] == nil {
+				c.cleared[obj.Address] = map[types.Hash]struct{}{}
+			}
+
+			c.cleared[obj.Address][types.BytesToHash(entry.Key)] = struct{}{}
+		}
+	}
+
+	// the blocks which don't delete anything share the changes of their parent
+	if len(c.destroyed) == 0 && len(c.cleared) == 0 {
+		c = parent
+	}
+
+	if c != nil {
+		s.lock.Lock()
+		s.changes[root] = c
+		s.lock.Unlock()
+	}
+
+	return c
+}
+
+// snapshot reads the missing accounts and storage slots of the local snapshot from the forked chain
+type snapshot struct {
+	state.Snapshot
+
+	state *State
+	// changes are the local changes of the snapshot state
+	changes *changes
+
+	lock sync.Mutex
+	// err is the first error of the remote reads
+	err error
+}
+
+var _ state.FailingSnapshot = (*snapshot)(nil)
+
+// Err implements the state.FailingSnapshot interface
+func (s *snapshot) Err() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.err
+}
+
+// fail records the error of a remote read
+func (s *snapshot) fail(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err == nil {
+		s.err = err
+		s.state.logger.Error("failed to read the state of the forked chain", "err", err)
+	}
+}
+
+// GetAccount implements the state.Snapshot interface
+func (s *snapshot) GetAccount(addr types.Address) (*state.Account, error) {
+	account, err := s.Snapshot.GetAccount(addr)
+	if err != nil || account != nil {
+		return account, err
+	}
+
+	if s.changes.isDestroyed(addr) {
+		return nil, nil
+	}
+
+	remote, err := s.state.remoteAccount(addr)
+	if err != nil {
+		s.fail(err)
+
+		return nil, err
+	}
+
+	if remote == nil {
+		return nil, nil
+	}
+
+	return &state.Account{
+		Nonce:    remote.nonce,
+		Balance:  new(big.Int).Set(remote.balance),
+		Root:     types.EmptyRootHash,
+		CodeHash: remote.codeHash.Bytes(),
+	}, nil
+}
+
+// GetStorage implements the state.Snapshot interface. A slot which is not set locally
+// is read from the forked chain. As the interface can't return the read errors,
+// they are kept and returned by Err
+func (s *snapshot) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	if value := s.Snapshot.GetStorage(addr, root, key); value != types.ZeroHash {
+		return value
+	}
+
+	if s.changes.isCleared(addr, key) {
+		return types.ZeroHash
+	}
+
+	value, err := s.state.remoteStorage(addr, key)
+	if err != nil {
+		s.fail(err)
+	}
+
+	return value
+}
+
+// Commit implements the state.Snapshot interface. The objects are not committed
+// if any remote read failed, as they may be based on a missing state
+func (s *snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error) {
+	if err := s.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", state.ErrStateUnavailable, err)
+	}
+
+	snap, root, err := s.Snapshot.Commit(objs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := s.state.track(s.changes, types.BytesToHash(root), objs)
+
+	return &snapshot{Snapshot: snap, state: s.state, changes: changes}, root, nil
+}
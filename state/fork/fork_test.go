@@ -0,0 +1,390 @@
+package fork
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+
+	slot1 = types.StringToHash("1")
+	slot2 = types.StringToHash("2")
+
+	remoteCode = []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+)
+
+// fakeUpstream is a JSON-RPC server serving the state of the forked chain
+type fakeUpstream struct {
+	*httptest.Server
+
+	lock  sync.Mutex
+	calls map[string]int
+	// blocks are the block numbers the state was requested at
+	blocks map[string]struct{}
+}
+
+func newFakeUpstream(t *testing.T) *fakeUpstream {
+	t.Helper()
+
+	f := &fakeUpstream{
+		calls:  map[string]int{},
+		blocks: map[string]struct{}{},
+	}
+
+	// addr1 is a contract, addr2 is an externally owned account, addr3 doesn't exist
+	balances := map[string]string{
+		addr1.String(): "0x64",
+		addr2.String(): "0x3e8",
+	}
+	nonces := map[string]string{
+		addr1.String(): "0x1",
+		addr2.String(): "0x5",
+	}
+	codes := map[string]string{
+		addr1.String(): hex.EncodeToHex(remoteCode),
+	}
+	storage := map[string]string{
+		slot1.String(): types.StringToHash("a").String(),
+		slot2.String(): types.StringToHash("b").String(),
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     interface{}   `json:"id"`
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		f.lock.Lock()
+		f.calls[req.Method]++
+
+		if len(req.Params) > 0 {
+			f.blocks[req.Params[len(req.Params)-1].(string)] = struct{}{} //nolint:forcetypeassert
+		}
+		f.lock.Unlock()
+
+		// the addresses are checksummed by the server and not by the client
+		lookup := func(values map[string]string, fallback string) string {
+			addr := types.StringToAddress(req.Params[0].(string)).String() //nolint:forcetypeassert
+			if value, ok := values[addr]; ok {
+				return value
+			}
+
+			return fallback
+		}
+
+		var result interface{}
+
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0xa"
+		case "eth_getBalance":
+			result = lookup(balances, "0x0")
+		case "eth_getTransactionCount":
+			result = lookup(nonces, "0x0")
+		case "eth_getCode":
+			result = lookup(codes, "0x")
+		case "eth_getStorageAt":
+			result = types.ZeroHash.String()
+			if types.StringToAddress(req.Params[0].(string)) == addr1 { //nolint:forcetypeassert
+				if value, ok := storage[types.StringToHash(req.Params[1].(string)).String()]; ok { //nolint:forcetypeassert
+					result = value
+				}
+			}
+		default:
+			t.Errorf("unexpected method %s", req.Method)
+		}
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		}))
+	}))
+
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeUpstream) callCount(method string) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.calls[method]
+}
+
+func newForkedState(t *testing.T, block uint64) (*State, *fakeUpstream) {
+	t.Helper()
+
+	server := newFakeUpstream(t)
+
+	upstream, err := NewUpstream(server.URL, block)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = upstream.Close()
+	})
+
+	return NewState(itrie.NewState(itrie.NewMemoryStorage()), upstream, hclog.NewNullLogger()), server
+}
+
+func TestUpstream_Block(t *testing.T) {
+	t.Parallel()
+
+	s, server := newForkedState(t, 0)
+	require.Equal(t, uint64(10), s.upstream.Block())
+	require.Equal(t, 1, server.callCount("eth_blockNumber"))
+
+	s, server = newForkedState(t, 5)
+	require.Equal(t, uint64(5), s.upstream.Block())
+	require.Equal(t, 0, server.callCount("eth_blockNumber"))
+
+	// the state is read at the fork block
+	_, err := s.NewSnapshot().GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"0x5": {}}, server.blocks)
+}
+
+func TestState_RemoteReads(t *testing.T) {
+	t.Parallel()
+
+	s, server := newForkedState(t, 0)
+	snap := s.NewSnapshot()
+
+	// contract
+	account, err := snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), account.Nonce)
+	require.Equal(t, big.NewInt(100), account.Balance)
+	require.Equal(t, crypto.Keccak256(remoteCode), account.CodeHash)
+
+	code, ok := s.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	require.Equal(t, remoteCode, code)
+
+	require.Equal(t, types.StringToHash("a"), snap.GetStorage(addr1, account.Root, slot1))
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, account.Root, types.StringToHash("3")))
+
+	// externally owned account without storage
+	account, err = snap.GetAccount(addr2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), account.Nonce)
+	require.Equal(t, big.NewInt(1000), account.Balance)
+	require.Equal(t, types.EmptyCodeHash.Bytes(), account.CodeHash)
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr2, account.Root, slot1))
+
+	// missing account
+	account, err = snap.GetAccount(addr3)
+	require.NoError(t, err)
+	require.Nil(t, account)
+
+	// the accounts and the storage slots are cached
+	for i := 0; i < 3; i++ {
+		_, err = snap.GetAccount(addr1)
+		require.NoError(t, err)
+
+		_, err = snap.GetAccount(addr3)
+		require.NoError(t, err)
+
+		snap.GetStorage(addr1, types.EmptyRootHash, slot1)
+	}
+
+	require.Equal(t, 3, server.callCount("eth_getBalance"))
+	require.Equal(t, 3, server.callCount("eth_getTransactionCount"))
+	require.Equal(t, 3, server.callCount("eth_getCode"))
+	require.Equal(t, 2, server.callCount("eth_getStorageAt"))
+}
+
+func TestState_LocalWrites(t *testing.T) {
+	t.Parallel()
+
+	s, server := newForkedState(t, 0)
+
+	txn := state.NewTxn(s.NewSnapshot())
+	txn.AddBalance(addr2, big.NewInt(1))
+	txn.SetState(addr1, slot1, types.StringToHash("c"))
+	txn.SetState(addr1, slot2, types.ZeroHash)
+
+	objs, err := txn.Commit(true)
+	require.NoError(t, err)
+
+	snap, _, err := s.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	// the balance is updated on top of the remote one
+	account, err := snap.GetAccount(addr2)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1001), account.Balance)
+	require.Equal(t, uint64(5), account.Nonce)
+
+	// the local slots take precedence, including the cleared ones
+	account, err = snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, types.StringToHash("c"), snap.GetStorage(addr1, account.Root, slot1))
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, account.Root, slot2))
+
+	// the code of the contract is kept
+	code, ok := s.GetCode(types.BytesToHash(account.CodeHash))
+	require.True(t, ok)
+	require.Equal(t, remoteCode, code)
+
+	// the slot set again after being cleared is read locally
+	txn = state.NewTxn(snap)
+	txn.SetState(addr1, slot2, types.StringToHash("d"))
+
+	objs, err = txn.Commit(true)
+	require.NoError(t, err)
+
+	snap, _, err = snap.Commit(objs)
+	require.NoError(t, err)
+
+	account, err = snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Equal(t, types.StringToHash("d"), snap.GetStorage(addr1, account.Root, slot2))
+
+	// the remote accounts are read once
+	require.Equal(t, 2, server.callCount("eth_getBalance"))
+}
+
+func TestState_DestroyedAccount(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newForkedState(t, 0)
+
+	txn := state.NewTxn(s.NewSnapshot())
+	require.Equal(t, types.StringToHash("a"), txn.GetState(addr1, slot1))
+	require.True(t, txn.Suicide(addr1))
+
+	objs, err := txn.Commit(true)
+	require.NoError(t, err)
+
+	snap, _, err := s.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	// the remote account and its storage are not read again
+	account, err := snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Nil(t, account)
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, types.EmptyRootHash, slot1))
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, types.EmptyRootHash, slot2))
+}
+
+func TestState_RevertedChanges(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newForkedState(t, 0)
+
+	// the first block clears a slot of the contract, the second one destroys it.
+	// Both of them change the local state, as the blocks do with the nonces of the senders
+	txn := state.NewTxn(s.NewSnapshot())
+	txn.AddBalance(addr2, big.NewInt(1))
+	txn.SetState(addr1, slot2, types.ZeroHash)
+
+	objs, err := txn.Commit(true)
+	require.NoError(t, err)
+
+	snap, root1, err := s.NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	txn = state.NewTxn(snap)
+	txn.AddBalance(addr2, big.NewInt(1))
+	require.True(t, txn.Suicide(addr1))
+
+	objs, err = txn.Commit(true)
+	require.NoError(t, err)
+
+	_, root2, err := snap.Commit(objs)
+	require.NoError(t, err)
+
+	snap, err = s.NewSnapshotAt(types.BytesToHash(root2))
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.Nil(t, account)
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, types.EmptyRootHash, slot1))
+	require.NotEqual(t, root1, root2)
+
+	// the state reverted to the first block reads the remote contract again
+	snap, err = s.NewSnapshotAt(types.BytesToHash(root1))
+	require.NoError(t, err)
+
+	account, err = snap.GetAccount(addr1)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+	require.Equal(t, types.StringToHash("a"), snap.GetStorage(addr1, account.Root, slot1))
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, account.Root, slot2))
+}
+
+func TestState_UpstreamFailure(t *testing.T) {
+	t.Parallel()
+
+	s, server := newForkedState(t, 0)
+
+	// the contract is read before the forked chain becomes unreachable
+	_, err := s.NewSnapshot().GetAccount(addr1)
+	require.NoError(t, err)
+
+	server.Close()
+
+	snap := s.NewSnapshot()
+
+	failing, ok := snap.(state.FailingSnapshot)
+	require.True(t, ok)
+
+	// the failed storage read is kept by the snapshot
+	require.Equal(t, types.ZeroHash, snap.GetStorage(addr1, types.EmptyRootHash, slot1))
+	require.Error(t, failing.Err())
+
+	// and the state can't be committed
+	_, _, err = snap.Commit(nil)
+	require.ErrorIs(t, err, state.ErrStateUnavailable)
+
+	// the failed account read is returned
+	_, err = s.NewSnapshot().GetAccount(addr2)
+	require.Error(t, err)
+
+	// the execution reading the missing state fails, whatever its outcome
+	forks := chain.AllForksEnabled.Copy().RemoveFork(chain.London)
+	executor := state.NewExecutor(&chain.Params{Forks: forks}, s, hclog.NewNullLogger())
+	executor.GetHash = func(*types.Header) func(i uint64) types.Hash {
+		return func(i uint64) types.Hash {
+			return types.ZeroHash
+		}
+	}
+
+	transition, err := executor.BeginTxn(types.EmptyRootHash, &types.Header{GasLimit: 1_000_000}, types.ZeroAddress)
+	require.NoError(t, err)
+
+	_, err = transition.Apply(&types.Transaction{
+		From:     addr3,
+		To:       &addr2,
+		Value:    big.NewInt(0),
+		Gas:      21000,
+		GasPrice: big.NewInt(0),
+	})
+	require.ErrorIs(t, err, state.ErrStateUnavailable)
+
+	_, _, err = transition.Commit()
+	require.ErrorIs(t, err, state.ErrStateUnavailable)
+}
@@ -0,0 +1,111 @@
+package fork
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/jsonrpc"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// remoteAccount is an account of the forked chain
+type remoteAccount struct {
+	nonce    uint64
+	balance  *big.Int
+	code     []byte
+	codeHash types.Hash
+}
+
+// empty checks if the account doesn't exist on the forked chain
+func (a *remoteAccount) empty() bool {
+	return a.nonce == 0 && a.balance.Sign() == 0 && len(a.code) == 0
+}
+
+// Upstream reads the state of the forked chain at the fork block through its JSON-RPC endpoint
+type Upstream struct {
+	client *jsonrpc.Client
+	block  uint64
+}
+
+// NewUpstream connects to the JSON-RPC endpoint of the forked chain.
+// The latest block of the chain is used if the block number is zero
+func NewUpstream(url string, block uint64) (*Upstream, error) {
+	client, err := jsonrpc.NewClient(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the forked chain: %w", err)
+	}
+
+	if block == 0 {
+		if block, err = client.Eth().BlockNumber(); err != nil {
+			return nil, fmt.Errorf("failed to get the latest block of the forked chain: %w", err)
+		}
+	}
+
+	return &Upstream{
+		client: client,
+		block:  block,
+	}, nil
+}
+
+// Block returns the number of the fork block
+func (u *Upstream) Block() uint64 {
+	return u.block
+}
+
+// Close closes the connection to the forked chain
+func (u *Upstream) Close() error {
+	return u.client.Close()
+}
+
+// account reads the account at the fork block
+func (u *Upstream) account(addr types.Address) (*remoteAccount, error) {
+	eth := u.client.Eth()
+	block := ethgo.BlockNumber(u.block)
+
+	balance, err := eth.GetBalance(ethgo.Address(addr), block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the balance of %s: %w", addr, err)
+	}
+
+	nonce, err := eth.GetNonce(ethgo.Address(addr), block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the nonce of %s: %w", addr, err)
+	}
+
+	rawCode, err := eth.GetCode(ethgo.Address(addr), block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the code of %s: %w", addr, err)
+	}
+
+	code, err := hex.DecodeHex(rawCode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code of %s: %w", addr, err)
+	}
+
+	account := &remoteAccount{
+		nonce:    nonce,
+		balance:  balance,
+		code:     code,
+		codeHash: types.EmptyCodeHash,
+	}
+
+	if len(code) > 0 {
+		account.codeHash = types.BytesToHash(crypto.Keccak256(code))
+	}
+
+	return account, nil
+}
+
+// storage reads the storage slot of the account at the fork block
+func (u *Upstream) storage(addr types.Address, key types.Hash) (types.Hash, error) {
+	value, err := u.client.Eth().GetStorageAt(ethgo.Address(addr), ethgo.Hash(key), ethgo.BlockNumber(u.block))
+	if err != nil {
+		return types.ZeroHash, fmt.Errorf("failed to get the storage slot %s of %s: %w", key, addr, err)
+	}
+
+	return types.Hash(value), nil
+}
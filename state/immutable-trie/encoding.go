@@ -78,3 +78,18 @@ func decodeCompact(compact []byte) []byte {
 
 	return base
 }
+
+// hexNibblesToBytes packs nibbles (with an optional terminator flag)
+// back into bytes. It is the inverse of bytesToHexNibbles
+func hexNibblesToBytes(nibbles []byte) []byte {
+	if hasTerminator(nibbles) {
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+
+	result := make([]byte, len(nibbles)/2)
+	for i := range result {
+		result[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+
+	return result
+}
@@ -0,0 +1,195 @@
+package itrie
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// ExportState calls fn for every trie node of the state with the given root, including the nodes
+// of the storage tries, and for every contract code of the accounts. The code flag tells the contract
+// codes apart. A storage trie or a contract code shared by several accounts is passed only once
+func ExportState(storage Storage, root types.Hash, fn func(data []byte, code bool) error) error {
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return nil
+	}
+
+	e := &stateExporter{
+		storage:      storage,
+		fn:           fn,
+		storageRoots: map[types.Hash]struct{}{},
+		codes:        map[types.Hash]struct{}{},
+	}
+
+	return e.exportHash(root.Bytes(), false)
+}
+
+// WalkLeaves calls fn for every leaf of the trie with the given root, the accounts of the state or the slots
+// of a storage trie, passing its key, which is the hash of the address or of the slot, and its RLP encoded value
+func WalkLeaves(storage Storage, root types.Hash, fn func(key types.Hash, value []byte) error) error {
+	if root == types.EmptyRootHash || root == types.ZeroHash {
+		return nil
+	}
+
+	return walkLeaves(storage, root.Bytes(), func(key, value []byte) error {
+		return fn(types.BytesToHash(key), value)
+	})
+}
+
+// stateExporter walks the trie nodes of the state
+type stateExporter struct {
+	storage Storage
+	fn      func(data []byte, code bool) error
+
+	// storageRoots and codes hold the storage tries and the codes already exported
+	storageRoots map[types.Hash]struct{}
+	codes        map[types.Hash]struct{}
+}
+
+func (e *stateExporter) exportHash(hash []byte, isStorage bool) error {
+	node, data, err := getCustomNode(hash, e.storage)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		return fmt.Errorf("trie node %s not found", hex.EncodeToHex(hash))
+	}
+
+	if err := e.fn(data, false); err != nil {
+		return err
+	}
+
+	return e.exportNode(node, isStorage)
+}
+
+func (e *stateExporter) exportNode(node Node, isStorage bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *FullNode:
+		for _, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := e.exportNode(child, isStorage); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return e.exportNode(n.value, isStorage)
+		}
+
+	case *ShortNode:
+		return e.exportNode(n.child, isStorage)
+
+	case *ValueNode:
+		if n.hash {
+			return e.exportHash(n.buf, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		return e.exportAccount(n.buf)
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+
+	return nil
+}
+
+// exportAccount exports the storage trie and the code of the account
+func (e *stateExporter) exportAccount(data []byte) error {
+	var account state.Account
+	if err := account.UnmarshalRlp(data); err != nil {
+		return fmt.Errorf("can't parse account: %w", err)
+	}
+
+	if root := account.Root; root != types.EmptyRootHash && root != types.ZeroHash {
+		if _, ok := e.storageRoots[root]; !ok {
+			e.storageRoots[root] = struct{}{}
+
+			if err := e.exportHash(root.Bytes(), true); err != nil {
+				return err
+			}
+		}
+	}
+
+	codeHash := types.BytesToHash(account.CodeHash)
+	if codeHash == types.EmptyCodeHash || codeHash == types.ZeroHash {
+		return nil
+	}
+
+	if _, ok := e.codes[codeHash]; ok {
+		return nil
+	}
+
+	code, ok := e.storage.GetCode(codeHash)
+	if !ok {
+		return fmt.Errorf("code %s not found", codeHash)
+	}
+
+	e.codes[codeHash] = struct{}{}
+
+	return e.fn(code, true)
+}
+
+// StateImport writes the trie nodes and the contract codes of an exported state to the storage.
+// The items are keyed by their hash, so the imported state is complete only if the state root
+// is verified once all the items are written
+type StateImport struct {
+	storage   Storage
+	batch     Batch
+	batchSize int
+}
+
+// NewStateImport creates the import of a state into the storage
+func NewStateImport(storage Storage) *StateImport {
+	return &StateImport{
+		storage: storage,
+		batch:   storage.Batch(),
+	}
+}
+
+// Put adds the trie node or the contract code to the import
+func (i *StateImport) Put(data []byte, code bool) error {
+	hash := hashit(data)
+
+	if code {
+		i.batch.Put(GetCodeKey(types.BytesToHash(hash)), data)
+	} else {
+		i.batch.Put(hash, data)
+	}
+
+	i.batchSize++
+
+	if i.batchSize >= stateSyncBatchSize {
+		return i.Commit()
+	}
+
+	return nil
+}
+
+// Commit writes the pending batch of the imported items to the storage
+func (i *StateImport) Commit() error {
+	if i.batchSize == 0 {
+		return nil
+	}
+
+	if err := i.batch.Write(); err != nil {
+		return err
+	}
+
+	i.batch = i.storage.Batch()
+	i.batchSize = 0
+
+	return nil
+}
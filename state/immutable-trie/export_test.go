@@ -0,0 +1,103 @@
+package itrie
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestExportState(t *testing.T) {
+	t.Parallel()
+
+	source, root := newSyncSourceState(t)
+	storage := NewMemoryStorage()
+	imp := NewStateImport(storage)
+
+	codes := 0
+
+	require.NoError(t, ExportState(source.storage, root, func(data []byte, code bool) error {
+		if code {
+			codes++
+		}
+
+		return imp.Put(data, code)
+	}))
+	require.NoError(t, imp.Commit())
+
+	// the code shared by two accounts is exported once
+	require.Equal(t, 1, codes)
+
+	hash, err := HashChecker(root.Bytes(), storage)
+	require.NoError(t, err)
+	require.Equal(t, root, hash)
+
+	requireSameSyncedState(t, source, NewState(storage), root)
+
+	// nothing is missing from the imported state
+	require.Zero(t, NewStateSync(storage, root).Pending())
+}
+
+func TestExportState_MissingNode(t *testing.T) {
+	t.Parallel()
+
+	source, root := newSyncSourceState(t)
+	storage := NewMemoryStorage()
+
+	data, ok, err := source.storage.Get(root.Bytes())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, storage.Put(root.Bytes(), data))
+
+	// the children of the root are not in the storage
+	require.Error(t, ExportState(storage, root, func([]byte, bool) error {
+		return nil
+	}))
+
+	// the empty state has no node
+	require.NoError(t, ExportState(storage, types.EmptyRootHash, func([]byte, bool) error {
+		return errors.New("no node is expected")
+	}))
+}
+
+func TestWalkLeaves(t *testing.T) {
+	t.Parallel()
+
+	source, root := newSyncSourceState(t)
+
+	accounts := map[types.Hash]int{}
+
+	require.NoError(t, WalkLeaves(source.storage, root, func(key types.Hash, value []byte) error {
+		var account state.Account
+		if err := account.UnmarshalRlp(value); err != nil {
+			return err
+		}
+
+		slots := 0
+
+		if err := WalkLeaves(source.storage, account.Root, func(types.Hash, []byte) error {
+			slots++
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		accounts[key] = slots
+
+		return nil
+	}))
+
+	// the leaves are keyed by the hashes of the addresses
+	addrs := testAddresses(50)
+	require.Len(t, accounts, len(addrs))
+
+	for i, addr := range addrs {
+		slots, ok := accounts[types.BytesToHash(hashit(addr.Bytes()))]
+		require.True(t, ok)
+		require.Equal(t, (i%5)*10, slots)
+	}
+}
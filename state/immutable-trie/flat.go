@@ -0,0 +1,491 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// defaultFlatDiffLayers is the number of the in-memory diff layers
+// kept on top of the flat snapshot on disk
+const defaultFlatDiffLayers = 128
+
+var (
+	// flatAccountPrefix is the prefix of the accounts in the flat snapshot
+	flatAccountPrefix = []byte("fa")
+
+	// flatStoragePrefix is the prefix of the storage slots in the flat snapshot
+	flatStoragePrefix = []byte("fs")
+
+	// flatRootKey is the key of the state root the flat snapshot on disk belongs to
+	flatRootKey = []byte("flatroot")
+)
+
+var (
+	// ErrFlatSnapshotMissing is returned when the flat snapshot on disk is missing
+	// and it has to be rebuilt
+	ErrFlatSnapshotMissing = errors.New("flat state snapshot is missing")
+
+	// ErrFlatGenerationAborted is returned when the generation of the flat snapshot
+	// is stopped by the shutdown, it is generated again on the next start
+	ErrFlatGenerationAborted = errors.New("flat state snapshot generation aborted")
+
+	errFlatSnapshotUnavailable = errors.New("flat state snapshot is not available for the state root")
+	errStorageNotFlat          = errors.New("state storage does not support the flat snapshot")
+	errFlatGenerationRunning   = errors.New("flat state snapshot generation is already running")
+)
+
+// flatUpdate holds the changes of the committed state root, keyed by the hashed trie keys
+type flatUpdate struct {
+	// accounts holds the encoded accounts, nil if the account is deleted
+	accounts map[types.Hash][]byte
+	// storage holds the encoded storage values, nil if the slot is deleted
+	storage map[types.Hash]map[types.Hash][]byte
+	// destructs holds the accounts whose storage is wiped before the storage changes are applied
+	destructs map[types.Hash]struct{}
+}
+
+func newFlatUpdate() *flatUpdate {
+	return &flatUpdate{
+		accounts:  map[types.Hash][]byte{},
+		storage:   map[types.Hash]map[types.Hash][]byte{},
+		destructs: map[types.Hash]struct{}{},
+	}
+}
+
+// flatDiffLayer is an in-memory layer holding the changes of a single state root
+type flatDiffLayer struct {
+	*flatUpdate
+
+	root types.Hash
+	// parent is nil if the layer is on top of the disk layer
+	parent *flatDiffLayer
+}
+
+// flatSnapshot is the flat key-value view of the recent states, made of the
+// latest state flattened on disk and the in-memory diff layers on top of it.
+// The state roots which are not covered by it are read from the trie
+type flatSnapshot struct {
+	lock sync.RWMutex
+
+	storage       FlatStorage
+	diskRoot      types.Hash
+	layers        map[types.Hash]*flatDiffLayer
+	maxDiffLayers int
+
+	// generation is the running generation of the disk layer from the trie, nil if there is none.
+	// While it runs the reads fall back to the trie and the diff layers are not flattened
+	generation *flatGeneration
+}
+
+// flatGeneration is the generation of the disk layer of the flat snapshot in the background
+type flatGeneration struct {
+	abort chan struct{}
+	done  chan struct{}
+	// failed is set if the generation didn't complete, the flat snapshot
+	// stays unavailable until it is generated again
+	failed bool
+}
+
+func flatAccountKey(account types.Hash) []byte {
+	return append(append([]byte{}, flatAccountPrefix...), account.Bytes()...)
+}
+
+func flatStorageKey(account types.Hash, slot types.Hash) []byte {
+	return append(flatAccountStoragePrefix(account), slot.Bytes()...)
+}
+
+func flatAccountStoragePrefix(account types.Hash) []byte {
+	return append(append([]byte{}, flatStoragePrefix...), account.Bytes()...)
+}
+
+// loadFlatSnapshot loads the flat snapshot from the storage. A fresh storage
+// gets the flat snapshot of the empty state
+func loadFlatSnapshot(storage FlatStorage) (*flatSnapshot, error) {
+	f := &flatSnapshot{
+		storage:       storage,
+		layers:        map[types.Hash]*flatDiffLayer{},
+		maxDiffLayers: defaultFlatDiffLayers,
+	}
+
+	root, ok, err := storage.Get(flatRootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		f.diskRoot = types.BytesToHash(root)
+
+		return f, nil
+	}
+
+	empty := true
+
+	if err := storage.IteratePrefix(nil, func(_, _ []byte) bool {
+		empty = false
+
+		return false
+	}); err != nil {
+		return nil, err
+	}
+
+	if !empty {
+		return nil, ErrFlatSnapshotMissing
+	}
+
+	if err := storage.Put(flatRootKey, types.EmptyRootHash.Bytes()); err != nil {
+		return nil, err
+	}
+
+	f.diskRoot = types.EmptyRootHash
+
+	return f, nil
+}
+
+// has checks if the flat snapshot covers the given state root
+func (f *flatSnapshot) has(root types.Hash) bool {
+	if f == nil {
+		return false
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	if f.generation != nil {
+		return false
+	}
+
+	_, ok := f.layers[root]
+
+	return ok || root == f.diskRoot
+}
+
+// account returns the encoded account at the given state root
+func (f *flatSnapshot) account(root types.Hash, account types.Hash) ([]byte, bool, error) {
+	if f == nil {
+		return nil, false, errFlatSnapshotUnavailable
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	layer, err := f.layer(root)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for l := layer; l != nil; l = l.parent {
+		if data, ok := l.accounts[account]; ok {
+			return data, data != nil, nil
+		}
+	}
+
+	return f.storage.Get(flatAccountKey(account))
+}
+
+// slot returns the encoded storage value of the account at the given state root
+func (f *flatSnapshot) slot(root types.Hash, account types.Hash, slot types.Hash) ([]byte, bool, error) {
+	if f == nil {
+		return nil, false, errFlatSnapshotUnavailable
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	layer, err := f.layer(root)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for l := layer; l != nil; l = l.parent {
+		if data, ok := l.storage[account][slot]; ok {
+			return data, data != nil, nil
+		}
+
+		if _, ok := l.destructs[account]; ok {
+			return nil, false, nil
+		}
+	}
+
+	return f.storage.Get(flatStorageKey(account, slot))
+}
+
+// layer returns the diff layer of the state root, nil for the disk layer
+func (f *flatSnapshot) layer(root types.Hash) (*flatDiffLayer, error) {
+	if f.generation != nil {
+		return nil, errFlatSnapshotUnavailable
+	}
+
+	if root == f.diskRoot {
+		return nil, nil
+	}
+
+	layer, ok := f.layers[root]
+	if !ok {
+		return nil, errFlatSnapshotUnavailable
+	}
+
+	return layer, nil
+}
+
+// update adds the diff layer of the state root committed on top of the parent state root.
+// The changes are not tracked if the parent state root is not covered by the flat snapshot
+func (f *flatSnapshot) update(parent types.Hash, root types.Hash, update *flatUpdate) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.layers[root]; ok || root == f.diskRoot {
+		return nil
+	}
+
+	// no changes are tracked on top of the incomplete disk layer
+	if f.generation != nil && f.generation.failed {
+		return nil
+	}
+
+	var parentLayer *flatDiffLayer
+
+	if parent != f.diskRoot {
+		// the changes on top of the states which are not covered are not tracked
+		if parentLayer = f.layers[parent]; parentLayer == nil {
+			return nil
+		}
+	}
+
+	layer := &flatDiffLayer{flatUpdate: update, root: root, parent: parentLayer}
+	f.layers[root] = layer
+
+	// the disk layer can't be written while it is generated
+	if f.generation != nil {
+		return nil
+	}
+
+	return f.capLayers(layer)
+}
+
+// capLayers flattens the bottom diff layers below the given one, until there are
+// at most maxDiffLayers of them
+func (f *flatSnapshot) capLayers(layer *flatDiffLayer) error {
+	for {
+		depth, bottom := 1, layer
+		for ; bottom.parent != nil; bottom = bottom.parent {
+			depth++
+		}
+
+		if depth <= f.maxDiffLayers {
+			return nil
+		}
+
+		if err := f.flatten(bottom); err != nil {
+			return err
+		}
+	}
+}
+
+// generate starts regenerating the disk layer from the trie at the given state root in the background.
+// The existing diff layers are dropped and the states committed on top of the root are tracked
+// by new ones, which are flattened once the generation completes. The result is sent to the returned channel
+func (f *flatSnapshot) generate(root types.Hash) (<-chan error, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.generation != nil && !f.generation.failed {
+		return nil, errFlatGenerationRunning
+	}
+
+	gen := &flatGeneration{abort: make(chan struct{}), done: make(chan struct{})}
+
+	f.generation = gen
+	f.diskRoot = root
+	f.layers = map[types.Hash]*flatDiffLayer{}
+
+	result := make(chan error, 1)
+
+	go func() {
+		defer close(gen.done)
+
+		err := rebuildFlatSnapshot(f.storage, root, gen.abort)
+		if err == nil {
+			err = f.completeGeneration()
+		} else {
+			f.failGeneration()
+		}
+
+		result <- err
+	}()
+
+	return result, nil
+}
+
+// completeGeneration enables the reads from the generated disk layer
+// and flattens the diff layers which were committed in the meantime
+func (f *flatSnapshot) completeGeneration() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.generation = nil
+
+	for {
+		var deepest *flatDiffLayer
+
+		// flattening drops some of the layers, so the depth is checked again for the remaining ones
+		for _, layer := range f.layers {
+			depth := 1
+			for l := layer; l.parent != nil; l = l.parent {
+				depth++
+			}
+
+			if depth > f.maxDiffLayers {
+				deepest = layer
+
+				break
+			}
+		}
+
+		if deepest == nil {
+			return nil
+		}
+
+		if err := f.capLayers(deepest); err != nil {
+			return err
+		}
+	}
+}
+
+// failGeneration drops the diff layers tracked on top of the incomplete disk layer
+func (f *flatSnapshot) failGeneration() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.generation.failed = true
+	f.layers = map[types.Hash]*flatDiffLayer{}
+}
+
+// generatingRoot returns the state root whose disk layer is being generated
+func (f *flatSnapshot) generatingRoot() (types.Hash, bool) {
+	if f == nil {
+		return types.ZeroHash, false
+	}
+
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	return f.diskRoot, f.generation != nil && !f.generation.failed
+}
+
+// abortGeneration stops the running generation and waits for it to exit.
+// The partially generated disk layer is regenerated on the next start
+func (f *flatSnapshot) abortGeneration() {
+	f.lock.RLock()
+	gen := f.generation
+	f.lock.RUnlock()
+
+	if gen == nil {
+		return
+	}
+
+	select {
+	case <-gen.done:
+	default:
+		close(gen.abort)
+		<-gen.done
+	}
+}
+
+// persist flattens all the diff layers up to the given state root to disk
+func (f *flatSnapshot) persist(root types.Hash) error {
+	// the incomplete disk layer can't be persisted
+	f.abortGeneration()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.generation != nil {
+		return nil
+	}
+
+	for root != f.diskRoot {
+		bottom, ok := f.layers[root]
+		if !ok {
+			return errFlatSnapshotUnavailable
+		}
+
+		for bottom.parent != nil {
+			bottom = bottom.parent
+		}
+
+		if err := f.flatten(bottom); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// flatten writes the bottom diff layer to disk, together with its state root,
+// and drops the diff layers which are not built on top of it
+func (f *flatSnapshot) flatten(bottom *flatDiffLayer) error {
+	batch := f.storage.Batch()
+
+	for account := range bottom.destructs {
+		if err := f.storage.IteratePrefix(flatAccountStoragePrefix(account), func(k, _ []byte) bool {
+			batch.Delete(append([]byte{}, k...))
+
+			return true
+		}); err != nil {
+			return err
+		}
+	}
+
+	for account, data := range bottom.accounts {
+		if data == nil {
+			batch.Delete(flatAccountKey(account))
+		} else {
+			batch.Put(flatAccountKey(account), data)
+		}
+	}
+
+	for account, slots := range bottom.storage {
+		for slot, data := range slots {
+			if data == nil {
+				batch.Delete(flatStorageKey(account, slot))
+			} else {
+				batch.Put(flatStorageKey(account, slot), data)
+			}
+		}
+	}
+
+	batch.Put(flatRootKey, bottom.root.Bytes())
+
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to write flat snapshot: %w", err)
+	}
+
+	for root, layer := range f.layers {
+		if layer == bottom {
+			continue
+		}
+
+		base := layer
+		for base.parent != nil && base.parent != bottom {
+			base = base.parent
+		}
+
+		if base.parent != bottom {
+			delete(f.layers, root)
+		}
+	}
+
+	for _, layer := range f.layers {
+		if layer.parent == bottom {
+			layer.parent = nil
+		}
+	}
+
+	delete(f.layers, bottom.root)
+	f.diskRoot = bottom.root
+
+	return nil
+}
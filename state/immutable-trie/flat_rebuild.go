@@ -0,0 +1,176 @@
+package itrie
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/helper/hex"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// flatRebuildBatchSize is the number of the flat snapshot entries written at once
+const flatRebuildBatchSize = 10000
+
+// RebuildFlatSnapshot regenerates the flat snapshot on disk from the trie at the given state root.
+// The previous flat snapshot is removed first, so an interrupted rebuild leaves no flat snapshot behind
+func RebuildFlatSnapshot(storage Storage, root types.Hash) error {
+	flatStorage, ok := storage.(FlatStorage)
+	if !ok {
+		return errStorageNotFlat
+	}
+
+	return rebuildFlatSnapshot(flatStorage, root, nil)
+}
+
+// rebuildFlatSnapshot regenerates the flat snapshot on disk, until the abort channel is closed
+func rebuildFlatSnapshot(flatStorage FlatStorage, root types.Hash, abort <-chan struct{}) error {
+	w := &flatWriter{storage: flatStorage, batch: flatStorage.Batch(), abort: abort}
+
+	w.batch.Delete(flatRootKey)
+
+	for _, prefix := range [][]byte{flatAccountPrefix, flatStoragePrefix} {
+		if err := flatStorage.IteratePrefix(prefix, func(k, _ []byte) bool {
+			w.batch.Delete(append([]byte{}, k...))
+			w.size++
+
+			if w.size >= flatRebuildBatchSize {
+				w.err = w.flush()
+			}
+
+			return w.err == nil
+		}); err != nil {
+			return err
+		}
+
+		if w.err != nil {
+			return w.err
+		}
+	}
+
+	if err := w.flush(); err != nil {
+		return err
+	}
+
+	if root != types.EmptyRootHash {
+		if err := walkLeaves(flatStorage, root.Bytes(), func(account, data []byte) error {
+			return w.writeAccount(types.BytesToHash(account), data)
+		}); err != nil {
+			return err
+		}
+	}
+
+	w.batch.Put(flatRootKey, root.Bytes())
+
+	return w.batch.Write()
+}
+
+// flatWriter writes the flat snapshot entries in batches
+type flatWriter struct {
+	storage FlatStorage
+	batch   Batch
+	size    int
+	err     error
+	abort   <-chan struct{}
+}
+
+func (w *flatWriter) put(k, v []byte) error {
+	w.batch.Put(k, v)
+	w.size++
+
+	if w.size < flatRebuildBatchSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+func (w *flatWriter) flush() error {
+	select {
+	case <-w.abort:
+		return ErrFlatGenerationAborted
+	default:
+	}
+
+	if err := w.batch.Write(); err != nil {
+		return err
+	}
+
+	w.batch = w.storage.Batch()
+	w.size = 0
+
+	return nil
+}
+
+func (w *flatWriter) writeAccount(account types.Hash, data []byte) error {
+	if err := w.put(flatAccountKey(account), data); err != nil {
+		return err
+	}
+
+	var acc state.Account
+	if err := acc.UnmarshalRlp(data); err != nil {
+		return fmt.Errorf("can't parse account %s: %w", account, err)
+	}
+
+	if acc.Root == types.EmptyRootHash || acc.Root == types.ZeroHash {
+		return nil
+	}
+
+	return walkLeaves(w.storage, acc.Root.Bytes(), func(slot, value []byte) error {
+		return w.put(flatStorageKey(account, types.BytesToHash(slot)), value)
+	})
+}
+
+// walkLeaves calls fn for every leaf of the trie with the given root, passing its key and value
+func walkLeaves(storage Storage, root []byte, fn func(key, value []byte) error) error {
+	return walkHash(storage, root, nil, fn)
+}
+
+func walkHash(storage Storage, hash []byte, path []byte, fn func(key, value []byte) error) error {
+	node, ok, err := GetNode(hash, storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("trie node %s not found", hex.EncodeToHex(hash))
+	}
+
+	return walkNode(storage, node, path, fn)
+}
+
+func walkNode(storage Storage, node Node, path []byte, fn func(key, value []byte) error) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *FullNode:
+		for i, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := walkNode(storage, child, concat(path, []byte{byte(i)}), fn); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return walkNode(storage, n.value, path, fn)
+		}
+
+	case *ShortNode:
+		return walkNode(storage, n.child, concat(path, n.key), fn)
+
+	case *ValueNode:
+		if n.hash {
+			return walkHash(storage, n.buf, path, fn)
+		}
+
+		return fn(hexNibblesToBytes(path), n.buf)
+
+	default:
+		return fmt.Errorf("unknown node type %T", node)
+	}
+
+	return nil
+}
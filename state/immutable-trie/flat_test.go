@@ -0,0 +1,447 @@
+package itrie
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// testAccountChange describes the change of an account in a test block
+type testAccountChange struct {
+	addr    types.Address
+	balance uint64
+	deleted bool
+	// recreated resets the storage of the account
+	recreated bool
+	// slots holds the storage changes, a zero value deletes the slot
+	slots map[uint64]uint64
+}
+
+func slotKey(slot uint64) types.Hash {
+	return types.BytesToHash(new(big.Int).SetUint64(slot).Bytes())
+}
+
+// commitChanges applies the changes on top of the parent state root and returns the new state root
+func commitChanges(t testing.TB, st *State, parent types.Hash, changes ...testAccountChange) types.Hash {
+	t.Helper()
+
+	snap, err := st.NewSnapshotAt(parent)
+	require.NoError(t, err)
+
+	objs := make([]*state.Object, 0, len(changes))
+
+	for _, change := range changes {
+		obj := &state.Object{
+			Address:  change.addr,
+			Balance:  new(big.Int).SetUint64(change.balance),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+			Deleted:  change.deleted,
+		}
+
+		account, err := snap.GetAccount(change.addr)
+		require.NoError(t, err)
+
+		if account != nil && !change.recreated {
+			obj.Root = account.Root
+		}
+
+		for slot, value := range change.slots {
+			entry := &state.StorageObject{Key: slotKey(slot).Bytes()}
+			if value == 0 {
+				entry.Deleted = true
+			} else {
+				entry.Val = new(big.Int).SetUint64(value).Bytes()
+			}
+
+			obj.Storage = append(obj.Storage, entry)
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := snap.Commit(objs)
+	require.NoError(t, err)
+
+	return types.BytesToHash(root)
+}
+
+// requireSameState checks that the state read from the flat snapshot matches the trie
+func requireSameState(t *testing.T, st *State, root types.Hash, addrs []types.Address, slots uint64) {
+	t.Helper()
+
+	flatSnap, err := st.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	// the state without the flat snapshot reads the trie only
+	trieSnap, err := NewState(st.storage).NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range addrs {
+		expected, err := trieSnap.GetAccount(addr)
+		require.NoError(t, err)
+
+		actual, err := flatSnap.GetAccount(addr)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, "account %s", addr)
+
+		if expected == nil {
+			continue
+		}
+
+		for slot := uint64(0); slot <= slots; slot++ {
+			require.Equal(t,
+				trieSnap.GetStorage(addr, expected.Root, slotKey(slot)),
+				flatSnap.GetStorage(addr, actual.Root, slotKey(slot)),
+				"account %s slot %d", addr, slot,
+			)
+		}
+	}
+}
+
+func testAddresses(n int) []types.Address {
+	addrs := make([]types.Address, n)
+	for i := range addrs {
+		addrs[i] = types.StringToAddress(fmt.Sprintf("%d", i+1))
+	}
+
+	return addrs
+}
+
+func TestFlatSnapshot_Layers(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(4)
+
+	st := NewState(NewMemoryStorage())
+	require.NoError(t, st.EnableFlatSnapshot())
+
+	st.flat.maxDiffLayers = 3
+
+	blocks := [][]testAccountChange{
+		{
+			{addr: addrs[0], balance: 1, slots: map[uint64]uint64{1: 1, 2: 2, 3: 3}},
+			{addr: addrs[1], balance: 1, slots: map[uint64]uint64{1: 1, 2: 2}},
+			{addr: addrs[2], balance: 1, slots: map[uint64]uint64{1: 1}},
+			{addr: addrs[3], balance: 1},
+		},
+		{
+			{addr: addrs[0], balance: 2, slots: map[uint64]uint64{1: 10, 2: 0}},
+			{addr: addrs[3], balance: 2, slots: map[uint64]uint64{4: 4}},
+		},
+		{
+			{addr: addrs[1], deleted: true},
+		},
+		{
+			{addr: addrs[1], balance: 3, slots: map[uint64]uint64{5: 5}},
+			{addr: addrs[2], balance: 3, recreated: true, slots: map[uint64]uint64{2: 2}},
+		},
+		{
+			{addr: addrs[0], balance: 4, recreated: true},
+		},
+		{
+			{addr: addrs[3], balance: 5, slots: map[uint64]uint64{4: 0, 1: 1}},
+		},
+	}
+
+	roots := []types.Hash{types.EmptyRootHash}
+
+	for _, changes := range blocks {
+		root := commitChanges(t, st, roots[len(roots)-1], changes...)
+		roots = append(roots, root)
+
+		require.True(t, st.HasFlatSnapshot(root))
+
+		for _, root := range roots {
+			requireSameState(t, st, root, addrs, 5)
+		}
+	}
+
+	// only the most recent states are kept in the diff layers
+	require.Len(t, st.flat.layers, 3)
+	require.Equal(t, roots[len(roots)-4], st.flat.diskRoot)
+	require.False(t, st.HasFlatSnapshot(roots[1]))
+
+	// only the reads of the states not covered by the flat snapshot go to the trie
+	for i, root := range []types.Hash{roots[1], roots[len(roots)-1]} {
+		snap, err := st.NewSnapshotAt(root)
+		require.NoError(t, err)
+
+		snapshot, _ := snap.(*Snapshot)
+		require.Equal(t, i == 0, snapshot.ReadsTrie())
+	}
+
+	// the flat snapshot on disk survives the restart
+	head := roots[len(roots)-1]
+	require.NoError(t, st.PersistFlatSnapshot(head))
+
+	restarted := NewState(st.storage)
+	require.NoError(t, restarted.EnableFlatSnapshot())
+	require.True(t, restarted.HasFlatSnapshot(head))
+	require.Empty(t, restarted.flat.layers)
+
+	requireSameState(t, restarted, head, addrs, 5)
+}
+
+func TestFlatSnapshot_Forks(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(2)
+
+	st := NewState(NewMemoryStorage())
+	require.NoError(t, st.EnableFlatSnapshot())
+
+	st.flat.maxDiffLayers = 2
+
+	base := commitChanges(t, st, types.EmptyRootHash,
+		testAccountChange{addr: addrs[0], balance: 1, slots: map[uint64]uint64{1: 1}})
+
+	forkA := commitChanges(t, st, base, testAccountChange{addr: addrs[0], balance: 2, slots: map[uint64]uint64{1: 2}})
+	forkB := commitChanges(t, st, base, testAccountChange{addr: addrs[1], balance: 3})
+
+	require.True(t, st.HasFlatSnapshot(forkA))
+	require.True(t, st.HasFlatSnapshot(forkB))
+
+	// building on top of the fork A flattens the common base and then the fork A,
+	// so the fork B is no longer covered
+	head := forkA
+	for i := uint64(0); i < 2; i++ {
+		head = commitChanges(t, st, head, testAccountChange{addr: addrs[1], balance: 10 + i})
+	}
+
+	require.Equal(t, forkA, st.flat.diskRoot)
+	require.False(t, st.HasFlatSnapshot(forkB))
+
+	// the state which is not covered is read from the trie
+	requireSameState(t, st, forkB, addrs, 1)
+	requireSameState(t, st, head, addrs, 1)
+}
+
+func TestFlatSnapshot_Rebuild(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(3)
+
+	// the state is committed without the flat snapshot
+	st := NewState(NewMemoryStorage())
+
+	root := commitChanges(t, st, types.EmptyRootHash,
+		testAccountChange{addr: addrs[0], balance: 1, slots: map[uint64]uint64{1: 1, 2: 2}},
+		testAccountChange{addr: addrs[1], balance: 2},
+		testAccountChange{addr: addrs[2], balance: 3, slots: map[uint64]uint64{3: 3}},
+	)
+	root = commitChanges(t, st, root,
+		testAccountChange{addr: addrs[0], balance: 4, slots: map[uint64]uint64{1: 0}},
+	)
+
+	require.ErrorIs(t, NewState(st.storage).EnableFlatSnapshot(), ErrFlatSnapshotMissing)
+
+	require.NoError(t, RebuildFlatSnapshot(st.storage, root))
+
+	rebuilt := NewState(st.storage)
+	require.NoError(t, rebuilt.EnableFlatSnapshot())
+	require.True(t, rebuilt.HasFlatSnapshot(root))
+
+	requireSameState(t, rebuilt, root, addrs, 3)
+
+	// rebuilding again replaces the previous flat snapshot
+	root = commitChanges(t, rebuilt, root, testAccountChange{addr: addrs[2], deleted: true})
+
+	require.NoError(t, RebuildFlatSnapshot(st.storage, root))
+
+	rebuilt = NewState(st.storage)
+	require.NoError(t, rebuilt.EnableFlatSnapshot())
+
+	requireSameState(t, rebuilt, root, addrs, 3)
+}
+
+func TestFlatSnapshot_Generate(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(3)
+
+	st := NewState(NewMemoryStorage())
+	require.NoError(t, st.EnableFlatSnapshot())
+
+	roots := []types.Hash{types.EmptyRootHash}
+	for i := uint64(1); i <= 3; i++ {
+		roots = append(roots, commitChanges(t, st, roots[len(roots)-1],
+			testAccountChange{addr: addrs[i%3], balance: i, slots: map[uint64]uint64{i: i}}))
+	}
+
+	// the node crashed before the diff layers were persisted
+	head := roots[len(roots)-1]
+
+	restarted := NewState(st.storage)
+	require.NoError(t, restarted.EnableFlatSnapshot())
+	require.False(t, restarted.HasFlatSnapshot(head))
+
+	restarted.flat.maxDiffLayers = 2
+
+	result, err := restarted.GenerateFlatSnapshot(head)
+	require.NoError(t, err)
+
+	// the states committed during the generation are read from the trie, and tracked
+	for i := uint64(4); i <= 6; i++ {
+		head = commitChanges(t, restarted, head,
+			testAccountChange{addr: addrs[i%3], balance: i, slots: map[uint64]uint64{i: i, i - 3: 0}})
+		roots = append(roots, head)
+
+		requireSameState(t, restarted, head, addrs, 6)
+	}
+
+	require.NoError(t, <-result)
+	require.True(t, restarted.HasFlatSnapshot(head))
+	require.LessOrEqual(t, len(restarted.flat.layers), 2)
+
+	for _, root := range roots {
+		requireSameState(t, restarted, root, addrs, 6)
+	}
+
+	// the generated flat snapshot survives the restart
+	require.NoError(t, restarted.PersistFlatSnapshot(head))
+
+	restarted = NewState(st.storage)
+	require.NoError(t, restarted.EnableFlatSnapshot())
+	require.True(t, restarted.HasFlatSnapshot(head))
+
+	requireSameState(t, restarted, head, addrs, 6)
+}
+
+func TestFlatSnapshot_GenerateFailure(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(1)
+
+	st := NewState(NewMemoryStorage())
+	root := commitChanges(t, st, types.EmptyRootHash, testAccountChange{addr: addrs[0], balance: 1})
+
+	// the trie of the state root is missing
+	result, err := st.GenerateFlatSnapshot(types.StringToHash("1"))
+	require.NoError(t, err)
+	require.Error(t, <-result)
+
+	// the changes are not tracked on top of the incomplete flat snapshot
+	head := commitChanges(t, st, root, testAccountChange{addr: addrs[0], balance: 2})
+	require.False(t, st.HasFlatSnapshot(head))
+	require.Empty(t, st.flat.layers)
+	requireSameState(t, st, head, addrs, 0)
+
+	// the generation can be started again
+	result, err = st.GenerateFlatSnapshot(head)
+	require.NoError(t, err)
+	require.NoError(t, <-result)
+	require.True(t, st.HasFlatSnapshot(head))
+}
+
+func TestFlatSnapshot_GenerateAbort(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+	root := commitChanges(t, st, types.EmptyRootHash, testAccountChange{addr: testAddresses(1)[0], balance: 1})
+
+	storage, ok := st.storage.(FlatStorage)
+	require.True(t, ok)
+
+	abort := make(chan struct{})
+	close(abort)
+
+	require.ErrorIs(t, rebuildFlatSnapshot(storage, root, abort), ErrFlatGenerationAborted)
+
+	// the interrupted generation leaves no flat snapshot behind
+	require.ErrorIs(t, NewState(st.storage).EnableFlatSnapshot(), ErrFlatSnapshotMissing)
+}
+
+// newBenchmarkState creates the state of accounts with a single contract holding storage slots
+func newBenchmarkState(b *testing.B, flat bool, accounts int, slots uint64) (*State, types.Hash, []types.Address) {
+	b.Helper()
+
+	storage, err := NewLevelDBStorage(b.TempDir(), hclog.NewNullLogger())
+	require.NoError(b, err)
+
+	b.Cleanup(func() {
+		_ = storage.Close()
+	})
+
+	st := NewState(storage)
+
+	if flat {
+		require.NoError(b, st.EnableFlatSnapshot())
+	}
+
+	addrs := testAddresses(accounts)
+	changes := make([]testAccountChange, 0, accounts)
+
+	for i, addr := range addrs {
+		changes = append(changes, testAccountChange{addr: addr, balance: uint64(i + 1)})
+	}
+
+	changes[0].slots = make(map[uint64]uint64, slots)
+	for slot := uint64(1); slot <= slots; slot++ {
+		changes[0].slots[slot] = slot
+	}
+
+	root := commitChanges(b, st, types.EmptyRootHash, changes...)
+	require.NoError(b, st.PersistFlatSnapshot(root))
+
+	return st, root, addrs
+}
+
+func BenchmarkSnapshot_GetAccount(b *testing.B) {
+	for _, flat := range []bool{false, true} {
+		b.Run(fmt.Sprintf("flat=%t", flat), func(b *testing.B) {
+			st, root, addrs := newBenchmarkState(b, flat, 10000, 0)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// reads hit the storage, not the trie nodes cached by the previous reads
+				st.cache.Purge()
+
+				snap, err := st.NewSnapshotAt(root)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if _, err := snap.GetAccount(addrs[i%len(addrs)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSnapshot_GetStorage(b *testing.B) {
+	const slots = 10000
+
+	for _, flat := range []bool{false, true} {
+		b.Run(fmt.Sprintf("flat=%t", flat), func(b *testing.B) {
+			st, root, addrs := newBenchmarkState(b, flat, 1, slots)
+
+			snap, err := st.NewSnapshotAt(root)
+			require.NoError(b, err)
+
+			account, err := snap.GetAccount(addrs[0])
+			require.NoError(b, err)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				st.cache.Purge()
+
+				snap, err := st.NewSnapshotAt(root)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				snap.GetStorage(addrs[0], account.Root, slotKey(uint64(i%slots)+1))
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package itrie
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+var (
+	// counterCode increments the slot 0 and logs its new value
+	counterCode = []byte{
+		0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, // slot0 = slot0 + 1
+		0x60, 0x00, 0x54, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xa0, // log0(slot0)
+		0x00,
+	}
+
+	// coinbaseReaderCode stores the balance of the coinbase in the slot 0
+	coinbaseReaderCode = []byte{0x41, 0x31, 0x60, 0x00, 0x55, 0x00}
+
+	// revertCode reverts
+	revertCode = []byte{0x60, 0x00, 0x60, 0x00, 0xfd}
+
+	// selfdestructCode sends its balance to the caller and destroys itself
+	selfdestructCode = []byte{0x33, 0xff}
+)
+
+// testBlockGenerator generates the blocks of random transactions between a few accounts and contracts
+type testBlockGenerator struct {
+	rand      *rand.Rand
+	senders   []types.Address
+	nonces    map[types.Address]uint64
+	contracts []types.Address
+}
+
+func (g *testBlockGenerator) block(number uint64, coinbase types.Address, size int) *types.Block {
+	txs := make([]*types.Transaction, size)
+
+	for i := range txs {
+		from := g.senders[g.rand.Intn(len(g.senders))]
+		tx := &types.Transaction{
+			Nonce:    g.nonces[from],
+			From:     from,
+			GasPrice: big.NewInt(int64(10 + g.rand.Intn(10))),
+			Gas:      100_000,
+			Value:    big.NewInt(int64(g.rand.Intn(1000))),
+		}
+
+		switch kind := g.rand.Intn(10); {
+		case kind < 4:
+			// transfer to another sender
+			to := g.senders[g.rand.Intn(len(g.senders))]
+			tx.To = &to
+		case kind < 5:
+			// transfer to a new account
+			to := types.BytesToAddress(encodeUint64(g.rand.Uint64()))
+			tx.To = &to
+		case kind < 6:
+			tx.To = &coinbase
+		case kind < 9:
+			tx.To = &g.contracts[g.rand.Intn(len(g.contracts))]
+		default:
+			// deploy an empty contract
+			tx.Input = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+		}
+
+		tx.ComputeHash(number)
+
+		g.nonces[from]++
+		txs[i] = tx
+	}
+
+	return &types.Block{
+		Header: &types.Header{
+			Number:   number,
+			GasLimit: 30_000_000,
+			BaseFee:  10,
+		},
+		Transactions: txs,
+	}
+}
+
+func encodeUint64(n uint64) []byte {
+	return new(big.Int).SetUint64(n).Bytes()
+}
+
+func TestExecutor_ParallelExecution(t *testing.T) {
+	t.Parallel()
+
+	params := &chain.Params{
+		ChainID:      100,
+		Forks:        chain.AllForksEnabled,
+		BurnContract: map[uint64]types.Address{0: types.StringToAddress("b0")},
+	}
+
+	executor := state.NewExecutor(params, NewState(NewMemoryStorage()), hclog.NewNullLogger())
+	executor.GetHash = func(header *types.Header) func(i uint64) types.Hash {
+		return func(i uint64) types.Hash {
+			return types.BytesToHash(encodeUint64(i))
+		}
+	}
+
+	gen := &testBlockGenerator{
+		rand:   rand.New(rand.NewSource(1)), //nolint:gosec
+		nonces: map[types.Address]uint64{},
+	}
+
+	alloc := map[types.Address]*chain.GenesisAccount{}
+
+	for i := 0; i < 32; i++ {
+		addr := types.BytesToAddress([]byte{0x10, byte(i + 1)})
+		gen.senders = append(gen.senders, addr)
+		alloc[addr] = &chain.GenesisAccount{Balance: big.NewInt(1_000_000_000_000)}
+	}
+
+	for i, code := range [][]byte{counterCode, counterCode, coinbaseReaderCode, revertCode, selfdestructCode} {
+		addr := types.BytesToAddress([]byte{0x20, byte(i + 1)})
+		gen.contracts = append(gen.contracts, addr)
+		alloc[addr] = &chain.GenesisAccount{Balance: big.NewInt(1000), Code: code}
+	}
+
+	root, err := executor.WriteGenesis(alloc, types.ZeroHash)
+	require.NoError(t, err)
+
+	for number := uint64(1); number <= 20; number++ {
+		// the coinbase is one of the senders in every other block
+		coinbase := types.StringToAddress("c0")
+		if number%2 == 0 {
+			coinbase = gen.senders[0]
+		}
+
+		block := gen.block(number, coinbase, 10+gen.rand.Intn(100))
+
+		process := func(parallel bool) (*state.Transition, types.Hash) {
+			executor.ParallelExecution = parallel
+
+			txn, err := executor.ProcessBlock(root, block, coinbase)
+			require.NoError(t, err)
+
+			_, blockRoot, err := txn.Commit()
+			require.NoError(t, err)
+
+			return txn, blockRoot
+		}
+
+		sequential, sequentialRoot := process(false)
+		parallel, parallelRoot := process(true)
+
+		require.Equal(t, sequentialRoot, parallelRoot, "block %d", number)
+		require.Equal(t, sequential.TotalGas(), parallel.TotalGas(), "block %d", number)
+		require.Equal(t, sequential.Receipts(), parallel.Receipts(), "block %d", number)
+
+		root = parallelRoot
+	}
+}
@@ -0,0 +1,182 @@
+package itrie
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain/storage"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/leveldb"
+	"github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// BenchmarkExecutor_Prefetch replays the blocks recorded by a node with a cold trie node cache,
+// with and without the prefetcher, reading the state from the trie and from the flat snapshot.
+// The blocks and their parent state are read from a copy of the data directory of a stopped node,
+// which the benchmark writes the flat snapshot to. It is configured by the environment variables:
+//
+//	PREFETCH_BENCH_DATA_DIR   the copy of the data directory
+//	PREFETCH_BENCH_GENESIS    the genesis file of the chain
+//	PREFETCH_BENCH_FROM       the first replayed block, whose parent state must not be pruned
+//	PREFETCH_BENCH_BLOCKS     the number of the replayed blocks, 128 by default
+//	PREFETCH_BENCH_DB_ENGINE  the database engine of the data directory, leveldb by default
+//
+// The replayed blocks must not be moved to the freezer yet
+func BenchmarkExecutor_Prefetch(b *testing.B) {
+	dataDir := os.Getenv("PREFETCH_BENCH_DATA_DIR")
+	if dataDir == "" {
+		b.Skip("PREFETCH_BENCH_DATA_DIR is not set")
+	}
+
+	chainConfig, err := chain.ImportFromFile(os.Getenv("PREFETCH_BENCH_GENESIS"))
+	require.NoError(b, err)
+
+	from, err := strconv.ParseUint(os.Getenv("PREFETCH_BENCH_FROM"), 10, 64)
+	require.NoError(b, err)
+	require.NotZero(b, from)
+
+	count := uint64(defaultFlatDiffLayers)
+	if value := os.Getenv("PREFETCH_BENCH_BLOCKS"); value != "" {
+		count, err = strconv.ParseUint(value, 10, 64)
+		require.NoError(b, err)
+	}
+
+	var (
+		chainStorage storage.Storage
+		stateStorage Storage
+		logger       = hclog.NewNullLogger()
+	)
+
+	switch engine := os.Getenv("PREFETCH_BENCH_DB_ENGINE"); engine {
+	case "", "leveldb":
+		chainStorage, err = leveldb.NewLevelDBStorage(filepath.Join(dataDir, "blockchain"), logger)
+		require.NoError(b, err)
+
+		stateStorage, err = NewLevelDBStorage(filepath.Join(dataDir, "trie"), logger)
+		require.NoError(b, err)
+	case "pebble":
+		chainStorage, err = pebble.NewPebbleStorage(filepath.Join(dataDir, "blockchain"), logger)
+		require.NoError(b, err)
+
+		stateStorage, err = NewPebbleStorage(filepath.Join(dataDir, "trie"), logger)
+		require.NoError(b, err)
+	default:
+		b.Fatalf("unknown database engine %s", engine)
+	}
+
+	b.Cleanup(func() {
+		_ = chainStorage.Close()
+		_ = stateStorage.Close()
+	})
+
+	// read the recorded blocks
+	blocks := make([]*types.Block, 0, count)
+
+	for number := from; number < from+count; number++ {
+		hash, ok := chainStorage.ReadCanonicalHash(number)
+		require.True(b, ok, "block %d not found", number)
+
+		header, err := chainStorage.ReadHeader(hash)
+		require.NoError(b, err)
+
+		body, err := chainStorage.ReadBody(hash)
+		require.NoError(b, err)
+
+		blocks = append(blocks, &types.Block{Header: header, Transactions: body.Transactions, Uncles: body.Uncles})
+	}
+
+	parentHash, ok := chainStorage.ReadCanonicalHash(from - 1)
+	require.True(b, ok)
+
+	parent, err := chainStorage.ReadHeader(parentHash)
+	require.NoError(b, err)
+
+	newExecutor := func(st *State, prefetcher *state.Prefetcher) *state.Executor {
+		executor := state.NewExecutor(chainConfig.Params, st, logger)
+		executor.Prefetcher = prefetcher
+		executor.GetHash = func(*types.Header) func(i uint64) types.Hash {
+			return func(i uint64) types.Hash {
+				hash, _ := chainStorage.ReadCanonicalHash(i)
+
+				return hash
+			}
+		}
+
+		return executor
+	}
+
+	// newState creates the state without any trie node cached, whose flat snapshot
+	// covers the parent state of the replayed blocks if it is enabled
+	newState := func(b *testing.B, flat bool) *State {
+		b.Helper()
+
+		if !flat {
+			return NewState(stateStorage)
+		}
+
+		st := NewState(stateStorage)
+		if err := st.EnableFlatSnapshot(); err == nil && st.HasFlatSnapshot(parent.StateRoot) {
+			return st
+		}
+
+		// the blocks replayed before were flattened on disk, or there is no flat snapshot yet
+		result, err := NewState(stateStorage).GenerateFlatSnapshot(parent.StateRoot)
+		require.NoError(b, err)
+		require.NoError(b, <-result)
+
+		st = NewState(stateStorage)
+		require.NoError(b, st.EnableFlatSnapshot())
+		require.True(b, st.HasFlatSnapshot(parent.StateRoot))
+
+		return st
+	}
+
+	replay := func(b *testing.B, flat, prefetch bool) {
+		b.Helper()
+
+		// the prefetcher learns the written slots while the blocks are replayed
+		var prefetcher *state.Prefetcher
+		if prefetch {
+			prefetcher = state.NewPrefetcher()
+		}
+
+		b.ResetTimer()
+		b.StopTimer()
+
+		for i := 0; i < b.N; i++ {
+			executor := newExecutor(newState(b, flat), prefetcher)
+			root := parent.StateRoot
+
+			for _, block := range blocks {
+				b.StartTimer()
+
+				txn, err := executor.ProcessBlock(root, block, types.BytesToAddress(block.Header.Miner))
+				require.NoError(b, err)
+
+				_, root, err = txn.Commit()
+				require.NoError(b, err)
+
+				b.StopTimer()
+
+				require.Equal(b, block.Header.StateRoot, root, "block %d", block.Number())
+			}
+		}
+	}
+
+	for _, flat := range []bool{false, true} {
+		for _, prefetch := range []bool{false, true} {
+			flat, prefetch := flat, prefetch
+
+			b.Run("flat="+strconv.FormatBool(flat)+"/prefetch="+strconv.FormatBool(prefetch), func(b *testing.B) {
+				replay(b, flat, prefetch)
+			})
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// pruneBatchSize is the number of trie nodes removed from the storage at once
+const pruneBatchSize = 10000
+
+var errStorageNotPrunable = errors.New("state storage does not support pruning")
+
+// EnablePruning enables the removal of the trie nodes which are not reachable
+// from the retained state roots. It has to be called before any state is committed
+func (s *State) EnablePruning() error {
+	if _, ok := s.storage.(PrunableStorage); !ok {
+		return errStorageNotPrunable
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pruning = true
+	s.committed = map[types.Hash]struct{}{}
+
+	return nil
+}
+
+// Prune removes from the storage all the trie nodes which are not reachable
+// from the given state roots, nor from the state roots committed since the
+// previous pruning (e.g. the ones of the blocks still being built).
+// Only unreachable nodes are ever removed, so an interrupted pruning leaves
+// every retained state intact and the leftovers are removed by the next one.
+// It returns the number of the removed trie nodes
+func (s *State) Prune(retained []types.Hash) (int, error) {
+	if !s.pruning {
+		return 0, errors.New("pruning is not enabled")
+	}
+
+	s.pruneLock.Lock()
+	defer s.pruneLock.Unlock()
+
+	// from now on every written trie node is pinned, so that the nodes of the
+	// state roots committed during the pruning don't get removed
+	s.lock.Lock()
+	committed := s.committed
+	s.committed = map[types.Hash]struct{}{}
+	s.pinned = map[types.Hash]struct{}{}
+
+	retained = retained[:len(retained):len(retained)]
+	for root := range s.retained {
+		retained = append(retained, root)
+	}
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		s.pinned = nil
+		s.lock.Unlock()
+
+		// cached tries could reference the removed nodes
+		s.cache.Purge()
+	}()
+
+	m := &marker{storage: s.storage, marked: map[types.Hash]struct{}{}}
+
+	// the flat snapshot being generated walks the trie of its state root
+	if root, ok := s.flat.generatingRoot(); ok {
+		retained = append(retained, root)
+	}
+
+	for _, root := range retained {
+		if err := m.markHash(root.Bytes(), false); err != nil {
+			return 0, fmt.Errorf("failed to mark state root %s: %w", root, err)
+		}
+	}
+
+	for root := range committed {
+		if err := m.markHash(root.Bytes(), false); err != nil {
+			return 0, fmt.Errorf("failed to mark state root %s: %w", root, err)
+		}
+	}
+
+	return s.sweep(m.marked)
+}
+
+// RetainRoot keeps the trie nodes of the state root from the pruning until the returned function
+// is called, e.g. while the state is exported. It waits for the pruning in progress, so the state
+// root has to be checked for availability once it is retained
+func (s *State) RetainRoot(root types.Hash) func() {
+	s.pruneLock.Lock()
+	defer s.pruneLock.Unlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.retained == nil {
+		s.retained = map[types.Hash]int{}
+	}
+
+	s.retained[root]++
+
+	return func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		if s.retained[root]--; s.retained[root] == 0 {
+			delete(s.retained, root)
+		}
+	}
+}
+
+// sweep removes the trie nodes which are neither marked nor pinned
+func (s *State) sweep(marked map[types.Hash]struct{}) (int, error) {
+	storage, _ := s.storage.(PrunableStorage)
+
+	var (
+		removed int
+		pending = make([][]byte, 0, pruneBatchSize)
+	)
+
+	flush := func() error {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		keys := make([][]byte, 0, len(pending))
+
+		for _, k := range pending {
+			if _, ok := s.pinned[types.BytesToHash(k)]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		pending = pending[:0]
+
+		if err := storage.Delete(keys); err != nil {
+			return err
+		}
+
+		removed += len(keys)
+
+		return nil
+	}
+
+	var flushErr error
+
+	err := storage.Iterate(func(k []byte) bool {
+		// the code is stored under the prefixed keys and it is never removed
+		if len(k) != types.HashLength {
+			return true
+		}
+
+		if _, ok := marked[types.BytesToHash(k)]; ok {
+			return true
+		}
+
+		pending = append(pending, append([]byte{}, k...))
+
+		if len(pending) == pruneBatchSize {
+			flushErr = flush()
+		}
+
+		return flushErr == nil
+	})
+	if err != nil {
+		return removed, err
+	}
+
+	if flushErr != nil {
+		return removed, flushErr
+	}
+
+	if len(pending) > 0 {
+		if err := flush(); err != nil {
+			return removed, err
+		}
+	}
+
+	return removed, nil
+}
+
+// marker collects the trie nodes reachable from the state roots,
+// including the nodes of the accounts' storage tries
+type marker struct {
+	storage Storage
+	marked  map[types.Hash]struct{}
+}
+
+func (m *marker) markHash(hash []byte, isStorage bool) error {
+	key := types.BytesToHash(hash)
+	if _, ok := m.marked[key]; ok {
+		return nil
+	}
+
+	node, ok, err := GetNode(hash, m.storage)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		// nothing to retain
+		return nil
+	}
+
+	m.marked[key] = struct{}{}
+
+	return m.markNode(node, isStorage)
+}
+
+func (m *marker) markNode(node Node, isStorage bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *FullNode:
+		for _, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := m.markNode(child, isStorage); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return m.markNode(n.value, isStorage)
+		}
+
+	case *ShortNode:
+		return m.markNode(n.child, isStorage)
+
+	case *ValueNode:
+		if n.hash {
+			return m.markHash(n.buf, isStorage)
+		}
+
+		if isStorage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("can't parse account: %w", err)
+		}
+
+		if account.Root != types.EmptyRootHash && account.Root != types.ZeroHash {
+			return m.markHash(account.Root.Bytes(), true)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package itrie
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// commitBlock sets the balance and a storage slot of the given accounts
+// on top of the parent state root and returns the new state root
+func commitBlock(t *testing.T, st *State, parent types.Hash, value uint64, addrs ...types.Address) types.Hash {
+	t.Helper()
+
+	snap, err := st.NewSnapshotAt(parent)
+	require.NoError(t, err)
+
+	objs := make([]*state.Object, 0, len(addrs))
+
+	for _, addr := range addrs {
+		storageRoot := types.EmptyRootHash
+
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		if account != nil {
+			storageRoot = account.Root
+		}
+
+		objs = append(objs, &state.Object{
+			Address:  addr,
+			Balance:  new(big.Int).SetUint64(value),
+			CodeHash: types.EmptyCodeHash,
+			Root:     storageRoot,
+			Storage: []*state.StorageObject{
+				{Key: types.StringToHash("0x1").Bytes(), Val: big.NewInt(int64(value)).Bytes()},
+			},
+		})
+	}
+
+	_, root, err := snap.Commit(objs)
+	require.NoError(t, err)
+
+	return types.BytesToHash(root)
+}
+
+func TestState_Prune(t *testing.T) {
+	t.Parallel()
+
+	addrs := []types.Address{types.StringToAddress("1"), types.StringToAddress("2"), types.StringToAddress("3")}
+
+	st := NewState(NewMemoryStorage())
+	require.NoError(t, st.EnablePruning())
+
+	root1 := commitBlock(t, st, types.EmptyRootHash, 1, addrs...)
+	root2 := commitBlock(t, st, root1, 2, addrs[0])
+	root3 := commitBlock(t, st, root2, 3, addrs[1])
+
+	// the roots committed since the last pruning are retained
+	_, err := st.Prune([]types.Hash{root3})
+	require.NoError(t, err)
+
+	_, err = st.NewSnapshotAt(root1)
+	require.NoError(t, err)
+
+	removed, err := st.Prune([]types.Hash{root3})
+	require.NoError(t, err)
+	require.Greater(t, removed, 0)
+
+	for _, root := range []types.Hash{root1, root2} {
+		_, err := st.NewSnapshotAt(root)
+		require.ErrorIs(t, err, state.ErrStatePruned)
+	}
+
+	// the retained state is complete, including the storage tries
+	snap, err := st.NewSnapshotAt(root3)
+	require.NoError(t, err)
+
+	expected := []uint64{2, 3, 1}
+
+	for i, addr := range addrs {
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+		require.Equal(t, expected[i], account.Balance.Uint64())
+
+		value := snap.GetStorage(addr, account.Root, types.StringToHash("0x1"))
+		require.Equal(t, types.BytesToHash(big.NewInt(int64(expected[i])).Bytes()), value)
+	}
+
+	// the chain can be extended on top of the retained state
+	root4 := commitBlock(t, st, root3, 4, addrs...)
+
+	removed, err = st.Prune([]types.Hash{root4})
+	require.NoError(t, err)
+	require.Greater(t, removed, 0)
+
+	_, err = st.NewSnapshotAt(root3)
+	require.ErrorIs(t, err, state.ErrStatePruned)
+
+	_, err = st.NewSnapshotAt(root4)
+	require.NoError(t, err)
+
+	// nothing left to remove
+	removed, err = st.Prune([]types.Hash{root4})
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+}
+
+func TestState_RetainRoot(t *testing.T) {
+	t.Parallel()
+
+	addrs := []types.Address{types.StringToAddress("1"), types.StringToAddress("2")}
+
+	st := NewState(NewMemoryStorage())
+	require.NoError(t, st.EnablePruning())
+
+	root1 := commitBlock(t, st, types.EmptyRootHash, 1, addrs...)
+	root2 := commitBlock(t, st, root1, 2, addrs...)
+
+	// the committed roots are retained by the first pruning
+	_, err := st.Prune([]types.Hash{root2})
+	require.NoError(t, err)
+
+	release := st.RetainRoot(root1)
+	releaseAgain := st.RetainRoot(root1)
+
+	removed, err := st.Prune([]types.Hash{root2})
+	require.NoError(t, err)
+	require.Equal(t, 0, removed)
+
+	// the state root stays retained until every reader releases it
+	release()
+
+	_, err = st.Prune([]types.Hash{root2})
+	require.NoError(t, err)
+
+	require.NoError(t, ExportState(st.storage, root1, func([]byte, bool) error {
+		return nil
+	}))
+
+	releaseAgain()
+
+	removed, err = st.Prune([]types.Hash{root2})
+	require.NoError(t, err)
+	require.Greater(t, removed, 0)
+
+	_, err = st.NewSnapshotAt(root1)
+	require.ErrorIs(t, err, state.ErrStatePruned)
+}
+
+func TestState_Prune_NotEnabled(t *testing.T) {
+	t.Parallel()
+
+	st := NewState(NewMemoryStorage())
+
+	_, err := st.Prune(nil)
+	require.Error(t, err)
+
+	_, err = st.NewSnapshotAt(types.StringToHash("0x1"))
+	require.Error(t, err)
+	require.False(t, errors.Is(err, state.ErrStatePruned))
+}
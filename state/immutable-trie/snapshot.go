@@ -13,28 +13,24 @@ import (
 type Snapshot struct {
 	state *State
 	trie  *Trie
+	root  types.Hash
 }
 
 var emptyStateHash = types.StringToHash("0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
 
 func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.Hash) types.Hash {
-	var (
-		err  error
-		trie *Trie
-	)
-
 	if root == emptyStateHash {
-		trie = s.state.newTrie()
-	} else {
-		trie, err = s.state.newTrieAt(root)
-		if err != nil {
-			return types.Hash{}
-		}
+		return types.Hash{}
 	}
 
 	key := crypto.Keccak256(rawkey.Bytes())
 
-	val, ok := trie.Get(key, s.state.storage)
+	val, ok, err := s.getFlatStorage(addr, root, key)
+	if err != nil {
+		// the flat snapshot is not available, fall back to the trie
+		val, ok = s.getTrieStorage(root, key)
+	}
+
 	if !ok {
 		return types.Hash{}
 	}
@@ -54,10 +50,56 @@ func (s *Snapshot) GetStorage(addr types.Address, root types.Hash, rawkey types.
 	return types.BytesToHash(res)
 }
 
+// getFlatStorage reads the storage value from the flat snapshot,
+// if the account has the given storage root at the snapshot's state root
+func (s *Snapshot) getFlatStorage(addr types.Address, root types.Hash, key []byte) ([]byte, bool, error) {
+	account := types.BytesToHash(hashit(addr.Bytes()))
+
+	data, ok, err := s.state.flat.account(s.root, account)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !ok {
+		return nil, false, errFlatSnapshotUnavailable
+	}
+
+	var acc state.Account
+	if err := acc.UnmarshalRlp(data); err != nil {
+		return nil, false, err
+	}
+
+	if acc.Root != root {
+		return nil, false, errFlatSnapshotUnavailable
+	}
+
+	return s.state.flat.slot(s.root, account, types.BytesToHash(key))
+}
+
+func (s *Snapshot) getTrieStorage(root types.Hash, key []byte) ([]byte, bool) {
+	trie, err := s.state.newTrieAt(root)
+	if err != nil {
+		return nil, false
+	}
+
+	return trie.get(key, s.state.storage, s.state.nodes)
+}
+
+// ReadsTrie checks if the reads of the snapshot go to the trie,
+// i.e. the flat snapshot doesn't cover the snapshot's state root
+func (s *Snapshot) ReadsTrie() bool {
+	return !s.state.flat.has(s.root)
+}
+
 func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	key := crypto.Keccak256(addr.Bytes())
 
-	data, ok := s.trie.Get(key, s.state.storage)
+	data, ok, err := s.state.flat.account(s.root, types.BytesToHash(key))
+	if err != nil {
+		// the flat snapshot is not available, fall back to the trie
+		data, ok = s.trie.get(key, s.state.storage, s.state.nodes)
+	}
+
 	if !ok {
 		return nil, nil
 	}
@@ -70,12 +112,28 @@ func (s *Snapshot) GetAccount(addr types.Address) (*state.Account, error) {
 	return &account, nil
 }
 
+// hasStorage checks if the account has a non-empty storage at the snapshot's state root,
+// according to the flat snapshot
+func (s *Snapshot) hasStorage(account types.Hash) bool {
+	data, ok, err := s.state.flat.account(s.root, account)
+	if err != nil || !ok {
+		return false
+	}
+
+	var acc state.Account
+	if err := acc.UnmarshalRlp(data); err != nil {
+		return false
+	}
+
+	return acc.Root != types.EmptyRootHash
+}
+
 func (s *Snapshot) GetCode(hash types.Hash) ([]byte, bool) {
 	return s.state.GetCode(hash)
 }
 
 func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error) {
-	batch := s.state.storage.Batch()
+	batch := &trackingBatch{Batch: s.state.storage.Batch(), track: s.state.pruning}
 
 	tt := s.trie.Txn(s.state.storage)
 	tt.batch = batch
@@ -83,9 +141,22 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 	arena := stateArenaPool.Get()
 	defer stateArenaPool.Put(arena)
 
+	// the changes tracked by the flat snapshot
+	var flat *flatUpdate
+	if s.state.flat != nil {
+		flat = newFlatUpdate()
+	}
+
 	for _, obj := range objs {
+		addrHash := hashit(obj.Address.Bytes())
+
 		if obj.Deleted {
-			tt.Delete(hashit(obj.Address.Bytes()))
+			tt.Delete(addrHash)
+
+			if flat != nil {
+				flat.accounts[types.BytesToHash(addrHash)] = nil
+				flat.destructs[types.BytesToHash(addrHash)] = struct{}{}
+			}
 		} else {
 			account := state.Account{
 				Balance:  obj.Balance,
@@ -94,6 +165,11 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 				Root:     obj.Root, // old root
 			}
 
+			// the account is either new or recreated, so the old storage is gone
+			if flat != nil && obj.Root == types.EmptyRootHash && s.hasStorage(types.BytesToHash(addrHash)) {
+				flat.destructs[types.BytesToHash(addrHash)] = struct{}{}
+			}
+
 			if len(obj.Storage) != 0 {
 				trie, err := s.state.newTrieAt(obj.Root)
 				if err != nil {
@@ -103,13 +179,28 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 				localTxn := trie.Txn(s.state.storage)
 				localTxn.batch = batch
 
+				var slots map[types.Hash][]byte
+				if flat != nil {
+					slots = make(map[types.Hash][]byte, len(obj.Storage))
+					flat.storage[types.BytesToHash(addrHash)] = slots
+				}
+
 				for _, entry := range obj.Storage {
 					k := hashit(entry.Key)
 					if entry.Deleted {
 						localTxn.Delete(k)
+
+						if slots != nil {
+							slots[types.BytesToHash(k)] = nil
+						}
 					} else {
 						vv := arena.NewBytes(bytes.TrimLeft(entry.Val, "\x00"))
-						localTxn.Insert(k, vv.MarshalTo(nil))
+						val := vv.MarshalTo(nil)
+						localTxn.Insert(k, val)
+
+						if slots != nil {
+							slots[types.BytesToHash(k)] = val
+						}
 					}
 				}
 
@@ -129,8 +220,12 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 			vv := account.MarshalWith(arena)
 			data := vv.MarshalTo(nil)
 
-			tt.Insert(hashit(obj.Address.Bytes()), data)
+			tt.Insert(addrHash, data)
 			arena.Reset()
+
+			if flat != nil {
+				flat.accounts[types.BytesToHash(addrHash)] = data
+			}
 		}
 	}
 
@@ -142,11 +237,17 @@ func (s *Snapshot) Commit(objs []*state.Object) (state.Snapshot, []byte, error)
 	nTrie := tt.Commit()
 
 	// Write all the entries to db
-	if err := batch.Write(); err != nil {
+	if err := s.state.writeBatch(types.BytesToHash(root), batch); err != nil {
 		return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit db write error: %w", err)
 	}
 
 	s.state.AddState(types.BytesToHash(root), nTrie)
 
-	return &Snapshot{trie: nTrie, state: s.state}, root, nil
+	if flat != nil {
+		if err := s.state.flat.update(s.root, types.BytesToHash(root), flat); err != nil {
+			return nil, types.ZeroHash[:], fmt.Errorf("snapshot commit flat snapshot update error: %w", err)
+		}
+	}
+
+	return &Snapshot{trie: nTrie, state: s.state, root: types.BytesToHash(root)}, root, nil
 }
@@ -2,6 +2,7 @@ package itrie
 
 import (
 	"fmt"
+	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
 
@@ -12,21 +13,45 @@ import (
 type State struct {
 	storage Storage
 	cache   *lru.Cache
+
+	// pruning is set if the trie nodes of the old state roots get removed
+	pruning bool
+	// lock serializes the state commits with the pruning
+	lock sync.Mutex
+	// committed holds the state roots committed since the last pruning
+	committed map[types.Hash]struct{}
+	// pinned holds the trie nodes written while the pruning is in progress
+	pinned map[types.Hash]struct{}
+	// retained holds the state roots kept by the pruning while they are read, by their readers count
+	retained map[types.Hash]int
+	// pruneLock is held for the whole pruning, so that no root is retained in the middle of it
+	pruneLock sync.Mutex
+
+	// nodes caches the trie nodes read from the storage by their hash
+	nodes *lru.Cache
+
+	// flat is the flat snapshot of the recent states, nil if it is disabled
+	flat *flatSnapshot
 }
 
+// nodeCacheSize is the number of the trie nodes kept in memory
+const nodeCacheSize = 64 * 1024
+
 func NewState(storage Storage) *State {
 	cache, _ := lru.New(128)
+	nodes, _ := lru.New(nodeCacheSize)
 
 	s := &State{
 		storage: storage,
 		cache:   cache,
+		nodes:   nodes,
 	}
 
 	return s
 }
 
 func (s *State) NewSnapshot() state.Snapshot {
-	return &Snapshot{state: s, trie: s.newTrie()}
+	return &Snapshot{state: s, trie: s.newTrie(), root: types.EmptyRootHash}
 }
 
 func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
@@ -35,7 +60,7 @@ func (s *State) NewSnapshotAt(root types.Hash) (state.Snapshot, error) {
 		return nil, err
 	}
 
-	return &Snapshot{state: s, trie: t}, nil
+	return &Snapshot{state: s, trie: t, root: root}, nil
 }
 
 func (s *State) newTrie() *Trie {
@@ -77,6 +102,10 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	}
 
 	if !ok {
+		if s.pruning {
+			return nil, fmt.Errorf("%w: state not found at hash %s", state.ErrStatePruned, root)
+		}
+
 		return nil, fmt.Errorf("state not found at hash %s", root)
 	}
 
@@ -87,6 +116,97 @@ func (s *State) newTrieAt(root types.Hash) (*Trie, error) {
 	return t, nil
 }
 
+// EnableFlatSnapshot enables the flat snapshot, which serves the account and storage reads
+// of the recent states without walking the trie. It has to be called before any state is committed.
+// ErrFlatSnapshotMissing is returned if the storage holds the state, but not its flat snapshot
+func (s *State) EnableFlatSnapshot() error {
+	storage, ok := s.storage.(FlatStorage)
+	if !ok {
+		return errStorageNotFlat
+	}
+
+	flat, err := loadFlatSnapshot(storage)
+	if err != nil {
+		return err
+	}
+
+	s.flat = flat
+
+	return nil
+}
+
+// GenerateFlatSnapshot regenerates the flat snapshot of the given state root from the trie in the background,
+// e.g. when the flat snapshot on disk is missing or doesn't match the head block after a crash.
+// The reads fall back to the trie until the generation completes, and the states committed on top of
+// the root in the meantime are applied afterwards. It has to be called before any state is committed
+// on top of the root. The result of the generation is sent to the returned channel
+func (s *State) GenerateFlatSnapshot(root types.Hash) (<-chan error, error) {
+	if s.flat == nil {
+		storage, ok := s.storage.(FlatStorage)
+		if !ok {
+			return nil, errStorageNotFlat
+		}
+
+		s.flat = &flatSnapshot{
+			storage:       storage,
+			layers:        map[types.Hash]*flatDiffLayer{},
+			maxDiffLayers: defaultFlatDiffLayers,
+		}
+	}
+
+	return s.flat.generate(root)
+}
+
+// HasFlatSnapshot checks if the flat snapshot covers the given state root
+func (s *State) HasFlatSnapshot(root types.Hash) bool {
+	return s.flat.has(root)
+}
+
+// PersistFlatSnapshot writes the flat snapshot of the given state root to disk,
+// so that it is available after the restart
+func (s *State) PersistFlatSnapshot(root types.Hash) error {
+	if s.flat == nil {
+		return nil
+	}
+
+	return s.flat.persist(root)
+}
+
 func (s *State) AddState(root types.Hash, t *Trie) {
 	s.cache.Add(root, t)
 }
+
+// writeBatch writes the trie nodes of the committed state root to the storage
+func (s *State) writeBatch(root types.Hash, batch *trackingBatch) error {
+	if !s.pruning {
+		return batch.Write()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.committed[root] = struct{}{}
+
+	if s.pinned != nil {
+		for _, k := range batch.keys {
+			s.pinned[k] = struct{}{}
+		}
+	}
+
+	return batch.Write()
+}
+
+// trackingBatch is a Batch which records the keys of the written trie nodes
+type trackingBatch struct {
+	Batch
+	track bool
+	keys  []types.Hash
+}
+
+func (b *trackingBatch) Put(k, v []byte) {
+	if b.track && len(k) == types.HashLength {
+		b.keys = append(b.keys, types.BytesToHash(k))
+	}
+
+	b.Batch.Put(k, v)
+}
@@ -1,13 +1,16 @@
 package itrie
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -25,6 +28,8 @@ var (
 type Batch interface {
 	// Put puts key and value into batch. It can not return error because actual writing is done with Write method
 	Put(k, v []byte)
+	// Delete deletes the key in batch
+	Delete(k []byte)
 	// Write writes all the key values pair previosly putted with Put method to the database
 	Write() error
 }
@@ -40,6 +45,24 @@ type Storage interface {
 	Close() error
 }
 
+// PrunableStorage is a Storage from which the trie nodes can be removed
+type PrunableStorage interface {
+	Storage
+	// Iterate calls fn for every key in the storage, until fn returns false.
+	// The key passed to fn is valid only until fn returns
+	Iterate(fn func(k []byte) bool) error
+	// Delete removes the given keys from the storage in a single batch
+	Delete(keys [][]byte) error
+}
+
+// FlatStorage is a Storage which can hold the flat snapshot of the state
+type FlatStorage interface {
+	Storage
+	// IteratePrefix calls fn for every key/value pair with the given prefix, until fn returns false.
+	// The key and the value passed to fn are valid only until fn returns
+	IteratePrefix(prefix []byte, fn func(k, v []byte) bool) error
+}
+
 // KVStorage is a k/v storage on memory using leveldb
 type KVStorage struct {
 	db *leveldb.DB
@@ -55,6 +78,10 @@ func (b *KVBatch) Put(k, v []byte) {
 	b.batch.Put(k, v)
 }
 
+func (b *KVBatch) Delete(k []byte) {
+	b.batch.Delete(k)
+}
+
 func (b *KVBatch) Write() error {
 	return b.db.Write(b.batch, nil)
 }
@@ -93,6 +120,41 @@ func (kv *KVStorage) Get(k []byte) ([]byte, bool, error) {
 	return data, true, nil
 }
 
+func (kv *KVStorage) Iterate(fn func(k []byte) bool) error {
+	iter := kv.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !fn(iter.Key()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) IteratePrefix(prefix []byte, fn func(k, v []byte) bool) error {
+	iter := kv.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
+func (kv *KVStorage) Delete(keys [][]byte) error {
+	batch := &leveldb.Batch{}
+	for _, k := range keys {
+		batch.Delete(k)
+	}
+
+	return kv.db.Write(batch, nil)
+}
+
 func (kv *KVStorage) Close() error {
 	return kv.db.Close()
 }
@@ -159,7 +221,69 @@ func (m *memStorage) GetCode(hash types.Hash) ([]byte, bool) {
 }
 
 func (m *memStorage) Batch() Batch {
-	return &memBatch{db: &m.db, l: new(sync.Mutex)}
+	return &memBatch{db: &m.db, l: m.l}
+}
+
+func (m *memStorage) Iterate(fn func(k []byte) bool) error {
+	// keys are copied so that fn is free to modify the storage
+	m.l.Lock()
+	keys := make([]string, 0, len(m.db))
+
+	for k := range m.db {
+		keys = append(keys, k)
+	}
+	m.l.Unlock()
+
+	for _, k := range keys {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if !fn(key) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) IteratePrefix(prefix []byte, fn func(k, v []byte) bool) error {
+	// entries are copied so that fn is free to modify the storage
+	m.l.Lock()
+	hexPrefix := hex.EncodeToHex(prefix)
+	entries := map[string][]byte{}
+
+	for k, v := range m.db {
+		if strings.HasPrefix(k, hexPrefix) {
+			entries[k] = v
+		}
+	}
+	m.l.Unlock()
+
+	for k, v := range entries {
+		key, err := hex.DecodeHex(k)
+		if err != nil {
+			return err
+		}
+
+		if !fn(key, v) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (m *memStorage) Delete(keys [][]byte) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	for _, k := range keys {
+		delete(m.db, hex.EncodeToHex(k))
+	}
+
+	return nil
 }
 
 func (m *memStorage) Close() error {
@@ -175,6 +299,13 @@ func (m *memBatch) Put(p, v []byte) {
 	(*m.db)[hex.EncodeToHex(p)] = buf
 }
 
+func (m *memBatch) Delete(p []byte) {
+	m.l.Lock()
+	defer m.l.Unlock()
+
+	delete(*m.db, hex.EncodeToHex(p))
+}
+
 func (m *memBatch) Write() error {
 	return nil
 }
@@ -275,6 +406,22 @@ func decodeNode(v *fastrlp.Value, s Storage) (Node, error) {
 	return nil, fmt.Errorf("node has incorrect number of leafs")
 }
 
+// DescribeKey returns the kind of the data stored under the key in the state storage
+func DescribeKey(k []byte) string {
+	switch {
+	case len(k) == types.HashLength:
+		return "trie nodes"
+	case bytes.HasPrefix(k, codePrefix):
+		return "contract code"
+	case bytes.HasPrefix(k, flatAccountPrefix):
+		return "flat accounts"
+	case bytes.HasPrefix(k, flatStoragePrefix):
+		return "flat storage"
+	default:
+		return "other"
+	}
+}
+
 func GetCodeKey(hash types.Hash) []byte {
 	return append(codePrefix, hash.Bytes()...)
 }
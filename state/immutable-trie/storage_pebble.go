@@ -0,0 +1,142 @@
+package itrie
+
+import (
+	"errors"
+
+	pebblestorage "github.com/0xPolygon/polygon-edge/blockchain/storage/pebble"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/cockroachdb/pebble"
+	"github.com/hashicorp/go-hclog"
+)
+
+// PebbleStorage is a k/v storage on disk using pebble
+type PebbleStorage struct {
+	db *pebble.DB
+}
+
+// PebbleBatch is a batch write for pebble
+type PebbleBatch struct {
+	batch *pebble.Batch
+}
+
+func (b *PebbleBatch) Put(k, v []byte) {
+	_ = b.batch.Set(k, v, nil)
+}
+
+func (b *PebbleBatch) Delete(k []byte) {
+	_ = b.batch.Delete(k, nil)
+}
+
+func (b *PebbleBatch) Write() error {
+	return b.batch.Commit(pebble.Sync)
+}
+
+func (kv *PebbleStorage) SetCode(hash types.Hash, code []byte) error {
+	return kv.Put(GetCodeKey(hash), code)
+}
+
+func (kv *PebbleStorage) GetCode(hash types.Hash) ([]byte, bool) {
+	res, ok, err := kv.Get(GetCodeKey(hash))
+	if err != nil {
+		return nil, false
+	}
+
+	return res, ok
+}
+
+func (kv *PebbleStorage) Batch() Batch {
+	return &PebbleBatch{batch: kv.db.NewBatch()}
+}
+
+func (kv *PebbleStorage) Put(k, v []byte) error {
+	return kv.db.Set(k, v, pebble.Sync)
+}
+
+func (kv *PebbleStorage) Get(k []byte) ([]byte, bool, error) {
+	data, closer, err := kv.db.Get(k)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	defer closer.Close()
+
+	// the returned data is valid only until the closer is closed
+	return append([]byte{}, data...), true, nil
+}
+
+func (kv *PebbleStorage) Iterate(fn func(k []byte) bool) error {
+	iter, err := kv.db.NewIter(nil)
+	if err != nil {
+		return err
+	}
+
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if !fn(iter.Key()) {
+			break
+		}
+	}
+
+	return errors.Join(iter.Error(), iter.Close())
+}
+
+func (kv *PebbleStorage) IteratePrefix(prefix []byte, fn func(k, v []byte) bool) error {
+	iter, err := kv.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	})
+	if err != nil {
+		return err
+	}
+
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if !fn(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+
+	return errors.Join(iter.Error(), iter.Close())
+}
+
+func (kv *PebbleStorage) Delete(keys [][]byte) error {
+	batch := kv.db.NewBatch()
+	for _, k := range keys {
+		if err := batch.Delete(k, nil); err != nil {
+			return err
+		}
+	}
+
+	return batch.Commit(pebble.Sync)
+}
+
+func (kv *PebbleStorage) Close() error {
+	return kv.db.Close()
+}
+
+func NewPebbleStorage(path string, logger hclog.Logger) (Storage, error) {
+	db, err := pebblestorage.OpenDB(path, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PebbleStorage{db}, nil
+}
+
+// prefixUpperBound returns the smallest key greater than all the keys with the given prefix,
+// or nil if there is no such key
+func prefixUpperBound(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
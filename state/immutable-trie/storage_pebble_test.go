@@ -0,0 +1,83 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func newPebbleTestStorage(t *testing.T) Storage {
+	t.Helper()
+
+	storage, err := NewPebbleStorage(t.TempDir(), hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, storage.Close())
+	})
+
+	return storage
+}
+
+func TestPebbleStorage_FlatSnapshot(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(3)
+	st := NewState(newPebbleTestStorage(t))
+
+	root := commitChanges(t, st, types.EmptyRootHash,
+		testAccountChange{addr: addrs[0], balance: 1, slots: map[uint64]uint64{1: 1, 2: 2}},
+		testAccountChange{addr: addrs[1], balance: 2},
+		testAccountChange{addr: addrs[2], balance: 3, slots: map[uint64]uint64{3: 3}},
+	)
+
+	// the flat snapshot is rebuilt by iterating the prefixed keys
+	require.NoError(t, RebuildFlatSnapshot(st.storage, root))
+
+	rebuilt := NewState(st.storage)
+	require.NoError(t, rebuilt.EnableFlatSnapshot())
+	require.True(t, rebuilt.HasFlatSnapshot(root))
+
+	requireSameState(t, rebuilt, root, addrs, 3)
+}
+
+func TestPebbleStorage_Prune(t *testing.T) {
+	t.Parallel()
+
+	addrs := testAddresses(3)
+
+	st := NewState(newPebbleTestStorage(t))
+	require.NoError(t, st.EnablePruning())
+
+	root1 := commitBlock(t, st, types.EmptyRootHash, 1, addrs...)
+	root2 := commitBlock(t, st, root1, 2, addrs[0])
+
+	_, err := st.Prune([]types.Hash{root2})
+	require.NoError(t, err)
+
+	removed, err := st.Prune([]types.Hash{root2})
+	require.NoError(t, err)
+	require.Greater(t, removed, 0)
+
+	_, err = st.NewSnapshotAt(root1)
+	require.ErrorIs(t, err, state.ErrStatePruned)
+
+	snap, err := st.NewSnapshotAt(root2)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(addrs[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), account.Balance.Uint64())
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []byte{0x01, 0x03}, prefixUpperBound([]byte{0x01, 0x02}))
+	require.Equal(t, []byte{0x02}, prefixUpperBound([]byte{0x01, 0xff}))
+	require.Nil(t, prefixUpperBound([]byte{0xff, 0xff}))
+}
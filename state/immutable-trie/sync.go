@@ -0,0 +1,283 @@
+package itrie
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/umbracle/fastrlp"
+)
+
+// stateSyncBatchSize is the number of the downloaded items written to the storage at once
+const stateSyncBatchSize = 10000
+
+var errUnrequestedStateItem = errors.New("received state item which was not requested")
+
+// syncRequest is a trie node or a contract code waiting to be downloaded,
+// or waiting for its children to be downloaded
+type syncRequest struct {
+	hash types.Hash
+	code bool
+	// isStorage is true for the nodes of the storage tries
+	isStorage bool
+
+	data []byte
+	// deps is the number of the children which are not written yet
+	deps int
+	// parents are the requests waiting for this one to be written
+	parents []*syncRequest
+}
+
+// StateSync downloads the state of the given state root, item by item. The trie nodes
+// and the contract code are requested by their hash, so every received item is verified
+// and the downloaded state matches the state root once nothing is pending.
+// An item is written to the storage only after all its children are written, so every
+// trie node found in the storage is the root of a complete subtrie. Thanks to that an
+// interrupted sync resumes by skipping the subtries already in the storage
+type StateSync struct {
+	storage Storage
+	root    types.Hash
+
+	// queue holds the items to be requested
+	queue []*syncRequest
+	// requests holds all the items which are not written yet, by hash
+	requests map[types.Hash]*syncRequest
+
+	batch     Batch
+	batchSize int
+}
+
+// NewStateSync creates the sync of the state with the given state root
+func NewStateSync(storage Storage, root types.Hash) *StateSync {
+	s := &StateSync{
+		storage:  storage,
+		root:     root,
+		requests: map[types.Hash]*syncRequest{},
+		batch:    storage.Batch(),
+	}
+
+	if root != types.EmptyRootHash && root != types.ZeroHash {
+		s.schedule(nil, root, false, false)
+	}
+
+	return s
+}
+
+// Missing returns up to max trie nodes and contract codes which have to be downloaded
+func (s *StateSync) Missing(max int) (nodes []types.Hash, codes []types.Hash) {
+	for len(s.queue) > 0 && len(nodes)+len(codes) < max {
+		req := s.queue[len(s.queue)-1]
+		s.queue = s.queue[:len(s.queue)-1]
+
+		if req.code {
+			codes = append(codes, req.hash)
+		} else {
+			nodes = append(nodes, req.hash)
+		}
+	}
+
+	return nodes, codes
+}
+
+// Retry puts back the trie nodes and the contract codes which were not delivered by the peer
+func (s *StateSync) Retry(hashes []types.Hash) {
+	for _, hash := range hashes {
+		if req, ok := s.requests[hash]; ok && req.data == nil {
+			s.queue = append(s.queue, req)
+		}
+	}
+}
+
+// Pending returns the number of the items which are not written yet
+func (s *StateSync) Pending() int {
+	return len(s.requests)
+}
+
+// ProcessNode processes the downloaded trie node and schedules its missing children
+func (s *StateSync) ProcessNode(data []byte) error {
+	req, err := s.received(data, false)
+	if err != nil {
+		return err
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	v, err := p.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	if v.Type() != fastrlp.TypeArray {
+		return fmt.Errorf("trie node %s should be an array", req.hash)
+	}
+
+	node, err := decodeNode(v, s.storage)
+	if err != nil {
+		return fmt.Errorf("can't parse trie node %s: %w", req.hash, err)
+	}
+
+	if err := s.scheduleChildren(req, node); err != nil {
+		return err
+	}
+
+	if req.deps == 0 {
+		return s.write(req)
+	}
+
+	return nil
+}
+
+// ProcessCode processes the downloaded contract code
+func (s *StateSync) ProcessCode(code []byte) error {
+	req, err := s.received(code, true)
+	if err != nil {
+		return err
+	}
+
+	return s.write(req)
+}
+
+// Commit writes the pending batch of the downloaded items to the storage
+func (s *StateSync) Commit() error {
+	if s.batchSize == 0 {
+		return nil
+	}
+
+	if err := s.batch.Write(); err != nil {
+		return err
+	}
+
+	s.batch = s.storage.Batch()
+	s.batchSize = 0
+
+	return nil
+}
+
+// received matches the downloaded item with its request
+func (s *StateSync) received(data []byte, code bool) (*syncRequest, error) {
+	hash := types.BytesToHash(hashit(data))
+
+	req, ok := s.requests[hash]
+	if !ok || req.code != code || req.data != nil {
+		return nil, fmt.Errorf("%w: %s", errUnrequestedStateItem, hash)
+	}
+
+	req.data = data
+
+	return req, nil
+}
+
+// schedule requests the item unless it is already in the storage
+func (s *StateSync) schedule(parent *syncRequest, hash types.Hash, code bool, isStorage bool) {
+	if req, ok := s.requests[hash]; ok {
+		// the same subtrie or code is referenced more than once
+		if parent != nil {
+			req.parents = append(req.parents, parent)
+			parent.deps++
+		}
+
+		return
+	}
+
+	if code {
+		if _, ok := s.storage.GetCode(hash); ok {
+			return
+		}
+	} else if _, ok, _ := s.storage.Get(hash.Bytes()); ok {
+		return
+	}
+
+	req := &syncRequest{hash: hash, code: code, isStorage: isStorage}
+
+	if parent != nil {
+		req.parents = append(req.parents, parent)
+		parent.deps++
+	}
+
+	s.requests[hash] = req
+	s.queue = append(s.queue, req)
+}
+
+// scheduleChildren schedules the hash referenced children of the trie node,
+// together with the storage tries and the code of the accounts
+func (s *StateSync) scheduleChildren(req *syncRequest, node Node) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+
+	case *FullNode:
+		for _, child := range n.children {
+			if child == nil {
+				continue
+			}
+
+			if err := s.scheduleChildren(req, child); err != nil {
+				return err
+			}
+		}
+
+		if n.value != nil {
+			return s.scheduleChildren(req, n.value)
+		}
+
+	case *ShortNode:
+		return s.scheduleChildren(req, n.child)
+
+	case *ValueNode:
+		if n.hash {
+			s.schedule(req, types.BytesToHash(n.buf), false, req.isStorage)
+
+			return nil
+		}
+
+		if req.isStorage {
+			return nil
+		}
+
+		var account state.Account
+		if err := account.UnmarshalRlp(n.buf); err != nil {
+			return fmt.Errorf("can't parse account: %w", err)
+		}
+
+		if account.Root != types.EmptyRootHash && account.Root != types.ZeroHash {
+			s.schedule(req, account.Root, false, true)
+		}
+
+		if codeHash := types.BytesToHash(account.CodeHash); codeHash != types.EmptyCodeHash &&
+			codeHash != types.ZeroHash {
+			s.schedule(req, codeHash, true, false)
+		}
+	}
+
+	return nil
+}
+
+// write puts the item in the batch and then the parents which are no longer waiting for children
+func (s *StateSync) write(req *syncRequest) error {
+	if req.code {
+		s.batch.Put(GetCodeKey(req.hash), req.data)
+	} else {
+		s.batch.Put(req.hash.Bytes(), req.data)
+	}
+
+	delete(s.requests, req.hash)
+	s.batchSize++
+
+	for _, parent := range req.parents {
+		parent.deps--
+
+		if parent.deps == 0 && parent.data != nil {
+			if err := s.write(parent); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.batchSize >= stateSyncBatchSize {
+		return s.Commit()
+	}
+
+	return nil
+}
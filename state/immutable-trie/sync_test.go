@@ -0,0 +1,167 @@
+package itrie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/crypto"
+	"github.com/0xPolygon/polygon-edge/state"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// syncTestCode is the contract code shared by the accounts of the synced state
+var syncTestCode = []byte{0x60, 0x01, 0x60, 0x02, 0x01}
+
+// newSyncSourceState creates the state of accounts holding storage slots, two of them sharing the same code
+func newSyncSourceState(t *testing.T) (*State, types.Hash) {
+	t.Helper()
+
+	st := NewState(NewMemoryStorage())
+	addrs := testAddresses(50)
+
+	changes := make([]testAccountChange, 0, len(addrs))
+	for i, addr := range addrs {
+		change := testAccountChange{addr: addr, balance: uint64(i + 1), slots: map[uint64]uint64{}}
+
+		for slot := uint64(1); slot <= uint64(i%5)*10; slot++ {
+			change.slots[slot] = slot * uint64(i+1)
+		}
+
+		changes = append(changes, change)
+	}
+
+	root := commitChanges(t, st, types.EmptyRootHash, changes...)
+
+	snap, err := st.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	objs := make([]*state.Object, 0, 2)
+
+	for _, addr := range addrs[:2] {
+		account, err := snap.GetAccount(addr)
+		require.NoError(t, err)
+
+		objs = append(objs, &state.Object{
+			Address:   addr,
+			Balance:   account.Balance,
+			Root:      account.Root,
+			CodeHash:  types.BytesToHash(crypto.Keccak256(syncTestCode)),
+			Code:      syncTestCode,
+			DirtyCode: true,
+		})
+	}
+
+	_, rootBytes, err := snap.Commit(objs)
+	require.NoError(t, err)
+
+	return st, types.BytesToHash(rootBytes)
+}
+
+// serveStateSync delivers up to limit missing items from the source storage
+func serveStateSync(t *testing.T, sync *StateSync, source Storage, limit int) {
+	t.Helper()
+
+	nodes, codes := sync.Missing(limit)
+
+	for _, hash := range nodes {
+		data, ok, err := source.Get(hash.Bytes())
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, sync.ProcessNode(data))
+	}
+
+	for _, hash := range codes {
+		code, ok := source.GetCode(hash)
+		require.True(t, ok)
+		require.NoError(t, sync.ProcessCode(code))
+	}
+}
+
+func TestStateSync(t *testing.T) {
+	t.Parallel()
+
+	source, root := newSyncSourceState(t)
+	storage := NewMemoryStorage()
+
+	// the sync is interrupted before completion, without committing the last batch
+	sync := NewStateSync(storage, root)
+	serveStateSync(t, sync, source.storage, 20)
+	require.NoError(t, sync.Commit())
+	serveStateSync(t, sync, source.storage, 20)
+	require.NotZero(t, sync.Pending())
+
+	// the restarted sync skips the complete subtries written before the interruption
+	sync = NewStateSync(storage, root)
+	for sync.Pending() > 0 {
+		serveStateSync(t, sync, source.storage, 16)
+	}
+
+	require.NoError(t, sync.Commit())
+
+	synced := NewState(storage)
+	requireSameSyncedState(t, source, synced, root)
+
+	// nothing is missing once the state is synced
+	require.Zero(t, NewStateSync(storage, root).Pending())
+}
+
+func TestStateSync_InvalidItem(t *testing.T) {
+	t.Parallel()
+
+	source, root := newSyncSourceState(t)
+	sync := NewStateSync(NewMemoryStorage(), root)
+
+	nodes, _ := sync.Missing(1)
+	require.Equal(t, []types.Hash{root}, nodes)
+
+	// the item which doesn't match any requested hash is rejected
+	require.ErrorIs(t, sync.ProcessNode([]byte{0xc0}), errUnrequestedStateItem)
+	require.ErrorIs(t, sync.ProcessCode([]byte{0x01}), errUnrequestedStateItem)
+
+	// the undelivered items are requested again
+	sync.Retry(nodes)
+
+	retried, _ := sync.Missing(1)
+	require.Equal(t, nodes, retried)
+
+	data, ok, err := source.storage.Get(root.Bytes())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, sync.ProcessNode(data))
+
+	// the same item is not accepted twice
+	require.ErrorIs(t, sync.ProcessNode(data), errUnrequestedStateItem)
+}
+
+func requireSameSyncedState(t *testing.T, source, synced *State, root types.Hash) {
+	t.Helper()
+
+	expected, err := source.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	actual, err := synced.NewSnapshotAt(root)
+	require.NoError(t, err)
+
+	for _, addr := range testAddresses(50) {
+		expectedAccount, err := expected.GetAccount(addr)
+		require.NoError(t, err)
+
+		actualAccount, err := actual.GetAccount(addr)
+		require.NoError(t, err)
+		require.Equal(t, expectedAccount, actualAccount)
+
+		for slot := uint64(1); slot <= 40; slot++ {
+			require.Equal(t,
+				expected.GetStorage(addr, expectedAccount.Root, slotKey(slot)),
+				actual.GetStorage(addr, actualAccount.Root, slotKey(slot)),
+			)
+		}
+
+		if codeHash := types.BytesToHash(expectedAccount.CodeHash); codeHash != types.EmptyCodeHash {
+			code, ok := synced.GetCode(codeHash)
+			require.True(t, ok)
+			require.Equal(t, syncTestCode, code)
+		}
+	}
+}
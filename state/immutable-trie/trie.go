@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/umbracle/fastrlp"
 	"golang.org/x/crypto/sha3"
 
@@ -100,7 +101,13 @@ func NewTrie() *Trie {
 }
 
 func (t *Trie) Get(k []byte, storage Storage) ([]byte, bool) {
+	return t.get(k, storage, nil)
+}
+
+// get looks up the key, resolving the stored nodes through the nodes cache
+func (t *Trie) get(k []byte, storage Storage, nodes *lru.Cache) ([]byte, bool) {
 	txn := t.Txn(storage)
+	txn.nodes = nodes
 	res := txn.Lookup(k)
 
 	return res, res != nil
@@ -148,6 +155,9 @@ type Txn struct {
 	epoch   uint32
 	storage Storage
 	batch   Putter
+
+	// nodes caches the decoded stored nodes by their hash, if set
+	nodes *lru.Cache
 }
 
 func (t *Txn) Commit() *Trie {
@@ -155,70 +165,76 @@ func (t *Txn) Commit() *Trie {
 }
 
 func (t *Txn) Lookup(key []byte) []byte {
-	_, res := t.lookup(t.root, bytesToHexNibbles(key))
-
-	return res
+	return t.lookup(t.root, bytesToHexNibbles(key))
 }
 
-func (t *Txn) lookup(node interface{}, key []byte) (Node, []byte) {
+// lookup finds the value of the key. The stored nodes are resolved through the nodes cache,
+// and the trie is not modified, so that it can be read concurrently
+func (t *Txn) lookup(node interface{}, key []byte) []byte {
 	switch n := node.(type) {
 	case nil:
-		return nil, nil
+		return nil
 
 	case *ValueNode:
 		if n.hash {
-			nc, ok, err := GetNode(n.buf, t.storage)
+			nc, ok, err := t.getNode(n.buf)
 			if err != nil {
 				panic(err) //nolint:gocritic
 			}
 
 			if !ok {
-				return nil, nil
+				return nil
 			}
 
-			_, res := t.lookup(nc, key)
-
-			return nc, res
+			return t.lookup(nc, key)
 		}
 
 		if len(key) == 0 {
-			return nil, n.buf
+			return n.buf
 		} else {
-			return nil, nil
+			return nil
 		}
 
 	case *ShortNode:
 		plen := len(n.key)
 		if plen > len(key) || !bytes.Equal(key[:plen], n.key) {
-			return nil, nil
-		}
-
-		child, res := t.lookup(n.child, key[plen:])
-
-		if child != nil {
-			n.child = child
+			return nil
 		}
 
-		return nil, res
+		return t.lookup(n.child, key[plen:])
 
 	case *FullNode:
 		if len(key) == 0 {
 			return t.lookup(n.value, key)
 		}
 
-		child, res := t.lookup(n.getEdge(key[0]), key[1:])
-
-		if child != nil {
-			n.children[key[0]] = child
-		}
-
-		return nil, res
+		return t.lookup(n.getEdge(key[0]), key[1:])
 
 	default:
 		panic(fmt.Sprintf("unknown node type %v", n)) //nolint:gocritic
 	}
 }
 
+// getNode reads the stored node, from the nodes cache if it is set
+func (t *Txn) getNode(hash []byte) (Node, bool, error) {
+	if t.nodes == nil {
+		return GetNode(hash, t.storage)
+	}
+
+	key := types.BytesToHash(hash)
+
+	if n, ok := t.nodes.Get(key); ok {
+		return n.(Node), true, nil //nolint:forcetypeassert
+	}
+
+	n, ok, err := GetNode(hash, t.storage)
+	if ok {
+		t.nodes.Add(key, n)
+	}
+
+	return n, ok, err
+}
+
 func (t *Txn) writeNode(n *FullNode) *FullNode {
 	if t.epoch == n.epoch {
 		return n
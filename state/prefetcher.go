@@ -0,0 +1,213 @@
+package state
+
+import (
+	"runtime"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// prefetchWorkers is the number of the accounts loaded concurrently
+	prefetchWorkers = 16
+
+	// prefetchContracts is the number of the contracts whose written slots are remembered
+	prefetchContracts = 4096
+
+	// prefetchSlots is the maximum number of the slots remembered per contract
+	prefetchSlots = 64
+)
+
+// Prefetcher loads the accounts and the storage slots which the transactions are likely to touch
+// from the state snapshot in the background, so that their trie nodes are already cached when the
+// transactions are executed instead of being read from the disk one by one. The slots of a contract
+// are the ones written by the recently committed transitions. Nothing is loaded if the reads of the
+// snapshot don't go to the trie, e.g. they are served by the flat snapshot
+type Prefetcher struct {
+	slots *lru.Cache // contract address -> slots written recently
+}
+
+// NewPrefetcher creates the state prefetcher
+func NewPrefetcher() *Prefetcher {
+	slots, _ := lru.New(prefetchContracts)
+
+	return &Prefetcher{
+		slots: slots,
+	}
+}
+
+// trieReader is implemented by the snapshot which can tell if its reads go to the trie.
+// The reads of the snapshot not implementing it are assumed to go to the trie
+type trieReader interface {
+	// ReadsTrie checks if the accounts and the slots are read from the trie
+	ReadsTrie() bool
+}
+
+// prefetch starts loading the accounts and their slots, and returns the function stopping it
+func (p *Prefetcher) prefetch(snap readSnapshot, addrs []types.Address) func() {
+	var (
+		jobs   = make(chan types.Address, len(addrs))
+		stopCh = make(chan struct{})
+		wg     sync.WaitGroup
+	)
+
+	for _, addr := range addrs {
+		jobs <- addr
+	}
+
+	close(jobs)
+
+	workers := prefetchWorkers
+	if workers > len(addrs) {
+		workers = len(addrs)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for addr := range jobs {
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+
+				p.load(snap, addr)
+			}
+		}()
+	}
+
+	var stopOnce sync.Once
+
+	return func() {
+		stopOnce.Do(func() {
+			close(stopCh)
+			wg.Wait()
+		})
+	}
+}
+
+// load reads the account and its remembered slots from the snapshot
+func (p *Prefetcher) load(snap readSnapshot, addr types.Address) {
+	account, err := snap.GetAccount(addr)
+	if err != nil || account == nil || account.Root == emptyStateHash {
+		return
+	}
+
+	slots, ok := p.slots.Get(addr)
+	if !ok {
+		return
+	}
+
+	for _, slot := range slots.([]types.Hash) { //nolint:forcetypeassert
+		snap.GetStorage(addr, account.Root, slot)
+	}
+}
+
+// record remembers the slots written by the committed objects, the most recent first
+func (p *Prefetcher) record(objs []*Object) {
+	for _, obj := range objs {
+		if obj.Deleted || len(obj.Storage) == 0 {
+			continue
+		}
+
+		slots := make([]types.Hash, 0, prefetchSlots)
+		seen := make(map[types.Hash]struct{}, prefetchSlots)
+
+		add := func(slot types.Hash) {
+			if _, ok := seen[slot]; ok || len(slots) == prefetchSlots {
+				return
+			}
+
+			seen[slot] = struct{}{}
+			slots = append(slots, slot)
+		}
+
+		for _, entry := range obj.Storage {
+			add(types.BytesToHash(entry.Key))
+		}
+
+		if previous, ok := p.slots.Get(obj.Address); ok {
+			for _, slot := range previous.([]types.Hash) { //nolint:forcetypeassert
+				add(slot)
+			}
+		}
+
+		p.slots.Add(obj.Address, slots)
+	}
+}
+
+// Prefetch recovers the senders of the transactions, and starts loading the accounts of the senders,
+// the recipients and the coinbase with the recently written slots of the contracts in the background,
+// if the snapshot reads them from the trie. The returned function stops the prefetching
+func (t *Transition) Prefetch(txs []*types.Transaction) func() {
+	if t.prefetcher == nil || t.snap == nil || len(txs) == 0 {
+		return func() {}
+	}
+
+	t.recoverSenders(txs)
+
+	if reader, ok := t.snap.(trieReader); ok && !reader.ReadsTrie() {
+		return func() {}
+	}
+
+	var (
+		addrs = []types.Address{t.ctx.Coinbase}
+		seen  = map[types.Address]struct{}{t.ctx.Coinbase: {}}
+	)
+
+	add := func(addr types.Address) {
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+	}
+
+	for _, txn := range txs {
+		if txn.From != emptyFrom {
+			add(txn.From)
+		}
+
+		if txn.To != nil {
+			add(*txn.To)
+		}
+	}
+
+	return t.prefetcher.prefetch(t.snap, addrs)
+}
+
+// recoverSenders recovers the missing senders of the transactions concurrently.
+// A transaction whose sender can't be recovered fails once it is written
+func (t *Transition) recoverSenders(txs []*types.Transaction) {
+	var (
+		jobs = make(chan *types.Transaction, len(txs))
+		wg   sync.WaitGroup
+	)
+
+	for _, txn := range txs {
+		if txn.From == emptyFrom {
+			jobs <- txn
+		}
+	}
+
+	close(jobs)
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for txn := range jobs {
+				_ = t.recoverSender(txn)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,151 @@
+package state
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// recordingSnapshot records the accounts and the slots read from the snapshot
+type recordingSnapshot struct {
+	mockSnapshot
+
+	lock     sync.Mutex
+	accounts map[types.Address]struct{}
+	slots    map[types.Hash]struct{}
+}
+
+func (r *recordingSnapshot) GetAccount(addr types.Address) (*Account, error) {
+	r.lock.Lock()
+	r.accounts[addr] = struct{}{}
+	r.lock.Unlock()
+
+	return r.mockSnapshot.GetAccount(addr)
+}
+
+func (r *recordingSnapshot) GetStorage(addr types.Address, root types.Hash, key types.Hash) types.Hash {
+	r.lock.Lock()
+	r.slots[key] = struct{}{}
+	r.lock.Unlock()
+
+	return r.mockSnapshot.GetStorage(addr, root, key)
+}
+
+func TestPrefetcher_Record(t *testing.T) {
+	t.Parallel()
+
+	p := NewPrefetcher()
+
+	storage := func(from, to int) []*StorageObject {
+		objs := []*StorageObject{}
+		for i := from; i < to; i++ {
+			objs = append(objs, &StorageObject{Key: types.BytesToHash([]byte{byte(i)}).Bytes()})
+		}
+
+		return objs
+	}
+
+	p.record([]*Object{
+		{Address: addr1, Storage: storage(0, 10)},
+		{Address: addr2, Storage: storage(0, 10), Deleted: true},
+	})
+	p.record([]*Object{{Address: addr1, Storage: storage(5, prefetchSlots+2)}})
+
+	cached, ok := p.slots.Get(addr1)
+	require.True(t, ok)
+
+	slots, _ := cached.([]types.Hash)
+	require.Len(t, slots, prefetchSlots)
+
+	// the slots written by the latest commit come first, the older ones are kept until the limit
+	require.Equal(t, types.BytesToHash([]byte{5}), slots[0])
+	require.Contains(t, slots, types.BytesToHash([]byte{0}))
+	require.NotContains(t, slots, types.BytesToHash([]byte{4}))
+
+	_, ok = p.slots.Get(addr2)
+	require.False(t, ok)
+}
+
+func TestTransition_Prefetch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coinbase = types.StringToAddress("c0")
+		sender   = types.StringToAddress("5e")
+		contract = types.StringToAddress("c7")
+	)
+
+	snap := &recordingSnapshot{
+		mockSnapshot: mockSnapshot{state: map[types.Address]*PreState{
+			sender:   {Balance: 1},
+			contract: {State: map[types.Hash]types.Hash{hash1: hash1}},
+		}},
+		accounts: map[types.Address]struct{}{},
+		slots:    map[types.Hash]struct{}{},
+	}
+
+	prefetcher := NewPrefetcher()
+	prefetcher.record([]*Object{{Address: contract, Storage: []*StorageObject{{Key: hash1.Bytes()}}}})
+
+	transition := NewTransition(chain.ForksInTime{}, snap, newTxn(snap))
+	transition.ctx.Coinbase = coinbase
+	transition.prefetcher = prefetcher
+
+	stop := transition.Prefetch([]*types.Transaction{
+		{From: sender, To: &contract},
+		{From: sender},
+	})
+
+	require.Eventually(t, func() bool {
+		snap.lock.Lock()
+		defer snap.lock.Unlock()
+
+		return len(snap.accounts) == 3 && len(snap.slots) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	stop()
+	stop()
+
+	require.Contains(t, snap.accounts, coinbase)
+	require.Contains(t, snap.accounts, sender)
+	require.Contains(t, snap.slots, hash1)
+
+	// the transitions without the prefetcher don't prefetch
+	require.NotPanics(t, NewTransition(chain.ForksInTime{}, snap, newTxn(snap)).Prefetch(nil))
+}
+
+// flatSnapshot is the snapshot whose reads don't go to the trie
+type flatSnapshot struct {
+	recordingSnapshot
+}
+
+func (f *flatSnapshot) ReadsTrie() bool {
+	return false
+}
+
+func TestTransition_PrefetchFlat(t *testing.T) {
+	t.Parallel()
+
+	sender := types.StringToAddress("5e")
+
+	snap := &flatSnapshot{
+		recordingSnapshot: recordingSnapshot{
+			mockSnapshot: mockSnapshot{state: map[types.Address]*PreState{}},
+			accounts:     map[types.Address]struct{}{},
+			slots:        map[types.Hash]struct{}{},
+		},
+	}
+
+	transition := NewTransition(chain.ForksInTime{}, snap, newTxn(snap))
+	transition.prefetcher = NewPrefetcher()
+
+	transition.Prefetch([]*types.Transaction{{From: sender, To: &sender}})()
+
+	// the accounts are not loaded, the reads don't go to the trie
+	require.Empty(t, snap.accounts)
+}
@@ -5,19 +5,32 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
 
 	"github.com/0xPolygon/polygon-edge/chain"
+	hexHelper "github.com/0xPolygon/polygon-edge/helper/hex"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer"
 	"github.com/0xPolygon/polygon-edge/types"
-	"github.com/umbracle/ethgo"
-	"github.com/umbracle/ethgo/abi"
 )
 
 //go:embed console.sol
 var consoleContract string
 
-var logOverloads = map[string]*abi.Type{}
+var (
+	logOverloads = map[string]*abi.Type{}
+
+	// sizedIntRegex matches the 'int' and 'uint' types without the size
+	sizedIntRegex = regexp.MustCompile(`\b(u?int)\b`)
+)
 
 func init() {
 	rxp := regexp.MustCompile("abi.encodeWithSignature\\(\"log(.*)\"")
@@ -33,42 +46,166 @@ func init() {
 			log.Fatal(fmt.Errorf("BUG: Failed to parse %s", signature))
 		}
 
-		// signature of the call. Use the version without the bytes in 'uint'.
-		sig := ethgo.Keccak256([]byte("log" + match[1]))[:4]
+		// signature of the call. The console.sol of this repository uses the version without
+		// the bytes in 'uint', while the recent Hardhat versions use 'uint256', so both are accepted
+		sig := ethgo.Keccak256([]byte("log" + signature))[:4]
 		logOverloads[hex.EncodeToString(sig)] = typ
+
+		sizedSig := ethgo.Keccak256([]byte("log" + sizedIntRegex.ReplaceAllString(signature, "${1}256")))[:4]
+		logOverloads[hex.EncodeToString(sizedSig)] = typ
 	}
 }
 
-func decodeConsole(input []byte) (val []string) {
-	sig := hex.EncodeToString(input[:4])
-	logSig, ok := logOverloads[sig]
+// decodeConsole decodes the arguments of the console.log call in their order.
+// Returns false if the input is not a known console.log call
+func decodeConsole(input []byte) ([]interface{}, bool) {
+	if len(input) < 4 {
+		return nil, false
+	}
 
+	logSig, ok := logOverloads[hex.EncodeToString(input[:4])]
 	if !ok {
-		return
+		return nil, false
 	}
 
-	input = input[4:]
-	raw, err := logSig.Decode(input)
+	// console.log() without arguments
+	if len(logSig.TupleElems()) == 0 {
+		return []interface{}{}, true
+	}
 
+	raw, err := logSig.Decode(input[4:])
 	if err != nil {
-		return
+		return nil, false
 	}
 
 	valuesMap, ok := raw.(map[string]interface{})
 	if !ok {
-		return
+		return nil, false
+	}
+
+	// the tuple elements are named by their index
+	keys := make([]string, 0, len(valuesMap))
+	for k := range valuesMap {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, _ := strconv.Atoi(keys[i])
+		b, _ := strconv.Atoi(keys[j])
+
+		return a < b
+	})
+
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		values[i] = valuesMap[k]
+	}
+
+	return values, true
+}
+
+// formatConsoleValue returns the readable representation of a decoded argument
+func formatConsoleValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return hexHelper.EncodeToHex(v)
+	case ethgo.Address:
+		return types.Address(v).String()
+	}
+
+	// fixed size byte arrays
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		buf := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(buf), rv)
+
+		return hexHelper.EncodeToHex(buf)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// formatConsole joins the arguments the way Hardhat does. The format specifiers
+// of the first string argument are replaced by the following arguments
+// and the remaining ones are appended, separated by a space
+func formatConsole(args []interface{}) string {
+	if len(args) == 0 {
+		return ""
 	}
 
-	val = []string{}
-	for _, v := range valuesMap {
-		val = append(val, fmt.Sprint(v))
+	parts := make([]string, 0, len(args))
+	next := 1
+
+	if format, ok := args[0].(string); ok {
+		var sb strings.Builder
+
+		for i := 0; i < len(format); i++ {
+			if format[i] != '%' || i+1 == len(format) {
+				sb.WriteByte(format[i])
+
+				continue
+			}
+
+			switch format[i+1] {
+			case '%':
+				sb.WriteByte('%')
+			case 's', 'd', 'i', 'o', 'O':
+				if next >= len(args) {
+					sb.WriteString(format[i : i+2])
+
+					break
+				}
+
+				sb.WriteString(formatConsoleValue(args[next]))
+				next++
+			default:
+				sb.WriteByte(format[i])
+
+				continue
+			}
+
+			i++
+		}
+
+		parts = append(parts, sb.String())
+	} else {
+		next = 0
+	}
+
+	for _, arg := range args[next:] {
+		parts = append(parts, formatConsoleValue(arg))
 	}
 
-	return
+	return strings.Join(parts, " ")
+}
+
+// console is a debug precompile contract that simulates the `console.sol` functionality.
+// The messages are printed to the logger if it is set and added to the trace of the transaction
+type console struct {
+	logger hclog.Logger
+
+	// buffered keeps the messages instead of printing them
+	buffered bool
+	messages []string
 }
 
-// console is a debug precompile contract that simulates the `console.sol` functionality
-type console struct{}
+// print prints the messages to the logger, or keeps them if the console is buffered
+func (c *console) print(messages ...string) {
+	if c.logger == nil {
+		return
+	}
+
+	if c.buffered {
+		c.messages = append(c.messages, messages...)
+
+		return
+	}
+
+	for _, message := range messages {
+		c.logger.Info(message)
+	}
+}
 
 // RequiredGas returns the gas required to execute the pre-compiled contract
 func (c *console) gas(_ []byte, _ *chain.ForksInTime) uint64 {
@@ -76,8 +213,19 @@ func (c *console) gas(_ []byte, _ *chain.ForksInTime) uint64 {
 }
 
 // Run contains the implementation logic of the precompiled contract
-func (c *console) run(input []byte, _ types.Address, _ runtime.Host) ([]byte, error) {
-	fmt.Printf("Console: %v\n", decodeConsole(input))
+func (c *console) run(input []byte, _ types.Address, host runtime.Host) ([]byte, error) {
+	args, ok := decodeConsole(input)
+	if !ok {
+		return nil, nil
+	}
+
+	message := formatConsole(args)
+
+	c.print(message)
+
+	if consoleTracer, ok := host.GetTracer().(tracer.ConsoleTracer); ok {
+		consoleTracer.ConsoleLog(message)
+	}
 
 	return nil, nil
 }
@@ -0,0 +1,171 @@
+package precompiled
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/ethgo"
+	"github.com/umbracle/ethgo/abi"
+
+	"github.com/0xPolygon/polygon-edge/contracts"
+	"github.com/0xPolygon/polygon-edge/state/runtime"
+	"github.com/0xPolygon/polygon-edge/state/runtime/tracer/structtracer"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// tracingHost is a host with a tracer, used by the console precompile
+type tracingHost struct {
+	dummyHost
+
+	tracer *structtracer.StructTracer
+}
+
+func (h *tracingHost) GetTracer() runtime.VMTracer {
+	return h.tracer
+}
+
+func encodeConsoleCall(t *testing.T, signature string, args ...interface{}) []byte {
+	t.Helper()
+
+	input := ethgo.Keccak256([]byte("log" + signature))[:4]
+
+	encoded, err := abi.MustNewType("tuple" + signature).Encode(args)
+	require.NoError(t, err)
+
+	return append(input, encoded...)
+}
+
+func TestConsole_Decode(t *testing.T) {
+	t.Parallel()
+
+	addr := types.StringToAddress("0x61324166B0202DB1E7502924326262274Fa4358F")
+
+	cases := []struct {
+		name      string
+		signature string
+		args      []interface{}
+		expected  string
+	}{
+		{"no arguments", "()", nil, ""},
+		{"string", "(string)", []interface{}{"hello"}, "hello"},
+		{"legacy uint", "(uint)", []interface{}{big.NewInt(42)}, "42"},
+		{"sized uint", "(uint256)", []interface{}{big.NewInt(42)}, "42"},
+		{"sized int", "(int256)", []interface{}{big.NewInt(-7)}, "-7"},
+		{"bool and address", "(bool,address)", []interface{}{true, addr}, "true " + addr.String()},
+		{"bytes", "(bytes)", []interface{}{[]byte{0x1, 0x2}}, "0x0102"},
+		{"fixed bytes", "(bytes2)", []interface{}{[2]byte{0xab, 0xcd}}, "0xabcd"},
+		{
+			"format specifiers",
+			"(string,uint256,address)",
+			[]interface{}{"sent %d to %s (100%%)", big.NewInt(5), addr},
+			"sent 5 to " + addr.String() + " (100%)",
+		},
+		{
+			"format without arguments",
+			"(string,string)",
+			[]interface{}{"%s and %s", "one"},
+			"one and %s",
+		},
+		{
+			"arguments after the format",
+			"(string,string,bool)",
+			[]interface{}{"%s", "one", false},
+			"one false",
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			args, ok := decodeConsole(encodeConsoleCall(t, c.signature, c.args...))
+			require.True(t, ok)
+			require.Equal(t, c.expected, formatConsole(args))
+		})
+	}
+
+	t.Run("unknown call", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := decodeConsole([]byte{0x1, 0x2, 0x3, 0x4})
+		require.False(t, ok)
+
+		_, ok = decodeConsole([]byte{0x1})
+		require.False(t, ok)
+	})
+}
+
+func TestConsole_Run(t *testing.T) {
+	t.Parallel()
+
+	var output bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{Output: &output})
+
+	p := NewPrecompiled()
+	require.NotContains(t, p.contracts, contracts.ConsolePrecompile)
+
+	p.EnableConsole(logger)
+
+	host := &tracingHost{
+		dummyHost: *newDummyHost(t),
+		tracer:    structtracer.NewStructTracer(structtracer.Config{}),
+	}
+
+	contract := &runtime.Contract{
+		CodeAddress: contracts.ConsolePrecompile,
+		Input:       encodeConsoleCall(t, "(string,uint256)", "balance", big.NewInt(100)),
+		Gas:         1000,
+	}
+
+	require.True(t, p.CanRun(contract, host, nil))
+
+	result := p.Run(contract, host, nil)
+	require.NoError(t, result.Err)
+	require.Equal(t, uint64(1000), result.GasLeft)
+	require.Contains(t, output.String(), "balance 100")
+
+	trace, err := host.tracer.GetResult()
+	require.NoError(t, err)
+	require.Equal(t, []string{"balance 100"}, trace.(*structtracer.StructTraceResult).ConsoleLogs)
+
+	// the silenced console is traced only
+	output.Reset()
+
+	copied := p.Copy()
+	copied.SilenceConsole()
+
+	result = copied.Run(contract, host, nil)
+	require.NoError(t, result.Err)
+	require.Empty(t, output.String())
+
+	trace, err = host.tracer.GetResult()
+	require.NoError(t, err)
+	require.Len(t, trace.(*structtracer.StructTraceResult).ConsoleLogs, 2)
+
+	// the original runtime keeps printing the messages
+	p.Run(contract, host, nil)
+	require.Contains(t, output.String(), "balance 100")
+
+	// the buffered console keeps the messages until they are printed by another runtime
+	output.Reset()
+
+	buffered := p.Copy()
+	buffered.BufferConsole()
+
+	result = buffered.Run(contract, host, nil)
+	require.NoError(t, result.Err)
+	require.Empty(t, output.String())
+	require.Equal(t, []string{"balance 100"}, buffered.ConsoleMessages())
+
+	copied.PrintConsole(buffered.ConsoleMessages())
+	require.Empty(t, output.String())
+
+	p.PrintConsole(buffered.ConsoleMessages())
+	require.Contains(t, output.String(), "balance 100")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/0xPolygon/polygon-edge/helper/common"
 	"github.com/0xPolygon/polygon-edge/state/runtime"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/ethgo/abi"
 )
 
@@ -73,13 +74,58 @@ func (p *Precompiled) setupContracts() {
 	// Native transfer precompile
 	p.register(contracts.NativeTransferPrecompile.String(), &nativeTransfer{})
 
-	// Console precompile
-	// p.register(contracts.ConsolePrecompile.String(), &console{})
-
 	// BLS aggregated signatures verification precompile
 	p.register(contracts.BLSAggSigsVerificationPrecompile.String(), &blsAggSignsVerification{})
 }
 
+// EnableConsole registers the Hardhat console.log precompile. The messages are printed
+// to the logger if it is set and added to the trace of the transaction
+func (p *Precompiled) EnableConsole(logger hclog.Logger) {
+	p.register(contracts.ConsolePrecompile.String(), &console{logger: logger})
+}
+
+// SilenceConsole stops printing the console.log messages to the logger, they are still traced
+func (p *Precompiled) SilenceConsole() {
+	if console, ok := p.contracts[contracts.ConsolePrecompile].(*console); ok {
+		console.logger = nil
+	}
+}
+
+// BufferConsole keeps the console.log messages instead of printing them to the logger,
+// for the executions which may be discarded. The kept messages are returned by ConsoleMessages
+func (p *Precompiled) BufferConsole() {
+	if console, ok := p.contracts[contracts.ConsolePrecompile].(*console); ok {
+		console.buffered = true
+	}
+}
+
+// ConsoleMessages returns the console.log messages kept since BufferConsole
+func (p *Precompiled) ConsoleMessages() []string {
+	if console, ok := p.contracts[contracts.ConsolePrecompile].(*console); ok {
+		return console.messages
+	}
+
+	return nil
+}
+
+// PrintConsole prints the console.log messages kept by another runtime, unless the console is silenced
+func (p *Precompiled) PrintConsole(messages []string) {
+	if console, ok := p.contracts[contracts.ConsolePrecompile].(*console); ok {
+		console.print(messages...)
+	}
+}
+
+// Copy returns a new runtime with the same precompiled contracts
+func (p *Precompiled) Copy() *Precompiled {
+	c := NewPrecompiled()
+
+	if console, ok := p.contracts[contracts.ConsolePrecompile].(*console); ok {
+		c.EnableConsole(console.logger)
+	}
+
+	return c
+}
+
 func (p *Precompiled) register(addrStr string, b contract) {
 	if len(p.contracts) == 0 {
 		p.contracts = map[types.Address]contract{}
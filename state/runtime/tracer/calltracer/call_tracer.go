@@ -31,6 +31,9 @@ type Call struct {
 	Output  string  `json:"output"`
 	Calls   []*Call `json:"calls,omitempty"`
 
+	// ConsoleLogs are the messages of the console.log precompile, set on its call
+	ConsoleLogs []string `json:"consoleLogs,omitempty"`
+
 	parent   *Call
 	startGas uint64
 }
@@ -143,6 +146,15 @@ func (c *CallTracer) CallEnd(depth int, output []byte, err error) {
 	}
 }
 
+// ConsoleLog records a message of the console.log precompile
+func (c *CallTracer) ConsoleLog(message string) {
+	if c.activeCall == nil {
+		return
+	}
+
+	c.activeCall.ConsoleLogs = append(c.activeCall.ConsoleLogs, message)
+}
+
 func (c *CallTracer) CaptureState(memory []byte, stack []*big.Int, opCode int,
 	contractAddress types.Address, sp int, host tracer.RuntimeHost, state tracer.VMState) {
 	if c.cancelled() {
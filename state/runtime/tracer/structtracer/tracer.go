@@ -51,6 +51,8 @@ type StructTracer struct {
 	storage       []map[types.Address]map[types.Hash]types.Hash
 	currentMemory [][]byte
 	currentStack  [][]*big.Int
+
+	consoleLogs []string
 }
 
 func NewStructTracer(config Config) *StructTracer {
@@ -96,6 +98,7 @@ func (t *StructTracer) Clear() {
 	}
 	t.currentMemory = make([][]byte, 1)
 	t.currentStack = make([][]*big.Int, 1)
+	t.consoleLogs = nil
 }
 
 func (t *StructTracer) TxStart(gasLimit uint64) {
@@ -331,6 +334,12 @@ type StructTraceResult struct {
 	Gas         uint64      `json:"gas"`
 	ReturnValue string      `json:"returnValue"`
 	StructLogs  []StructLog `json:"structLogs"`
+	ConsoleLogs []string    `json:"consoleLogs,omitempty"`
+}
+
+// ConsoleLog records a message of the console.log precompile
+func (t *StructTracer) ConsoleLog(message string) {
+	t.consoleLogs = append(t.consoleLogs, message)
 }
 
 func (t *StructTracer) GetResult() (interface{}, error) {
@@ -354,5 +363,6 @@ func (t *StructTracer) GetResult() (interface{}, error) {
 		Gas:         t.consumedGas,
 		ReturnValue: returnValue,
 		StructLogs:  t.logs,
+		ConsoleLogs: t.consoleLogs,
 	}, nil
 }
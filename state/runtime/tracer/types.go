@@ -68,3 +68,10 @@ type Tracer interface {
 		host RuntimeHost,
 	)
 }
+
+// ConsoleTracer is implemented by the tracers collecting
+// the messages of the console.log precompile
+type ConsoleTracer interface {
+	// ConsoleLog records a formatted console.log message
+	ConsoleLog(message string)
+}
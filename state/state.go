@@ -2,8 +2,10 @@ package state
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	iradix "github.com/hashicorp/go-immutable-radix"
 	"github.com/umbracle/fastrlp"
@@ -11,6 +13,13 @@ import (
 	"github.com/0xPolygon/polygon-edge/types"
 )
 
+// ErrStatePruned is returned when the requested state is no longer available
+// because the node keeps only the recent state (the full state scheme)
+var ErrStatePruned = errors.New("historical state is not available, it has been pruned")
+
+// ErrStateUnavailable is returned when the state read by the execution could not be read
+var ErrStateUnavailable = errors.New("state is not available")
+
 type State interface {
 	NewSnapshotAt(types.Hash) (Snapshot, error)
 	NewSnapshot() Snapshot
@@ -23,6 +32,16 @@ type Snapshot interface {
 	Commit(objs []*Object) (Snapshot, []byte, error)
 }
 
+// FailingSnapshot is a snapshot whose reads can fail, like the state read from a remote node.
+// The reads of the accounts and the storage slots by the transactions don't return their errors,
+// so the snapshot keeps the first one, which fails the execution and the commit
+type FailingSnapshot interface {
+	Snapshot
+
+	// Err returns the first error of the reads of the snapshot
+	Err() error
+}
+
 // Account is the account reference in the ethereum state
 type Account struct {
 	Nonce    uint64
@@ -121,6 +140,10 @@ func (s *StateObject) Empty() bool {
 		bytes.Equal(s.Account.CodeHash, types.EmptyCodeHash.Bytes())
 }
 
+// storageCopyLock guards the copies of the storage radix, since committing it resets
+// its internal cache, and the objects are shared by the transactions executed in parallel
+var storageCopyLock sync.Mutex
+
 // Copy makes a copy of the state object
 func (s *StateObject) Copy() *StateObject {
 	ss := new(StateObject)
@@ -135,7 +158,9 @@ func (s *StateObject) Copy() *StateObject {
 	ss.withFakeStorage = s.withFakeStorage
 
 	if s.Txn != nil {
+		storageCopyLock.Lock()
 		ss.Txn = s.Txn.CommitOnly().Txn()
+		storageCopyLock.Unlock()
 	}
 
 	return ss
@@ -36,6 +36,34 @@ type Txn struct {
 	snapshots []*iradix.Tree
 	txn       *iradix.Txn
 	codeCache *lru.Cache
+
+	// access records the accounts read and written, if set
+	access *accessSet
+}
+
+// accessSet is the set of the accounts read and written by the transactions
+// executed in parallel, which are used to detect the conflicts between them
+type accessSet struct {
+	reads  map[types.Address]struct{}
+	writes map[types.Address]struct{}
+}
+
+func newAccessSet() *accessSet {
+	return &accessSet{
+		reads:  map[types.Address]struct{}{},
+		writes: map[types.Address]struct{}{},
+	}
+}
+
+// conflicts returns true if any of the accounts read in the set has been written in the other set
+func (a *accessSet) conflicts(written *accessSet) bool {
+	for addr := range a.reads {
+		if _, ok := written.writes[addr]; ok {
+			return true
+		}
+	}
+
+	return false
 }
 
 func NewTxn(snapshot Snapshot) *Txn {
@@ -92,6 +120,10 @@ func (txn *Txn) GetAccount(addr types.Address) (*Account, bool) {
 }
 
 func (txn *Txn) getStateObject(addr types.Address) (*StateObject, bool) {
+	if txn.access != nil {
+		txn.access.reads[addr] = struct{}{}
+	}
+
 	// Try to get state from radix tree which holds transient states during block processing first
 	val, exists := txn.txn.Get(addr.Bytes())
 	if exists {
@@ -135,8 +167,17 @@ func (txn *Txn) upsertAccount(addr types.Address, create bool, f func(object *St
 	f(object)
 
 	if object != nil {
-		txn.txn.Insert(addr.Bytes(), object)
+		txn.insertObject(addr, object)
+	}
+}
+
+// insertObject writes the account to the radix
+func (txn *Txn) insertObject(addr types.Address, object *StateObject) {
+	if txn.access != nil {
+		txn.access.writes[addr] = struct{}{}
 	}
+
+	txn.txn.Insert(addr.Bytes(), object)
 }
 
 func (txn *Txn) AddSealingReward(addr types.Address, balance *big.Int) {
@@ -391,17 +432,19 @@ func (txn *Txn) GetCode(addr types.Address) []byte {
 	if object.DirtyCode {
 		return object.Code
 	}
-	//nolint:godox
-	// TODO; Should we move this to state? (to be fixed in EVM-527)
-	v, ok := txn.codeCache.Get(addr)
 
+	// the code is cached by its hash, since the code of an address changes
+	// if the account is destroyed and created again
+	codeHash := types.BytesToHash(object.Account.CodeHash)
+
+	v, ok := txn.codeCache.Get(codeHash)
 	if ok {
 		//nolint:forcetypeassert
 		return v.([]byte)
 	}
 
-	code, _ := txn.snapshot.GetCode(types.BytesToHash(object.Account.CodeHash))
-	txn.codeCache.Add(addr, code)
+	code, _ := txn.snapshot.GetCode(codeHash)
+	txn.codeCache.Add(codeHash, code)
 
 	return code
 }
@@ -544,7 +587,7 @@ func (txn *Txn) CreateAccount(addr types.Address) {
 		obj.Account.Balance.SetBytes(prev.Account.Balance.Bytes())
 	}
 
-	txn.txn.Insert(addr.Bytes(), obj)
+	txn.insertObject(addr, obj)
 }
 
 func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
@@ -573,9 +616,13 @@ func (txn *Txn) CleanDeleteObjects(deleteEmptyObjects bool) error {
 			return errors.New("found object is not of StateObject type")
 		}
 
+		if obj.Deleted {
+			continue
+		}
+
 		obj2 := obj.Copy()
 		obj2.Deleted = true
-		txn.txn.Insert(k, obj2)
+		txn.insertObject(types.BytesToAddress(k), obj2)
 	}
 
 	// delete refunds
@@ -24,6 +24,7 @@ const (
 	SyncPeerClientLoggerName = "sync-peer-client"
 	statusTopicName          = "syncer/status/0.1"
 	defaultTimeoutForStatus  = 10 * time.Second
+	defaultTimeoutForHeaders = 30 * time.Second
 )
 
 type syncPeerClient struct {
@@ -347,6 +348,94 @@ func (m *syncPeerClient) GetBlocks(
 	return blockCh, nil
 }
 
+// GetHeaders returns the headers of the given range the peer has, in ascending order.
+// The peer may return fewer headers than requested
+func (m *syncPeerClient) GetHeaders(peerID peer.ID, from, to uint64) ([]*types.Header, error) {
+	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	defer conn.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), defaultTimeoutForHeaders)
+	defer cancel()
+
+	resp, err := proto.NewSyncPeerClient(conn).GetHeaders(timeoutCtx, &proto.GetHeadersRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*types.Header, len(resp.Headers))
+
+	for i, raw := range resp.Headers {
+		headers[i] = &types.Header{}
+		if err := headers[i].UnmarshalRLP(raw); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_message"}, 1)
+
+			return nil, err
+		}
+	}
+
+	return headers, nil
+}
+
+// GetBlockRange returns the blocks of the given range the peer sends,
+// until the range completes or a block doesn't arrive within the timeout
+func (m *syncPeerClient) GetBlockRange(
+	peerID peer.ID,
+	from, to uint64,
+	timeoutPerBlock time.Duration,
+) ([]*types.Block, error) {
+	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := proto.NewSyncPeerClient(conn).GetBlocks(ctx, &proto.GetBlocksRequest{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GetBlocks stream: %w", err)
+	}
+
+	streamBlockCh, streamErrorCh := blockStreamToChannel(stream)
+
+	defer func() {
+		cancel()
+
+		for range streamBlockCh {
+			// unblock the stream reader, it stops once the stream is canceled
+		}
+	}()
+
+	blocks := make([]*types.Block, 0, to-from+1)
+
+	for {
+		select {
+		case block, ok := <-streamBlockCh:
+			if !ok {
+				return blocks, nil
+			}
+
+			blocks = append(blocks, block)
+		case err := <-streamErrorCh:
+			return blocks, err
+		case <-time.After(timeoutPerBlock):
+			return blocks, errTimeout
+		}
+	}
+}
+
 // newSyncPeerClient creates gRPC client
 func (m *syncPeerClient) newSyncPeerClient(peerID peer.ID) (proto.SyncPeerClient, error) {
 	conn, err := m.network.NewProtoConnection(syncerProto, peerID)
@@ -527,6 +527,53 @@ func Test_syncPeerClient_GetBlocks(t *testing.T) {
 	assert.Equal(t, expected, blocks)
 }
 
+func Test_syncPeerClient_GetHeadersAndBlockRange(t *testing.T) {
+	t.Parallel()
+
+	clientSrv := newTestNetwork(t)
+	client := newTestSyncPeerClient(clientSrv, nil)
+
+	peerLatest := uint64(10)
+
+	_, peerSrv := createTestSyncerService(t, &mockBlockchain{
+		headerHandler: newSimpleHeaderHandler(peerLatest),
+		getHeaderByNumberHandler: func(u uint64) (*types.Header, bool) {
+			return &types.Header{Number: u}, u <= peerLatest
+		},
+		getBlockByNumberHandler: func(u uint64, b bool) (*types.Block, bool) {
+			return &types.Block{Header: &types.Header{Number: u}}, u <= peerLatest
+		},
+	})
+
+	err := network.JoinAndWait(
+		clientSrv,
+		peerSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	)
+
+	require.NoError(t, err)
+
+	// hash is calculated on unmarshaling
+	expected := createMockBlocks(10)
+	for _, b := range expected {
+		b.Header.ComputeHash()
+	}
+
+	headers, err := client.GetHeaders(peerSrv.AddrInfo().ID, 3, 6)
+	require.NoError(t, err)
+	assert.Equal(t, headersOf(expected[2:6]), headers)
+
+	blocks, err := client.GetBlockRange(peerSrv.AddrInfo().ID, 3, 6, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, expected[2:6], blocks)
+
+	// the range is trimmed to the latest block of the peer
+	blocks, err = client.GetBlockRange(peerSrv.AddrInfo().ID, 8, 20, 5*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, expected[7:], blocks)
+}
+
 func Test_EmitMultipleBlocks(t *testing.T) {
 	t.Parallel()
 
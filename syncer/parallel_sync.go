@@ -0,0 +1,483 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/0xPolygon/polygon-edge/types/buildroot"
+	"github.com/armon/go-metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// parallelSyncRangeSize is the number of the blocks requested from a peer at once.
+	// The node syncs from several peers only if it is more than one range behind
+	parallelSyncRangeSize = 64
+
+	// parallelSyncMinPeers is the minimum number of the peers ahead to sync from several peers
+	parallelSyncMinPeers = 2
+
+	// parallelSyncMaxRangesAhead is the maximum number of the ranges which are
+	// downloaded, or waiting to be downloaded, ahead of the written blocks
+	parallelSyncMaxRangesAhead = 32
+
+	// parallelSyncMaxMismatches is the number of the peers whose blocks don't match the headers
+	// which the consensus can't verify in advance, before the headers are considered invalid
+	parallelSyncMaxMismatches = 2
+)
+
+var (
+	errInvalidHeaderChain = errors.New("headers don't link to the local chain")
+	errHeadersNotServed   = errors.New("peer doesn't serve the requested headers")
+	errBlockMismatch      = errors.New("block doesn't match the header")
+	errIncompleteRange    = errors.New("peer didn't send the whole range")
+	errNoRangePeer        = errors.New("no peer left to download the range from")
+	errHeadersMismatch    = errors.New("blocks of several peers don't match the headers")
+)
+
+// blockRange is a range of the blocks whose headers are verified
+type blockRange struct {
+	headers []*types.Header
+	blocks  []*types.Block
+
+	// verified is set if the consensus verified the headers in advance
+	verified bool
+}
+
+func (r *blockRange) from() uint64 {
+	return r.headers[0].Number
+}
+
+func (r *blockRange) to() uint64 {
+	return r.headers[len(r.headers)-1].Number
+}
+
+// verify checks the downloaded blocks match the verified headers of the range
+func (r *blockRange) verify(blocks []*types.Block) error {
+	if len(blocks) != len(r.headers) {
+		return fmt.Errorf("%w: expected %d blocks, got %d", errIncompleteRange, len(r.headers), len(blocks))
+	}
+
+	for i, block := range blocks {
+		header := r.headers[i]
+
+		if block.Hash() != header.Hash {
+			return fmt.Errorf("%w: block %d has hash %s, expected %s",
+				errBlockMismatch, header.Number, block.Hash(), header.Hash)
+		}
+
+		if root := buildroot.CalculateTransactionsRoot(block.Transactions, header.Number); root != header.TxRoot {
+			return fmt.Errorf("%w: block %d has transactions root %s, expected %s",
+				errBlockMismatch, header.Number, root, header.TxRoot)
+		}
+	}
+
+	return nil
+}
+
+type headersResult struct {
+	headers []*types.Header
+	err     error
+}
+
+type rangeResult struct {
+	peerID  peer.ID
+	rng     *blockRange
+	blocks  []*types.Block
+	elapsed time.Duration
+	err     error
+}
+
+// parallelSync downloads the blocks from several peers at once. The headers are fetched
+// first from the best peer, every header is checked to link to the previous one, starting
+// from the local head, and verified with the consensus. The ranges of the blocks are then
+// requested concurrently from the peers having them, and every block is checked against
+// its header as soon as it arrives. The range which is not delivered within the block
+// timeout, or which doesn't match the verified headers, is re-assigned to another peer,
+// and the failed peer isn't used anymore. The downloaded blocks are reassembled in order,
+// then verified with the consensus once more, and written to the chain.
+//
+// The consensus which can verify a header only once its parent is written doesn't verify
+// the headers in advance. The header peer is blamed then, if the blocks of several peers
+// don't match its headers
+type parallelSync struct {
+	syncer     *syncer
+	headerPeer peer.ID
+	target     uint64
+	callback   func(*types.FullBlock) bool
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	headersCh chan *headersResult
+	rangesCh  chan *rangeResult
+
+	// lastHeader is the last verified header
+	lastHeader      *types.Header
+	fetchingHeaders bool
+	// verifiedHeaders are the headers verified by the consensus whose blocks are not written yet
+	verifiedHeaders []*types.Header
+	// mismatches is the number of the peers whose blocks didn't match the unverified headers
+	mismatches int
+
+	// queue holds the ranges waiting for a peer, by the first block
+	queue []*blockRange
+	// downloaded holds the ranges waiting to be written, by the first block
+	downloaded map[uint64]*blockRange
+	// ranges is the number of the ranges which are not written yet
+	ranges int
+
+	// busy holds the peers downloading a range
+	busy map[peer.ID]bool
+	// failed holds the peers which failed to deliver a range
+	failed map[peer.ID]bool
+
+	nextWrite       uint64
+	shouldTerminate bool
+
+	started time.Time
+	written uint64
+}
+
+// parallelSyncWithPeers syncs blocks up to the latest block of the best peer from several peers
+func (s *syncer) parallelSyncWithPeers(
+	bestPeer *NoForkPeer,
+	skipList map[peer.ID]bool,
+	newBlockCallback func(*types.FullBlock) bool,
+) (uint64, bool, error) {
+	localLatest := s.blockchain.Header().Number
+
+	// Create a blockchain subscription for the sync progression and start tracking
+	subscription := s.blockchain.SubscribeEvents()
+	s.syncProgression.StartProgression(localLatest+1, subscription)
+	s.syncProgression.UpdateHighestProgression(bestPeer.Number)
+
+	defer func() {
+		// Stop monitoring the sync progression upon exit
+		s.syncProgression.StopProgression()
+		s.blockchain.UnsubscribeEvents(subscription)
+	}()
+
+	return newParallelSync(s, bestPeer.ID, bestPeer.Number, skipList, newBlockCallback).run()
+}
+
+// shouldParallelSync checks if the node is far enough behind enough peers to sync from several peers
+func (s *syncer) shouldParallelSync(bestPeer *NoForkPeer, localLatest uint64, skipList map[peer.ID]bool) bool {
+	if bestPeer.Number-localLatest <= parallelSyncRangeSize {
+		return false
+	}
+
+	peers := 0
+
+	s.peerMap.Range(func(_, value interface{}) bool {
+		status, _ := value.(*NoForkPeer)
+		if !skipList[status.ID] && status.Number > localLatest+parallelSyncRangeSize {
+			peers++
+		}
+
+		return peers < parallelSyncMinPeers
+	})
+
+	return peers >= parallelSyncMinPeers
+}
+
+func newParallelSync(
+	s *syncer,
+	headerPeer peer.ID,
+	target uint64,
+	skipList map[peer.ID]bool,
+	callback func(*types.FullBlock) bool,
+) *parallelSync {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	failed := make(map[peer.ID]bool, len(skipList))
+	for id, skip := range skipList {
+		failed[id] = skip
+	}
+
+	head := s.blockchain.Header()
+
+	return &parallelSync{
+		syncer:     s,
+		headerPeer: headerPeer,
+		target:     target,
+		callback:   callback,
+		ctx:        ctx,
+		cancel:     cancel,
+		headersCh:  make(chan *headersResult),
+		rangesCh:   make(chan *rangeResult),
+		lastHeader: head,
+		downloaded: make(map[uint64]*blockRange),
+		busy:       make(map[peer.ID]bool),
+		failed:     failed,
+		nextWrite:  head.Number + 1,
+	}
+}
+
+// run syncs the blocks up to the target and returns the number of the last written block
+func (p *parallelSync) run() (uint64, bool, error) {
+	defer p.cancel()
+
+	p.started = time.Now()
+
+	for p.nextWrite <= p.target {
+		p.requestHeaders()
+
+		if err := p.assignRanges(); err != nil {
+			return p.nextWrite - 1, p.shouldTerminate, err
+		}
+
+		select {
+		case res := <-p.headersCh:
+			if err := p.processHeaders(res); err != nil {
+				return p.nextWrite - 1, p.shouldTerminate, err
+			}
+		case res := <-p.rangesCh:
+			if err := p.processRange(res); err != nil {
+				return p.nextWrite - 1, p.shouldTerminate, err
+			}
+		}
+
+		if err := p.writeBlocks(); err != nil {
+			return p.nextWrite - 1, p.shouldTerminate, err
+		}
+	}
+
+	return p.nextWrite - 1, p.shouldTerminate, nil
+}
+
+// requestHeaders fetches the next headers unless enough ranges are ahead of the written blocks
+func (p *parallelSync) requestHeaders() {
+	if p.fetchingHeaders || p.lastHeader.Number >= p.target || p.ranges >= parallelSyncMaxRangesAhead {
+		return
+	}
+
+	from := p.lastHeader.Number + 1
+
+	to := p.target
+	if to-from >= maxHeadersPerResponse {
+		to = from + maxHeadersPerResponse - 1
+	}
+
+	p.fetchingHeaders = true
+
+	go func() {
+		headers, err := p.syncer.syncPeerClient.GetHeaders(p.headerPeer, from, to)
+
+		select {
+		case p.headersCh <- &headersResult{headers: headers, err: err}:
+		case <-p.ctx.Done():
+		}
+	}()
+}
+
+// processHeaders verifies the headers link to the last verified header and verifies them with
+// the consensus, then splits them into ranges
+func (p *parallelSync) processHeaders(res *headersResult) error {
+	p.fetchingHeaders = false
+
+	if res.err != nil {
+		return fmt.Errorf("failed to get headers: %w", res.err)
+	}
+
+	if len(res.headers) == 0 {
+		return errHeadersNotServed
+	}
+
+	for _, header := range res.headers {
+		if header.Number != p.lastHeader.Number+1 || header.ParentHash != p.lastHeader.Hash {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return fmt.Errorf("%w: header %d (%s) doesn't follow header %d (%s)",
+				errInvalidHeaderChain, header.Number, header.Hash, p.lastHeader.Number, p.lastHeader.Hash)
+		}
+
+		p.lastHeader = header
+	}
+
+	verified, err := p.syncer.blockchain.VerifyHeaders(p.verifiedHeaders, res.headers)
+	if err != nil {
+		metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+		return fmt.Errorf("failed to verify headers: %w", err)
+	}
+
+	if verified {
+		p.verifiedHeaders = append(p.verifiedHeaders, res.headers...)
+	}
+
+	for start := 0; start < len(res.headers); start += parallelSyncRangeSize {
+		end := start + parallelSyncRangeSize
+		if end > len(res.headers) {
+			end = len(res.headers)
+		}
+
+		p.queue = append(p.queue, &blockRange{headers: res.headers[start:end], verified: verified})
+		p.ranges++
+	}
+
+	return nil
+}
+
+// assignRanges requests the queued ranges from the idle peers having them
+func (p *parallelSync) assignRanges() error {
+	for len(p.queue) > 0 {
+		rng := p.queue[0]
+
+		peerID, ok := p.idlePeer(rng.to())
+		if !ok {
+			break
+		}
+
+		p.queue = p.queue[1:]
+		p.busy[peerID] = true
+
+		go p.download(peerID, rng)
+	}
+
+	if len(p.queue) > 0 && len(p.busy) == 0 {
+		// nobody is left to deliver the queued range
+		return fmt.Errorf("%w: %d-%d", errNoRangePeer, p.queue[0].from(), p.queue[0].to())
+	}
+
+	return nil
+}
+
+// idlePeer returns the best idle peer having the given block
+func (p *parallelSync) idlePeer(number uint64) (peer.ID, bool) {
+	var best *NoForkPeer
+
+	p.syncer.peerMap.Range(func(_, value interface{}) bool {
+		status, _ := value.(*NoForkPeer)
+
+		if status.Number < number || p.busy[status.ID] || p.failed[status.ID] {
+			return true
+		}
+
+		if best == nil || status.IsBetter(best) {
+			best = status
+		}
+
+		return true
+	})
+
+	if best == nil {
+		return "", false
+	}
+
+	return best.ID, true
+}
+
+// download requests the range from the peer
+func (p *parallelSync) download(peerID peer.ID, rng *blockRange) {
+	started := time.Now()
+
+	blocks, err := p.syncer.syncPeerClient.GetBlockRange(peerID, rng.from(), rng.to(), p.syncer.blockTimeout)
+
+	select {
+	case p.rangesCh <- &rangeResult{
+		peerID:  peerID,
+		rng:     rng,
+		blocks:  blocks,
+		elapsed: time.Since(started),
+		err:     err,
+	}:
+	case <-p.ctx.Done():
+	}
+}
+
+// processRange verifies the downloaded range, or queues it again for another peer.
+// It fails if the blocks of several peers don't match the headers of the header peer
+func (p *parallelSync) processRange(res *rangeResult) error {
+	delete(p.busy, res.peerID)
+
+	err := res.err
+	if err == nil {
+		err = res.rng.verify(res.blocks)
+	}
+
+	if err != nil {
+		if errors.Is(err, errBlockMismatch) {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			// the headers which are not verified in advance may be the invalid ones
+			if !res.rng.verified {
+				if p.mismatches++; p.mismatches >= parallelSyncMaxMismatches {
+					return fmt.Errorf("%w: %d-%d from peer %s",
+						errHeadersMismatch, res.rng.from(), res.rng.to(), p.headerPeer)
+				}
+			}
+		}
+
+		p.syncer.logger.Warn("failed to download blocks, re-assign them to another peer",
+			"peer", res.peerID, "from", res.rng.from(), "to", res.rng.to(), "err", err)
+		metrics.IncrCounter([]string{syncerMetrics, "reassigned_ranges"}, 1)
+
+		p.failed[res.peerID] = true
+		// the earliest range is the most urgent one
+		p.queue = append([]*blockRange{res.rng}, p.queue...)
+
+		return nil
+	}
+
+	if seconds := res.elapsed.Seconds(); seconds > 0 {
+		metrics.SetGaugeWithLabels(
+			[]string{syncerMetrics, "peer_throughput"},
+			float32(float64(len(res.blocks))/seconds),
+			[]metrics.Label{{Name: "peer", Value: res.peerID.String()}},
+		)
+	}
+
+	res.rng.blocks = res.blocks
+	p.downloaded[res.rng.from()] = res.rng
+
+	return nil
+}
+
+// writeBlocks verifies and writes the downloaded ranges following the written blocks
+func (p *parallelSync) writeBlocks() error {
+	for {
+		rng, ok := p.downloaded[p.nextWrite]
+		if !ok {
+			return nil
+		}
+
+		for _, block := range rng.blocks {
+			fullBlock, err := p.syncer.blockchain.VerifyFinalizedBlock(block)
+			if err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return fmt.Errorf("unable to verify block, %w", err)
+			}
+
+			if err := p.syncer.blockchain.WriteFullBlock(fullBlock, syncerName); err != nil {
+				metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+				return fmt.Errorf("failed to write block while syncing from several peers: %w", err)
+			}
+
+			updateMetrics(fullBlock)
+
+			if p.callback(fullBlock) {
+				p.shouldTerminate = true
+			}
+
+			p.nextWrite = block.Number() + 1
+			p.written++
+		}
+
+		delete(p.downloaded, rng.from())
+		p.ranges--
+
+		// the written headers are read from the chain
+		for len(p.verifiedHeaders) > 0 && p.verifiedHeaders[0].Number < p.nextWrite {
+			p.verifiedHeaders = p.verifiedHeaders[1:]
+		}
+
+		if seconds := time.Since(p.started).Seconds(); seconds > 0 {
+			metrics.SetGauge([]string{syncerMetrics, "sync_rate"}, float32(float64(p.written)/seconds))
+		}
+	}
+}
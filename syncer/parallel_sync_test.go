@@ -0,0 +1,450 @@
+package syncer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// createLinkedBlocks creates the chain of the given number of blocks following the genesis block
+func createLinkedBlocks(num int) (*types.Header, []*types.Block) {
+	genesis := &types.Header{Number: 0, TxRoot: types.EmptyRootHash}
+	genesis.ComputeHash()
+
+	blocks := make([]*types.Block, num)
+	parent := genesis
+
+	for i := 0; i < num; i++ {
+		header := &types.Header{
+			Number:     uint64(i + 1),
+			ParentHash: parent.Hash,
+			TxRoot:     types.EmptyRootHash,
+		}
+		header.ComputeHash()
+
+		blocks[i] = &types.Block{Header: header}
+		parent = header
+	}
+
+	return genesis, blocks
+}
+
+func headersOf(blocks []*types.Block) []*types.Header {
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header
+	}
+
+	return headers
+}
+
+// verifyAllHeaders is the consensus which accepts all the headers in advance
+func verifyAllHeaders([]*types.Header, []*types.Header) (bool, error) {
+	return true, nil
+}
+
+// newTestParallelSyncer creates the syncer on the given chain, which writes the synced blocks
+func newTestParallelSyncer(
+	genesis *types.Header,
+	peers []*NoForkPeer,
+	client *mockSyncPeerClient,
+	verifyHeaders func([]*types.Header, []*types.Header) (bool, error),
+) (*syncer, func() []*types.Block) {
+	var (
+		syncedBlocks []*types.Block
+		head         = genesis
+	)
+
+	syncer := NewTestSyncer(
+		nil,
+		&mockBlockchain{
+			headerHandler: func() *types.Header {
+				return head
+			},
+			verifyFinalizedBlockHandler: func(b *types.Block) (*types.FullBlock, error) {
+				return &types.FullBlock{Block: b}, nil
+			},
+			verifyHeadersHandler: verifyHeaders,
+			writeFullBlockHandler: func(b *types.FullBlock) error {
+				syncedBlocks = append(syncedBlocks, b.Block)
+				head = b.Block.Header
+
+				return nil
+			},
+		},
+		time.Second,
+		client,
+		&mockProgression{},
+	)
+
+	syncer.peerMap.Put(peers...)
+
+	return syncer, func() []*types.Block {
+		return syncedBlocks
+	}
+}
+
+func newTestPeers(number uint64, ids ...string) []*NoForkPeer {
+	peers := make([]*NoForkPeer, len(ids))
+	for i, id := range ids {
+		peers[i] = &NoForkPeer{ID: peer.ID(id), Number: number, Distance: big.NewInt(int64(i))}
+	}
+
+	return peers
+}
+
+func serveHeaders(blocks []*types.Block) func(peer.ID, uint64, uint64) ([]*types.Header, error) {
+	return func(_ peer.ID, from, to uint64) ([]*types.Header, error) {
+		if to-from >= maxHeadersPerResponse {
+			to = from + maxHeadersPerResponse - 1
+		}
+
+		return headersOf(blocks[from-1 : to]), nil
+	}
+}
+
+func TestParallelSync(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := createLinkedBlocks(1000)
+
+	var (
+		servedLock sync.Mutex
+		served     = map[peer.ID]int{}
+	)
+
+	syncer, syncedBlocks := newTestParallelSyncer(
+		genesis,
+		newTestPeers(1000, "A", "B", "C"),
+		&mockSyncPeerClient{
+			getHeadersHandler: serveHeaders(blocks),
+			getBlockRangeHandler: func(id peer.ID, from, to uint64, _ time.Duration) ([]*types.Block, error) {
+				servedLock.Lock()
+				served[id]++
+				servedLock.Unlock()
+
+				// the peers serve the ranges out of order
+				time.Sleep(time.Duration(from%3) * time.Millisecond)
+
+				return blocks[from-1 : to], nil
+			},
+		},
+		func(parents []*types.Header, headers []*types.Header) (bool, error) {
+			// the headers follow the verified headers whose blocks are not written yet
+			if len(parents) > 0 {
+				assert.Equal(t, headers[0].ParentHash, parents[len(parents)-1].Hash)
+			}
+
+			return true, nil
+		},
+	)
+
+	lastNumber, shouldTerminate, err := syncer.parallelSyncWithPeers(
+		&NoForkPeer{ID: peer.ID("A"), Number: 1000},
+		map[peer.ID]bool{},
+		func(b *types.FullBlock) bool { return b.Block.Number() == 1000 },
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), lastNumber)
+	assert.True(t, shouldTerminate)
+	assert.Equal(t, blocks, syncedBlocks())
+
+	// the ranges are downloaded from all the peers
+	assert.Len(t, served, 3)
+}
+
+func TestParallelSync_ReassignRanges(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := createLinkedBlocks(300)
+
+	// the block whose transactions don't match the transactions root of the header
+	invalidBlock := &types.Block{
+		Header:       blocks[100].Header,
+		Transactions: []*types.Transaction{{Nonce: 1, Value: big.NewInt(1)}},
+	}
+
+	tests := []struct {
+		name       string
+		faultyPeer func(from, to uint64) ([]*types.Block, error)
+	}{
+		{
+			name: "stalled peer",
+			faultyPeer: func(from, to uint64) ([]*types.Block, error) {
+				return blocks[from-1 : from], errTimeout
+			},
+		},
+		{
+			name: "incomplete range",
+			faultyPeer: func(from, to uint64) ([]*types.Block, error) {
+				return blocks[from-1 : to-1], nil
+			},
+		},
+		{
+			name: "block not matching the header",
+			faultyPeer: func(from, to uint64) ([]*types.Block, error) {
+				served := append([]*types.Block{}, blocks[from-1:to]...)
+				served[0] = invalidBlock
+
+				return served, nil
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				faultyLock  sync.Mutex
+				faultyCalls int
+			)
+
+			syncer, syncedBlocks := newTestParallelSyncer(
+				genesis,
+				newTestPeers(300, "A", "B"),
+				&mockSyncPeerClient{
+					getHeadersHandler: serveHeaders(blocks),
+					getBlockRangeHandler: func(id peer.ID, from, to uint64, _ time.Duration) ([]*types.Block, error) {
+						if id == peer.ID("A") {
+							faultyLock.Lock()
+							faultyCalls++
+							faultyLock.Unlock()
+
+							return test.faultyPeer(from, to)
+						}
+
+						return blocks[from-1 : to], nil
+					},
+				},
+				verifyAllHeaders,
+			)
+
+			lastNumber, _, err := syncer.parallelSyncWithPeers(
+				&NoForkPeer{ID: peer.ID("B"), Number: 300},
+				map[peer.ID]bool{},
+				func(*types.FullBlock) bool { return false },
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(300), lastNumber)
+			assert.Equal(t, blocks, syncedBlocks())
+
+			// the faulty peer is not used after the first failure
+			assert.Equal(t, 1, faultyCalls)
+		})
+	}
+}
+
+func TestParallelSync_InvalidHeaders(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := createLinkedBlocks(200)
+	_, otherBlocks := createLinkedBlocks(200)
+
+	// the headers of the other chain don't link to the local genesis
+	otherBlocks[0].Header.ParentHash = types.StringToHash("0x1")
+	otherBlocks[0].Header.ComputeHash()
+
+	syncer, syncedBlocks := newTestParallelSyncer(
+		genesis,
+		newTestPeers(200, "A", "B"),
+		&mockSyncPeerClient{
+			getHeadersHandler: serveHeaders(otherBlocks),
+			getBlockRangeHandler: func(_ peer.ID, from, to uint64, _ time.Duration) ([]*types.Block, error) {
+				return blocks[from-1 : to], nil
+			},
+		},
+		verifyAllHeaders,
+	)
+
+	lastNumber, _, err := syncer.parallelSyncWithPeers(
+		&NoForkPeer{ID: peer.ID("A"), Number: 200},
+		map[peer.ID]bool{},
+		func(*types.FullBlock) bool { return false },
+	)
+
+	assert.ErrorIs(t, err, errInvalidHeaderChain)
+	assert.Equal(t, uint64(0), lastNumber)
+	assert.Empty(t, syncedBlocks())
+}
+
+func TestParallelSync_HeadersRejectedByConsensus(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := createLinkedBlocks(200)
+	errInvalidSeal := errors.New("invalid seal")
+
+	var downloaded int32
+
+	syncer, syncedBlocks := newTestParallelSyncer(
+		genesis,
+		newTestPeers(200, "A", "B"),
+		&mockSyncPeerClient{
+			getHeadersHandler: serveHeaders(blocks),
+			getBlockRangeHandler: func(_ peer.ID, from, to uint64, _ time.Duration) ([]*types.Block, error) {
+				atomic.AddInt32(&downloaded, 1)
+
+				return blocks[from-1 : to], nil
+			},
+		},
+		func([]*types.Header, []*types.Header) (bool, error) {
+			return false, errInvalidSeal
+		},
+	)
+
+	lastNumber, _, err := syncer.parallelSyncWithPeers(
+		&NoForkPeer{ID: peer.ID("A"), Number: 200},
+		map[peer.ID]bool{},
+		func(*types.FullBlock) bool { return false },
+	)
+
+	assert.ErrorIs(t, err, errInvalidSeal)
+	assert.Equal(t, uint64(0), lastNumber)
+	assert.Empty(t, syncedBlocks())
+
+	// the blocks of the rejected headers are not downloaded
+	assert.Zero(t, atomic.LoadInt32(&downloaded))
+}
+
+func TestParallelSync_UnverifiedHeadersMismatch(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := createLinkedBlocks(200)
+
+	// the header peer serves the headers linked to the local genesis,
+	// which the blocks of the other peers don't match
+	fakeBlocks := make([]*types.Block, len(blocks))
+	parent := genesis
+
+	for i, block := range blocks {
+		header := block.Header.Copy()
+		header.ParentHash = parent.Hash
+		header.ExtraData = []byte{0x1}
+		header.ComputeHash()
+
+		fakeBlocks[i] = &types.Block{Header: header}
+		parent = header
+	}
+
+	syncer, syncedBlocks := newTestParallelSyncer(
+		genesis,
+		newTestPeers(200, "B", "C"),
+		&mockSyncPeerClient{
+			getHeadersHandler: serveHeaders(fakeBlocks),
+			getBlockRangeHandler: func(_ peer.ID, from, to uint64, _ time.Duration) ([]*types.Block, error) {
+				return blocks[from-1 : to], nil
+			},
+		},
+		// the consensus can't verify the headers in advance
+		func([]*types.Header, []*types.Header) (bool, error) {
+			return false, nil
+		},
+	)
+
+	lastNumber, _, err := syncer.parallelSyncWithPeers(
+		&NoForkPeer{ID: peer.ID("A"), Number: 200},
+		map[peer.ID]bool{},
+		func(*types.FullBlock) bool { return false },
+	)
+
+	assert.ErrorIs(t, err, errHeadersMismatch)
+	assert.Equal(t, uint64(0), lastNumber)
+	assert.Empty(t, syncedBlocks())
+}
+
+func TestParallelSync_NoPeerLeft(t *testing.T) {
+	t.Parallel()
+
+	genesis, blocks := createLinkedBlocks(200)
+	errPeerNoResponse := errors.New("peer is not responding")
+
+	syncer, syncedBlocks := newTestParallelSyncer(
+		genesis,
+		newTestPeers(200, "A", "B"),
+		&mockSyncPeerClient{
+			getHeadersHandler: serveHeaders(blocks),
+			getBlockRangeHandler: func(_ peer.ID, from, to uint64, _ time.Duration) ([]*types.Block, error) {
+				if from > 64 {
+					return nil, errPeerNoResponse
+				}
+
+				return blocks[from-1 : to], nil
+			},
+		},
+		verifyAllHeaders,
+	)
+
+	lastNumber, _, err := syncer.parallelSyncWithPeers(
+		&NoForkPeer{ID: peer.ID("A"), Number: 200},
+		map[peer.ID]bool{},
+		func(*types.FullBlock) bool { return false },
+	)
+
+	assert.ErrorIs(t, err, errNoRangePeer)
+	assert.Equal(t, uint64(64), lastNumber)
+	assert.Equal(t, blocks[:64], syncedBlocks())
+}
+
+func TestShouldParallelSync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		localLatest uint64
+		peers       []*NoForkPeer
+		skipList    map[peer.ID]bool
+		expected    bool
+	}{
+		{
+			name:        "several peers far ahead",
+			localLatest: 0,
+			peers:       newTestPeers(1000, "A", "B"),
+			expected:    true,
+		},
+		{
+			name:        "single peer far ahead",
+			localLatest: 0,
+			peers:       newTestPeers(1000, "A"),
+			expected:    false,
+		},
+		{
+			name:        "several peers less than a range ahead",
+			localLatest: 990,
+			peers:       newTestPeers(1000, "A", "B"),
+			expected:    false,
+		},
+		{
+			name:        "skipped peer",
+			localLatest: 0,
+			peers:       newTestPeers(1000, "A", "B"),
+			skipList:    map[peer.ID]bool{peer.ID("B"): true},
+			expected:    false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer := NewTestSyncer(nil, nil, 0, &mockSyncPeerClient{}, &mockProgression{})
+			syncer.peerMap.Put(test.peers...)
+
+			assert.Equal(t, test.expected, syncer.shouldParallelSync(test.peers[0], test.localLatest, test.skipList))
+		})
+	}
+}
@@ -0,0 +1,388 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.7
+// source: syncer/proto/snap.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// GetPivotRequest is a request for GetPivot
+type GetPivotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The height of the pivot block
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *GetPivotRequest) Reset() {
+	*x = GetPivotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPivotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPivotRequest) ProtoMessage() {}
+
+func (x *GetPivotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPivotRequest.ProtoReflect.Descriptor instead.
+func (*GetPivotRequest) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetPivotRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+// Pivot contains the pivot block data
+type Pivot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RLP Encoded Block Data
+	Block []byte `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// RLP Encoded Receipts of the block
+	Receipts []byte `protobuf:"bytes,2,opt,name=receipts,proto3" json:"receipts,omitempty"`
+	// Deprecated: not served, the total difficulty is computed from the verified headers
+	TotalDifficulty []byte `protobuf:"bytes,3,opt,name=totalDifficulty,proto3" json:"totalDifficulty,omitempty"`
+	// Deprecated: not served, the headers of the ancestors are synced and verified separately
+	Ancestors [][]byte `protobuf:"bytes,4,rep,name=ancestors,proto3" json:"ancestors,omitempty"`
+}
+
+func (x *Pivot) Reset() {
+	*x = Pivot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pivot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pivot) ProtoMessage() {}
+
+func (x *Pivot) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pivot.ProtoReflect.Descriptor instead.
+func (*Pivot) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Pivot) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *Pivot) GetReceipts() []byte {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+func (x *Pivot) GetTotalDifficulty() []byte {
+	if x != nil {
+		return x.TotalDifficulty
+	}
+	return nil
+}
+
+func (x *Pivot) GetAncestors() [][]byte {
+	if x != nil {
+		return x.Ancestors
+	}
+	return nil
+}
+
+// GetStateItemsRequest is a request for GetTrieNodes and GetCodes
+type GetStateItemsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Hashes of the requested items
+	Hashes [][]byte `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+}
+
+func (x *GetStateItemsRequest) Reset() {
+	*x = GetStateItemsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStateItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStateItemsRequest) ProtoMessage() {}
+
+func (x *GetStateItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStateItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetStateItemsRequest) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetStateItemsRequest) GetHashes() [][]byte {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+// StateItems contains the requested items the peer has, in any order
+type StateItems struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Trie nodes or contract codes
+	Items [][]byte `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *StateItems) Reset() {
+	*x = StateItems{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_syncer_proto_snap_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateItems) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateItems) ProtoMessage() {}
+
+func (x *StateItems) ProtoReflect() protoreflect.Message {
+	mi := &file_syncer_proto_snap_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateItems.ProtoReflect.Descriptor instead.
+func (*StateItems) Descriptor() ([]byte, []int) {
+	return file_syncer_proto_snap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StateItems) GetItems() [][]byte {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_syncer_proto_snap_proto protoreflect.FileDescriptor
+
+var file_syncer_proto_snap_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73,
+	0x6e, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x76, 0x31, 0x22, 0x29, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x50, 0x69, 0x76, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x81, 0x01, 0x0a, 0x05, 0x50, 0x69, 0x76,
+	0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x69, 0x66,
+	0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x09, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x2e, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x22, 0x0a, 0x0a,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x32, 0xa6, 0x01, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x2a, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x50, 0x69, 0x76, 0x6f, 0x74, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x50, 0x69, 0x76, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x09,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x76, 0x6f, 0x74, 0x12, 0x38, 0x0a, 0x0c, 0x47, 0x65, 0x74,
+	0x54, 0x72, 0x69, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x74,
+	0x65, 0x6d, 0x73, 0x12, 0x34, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x18, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x2f, 0x73, 0x79,
+	0x6e, 0x63, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_syncer_proto_snap_proto_rawDescOnce sync.Once
+	file_syncer_proto_snap_proto_rawDescData = file_syncer_proto_snap_proto_rawDesc
+)
+
+func file_syncer_proto_snap_proto_rawDescGZIP() []byte {
+	file_syncer_proto_snap_proto_rawDescOnce.Do(func() {
+		file_syncer_proto_snap_proto_rawDescData = protoimpl.X.CompressGZIP(file_syncer_proto_snap_proto_rawDescData)
+	})
+	return file_syncer_proto_snap_proto_rawDescData
+}
+
+var file_syncer_proto_snap_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_syncer_proto_snap_proto_goTypes = []interface{}{
+	(*GetPivotRequest)(nil),      // 0: v1.GetPivotRequest
+	(*Pivot)(nil),                // 1: v1.Pivot
+	(*GetStateItemsRequest)(nil), // 2: v1.GetStateItemsRequest
+	(*StateItems)(nil),           // 3: v1.StateItems
+}
+var file_syncer_proto_snap_proto_depIdxs = []int32{
+	0, // 0: v1.SnapSync.GetPivot:input_type -> v1.GetPivotRequest
+	2, // 1: v1.SnapSync.GetTrieNodes:input_type -> v1.GetStateItemsRequest
+	2, // 2: v1.SnapSync.GetCodes:input_type -> v1.GetStateItemsRequest
+	1, // 3: v1.SnapSync.GetPivot:output_type -> v1.Pivot
+	3, // 4: v1.SnapSync.GetTrieNodes:output_type -> v1.StateItems
+	3, // 5: v1.SnapSync.GetCodes:output_type -> v1.StateItems
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_syncer_proto_snap_proto_init() }
+func file_syncer_proto_snap_proto_init() {
+	if File_syncer_proto_snap_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_syncer_proto_snap_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPivotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_snap_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pivot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_snap_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetStateItemsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_syncer_proto_snap_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StateItems); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_syncer_proto_snap_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_syncer_proto_snap_proto_goTypes,
+		DependencyIndexes: file_syncer_proto_snap_proto_depIdxs,
+		MessageInfos:      file_syncer_proto_snap_proto_msgTypes,
+	}.Build()
+	File_syncer_proto_snap_proto = out.File
+	file_syncer_proto_snap_proto_rawDesc = nil
+	file_syncer_proto_snap_proto_goTypes = nil
+	file_syncer_proto_snap_proto_depIdxs = nil
+}
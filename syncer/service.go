@@ -11,8 +11,14 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+const (
+	// maxHeadersPerResponse is the maximum number of the headers served in one response
+	maxHeadersPerResponse = 512
+)
+
 var (
-	ErrBlockNotFound = errors.New("block not found")
+	ErrBlockNotFound  = errors.New("block not found")
+	ErrHeaderNotFound = errors.New("header not found")
 )
 
 type syncPeerService struct {
@@ -57,8 +63,8 @@ func (s *syncPeerService) GetBlocks(
 	req *proto.GetBlocksRequest,
 	stream proto.SyncPeer_GetBlocksServer,
 ) error {
-	// from to latest
-	for i := req.From; i <= s.blockchain.Header().Number; i++ {
+	// from to latest, or to the requested height
+	for i := req.From; i <= s.blockchain.Header().Number && (req.To == 0 || i <= req.To); i++ {
 		block, ok := s.blockchain.GetBlockByNumber(i, true)
 		if !ok {
 			return ErrBlockNotFound
@@ -91,6 +97,37 @@ func (s *syncPeerService) GetStatus(
 	}, nil
 }
 
+// GetHeaders is a gRPC endpoint to return the headers in the specific range.
+// The range is trimmed to the latest block and to maxHeadersPerResponse headers
+func (s *syncPeerService) GetHeaders(
+	ctx context.Context,
+	req *proto.GetHeadersRequest,
+) (*proto.Headers, error) {
+	to := req.To
+	if latest := s.blockchain.Header().Number; to > latest {
+		to = latest
+	}
+
+	if req.From <= to && to-req.From >= maxHeadersPerResponse {
+		to = req.From + maxHeadersPerResponse - 1
+	}
+
+	headers := make([][]byte, 0, maxHeadersPerResponse)
+
+	for i := req.From; i <= to; i++ {
+		header, ok := s.blockchain.GetHeaderByNumber(i)
+		if !ok {
+			return nil, ErrHeaderNotFound
+		}
+
+		headers = append(headers, header.MarshalRLP())
+	}
+
+	return &proto.Headers{
+		Headers: headers,
+	}, nil
+}
+
 // toProtoBlock converts type.Block -> proto.Block
 func toProtoBlock(block *types.Block) *proto.Block {
 	return &proto.Block{
@@ -60,6 +60,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 	tests := []struct {
 		name           string
 		from           uint64
+		to             uint64
 		latest         uint64
 		blocks         []*types.Block
 		receivedBlocks []*types.Block
@@ -73,6 +74,15 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 			receivedBlocks: blocks[4:], // from 5
 			err:            io.EOF,
 		},
+		{
+			name:           "should send the blocks to the requested height",
+			from:           5,
+			to:             7,
+			latest:         10,
+			blocks:         blocks,
+			receivedBlocks: blocks[4:7], // from 5 to 7
+			err:            io.EOF,
+		},
 		{
 			name:           "should return ErrBlockNotFound",
 			from:           5,
@@ -112,6 +122,7 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 
 			stream, err := client.GetBlocks(context.Background(), &proto.GetBlocksRequest{
 				From: test.from,
+				To:   test.to,
 			})
 
 			assert.NoError(t, err)
@@ -132,6 +143,8 @@ func Test_syncPeerService_GetBlocks(t *testing.T) {
 
 				count++
 			}
+
+			assert.Equal(t, len(test.receivedBlocks), count)
 		})
 	}
 }
@@ -154,3 +167,70 @@ func TestGetStatus(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, headerNumber, status.Number)
 }
+
+func TestGetHeaders(t *testing.T) {
+	t.Parallel()
+
+	blocks := createMockBlocks(600)
+
+	service := &syncPeerService{
+		blockchain: &mockBlockchain{
+			headerHandler: newSimpleHeaderHandler(600),
+			getHeaderByNumberHandler: func(number uint64) (*types.Header, bool) {
+				if number == 0 || number > 600 {
+					return nil, false
+				}
+
+				return blocks[number-1].Header, true
+			},
+		},
+	}
+
+	client := newMockGrpcClient(t, service)
+
+	tests := []struct {
+		name     string
+		from     uint64
+		to       uint64
+		expected []*types.Block
+	}{
+		{
+			name:     "should return the headers of the range",
+			from:     5,
+			to:       10,
+			expected: blocks[4:10],
+		},
+		{
+			name:     "should trim the range to the latest block",
+			from:     590,
+			to:       700,
+			expected: blocks[589:],
+		},
+		{
+			name:     "should trim the range to the maximum number of the headers",
+			from:     1,
+			to:       600,
+			expected: blocks[:maxHeadersPerResponse],
+		},
+		{
+			name:     "should return no headers above the latest block",
+			from:     601,
+			to:       610,
+			expected: []*types.Block{},
+		},
+	}
+
+	for _, test := range tests {
+		resp, err := client.GetHeaders(context.Background(), &proto.GetHeadersRequest{
+			From: test.from,
+			To:   test.to,
+		})
+
+		assert.NoError(t, err, test.name)
+		assert.Len(t, resp.Headers, len(test.expected), test.name)
+
+		for i, raw := range resp.Headers {
+			assert.Equal(t, test.expected[i].Header.MarshalRLP(), raw, test.name)
+		}
+	}
+}
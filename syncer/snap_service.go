@@ -0,0 +1,120 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/armon/go-metrics"
+)
+
+const (
+	snapSyncProto = "/snap/0.1"
+
+	// maxSnapSyncItems is the maximum number of the state items served in one response
+	maxSnapSyncItems = 1024
+)
+
+var (
+	errPivotNotFound = errors.New("pivot block not found")
+)
+
+type snapSyncService struct {
+	proto.UnimplementedSnapSyncServer
+
+	blockchain Blockchain       // reference to the blockchain module
+	network    Network          // reference to the network module
+	storage    itrie.Storage    // reference to the state storage
+	stream     *grpc.GrpcStream // reference to the grpc stream
+}
+
+func newSnapSyncService(network Network, blockchain Blockchain, storage itrie.Storage) *snapSyncService {
+	return &snapSyncService{
+		blockchain: blockchain,
+		network:    network,
+		storage:    storage,
+	}
+}
+
+// Start starts snapSyncService
+func (s *snapSyncService) Start() {
+	s.stream = grpc.NewGrpcStream()
+
+	proto.RegisterSnapSyncServer(s.stream.GrpcServer(), s)
+	s.stream.Serve()
+	s.network.RegisterProtocol(snapSyncProto, s.stream)
+}
+
+// Close closes snapSyncService
+func (s *snapSyncService) Close() error {
+	return s.stream.Close()
+}
+
+// GetPivot is a gRPC endpoint to return the pivot block with its receipts
+func (s *snapSyncService) GetPivot(
+	ctx context.Context,
+	req *proto.GetPivotRequest,
+) (*proto.Pivot, error) {
+	block, ok := s.blockchain.GetBlockByNumber(req.Number, true)
+	if !ok {
+		return nil, errPivotNotFound
+	}
+
+	receipts, err := s.blockchain.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.Pivot{
+		Block:    block.MarshalRLP(),
+		Receipts: types.Receipts(receipts).MarshalStoreRLPTo(nil),
+	}, nil
+}
+
+// GetTrieNodes is a gRPC endpoint to return the trie nodes with the given hashes.
+// The nodes which are not found are skipped
+func (s *snapSyncService) GetTrieNodes(
+	ctx context.Context,
+	req *proto.GetStateItemsRequest,
+) (*proto.StateItems, error) {
+	return s.getStateItems(req, func(hash types.Hash) ([]byte, bool) {
+		data, ok, err := s.storage.Get(hash.Bytes())
+
+		return data, ok && err == nil
+	})
+}
+
+// GetCodes is a gRPC endpoint to return the contract codes with the given hashes.
+// The codes which are not found are skipped
+func (s *snapSyncService) GetCodes(
+	ctx context.Context,
+	req *proto.GetStateItemsRequest,
+) (*proto.StateItems, error) {
+	return s.getStateItems(req, s.storage.GetCode)
+}
+
+func (s *snapSyncService) getStateItems(
+	req *proto.GetStateItemsRequest,
+	get func(types.Hash) ([]byte, bool),
+) (*proto.StateItems, error) {
+	items := make([][]byte, 0, len(req.Hashes))
+	size := 0
+
+	for _, hash := range req.Hashes {
+		if len(items) == maxSnapSyncItems {
+			break
+		}
+
+		if data, ok := get(types.BytesToHash(hash)); ok {
+			items = append(items, data)
+			size += len(data)
+		}
+	}
+
+	metrics.SetGauge([]string{syncerMetrics, "snap_egress_bytes"}, float32(size))
+
+	return &proto.StateItems{Items: items}, nil
+}
@@ -0,0 +1,272 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rawGrpc "google.golang.org/grpc"
+
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/syncer/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// DefaultSnapSyncPivotDistance is the default number of blocks between the pivot block
+	// and the head of the peer. It has to be lower than the number of the state roots
+	// retained by the peers which prune the state
+	DefaultSnapSyncPivotDistance = 64
+
+	// snapSyncRequestSize is the number of the state items requested at once
+	snapSyncRequestSize = 384
+
+	// snapSyncRequestTimeout is the timeout of a single request to the peer
+	snapSyncRequestTimeout = 30 * time.Second
+)
+
+var (
+	errInvalidPivot     = errors.New("invalid pivot block")
+	errSnapSyncStalled  = errors.New("peer doesn't serve the requested state")
+	errSnapSyncDisabled = errors.New("snap sync is disabled")
+)
+
+// SnapSyncConfig is the configuration of the snap sync
+type SnapSyncConfig struct {
+	// Enabled makes a node starting with an empty chain download the state of a recent
+	// pivot block from a peer, instead of executing all the blocks from the genesis
+	Enabled bool
+	// PivotDistance is the number of blocks between the pivot block and the head of the peer
+	PivotDistance uint64
+	// StateStorage is the storage the state is served from and synced into
+	StateStorage itrie.Storage
+}
+
+// snapSyncer syncs the state of the pivot block from a peer. The headers from the genesis
+// up to the pivot block are verified by the consensus first, so the pivot block is trusted
+// as much as a block imported by the bulk sync. Every trie node and contract code is then
+// verified against its hash, and the state is complete only once all the items reachable
+// from the state root of the pivot block are downloaded. The blocks following the pivot
+// block are verified by the bulk sync as usual
+type snapSyncer struct {
+	logger     hclog.Logger
+	network    Network
+	blockchain Blockchain
+	peerClient SyncPeerClient
+	config     *SnapSyncConfig
+
+	// lastHeader is the number of the last header verified and written by the previous sync attempts
+	lastHeader uint64
+}
+
+// shouldSync checks if the node should snap sync with the peer having the given latest block
+func (s *snapSyncer) shouldSync(peerLatest uint64) bool {
+	return s != nil && s.config.Enabled &&
+		s.blockchain.Header().Number == 0 &&
+		peerLatest > s.config.PivotDistance
+}
+
+// sync downloads the state of the pivot block from the peer and writes the pivot block as the head.
+// An interrupted sync keeps the complete subtries downloaded so far, so the next one resumes from them
+func (s *snapSyncer) sync(peerID peer.ID, peerLatest uint64) error {
+	if s == nil || !s.config.Enabled {
+		return errSnapSyncDisabled
+	}
+
+	client, err := s.newClient(peerID)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := s.network.CloseProtocolStream(snapSyncProto, peerID); err != nil {
+			s.logger.Error("failed to close stream", "peer", peerID, "err", err)
+		}
+	}()
+
+	pivot, err := s.getPivot(client, peerLatest-s.config.PivotDistance)
+	if err != nil {
+		return err
+	}
+
+	header := pivot.Block.Header
+
+	if err := s.syncHeaders(peerID, header); err != nil {
+		return err
+	}
+
+	td, ok := s.blockchain.GetTD(header.Hash)
+	if !ok {
+		return fmt.Errorf("%w: total difficulty of block %d not found", errInvalidPivot, header.Number)
+	}
+
+	s.logger.Info("snap sync started", "peer", peerID, "pivot", header.Number, "root", header.StateRoot)
+
+	stateSync := itrie.NewStateSync(s.config.StateStorage, header.StateRoot)
+
+	if err := s.syncState(client, stateSync); err != nil {
+		// keep the progress for the next attempt
+		if commitErr := stateSync.Commit(); commitErr != nil {
+			s.logger.Error("failed to write synced state", "err", commitErr)
+		}
+
+		return err
+	}
+
+	if err := stateSync.Commit(); err != nil {
+		return err
+	}
+
+	// the ancestors are written with the verified headers
+	if err := s.blockchain.WritePivotBlock(pivot, td, nil); err != nil {
+		return fmt.Errorf("failed to write pivot block: %w", err)
+	}
+
+	s.logger.Info("snap sync completed", "pivot", header.Number, "root", header.StateRoot)
+
+	return nil
+}
+
+// syncState requests the missing state items until nothing is pending
+func (s *snapSyncer) syncState(client proto.SnapSyncClient, stateSync *itrie.StateSync) error {
+	for stateSync.Pending() > 0 {
+		nodes, codes := stateSync.Missing(snapSyncRequestSize)
+		if len(nodes) == 0 && len(codes) == 0 {
+			// the pending items are waiting for their children only
+			return errSnapSyncStalled
+		}
+
+		if err := s.fetch(client.GetTrieNodes, nodes, stateSync, stateSync.ProcessNode); err != nil {
+			return err
+		}
+
+		if err := s.fetch(client.GetCodes, codes, stateSync, stateSync.ProcessCode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetch requests the state items from the peer and processes the received ones
+func (s *snapSyncer) fetch(
+	request func(context.Context, *proto.GetStateItemsRequest, ...rawGrpc.CallOption) (*proto.StateItems, error),
+	hashes []types.Hash,
+	stateSync *itrie.StateSync,
+	process func([]byte) error,
+) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	// the items which are not delivered are requested again
+	defer stateSync.Retry(hashes)
+
+	req := &proto.GetStateItemsRequest{Hashes: make([][]byte, len(hashes))}
+	for i, hash := range hashes {
+		req.Hashes[i] = hash.Bytes()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), snapSyncRequestTimeout)
+	defer cancel()
+
+	resp, err := request(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Items) == 0 {
+		return errSnapSyncStalled
+	}
+
+	for _, item := range resp.Items {
+		if err := process(item); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_message"}, 1)
+
+			return err
+		}
+	}
+
+	metrics.IncrCounter([]string{syncerMetrics, "snap_state_items"}, float32(len(resp.Items)))
+
+	return nil
+}
+
+// syncHeaders requests the headers from the last verified one up to the pivot block from the peer,
+// and writes them once the consensus verifies them. The pivot block has to match the verified header
+func (s *snapSyncer) syncHeaders(peerID peer.ID, pivot *types.Header) error {
+	for from := s.lastHeader + 1; from <= pivot.Number; from = s.lastHeader + 1 {
+		to := pivot.Number
+		if to-from >= maxHeadersPerResponse {
+			to = from + maxHeadersPerResponse - 1
+		}
+
+		headers, err := s.peerClient.GetHeaders(peerID, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to get headers: %w", err)
+		}
+
+		if len(headers) == 0 {
+			return errHeadersNotServed
+		}
+
+		if err := s.blockchain.WritePivotHeaders(headers); err != nil {
+			metrics.IncrCounter([]string{syncerMetrics, "bad_block"}, 1)
+
+			return fmt.Errorf("failed to verify headers: %w", err)
+		}
+
+		s.lastHeader = headers[len(headers)-1].Number
+
+		s.logger.Debug("verified headers", "from", from, "to", s.lastHeader, "pivot", pivot.Number)
+	}
+
+	if header, ok := s.blockchain.GetHeaderByNumber(pivot.Number); !ok || header.Hash != pivot.Hash {
+		return fmt.Errorf("%w: block %d doesn't match the verified header", errInvalidPivot, pivot.Number)
+	}
+
+	return nil
+}
+
+// getPivot fetches the pivot block from the peer and verifies it is consistent
+func (s *snapSyncer) getPivot(client proto.SnapSyncClient, number uint64) (*types.FullBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), snapSyncRequestTimeout)
+	defer cancel()
+
+	resp, err := client.GetPivot(ctx, &proto.GetPivotRequest{Number: number})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pivot block: %w", err)
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(resp.Block); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPivot, err)
+	}
+
+	if block.Number() != number {
+		return nil, fmt.Errorf("%w: expected number %d, got %d", errInvalidPivot, number, block.Number())
+	}
+
+	receipts := types.Receipts{}
+	if err := receipts.UnmarshalStoreRLP(resp.Receipts); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPivot, err)
+	}
+
+	return &types.FullBlock{Block: block, Receipts: receipts}, nil
+}
+
+// newClient creates gRPC client
+func (s *snapSyncer) newClient(peerID peer.ID) (proto.SnapSyncClient, error) {
+	conn, err := s.network.NewProtoConnection(snapSyncProto, peerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a stream, err %w", err)
+	}
+
+	s.network.SaveProtocolStream(snapSyncProto, conn, peerID)
+
+	return proto.NewSnapSyncClient(conn), nil
+}
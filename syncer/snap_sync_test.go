@@ -0,0 +1,364 @@
+package syncer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/blockchain"
+	"github.com/0xPolygon/polygon-edge/network"
+	"github.com/0xPolygon/polygon-edge/network/grpc"
+	"github.com/0xPolygon/polygon-edge/state"
+	itrie "github.com/0xPolygon/polygon-edge/state/immutable-trie"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// newSnapSyncSource creates the chain whose last block has the state of accounts holding storage slots
+func newSnapSyncSource(t *testing.T, blocks int) (itrie.Storage, []*types.Header) {
+	t.Helper()
+
+	storage := itrie.NewMemoryStorage()
+
+	objs := make([]*state.Object, 0, 20)
+
+	for i := 0; i < 20; i++ {
+		obj := &state.Object{
+			Address:  types.BytesToAddress([]byte{byte(i + 1)}),
+			Balance:  big.NewInt(int64(i + 1)),
+			CodeHash: types.EmptyCodeHash,
+			Root:     types.EmptyRootHash,
+		}
+
+		for slot := 1; slot <= i; slot++ {
+			obj.Storage = append(obj.Storage, &state.StorageObject{
+				Key: types.BytesToHash(big.NewInt(int64(slot)).Bytes()).Bytes(),
+				Val: big.NewInt(int64(slot * i)).Bytes(),
+			})
+		}
+
+		objs = append(objs, obj)
+	}
+
+	_, root, err := itrie.NewState(storage).NewSnapshot().Commit(objs)
+	require.NoError(t, err)
+
+	headers := blockchain.NewTestHeaders(blocks)
+
+	pivot := headers[len(headers)-1]
+	pivot.StateRoot = types.BytesToHash(root)
+	pivot.ComputeHash()
+
+	return storage, headers
+}
+
+func newSnapSyncSourceChain(headers []*types.Header) *mockBlockchain {
+	return &mockBlockchain{
+		headerHandler: func() *types.Header {
+			return headers[len(headers)-1]
+		},
+		getBlockByNumberHandler: func(number uint64, _ bool) (*types.Block, bool) {
+			if number >= uint64(len(headers)) {
+				return nil, false
+			}
+
+			return &types.Block{Header: headers[number]}, true
+		},
+		getHeaderByNumberHandler: func(number uint64) (*types.Header, bool) {
+			if number >= uint64(len(headers)) {
+				return nil, false
+			}
+
+			return headers[number], true
+		},
+		getTDHandler: func(types.Hash) (*big.Int, bool) {
+			return big.NewInt(100), true
+		},
+		getReceiptsByHashHandler: func(types.Hash) ([]*types.Receipt, error) {
+			return []*types.Receipt{}, nil
+		},
+	}
+}
+
+// newSnapSyncTargetChain creates the empty chain which writes the verified headers up to the pivot block
+func newSnapSyncTargetChain(genesis *types.Header, verify func(*types.Header) error) *mockBlockchain {
+	written := map[uint64]*types.Header{0: genesis}
+
+	return &mockBlockchain{
+		headerHandler: newSimpleHeaderHandler(0),
+		getHeaderByNumberHandler: func(number uint64) (*types.Header, bool) {
+			header, ok := written[number]
+
+			return header, ok
+		},
+		getTDHandler: func(hash types.Hash) (*big.Int, bool) {
+			for _, header := range written {
+				if header.Hash == hash {
+					return new(big.Int).SetUint64(header.Number * 10), true
+				}
+			}
+
+			return nil, false
+		},
+		writePivotHeadersHandler: func(headers []*types.Header) error {
+			for _, header := range headers {
+				if parent, ok := written[header.Number-1]; !ok || parent.Hash != header.ParentHash {
+					return blockchain.ErrParentHashMismatch
+				}
+
+				if err := verify(header); err != nil {
+					return err
+				}
+
+				written[header.Number] = header
+			}
+
+			return nil
+		},
+	}
+}
+
+// newTestSnapSyncer creates the snap syncer connected to the peer serving the given state and chain
+func newTestSnapSyncer(
+	t *testing.T,
+	sourceStorage itrie.Storage,
+	sourceChain *mockBlockchain,
+	targetChain *mockBlockchain,
+	targetStorage itrie.Storage,
+) (*snapSyncer, *network.Server) {
+	t.Helper()
+
+	peerSrv := newTestNetwork(t)
+	newSnapSyncService(peerSrv, sourceChain, sourceStorage).Start()
+
+	clientSrv := newTestNetwork(t)
+	clientSrv.RegisterProtocol(snapSyncProto, grpc.NewGrpcStream())
+
+	require.NoError(t, network.JoinAndWait(
+		clientSrv,
+		peerSrv,
+		network.DefaultBufferTimeout,
+		network.DefaultJoinTimeout,
+	))
+
+	peerClient := &mockSyncPeerClient{
+		getHeadersHandler: func(_ peer.ID, from, to uint64) ([]*types.Header, error) {
+			headers := make([]*types.Header, 0, to-from+1)
+
+			for number := from; number <= to; number++ {
+				header, ok := sourceChain.GetHeaderByNumber(number)
+				if !ok {
+					break
+				}
+
+				headers = append(headers, header)
+			}
+
+			return headers, nil
+		},
+	}
+
+	return &snapSyncer{
+		logger:     hclog.NewNullLogger(),
+		network:    clientSrv,
+		blockchain: targetChain,
+		peerClient: peerClient,
+		config: &SnapSyncConfig{
+			Enabled:       true,
+			PivotDistance: 5,
+			StateStorage:  targetStorage,
+		},
+	}, peerSrv
+}
+
+func TestSnapSync(t *testing.T) {
+	t.Parallel()
+
+	sourceStorage, headers := newSnapSyncSource(t, 11)
+	pivot := headers[len(headers)-1]
+
+	var (
+		writtenBlock     *types.FullBlock
+		writtenTD        *big.Int
+		writtenAncestors []*types.Header
+		verified         []uint64
+	)
+
+	targetChain := newSnapSyncTargetChain(headers[0], func(header *types.Header) error {
+		verified = append(verified, header.Number)
+
+		return nil
+	})
+	targetChain.writePivotBlockHandler = func(b *types.FullBlock, td *big.Int, ancestors []*types.Header) error {
+		writtenBlock, writtenTD, writtenAncestors = b, td, ancestors
+
+		return nil
+	}
+
+	targetStorage := itrie.NewMemoryStorage()
+
+	syncer, peerSrv := newTestSnapSyncer(t, sourceStorage, newSnapSyncSourceChain(headers), targetChain, targetStorage)
+
+	peerLatest := pivot.Number + syncer.config.PivotDistance
+	require.True(t, syncer.shouldSync(peerLatest))
+	require.NoError(t, syncer.sync(peerSrv.AddrInfo().ID, peerLatest))
+
+	// every header up to the pivot block is verified by the consensus
+	require.Len(t, verified, int(pivot.Number))
+
+	for i, number := range verified {
+		require.Equal(t, uint64(i+1), number)
+	}
+
+	// the total difficulty is computed locally, and the ancestors are written already
+	require.Equal(t, pivot.Hash, writtenBlock.Block.Hash())
+	require.Equal(t, big.NewInt(100), writtenTD)
+	require.Empty(t, writtenAncestors)
+
+	// the whole state of the pivot block is synced
+	require.Zero(t, itrie.NewStateSync(targetStorage, pivot.StateRoot).Pending())
+
+	snap, err := itrie.NewState(targetStorage).NewSnapshotAt(pivot.StateRoot)
+	require.NoError(t, err)
+
+	account, err := snap.GetAccount(types.BytesToAddress([]byte{11}))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(11), account.Balance)
+	require.Equal(t,
+		types.BytesToHash(big.NewInt(30).Bytes()),
+		snap.GetStorage(types.BytesToAddress([]byte{11}), account.Root, types.BytesToHash(big.NewInt(3).Bytes())),
+	)
+}
+
+func TestSnapSync_StateNotServed(t *testing.T) {
+	t.Parallel()
+
+	_, headers := newSnapSyncSource(t, 11)
+	pivot := headers[len(headers)-1]
+
+	verified := 0
+
+	targetChain := newSnapSyncTargetChain(headers[0], func(*types.Header) error {
+		verified++
+
+		return nil
+	})
+	targetChain.writePivotBlockHandler = func(*types.FullBlock, *big.Int, []*types.Header) error {
+		t.Fatal("pivot block should not be written")
+
+		return nil
+	}
+
+	// the peer doesn't have the state of the pivot block, e.g. it has been pruned
+	syncer, peerSrv := newTestSnapSyncer(
+		t, itrie.NewMemoryStorage(), newSnapSyncSourceChain(headers), targetChain, itrie.NewMemoryStorage(),
+	)
+
+	err := syncer.sync(peerSrv.AddrInfo().ID, pivot.Number+syncer.config.PivotDistance)
+	require.ErrorIs(t, err, errSnapSyncStalled)
+
+	// the verified headers are not requested again by the next attempt
+	err = syncer.sync(peerSrv.AddrInfo().ID, pivot.Number+syncer.config.PivotDistance)
+	require.ErrorIs(t, err, errSnapSyncStalled)
+	require.Equal(t, int(pivot.Number), verified)
+}
+
+func TestSnapSync_InvalidHeaders(t *testing.T) {
+	t.Parallel()
+
+	errInvalidSeal := errors.New("invalid seal")
+
+	sourceStorage, headers := newSnapSyncSource(t, 11)
+	pivot := headers[len(headers)-1]
+
+	// the consensus rejects a header below the pivot block
+	targetChain := newSnapSyncTargetChain(headers[0], func(header *types.Header) error {
+		if header.Number == 4 {
+			return errInvalidSeal
+		}
+
+		return nil
+	})
+	targetChain.writePivotBlockHandler = func(*types.FullBlock, *big.Int, []*types.Header) error {
+		t.Fatal("pivot block should not be written")
+
+		return nil
+	}
+
+	targetStorage := itrie.NewMemoryStorage()
+
+	syncer, peerSrv := newTestSnapSyncer(t, sourceStorage, newSnapSyncSourceChain(headers), targetChain, targetStorage)
+
+	err := syncer.sync(peerSrv.AddrInfo().ID, pivot.Number+syncer.config.PivotDistance)
+	require.ErrorIs(t, err, errInvalidSeal)
+
+	// no state is downloaded for the unverified pivot block
+	require.Equal(t, 1, itrie.NewStateSync(targetStorage, pivot.StateRoot).Pending())
+}
+
+func TestSnapSync_PivotNotMatchingHeaders(t *testing.T) {
+	t.Parallel()
+
+	sourceStorage, headers := newSnapSyncSource(t, 11)
+	pivot := headers[len(headers)-1]
+
+	// the peer serves a pivot block which is not the one of the verified headers
+	fakePivot := pivot.Copy()
+	fakePivot.StateRoot = types.StringToHash("1")
+	fakePivot.ComputeHash()
+
+	sourceChain := newSnapSyncSourceChain(headers)
+	sourceChain.getBlockByNumberHandler = func(number uint64, _ bool) (*types.Block, bool) {
+		return &types.Block{Header: fakePivot}, number == pivot.Number
+	}
+
+	targetChain := newSnapSyncTargetChain(headers[0], func(*types.Header) error { return nil })
+	targetChain.writePivotBlockHandler = func(*types.FullBlock, *big.Int, []*types.Header) error {
+		t.Fatal("pivot block should not be written")
+
+		return nil
+	}
+
+	syncer, peerSrv := newTestSnapSyncer(t, sourceStorage, sourceChain, targetChain, itrie.NewMemoryStorage())
+
+	err := syncer.sync(peerSrv.AddrInfo().ID, pivot.Number+syncer.config.PivotDistance)
+	require.ErrorIs(t, err, errInvalidPivot)
+}
+
+func TestSnapSync_ShouldSync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		localLatest uint64
+		peerLatest  uint64
+		expected    bool
+	}{
+		{name: "empty chain behind the peer", enabled: true, localLatest: 0, peerLatest: 100, expected: true},
+		{name: "disabled", enabled: false, localLatest: 0, peerLatest: 100, expected: false},
+		{name: "non empty chain", enabled: true, localLatest: 1, peerLatest: 100, expected: false},
+		{name: "peer below the pivot distance", enabled: true, localLatest: 0, peerLatest: 64, expected: false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer := &snapSyncer{
+				blockchain: &mockBlockchain{headerHandler: newSimpleHeaderHandler(test.localLatest)},
+				config:     &SnapSyncConfig{Enabled: test.enabled, PivotDistance: DefaultSnapSyncPivotDistance},
+			}
+
+			require.Equal(t, test.expected, syncer.shouldSync(test.peerLatest))
+		})
+	}
+
+	var syncer *snapSyncer
+	require.False(t, syncer.shouldSync(100))
+}
@@ -33,6 +33,9 @@ type syncer struct {
 	syncPeerService SyncPeerService
 	syncPeerClient  SyncPeerClient
 
+	snapSyncService *snapSyncService
+	snapSyncer      *snapSyncer
+
 	// Timeout for syncing a block
 	blockTimeout time.Duration
 
@@ -45,8 +48,9 @@ func NewSyncer(
 	network Network,
 	blockchain Blockchain,
 	blockTimeout time.Duration,
+	snapSyncConfig *SnapSyncConfig,
 ) Syncer {
-	return &syncer{
+	s := &syncer{
 		logger:          logger.Named(syncerName),
 		blockchain:      blockchain,
 		syncProgression: progress.NewProgressionWrapper(progress.ChainSyncBulk),
@@ -56,6 +60,23 @@ func NewSyncer(
 		newStatusCh:     make(chan struct{}),
 		peerMap:         new(PeerMap),
 	}
+
+	// the state is served to the syncing peers regardless of the local sync mode
+	if snapSyncConfig != nil && snapSyncConfig.StateStorage != nil {
+		s.snapSyncService = newSnapSyncService(network, blockchain, snapSyncConfig.StateStorage)
+
+		if snapSyncConfig.Enabled {
+			s.snapSyncer = &snapSyncer{
+				logger:     s.logger.Named("snap"),
+				network:    network,
+				blockchain: blockchain,
+				peerClient: s.syncPeerClient,
+				config:     snapSyncConfig,
+			}
+		}
+	}
+
+	return s
 }
 
 // Start starts goroutine processes
@@ -66,6 +87,10 @@ func (s *syncer) Start() error {
 
 	s.syncPeerService.Start()
 
+	if s.snapSyncService != nil {
+		s.snapSyncService.Start()
+	}
+
 	s.initializePeerMap()
 
 	go s.startPeerStatusUpdateProcess()
@@ -82,6 +107,12 @@ func (s *syncer) Close() error {
 		return err
 	}
 
+	if s.snapSyncService != nil {
+		if err := s.snapSyncService.Close(); err != nil {
+			return err
+		}
+	}
+
 	s.syncPeerClient.Close()
 
 	return nil
@@ -187,8 +218,31 @@ func (s *syncer) Sync(callback func(*types.FullBlock) bool) error {
 			continue
 		}
 
-		// fetch block from the peer
-		lastNumber, shouldTerminate, err := s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+		// an empty chain starts from the synced state of the pivot block
+		if s.snapSyncer.shouldSync(bestPeer.Number) {
+			if err := s.snapSyncer.sync(bestPeer.ID, bestPeer.Number); err != nil {
+				s.logger.Warn("failed to complete snap sync with peer, try to next one", "peer ID", bestPeer.ID, "error", err)
+
+				skipList[bestPeer.ID] = true
+
+				continue
+			}
+		}
+
+		var (
+			lastNumber      uint64
+			shouldTerminate bool
+			err             error
+		)
+
+		if s.shouldParallelSync(bestPeer, localLatest, skipList) {
+			// fetch blocks from several peers
+			lastNumber, shouldTerminate, err = s.parallelSyncWithPeers(bestPeer, skipList, callback)
+		} else {
+			// fetch block from the peer
+			lastNumber, shouldTerminate, err = s.bulkSyncWithPeer(bestPeer.ID, bestPeer.Number, callback)
+		}
+
 		if err != nil {
 			s.logger.Warn("failed to complete bulk sync with peer, try to next one", "peer ID", "error", bestPeer.ID, err)
 		}
@@ -40,8 +40,14 @@ type mockBlockchain struct {
 	headerHandler               func() *types.Header
 	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
 	verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
+	verifyHeadersHandler        func([]*types.Header, []*types.Header) (bool, error)
 	writeBlockHandler           func(*types.Block) error
 	writeFullBlockHandler       func(*types.FullBlock) error
+	getHeaderByNumberHandler    func(uint64) (*types.Header, bool)
+	getTDHandler                func(types.Hash) (*big.Int, bool)
+	getReceiptsByHashHandler    func(types.Hash) ([]*types.Receipt, error)
+	writePivotHeadersHandler    func([]*types.Header) error
+	writePivotBlockHandler      func(*types.FullBlock, *big.Int, []*types.Header) error
 }
 
 func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
@@ -63,6 +69,10 @@ func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) (*types.FullBlock,
 	return m.verifyFinalizedBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyHeaders(parents []*types.Header, headers []*types.Header) (bool, error) {
+	return m.verifyHeadersHandler(parents, headers)
+}
+
 func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
@@ -71,6 +81,26 @@ func (m *mockBlockchain) WriteFullBlock(b *types.FullBlock, s string) error {
 	return m.writeFullBlockHandler(b)
 }
 
+func (m *mockBlockchain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	return m.getHeaderByNumberHandler(number)
+}
+
+func (m *mockBlockchain) GetTD(hash types.Hash) (*big.Int, bool) {
+	return m.getTDHandler(hash)
+}
+
+func (m *mockBlockchain) GetReceiptsByHash(hash types.Hash) ([]*types.Receipt, error) {
+	return m.getReceiptsByHashHandler(hash)
+}
+
+func (m *mockBlockchain) WritePivotHeaders(headers []*types.Header) error {
+	return m.writePivotHeadersHandler(headers)
+}
+
+func (m *mockBlockchain) WritePivotBlock(b *types.FullBlock, td *big.Int, ancestors []*types.Header) error {
+	return m.writePivotBlockHandler(b, td, ancestors)
+}
+
 func newSimpleHeaderHandler(num uint64) func() *types.Header {
 	return func() *types.Header {
 		return &types.Header{
@@ -93,6 +123,8 @@ type mockSyncPeerClient struct {
 	getPeerStatusHandler                  func(peer.ID) (*NoForkPeer, error)
 	getConnectedPeerStatusesHandler       func() []*NoForkPeer
 	getBlocksHandler                      func(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	getHeadersHandler                     func(peer.ID, uint64, uint64) ([]*types.Header, error)
+	getBlockRangeHandler                  func(peer.ID, uint64, uint64, time.Duration) ([]*types.Block, error)
 	getPeerStatusUpdateChHandler          func() <-chan *NoForkPeer
 	getPeerConnectionUpdateEventChHandler func() <-chan *event.PeerEvent
 }
@@ -123,6 +155,18 @@ func (m *mockSyncPeerClient) GetBlocks(
 	return m.getBlocksHandler(id, start, timeoutPerBlock)
 }
 
+func (m *mockSyncPeerClient) GetHeaders(id peer.ID, from, to uint64) ([]*types.Header, error) {
+	return m.getHeadersHandler(id, from, to)
+}
+
+func (m *mockSyncPeerClient) GetBlockRange(
+	id peer.ID,
+	from, to uint64,
+	timeoutPerBlock time.Duration,
+) ([]*types.Block, error) {
+	return m.getBlockRangeHandler(id, from, to, timeoutPerBlock)
+}
+
 func (m *mockSyncPeerClient) GetPeerStatusUpdateCh() <-chan *NoForkPeer {
 	return m.getPeerStatusUpdateChHandler()
 }
@@ -694,6 +738,7 @@ func Test_bulkSyncWithPeer(t *testing.T) {
 
 		// handlers
 		verifyFinalizedBlockHandler func(*types.Block) (*types.FullBlock, error)
+		verifyHeadersHandler        func([]*types.Header, []*types.Header) (bool, error)
 		writeFullBlockHandler       func(*types.FullBlock) error
 
 		// results
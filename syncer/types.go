@@ -29,10 +29,22 @@ type Blockchain interface {
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(block *types.Block) (*types.FullBlock, error)
+	// VerifyHeaders verifies the headers following the verified parents, false if the consensus can't verify them yet
+	VerifyHeaders([]*types.Header, []*types.Header) (bool, error)
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 	// WriteFullBlock writes a given block to chain and saves its receipts to cache
 	WriteFullBlock(*types.FullBlock, string) error
+	// GetHeaderByNumber returns header by number
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	// GetTD returns the total difficulty of the block
+	GetTD(types.Hash) (*big.Int, bool)
+	// GetReceiptsByHash returns the receipts of the block
+	GetReceiptsByHash(types.Hash) ([]*types.Receipt, error)
+	// WritePivotHeaders verifies and writes the headers up to the snap sync pivot block, without moving the head
+	WritePivotHeaders([]*types.Header) error
+	// WritePivotBlock writes the snap synced pivot block as the head of an empty chain
+	WritePivotBlock(*types.FullBlock, *big.Int, []*types.Header) error
 }
 
 type Network interface {
@@ -101,6 +113,10 @@ type SyncPeerClient interface {
 	GetConnectedPeerStatuses() []*NoForkPeer
 	// GetBlocks returns a stream of blocks from given height to peer's latest
 	GetBlocks(peer.ID, uint64, time.Duration) (<-chan *types.Block, error)
+	// GetHeaders returns the headers of the given range the peer has
+	GetHeaders(peer.ID, uint64, uint64) ([]*types.Header, error)
+	// GetBlockRange returns the blocks of the given range the peer sends within the timeout per block
+	GetBlockRange(peer.ID, uint64, uint64, time.Duration) ([]*types.Block, error)
 	// GetPeerStatusUpdateCh returns a channel of peer's status update
 	GetPeerStatusUpdateCh() <-chan *NoForkPeer
 	// GetPeerConnectionUpdateEventCh returns peer's connection change event
@@ -0,0 +1,290 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/armon/go-metrics"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+const (
+	// maximum number of transactions in a single bundle
+	maxBundleTxs = 32
+
+	// maximum number of bundles kept in the pool
+	maxBundles = 1024
+
+	// maximum number of pending bundles containing transactions of a single account
+	maxAccountBundles = 16
+
+	// maximum number of blocks, counted from the current block,
+	// a bundle can target
+	maxBundleBlockRange = 256
+)
+
+var (
+	ErrEmptyBundle           = errors.New("bundle has no transactions")
+	ErrBundleTooLarge        = errors.New("bundle has too many transactions")
+	ErrInvalidBundleRange    = errors.New("invalid bundle block range")
+	ErrBundleExpired         = errors.New("bundle block range is in the past")
+	ErrBundleAlreadyKnown    = errors.New("bundle already known")
+	ErrBundlePoolOverflow    = errors.New("bundle pool is full")
+	ErrMaxAccountBundles     = errors.New("maximum number of bundles per account reached")
+	ErrDuplicateBundleTxHash = errors.New("duplicate transaction in bundle")
+)
+
+// bundleStore keeps track of all the bundles
+// submitted to the pool, indexed by their hash
+type bundleStore struct {
+	sync.RWMutex
+	all map[types.Hash]*types.Bundle
+
+	// number of stored bundles containing transactions of an account
+	accounts map[types.Address]int
+}
+
+func newBundleStore() *bundleStore {
+	return &bundleStore{
+		all:      make(map[types.Hash]*types.Bundle),
+		accounts: make(map[types.Address]int),
+	}
+}
+
+// add inserts the bundle into the store. [thread-safe]
+func (s *bundleStore) add(bundle *types.Bundle) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, exists := s.all[bundle.Hash]; exists {
+		return ErrBundleAlreadyKnown
+	}
+
+	if len(s.all) >= maxBundles {
+		return ErrBundlePoolOverflow
+	}
+
+	senders := bundleSenders(bundle)
+	for _, sender := range senders {
+		if s.accounts[sender] >= maxAccountBundles {
+			return fmt.Errorf("%w: %s", ErrMaxAccountBundles, sender)
+		}
+	}
+
+	for _, sender := range senders {
+		s.accounts[sender]++
+	}
+
+	s.all[bundle.Hash] = bundle
+
+	return nil
+}
+
+// remove deletes the bundles with given hashes from the store. [thread-safe]
+func (s *bundleStore) remove(hashes ...types.Hash) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, hash := range hashes {
+		if bundle, exists := s.all[hash]; exists {
+			s.delete(bundle)
+		}
+	}
+}
+
+// delete removes the bundle and releases its account counters. [not thread-safe]
+func (s *bundleStore) delete(bundle *types.Bundle) {
+	delete(s.all, bundle.Hash)
+
+	for _, sender := range bundleSenders(bundle) {
+		if s.accounts[sender]--; s.accounts[sender] <= 0 {
+			delete(s.accounts, sender)
+		}
+	}
+}
+
+// forBlock returns all the bundles which can be included in the block
+// with the given number, ordered by the lowest MinBlock first
+// (older bundles first), and by the hash for determinism. [thread-safe]
+func (s *bundleStore) forBlock(blockNumber uint64) []*types.Bundle {
+	s.RLock()
+	defer s.RUnlock()
+
+	bundles := make([]*types.Bundle, 0)
+
+	for _, bundle := range s.all {
+		if bundle.IsValidFor(blockNumber) {
+			bundles = append(bundles, bundle)
+		}
+	}
+
+	sort.Slice(bundles, func(i, j int) bool {
+		if bundles[i].MinBlock != bundles[j].MinBlock {
+			return bundles[i].MinBlock < bundles[j].MinBlock
+		}
+
+		return bundles[i].Hash.String() < bundles[j].Hash.String()
+	})
+
+	return bundles
+}
+
+// prune removes the bundles which are expired at the given block number
+// or contain any of the given (already mined) transactions. [thread-safe]
+func (s *bundleStore) prune(blockNumber uint64, mined map[types.Hash]struct{}) int {
+	s.Lock()
+	defer s.Unlock()
+
+	pruned := 0
+
+	for _, bundle := range s.all {
+		remove := bundle.IsExpired(blockNumber)
+
+		for _, tx := range bundle.Txs {
+			if remove {
+				break
+			}
+
+			_, remove = mined[tx.Hash]
+		}
+
+		if remove {
+			s.delete(bundle)
+
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+// length returns the number of bundles in the store. [thread-safe]
+func (s *bundleStore) length() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return len(s.all)
+}
+
+// bundleSenders returns the distinct senders of the bundle transactions
+func bundleSenders(bundle *types.Bundle) []types.Address {
+	senders := make([]types.Address, 0, 1)
+	seen := make(map[types.Address]struct{}, len(bundle.Txs))
+
+	for _, tx := range bundle.Txs {
+		if _, exists := seen[tx.From]; !exists {
+			seen[tx.From] = struct{}{}
+			senders = append(senders, tx.From)
+		}
+	}
+
+	return senders
+}
+
+// AddBundle validates the given bundle and stores it in the pool
+// until it gets included into a block or its block range expires.
+// Bundle transactions are not added to the regular pool queues.
+//
+// Bundles are not gossiped, so they are only considered for inclusion
+// when this node is the block proposer. Clients should send bundles
+// to the validators directly.
+func (p *TxPool) AddBundle(bundle *types.Bundle) error {
+	if err := p.validateBundle(bundle); err != nil {
+		metrics.IncrCounter([]string{txPoolMetrics, "invalid_bundles"}, 1)
+
+		return err
+	}
+
+	bundle.ComputeHash()
+
+	if err := p.bundles.add(bundle); err != nil {
+		return err
+	}
+
+	metrics.SetGauge([]string{txPoolMetrics, "bundles"}, float32(p.bundles.length()))
+
+	if p.logger.IsDebug() {
+		p.logger.Debug("add bundle", "hash", bundle.Hash.String(), "txs", len(bundle.Txs),
+			"minBlock", bundle.MinBlock, "maxBlock", bundle.MaxBlock)
+	}
+
+	return nil
+}
+
+// Bundles returns the bundles that can be included in the block with the given number
+func (p *TxPool) Bundles(blockNumber uint64) []*types.Bundle {
+	return p.bundles.forBlock(blockNumber)
+}
+
+// RemoveBundle removes the bundle with the given hash from the pool
+func (p *TxPool) RemoveBundle(hash types.Hash) {
+	p.bundles.remove(hash)
+}
+
+// validateBundle checks the bundle block range and validates each bundle
+// transaction with the same rules applied to the regular pool transactions
+func (p *TxPool) validateBundle(bundle *types.Bundle) error {
+	if len(bundle.Txs) == 0 {
+		return ErrEmptyBundle
+	}
+
+	if len(bundle.Txs) > maxBundleTxs {
+		return fmt.Errorf("%w: %d, max %d", ErrBundleTooLarge, len(bundle.Txs), maxBundleTxs)
+	}
+
+	currentHeader := p.store.Header()
+	currentBlockNumber := currentHeader.Number
+
+	if bundle.MinBlock == 0 {
+		bundle.MinBlock = currentBlockNumber + 1
+	}
+
+	if bundle.MaxBlock == 0 {
+		bundle.MaxBlock = bundle.MinBlock
+	}
+
+	if bundle.MinBlock > bundle.MaxBlock {
+		return fmt.Errorf("%w: min block %d is greater than max block %d",
+			ErrInvalidBundleRange, bundle.MinBlock, bundle.MaxBlock)
+	}
+
+	if bundle.IsExpired(currentBlockNumber) {
+		return fmt.Errorf("%w: max block %d, current block %d",
+			ErrBundleExpired, bundle.MaxBlock, currentBlockNumber)
+	}
+
+	if bundle.MaxBlock-currentBlockNumber > maxBundleBlockRange {
+		return fmt.Errorf("%w: max block %d is more than %d blocks ahead of current block %d",
+			ErrInvalidBundleRange, bundle.MaxBlock, maxBundleBlockRange, currentBlockNumber)
+	}
+
+	if bundle.Gas() > currentHeader.GasLimit {
+		return ErrBlockLimitExceeded
+	}
+
+	seen := make(map[types.Hash]struct{}, len(bundle.Txs))
+
+	for _, tx := range bundle.Txs {
+		// the same checks as for regular transactions (signature, fees, nonce, balance, gas)
+		if err := p.validateTx(local, tx); err != nil {
+			return err
+		}
+
+		if tx.Type == types.DynamicFeeTx {
+			tx.ChainID = p.chainID
+		}
+
+		tx.ComputeHash(currentBlockNumber)
+
+		if _, exists := seen[tx.Hash]; exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateBundleTxHash, tx.Hash)
+		}
+
+		seen[tx.Hash] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// returns a new valid bundle tx with the given nonce
+func newBundleTx(addr types.Address, nonce uint64) *types.Transaction {
+	tx := newTx(addr, nonce, 1)
+	tx.Gas = 100_000
+
+	// the sender is not part of the hash, so the transactions
+	// of different senders are told apart by the receiver
+	to := addr
+	tx.To = &to
+
+	return tx
+}
+
+func TestAddBundle(t *testing.T) {
+	t.Parallel()
+
+	setupPool := func(t *testing.T, headNumber uint64) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool(NewDefaultMockStore(&types.Header{Number: headNumber, GasLimit: mockHeader.GasLimit}))
+		require.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+
+		return pool
+	}
+
+	t.Run("empty bundle", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		assert.ErrorIs(t, pool.AddBundle(&types.Bundle{}), ErrEmptyBundle)
+	})
+
+	t.Run("invalid block range", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		err := pool.AddBundle(&types.Bundle{
+			Txs:      []*types.Transaction{newBundleTx(addr1, 0)},
+			MinBlock: 15,
+			MaxBlock: 12,
+		})
+		assert.ErrorIs(t, err, ErrInvalidBundleRange)
+	})
+
+	t.Run("expired bundle", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		err := pool.AddBundle(&types.Bundle{
+			Txs:      []*types.Transaction{newBundleTx(addr1, 0)},
+			MinBlock: 5,
+			MaxBlock: 10,
+		})
+		assert.ErrorIs(t, err, ErrBundleExpired)
+	})
+
+	t.Run("state transaction in bundle", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		tx := newBundleTx(addr1, 0)
+		tx.Type = types.StateTx
+
+		assert.ErrorIs(t, pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{tx}}), ErrInvalidTxType)
+	})
+
+	t.Run("block range too far ahead", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		err := pool.AddBundle(&types.Bundle{
+			Txs:      []*types.Transaction{newBundleTx(addr1, 0)},
+			MaxBlock: 10 + maxBundleBlockRange + 1,
+		})
+		assert.ErrorIs(t, err, ErrInvalidBundleRange)
+	})
+
+	t.Run("bundle exceeds block gas limit", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		err := pool.AddBundle(&types.Bundle{
+			Txs: []*types.Transaction{newTx(addr1, 0, 1), newTx(addr1, 1, 1)},
+		})
+		assert.ErrorIs(t, err, ErrBlockLimitExceeded)
+	})
+
+	t.Run("transaction nonce too low", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewDefaultMockStore(&types.Header{Number: 10, GasLimit: mockHeader.GasLimit})
+		store.nonce = 5
+
+		pool, err := newTestPool(store)
+		require.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+
+		assert.ErrorIs(t, pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{newBundleTx(addr1, 4)}}), ErrNonceTooLow)
+	})
+
+	t.Run("transaction underpriced", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		tx := newBundleTx(addr1, 0)
+		tx.GasPrice.SetUint64(0)
+
+		assert.ErrorIs(t, pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{tx}}), ErrUnderpriced)
+	})
+
+	t.Run("maximum bundles per account", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+
+		for i := 0; i < maxAccountBundles; i++ {
+			require.NoError(t, pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{newBundleTx(addr1, uint64(i))}}))
+		}
+
+		err := pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{newBundleTx(addr1, maxAccountBundles)}})
+		assert.ErrorIs(t, err, ErrMaxAccountBundles)
+
+		// other accounts are not affected
+		require.NoError(t, pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{newBundleTx(addr2, 0)}}))
+
+		// removing a bundle releases the account slot
+		pool.RemoveBundle(pool.Bundles(11)[0].Hash)
+		require.NoError(t, pool.AddBundle(&types.Bundle{Txs: []*types.Transaction{newBundleTx(addr1, maxAccountBundles)}}))
+	})
+
+	t.Run("duplicate bundle", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+		txs := []*types.Transaction{newBundleTx(addr1, 0), newBundleTx(addr2, 0)}
+
+		require.NoError(t, pool.AddBundle(&types.Bundle{Txs: txs}))
+		assert.ErrorIs(t, pool.AddBundle(&types.Bundle{Txs: txs}), ErrBundleAlreadyKnown)
+
+		// the same transactions with a different block range are a different bundle
+		require.NoError(t, pool.AddBundle(&types.Bundle{Txs: txs, MinBlock: 11, MaxBlock: 12}))
+	})
+
+	t.Run("default block range targets the next block", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t, 10)
+		bundle := &types.Bundle{Txs: []*types.Transaction{newBundleTx(addr1, 0)}}
+
+		require.NoError(t, pool.AddBundle(bundle))
+		assert.Equal(t, uint64(11), bundle.MinBlock)
+		assert.Equal(t, uint64(11), bundle.MaxBlock)
+		assert.NotEqual(t, types.ZeroHash, bundle.Hash)
+
+		assert.Len(t, pool.Bundles(10), 0)
+		assert.Len(t, pool.Bundles(11), 1)
+		assert.Len(t, pool.Bundles(12), 0)
+
+		pool.RemoveBundle(bundle.Hash)
+		assert.Len(t, pool.Bundles(11), 0)
+	})
+}
+
+func TestBundlesPrunedOnReset(t *testing.T) {
+	t.Parallel()
+
+	minedTx := newBundleTx(addr1, 0)
+	minedTx.ComputeHash(0)
+
+	block := &types.Block{
+		Header:       &types.Header{Number: 11, Hash: types.Hash{0x11}, GasLimit: mockHeader.GasLimit},
+		Transactions: []*types.Transaction{minedTx},
+	}
+
+	store := NewDefaultMockStore(&types.Header{Number: 10, GasLimit: mockHeader.GasLimit})
+	store.getBlockByHashFn = func(h types.Hash, _ bool) (*types.Block, bool) {
+		return block, h == block.Hash()
+	}
+
+	pool, err := newTestPool(store)
+	require.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	// included in the mined block
+	included := &types.Bundle{Txs: []*types.Transaction{minedTx.Copy()}, MinBlock: 11, MaxBlock: 20}
+	// expires with the mined block
+	expired := &types.Bundle{Txs: []*types.Transaction{newBundleTx(addr2, 0)}, MinBlock: 11, MaxBlock: 11}
+	// still valid for the next blocks
+	pending := &types.Bundle{Txs: []*types.Transaction{newBundleTx(addr3, 0)}, MinBlock: 11, MaxBlock: 12}
+
+	require.NoError(t, pool.AddBundle(included))
+	require.NoError(t, pool.AddBundle(expired))
+	require.NoError(t, pool.AddBundle(pending))
+	require.Len(t, pool.Bundles(11), 3)
+
+	pool.ResetWithHeaders(block.Header)
+
+	bundles := pool.Bundles(12)
+	require.Len(t, bundles, 1)
+	assert.Equal(t, pending.Hash, bundles[0].Hash)
+}
@@ -10,6 +10,18 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// arrival sequence number of each transaction,
+	// used by the arrival based ordering policies
+	arrivals    map[types.Hash]uint64
+	nextArrival uint64
+}
+
+func newLookupMap() lookupMap {
+	return lookupMap{
+		all:      make(map[types.Hash]*types.Transaction),
+		arrivals: make(map[types.Hash]uint64),
+	}
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -23,6 +35,8 @@ func (m *lookupMap) add(tx *types.Transaction) bool {
 	}
 
 	m.all[tx.Hash] = tx
+	m.arrivals[tx.Hash] = m.nextArrival
+	m.nextArrival++
 
 	return true
 }
@@ -34,6 +48,7 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.arrivals, tx.Hash)
 	}
 }
 
@@ -49,3 +64,17 @@ func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 
 	return tx, true
 }
+
+// arrival returns the arrival sequence number of the given transaction.
+// Transactions not present in the map are considered the most recent ones. [thread-safe]
+func (m *lookupMap) arrival(tx *types.Transaction) uint64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	arrival, ok := m.arrivals[tx.Hash]
+	if !ok {
+		return m.nextArrival
+	}
+
+	return arrival
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/txpool/proto"
 	"github.com/0xPolygon/polygon-edge/types"
+	"github.com/golang/protobuf/ptypes/any"
 	empty "google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -97,3 +98,79 @@ func (p *TxPool) TxPoolSubscribe(request *proto.SubscribeRequest) (<-chan *proto
 
 	return subscription.subscriptionChannel, cancelSubscription, nil
 }
+
+// DropTx implements the operator endpoint. It removes the transaction,
+// and the subsequent transactions of its sender, from the pool
+func (p *TxPool) DropTx(ctx context.Context, req *proto.DropTxReq) (*proto.DropResp, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	removed, err := p.RemoveTx(types.StringToHash(req.Hash))
+	if err != nil {
+		return nil, err
+	}
+
+	return toDropResp(removed), nil
+}
+
+// DropAccount implements the operator endpoint. It removes all the transactions of the account from the pool
+func (p *TxPool) DropAccount(ctx context.Context, req *proto.AccountReq) (*proto.DropResp, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	removed, err := p.RemoveAccount(types.StringToAddress(req.Address))
+	if err != nil {
+		return nil, err
+	}
+
+	return toDropResp(removed), nil
+}
+
+// ContentFrom implements the operator endpoint. It returns the pending and queued transactions of the account
+func (p *TxPool) ContentFrom(ctx context.Context, req *proto.AccountReq) (*proto.ContentFromResp, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	promoted, enqueued := p.GetAccountTxs(types.StringToAddress(req.Address))
+
+	return &proto.ContentFromResp{
+		Pending: toPoolTxns(promoted),
+		Queued:  toPoolTxns(enqueued),
+	}, nil
+}
+
+// Clear implements the operator endpoint. It removes all the transactions from the pool
+func (p *TxPool) Clear(ctx context.Context, req *empty.Empty) (*proto.DropResp, error) {
+	return toDropResp(p.RemoveAll()), nil
+}
+
+func toDropResp(txs []*types.Transaction) *proto.DropResp {
+	resp := &proto.DropResp{
+		TxHashes: make([]string, len(txs)),
+	}
+
+	for i, tx := range txs {
+		resp.TxHashes[i] = tx.Hash.String()
+	}
+
+	return resp
+}
+
+func toPoolTxns(txs []*types.Transaction) []*proto.PoolTxn {
+	poolTxns := make([]*proto.PoolTxn, len(txs))
+
+	for i, tx := range txs {
+		poolTxns[i] = &proto.PoolTxn{
+			Hash:  tx.Hash.String(),
+			Nonce: tx.Nonce,
+			Raw: &any.Any{
+				Value: tx.MarshalRLP(),
+			},
+		}
+	}
+
+	return poolTxns
+}
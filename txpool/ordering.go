@@ -0,0 +1,246 @@
+package txpool
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// OrderingPolicy defines the order in which the block proposer
+// drains the executable transactions of the pool
+type OrderingPolicy string
+
+const (
+	// PriceOrdering selects the transaction with the highest effective price first
+	PriceOrdering OrderingPolicy = "price"
+
+	// FIFOOrdering selects the transaction which arrived to the pool first
+	FIFOOrdering OrderingPolicy = "fifo"
+
+	// RoundRobinOrdering selects a single transaction of each sender in turns,
+	// starting from the sender whose transaction arrived to the pool first
+	RoundRobinOrdering OrderingPolicy = "roundrobin"
+)
+
+var ErrUnknownOrderingPolicy = errors.New("unknown transaction ordering policy")
+
+// ParseOrderingPolicy returns the ordering policy with the given name,
+// defaulting to the price ordering if the name is empty
+func ParseOrderingPolicy(name string) (OrderingPolicy, error) {
+	switch policy := OrderingPolicy(name); policy {
+	case "":
+		return PriceOrdering, nil
+	case PriceOrdering, FIFOOrdering, RoundRobinOrdering:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownOrderingPolicy, name)
+	}
+}
+
+// executablesQueue is the queue of primaries (first-in-line promoted transactions
+// of each account) consumed by the block proposer through Peek and Pop.
+// Each ordering policy provides its own implementation
+type executablesQueue interface {
+	// push adds the next primary of an account to the queue
+	push(tx *types.Transaction)
+
+	// pop removes the next transaction to be executed from the queue,
+	// or returns nil if the queue is empty
+	pop() *types.Transaction
+
+	// length returns the number of transactions in the queue
+	length() int
+
+	// removeAccount removes the primary of the given account from the queue.
+	// Returns true if the queue contained it
+	removeAccount(addr types.Address) bool
+}
+
+// newExecutablesQueue creates the queue of the given ordering policy with the initial transactions.
+// arrival returns the sequence number of the transaction arrival to the pool
+func newExecutablesQueue(
+	policy OrderingPolicy,
+	baseFee uint64,
+	initialTxs []*types.Transaction,
+	arrival func(tx *types.Transaction) uint64,
+) executablesQueue {
+	switch policy {
+	case FIFOOrdering:
+		return newFIFOQueue(initialTxs, arrival)
+	case RoundRobinOrdering:
+		return newRoundRobinQueue(initialTxs, arrival)
+	default:
+		return newPricesQueue(baseFee, initialTxs)
+	}
+}
+
+// arrivedTx is a transaction with the sequence number of its arrival to the pool
+type arrivedTx struct {
+	tx      *types.Transaction
+	arrival uint64
+}
+
+// fifoQueue orders the transactions by their arrival to the pool (oldest first)
+type fifoQueue struct {
+	queue   *minArrivalQueue
+	arrival func(tx *types.Transaction) uint64
+}
+
+func newFIFOQueue(initialTxs []*types.Transaction, arrival func(tx *types.Transaction) uint64) *fifoQueue {
+	q := &fifoQueue{
+		queue:   &minArrivalQueue{txs: make([]arrivedTx, 0, len(initialTxs))},
+		arrival: arrival,
+	}
+
+	for _, tx := range initialTxs {
+		q.queue.txs = append(q.queue.txs, arrivedTx{tx: tx, arrival: arrival(tx)})
+	}
+
+	heap.Init(q.queue)
+
+	return q
+}
+
+// push adds the given transaction onto the queue
+func (q *fifoQueue) push(tx *types.Transaction) {
+	heap.Push(q.queue, arrivedTx{tx: tx, arrival: q.arrival(tx)})
+}
+
+// pop removes the first transaction from the queue
+// or nil if the queue is empty.
+func (q *fifoQueue) pop() *types.Transaction {
+	if q.length() == 0 {
+		return nil
+	}
+
+	transaction, ok := heap.Pop(q.queue).(arrivedTx)
+	if !ok {
+		return nil
+	}
+
+	return transaction.tx
+}
+
+// length returns the number of transactions in the queue.
+func (q *fifoQueue) length() int {
+	return q.queue.Len()
+}
+
+// removeAccount removes the transaction of the given account from the queue
+func (q *fifoQueue) removeAccount(addr types.Address) bool {
+	kept := q.queue.txs[:0]
+
+	for _, arrived := range q.queue.txs {
+		if arrived.tx.From != addr {
+			kept = append(kept, arrived)
+		}
+	}
+
+	if len(kept) == len(q.queue.txs) {
+		return false
+	}
+
+	q.queue.txs = kept
+	heap.Init(q.queue)
+
+	return true
+}
+
+// transactions sorted by arrival (ascending)
+type minArrivalQueue struct {
+	txs []arrivedTx
+}
+
+/* Queue methods required by the heap interface */
+
+func (q *minArrivalQueue) Len() int {
+	return len(q.txs)
+}
+
+func (q *minArrivalQueue) Swap(i, j int) {
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
+}
+
+func (q *minArrivalQueue) Less(i, j int) bool {
+	return q.txs[i].arrival < q.txs[j].arrival
+}
+
+func (q *minArrivalQueue) Push(x interface{}) {
+	transaction, ok := x.(arrivedTx)
+	if !ok {
+		return
+	}
+
+	q.txs = append(q.txs, transaction)
+}
+
+func (q *minArrivalQueue) Pop() interface{} {
+	old := q.txs
+	n := len(old)
+	x := old[n-1]
+	q.txs = old[0 : n-1]
+
+	return x
+}
+
+// roundRobinQueue takes a single transaction of each account in turns.
+// Since the pool pushes the next primary of an account only after its
+// previous one is popped, a plain FIFO list of primaries cycles through the accounts
+type roundRobinQueue struct {
+	txs []*types.Transaction
+}
+
+func newRoundRobinQueue(initialTxs []*types.Transaction, arrival func(tx *types.Transaction) uint64) *roundRobinQueue {
+	txs := make([]*types.Transaction, len(initialTxs))
+	copy(txs, initialTxs)
+
+	// the first turn starts with the account whose primary arrived first
+	sort.SliceStable(txs, func(i, j int) bool {
+		return arrival(txs[i]) < arrival(txs[j])
+	})
+
+	return &roundRobinQueue{txs: txs}
+}
+
+// push adds the given transaction at the end of the queue (the account's next turn)
+func (q *roundRobinQueue) push(tx *types.Transaction) {
+	q.txs = append(q.txs, tx)
+}
+
+// pop removes the first transaction from the queue
+// or nil if the queue is empty.
+func (q *roundRobinQueue) pop() *types.Transaction {
+	if q.length() == 0 {
+		return nil
+	}
+
+	tx := q.txs[0]
+	q.txs[0] = nil
+	q.txs = q.txs[1:]
+
+	return tx
+}
+
+// length returns the number of transactions in the queue.
+func (q *roundRobinQueue) length() int {
+	return len(q.txs)
+}
+
+// removeAccount removes the transaction of the given account from the queue
+func (q *roundRobinQueue) removeAccount(addr types.Address) bool {
+	kept := q.txs[:0]
+
+	for _, tx := range q.txs {
+		if tx.From != addr {
+			kept = append(kept, tx)
+		}
+	}
+
+	removed := len(kept) != len(q.txs)
+	q.txs = kept
+
+	return removed
+}
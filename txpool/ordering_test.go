@@ -0,0 +1,207 @@
+package txpool
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/polygon-edge/txpool/proto"
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+func TestParseOrderingPolicy(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]OrderingPolicy{
+		"":           PriceOrdering,
+		"price":      PriceOrdering,
+		"fifo":       FIFOOrdering,
+		"roundrobin": RoundRobinOrdering,
+	} {
+		policy, err := ParseOrderingPolicy(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, policy)
+	}
+
+	_, err := ParseOrderingPolicy("lifo")
+	assert.ErrorIs(t, err, ErrUnknownOrderingPolicy)
+}
+
+func TestNewTxPool_UnknownOrderingPolicy(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTxPool(
+		hclog.NewNullLogger(),
+		getDefaultEnabledForks(),
+		defaultMockStore{DefaultHeader: mockHeader},
+		nil,
+		nil,
+		&Config{
+			PriceLimit:         defaultPriceLimit,
+			MaxSlots:           defaultMaxSlots,
+			MaxAccountEnqueued: defaultMaxAccountEnqueued,
+			Ordering:           "lifo",
+		},
+	)
+	assert.ErrorIs(t, err, ErrUnknownOrderingPolicy)
+}
+
+func Test_executablesQueue(t *testing.T) {
+	t.Parallel()
+
+	txs := []*types.Transaction{
+		{Nonce: 0, From: addr1, Hash: types.Hash{0x1}},
+		{Nonce: 0, From: addr2, Hash: types.Hash{0x2}},
+		{Nonce: 0, From: addr3, Hash: types.Hash{0x3}},
+	}
+
+	// arrival order: addr3, addr1, addr2, addr1 (next primary)
+	arrivals := map[types.Hash]uint64{{0x3}: 0, {0x1}: 1, {0x2}: 2, {0x4}: 3}
+	arrival := func(tx *types.Transaction) uint64 {
+		return arrivals[tx.Hash]
+	}
+
+	next := &types.Transaction{Nonce: 1, From: addr1, Hash: types.Hash{0x4}}
+
+	t.Run("fifo", func(t *testing.T) {
+		t.Parallel()
+
+		q := newExecutablesQueue(FIFOOrdering, 0, append([]*types.Transaction{}, txs...), arrival)
+		require.Equal(t, 3, q.length())
+
+		require.Equal(t, txs[2], q.pop())
+		require.Equal(t, txs[0], q.pop())
+
+		// the next primary arrived after all the others
+		q.push(next)
+
+		require.Equal(t, txs[1], q.pop())
+		require.Equal(t, next, q.pop())
+		require.Nil(t, q.pop())
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		t.Parallel()
+
+		// the next primary arrived before any of the others
+		arrival := func(tx *types.Transaction) uint64 {
+			if tx == next {
+				return 0
+			}
+
+			return arrivals[tx.Hash] + 1
+		}
+
+		q := newExecutablesQueue(RoundRobinOrdering, 0, append([]*types.Transaction{}, txs...), arrival)
+		require.Equal(t, 3, q.length())
+
+		require.Equal(t, txs[2], q.pop())
+		require.Equal(t, txs[0], q.pop())
+
+		// the next primary waits for the turn of its account
+		q.push(next)
+
+		require.Equal(t, txs[1], q.pop())
+		require.Equal(t, next, q.pop())
+		require.Nil(t, q.pop())
+	})
+}
+
+func TestExecutablesOrderingPolicies(t *testing.T) {
+	t.Parallel()
+
+	newPricedTx := func(addr types.Address, nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	type sent struct {
+		addr  types.Address
+		nonce uint64
+	}
+
+	testCases := []struct {
+		ordering      OrderingPolicy
+		expectedOrder []sent
+	}{
+		{
+			ordering:      PriceOrdering,
+			expectedOrder: []sent{{addr2, 0}, {addr2, 1}, {addr1, 0}, {addr1, 1}, {addr1, 2}},
+		},
+		{
+			ordering:      FIFOOrdering,
+			expectedOrder: []sent{{addr1, 0}, {addr1, 1}, {addr1, 2}, {addr2, 0}, {addr2, 1}},
+		},
+		{
+			ordering:      RoundRobinOrdering,
+			expectedOrder: []sent{{addr1, 0}, {addr2, 0}, {addr1, 1}, {addr2, 1}, {addr1, 2}},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(string(test.ordering), func(t *testing.T) {
+			t.Parallel()
+
+			pool, err := NewTxPool(
+				hclog.NewNullLogger(),
+				getDefaultEnabledForks(),
+				defaultMockStore{DefaultHeader: mockHeader},
+				nil,
+				nil,
+				&Config{
+					PriceLimit:         defaultPriceLimit,
+					MaxSlots:           defaultMaxSlots,
+					MaxAccountEnqueued: defaultMaxAccountEnqueued,
+					Ordering:           test.ordering,
+				},
+			)
+			require.NoError(t, err)
+
+			pool.SetSigner(&mockSigner{})
+
+			pool.Start()
+			defer pool.Close()
+
+			subscription := pool.eventManager.subscribe(
+				[]proto.EventType{proto.EventType_PROMOTED},
+			)
+
+			// addr1 transactions arrive first, addr2 transactions pay more
+			txs := []*types.Transaction{
+				newPricedTx(addr1, 0, 1),
+				newPricedTx(addr1, 1, 1),
+				newPricedTx(addr1, 2, 1),
+				newPricedTx(addr2, 0, 10),
+				newPricedTx(addr2, 1, 10),
+			}
+
+			for _, tx := range txs {
+				require.NoError(t, pool.addTx(local, tx))
+			}
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*10)
+			defer cancelFn()
+
+			require.Len(t, waitForEvents(ctx, subscription, len(txs)), len(txs))
+
+			pool.Prepare()
+
+			var order []sent
+
+			for tx := pool.Peek(); tx != nil; tx = pool.Peek() {
+				pool.Pop(tx)
+				order = append(order, sent{tx.From, tx.Nonce})
+			}
+
+			require.Equal(t, test.expectedOrder, order)
+		})
+	}
+}
@@ -267,6 +267,630 @@ var _ interface {
 	ErrorName() string
 } = AddTxnRespValidationError{}
 
+// Validate checks the field values on DropTxReq with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DropTxReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DropTxReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in DropTxReqMultiError, or nil if none
+// found.
+func (m *DropTxReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DropTxReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_DropTxReq_Hash_Pattern.MatchString(m.GetHash()) {
+		err := DropTxReqValidationError{
+			field:  "Hash",
+			reason: "value does not match regex pattern \"^0x[a-fA-F0-9]{64}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DropTxReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// DropTxReqMultiError is an error wrapping multiple validation errors returned
+// by DropTxReq.ValidateAll() if the designated constraints aren't met.
+type DropTxReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DropTxReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DropTxReqMultiError) AllErrors() []error { return m }
+
+// DropTxReqValidationError is the validation error returned by
+// DropTxReq.Validate if the designated constraints aren't met.
+type DropTxReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DropTxReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DropTxReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DropTxReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DropTxReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DropTxReqValidationError) ErrorName() string { return "DropTxReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DropTxReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDropTxReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DropTxReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DropTxReqValidationError{}
+
+var _DropTxReq_Hash_Pattern = regexp.MustCompile("^0x[a-fA-F0-9]{64}$")
+
+// Validate checks the field values on AccountReq with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AccountReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AccountReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in AccountReqMultiError, or nil if
+// none found.
+func (m *AccountReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AccountReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_AccountReq_Address_Pattern.MatchString(m.GetAddress()) {
+		err := AccountReqValidationError{
+			field:  "Address",
+			reason: "value does not match regex pattern \"^0x[a-fA-F0-9]{40}$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return AccountReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// AccountReqMultiError is an error wrapping multiple validation errors
+// returned by AccountReq.ValidateAll() if the designated constraints aren't
+// met.
+type AccountReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AccountReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AccountReqMultiError) AllErrors() []error { return m }
+
+// AccountReqValidationError is the validation error returned by
+// AccountReq.Validate if the designated constraints aren't met.
+type AccountReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AccountReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AccountReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AccountReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AccountReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AccountReqValidationError) ErrorName() string { return "AccountReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AccountReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAccountReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AccountReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AccountReqValidationError{}
+
+var _AccountReq_Address_Pattern = regexp.MustCompile("^0x[a-fA-F0-9]{40}$")
+
+// Validate checks the field values on DropResp with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DropResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DropResp with the rules defined in
+// the proto definition for this message. If any rules are violated, the result
+// is a list of violation errors wrapped in DropRespMultiError, or nil if none
+// found.
+func (m *DropResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DropResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DropRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// DropRespMultiError is an error wrapping multiple validation errors returned
+// by DropResp.ValidateAll() if the designated constraints aren't met.
+type DropRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DropRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DropRespMultiError) AllErrors() []error { return m }
+
+// DropRespValidationError is the validation error returned by
+// DropResp.Validate if the designated constraints aren't met.
+type DropRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DropRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DropRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DropRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DropRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DropRespValidationError) ErrorName() string { return "DropRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DropRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDropResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DropRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DropRespValidationError{}
+
+// Validate checks the field values on PoolTxn with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *PoolTxn) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PoolTxn with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in PoolTxnMultiError, or nil if none
+// found.
+func (m *PoolTxn) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PoolTxn) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Hash
+
+	// no validation rules for Nonce
+
+	if all {
+		switch v := interface{}(m.GetRaw()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, PoolTxnValidationError{
+					field:  "Raw",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, PoolTxnValidationError{
+					field:  "Raw",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRaw()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return PoolTxnValidationError{
+				field:  "Raw",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return PoolTxnMultiError(errors)
+	}
+
+	return nil
+}
+
+// PoolTxnMultiError is an error wrapping multiple validation errors returned
+// by PoolTxn.ValidateAll() if the designated constraints aren't met.
+type PoolTxnMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PoolTxnMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PoolTxnMultiError) AllErrors() []error { return m }
+
+// PoolTxnValidationError is the validation error returned by PoolTxn.Validate
+// if the designated constraints aren't met.
+type PoolTxnValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PoolTxnValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PoolTxnValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PoolTxnValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PoolTxnValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PoolTxnValidationError) ErrorName() string { return "PoolTxnValidationError" }
+
+// Error satisfies the builtin error interface
+func (e PoolTxnValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPoolTxn.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PoolTxnValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PoolTxnValidationError{}
+
+// Validate checks the field values on ContentFromResp with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ContentFromResp) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ContentFromResp with the rules
+// defined in the proto definition for this message. If any rules are violated,
+// the result is a list of violation errors wrapped in
+// ContentFromRespMultiError, or nil if none found.
+func (m *ContentFromResp) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ContentFromResp) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetPending() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Pending[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Pending[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContentFromRespValidationError{
+					field:  fmt.Sprintf("Pending[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetQueued() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Queued[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContentFromRespValidationError{
+						field:  fmt.Sprintf("Queued[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContentFromRespValidationError{
+					field:  fmt.Sprintf("Queued[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ContentFromRespMultiError(errors)
+	}
+
+	return nil
+}
+
+// ContentFromRespMultiError is an error wrapping multiple validation errors
+// returned by ContentFromResp.ValidateAll() if the designated constraints
+// aren't met.
+type ContentFromRespMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ContentFromRespMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ContentFromRespMultiError) AllErrors() []error { return m }
+
+// ContentFromRespValidationError is the validation error returned by
+// ContentFromResp.Validate if the designated constraints aren't met.
+type ContentFromRespValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ContentFromRespValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ContentFromRespValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ContentFromRespValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ContentFromRespValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ContentFromRespValidationError) ErrorName() string { return "ContentFromRespValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ContentFromRespValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sContentFromResp.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ContentFromRespValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ContentFromRespValidationError{}
+
 // Validate checks the field values on TxnPoolStatusResp with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -29,6 +29,14 @@ type TxnPoolOperatorClient interface {
 	AddTxn(ctx context.Context, in *AddTxnReq, opts ...grpc.CallOption) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (TxnPoolOperator_SubscribeClient, error)
+	// DropTx removes the transaction, and the subsequent transactions of its sender, from the pool
+	DropTx(ctx context.Context, in *DropTxReq, opts ...grpc.CallOption) (*DropResp, error)
+	// DropAccount removes all the transactions of the account from the pool
+	DropAccount(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*DropResp, error)
+	// ContentFrom returns the pending and queued transactions of the account
+	ContentFrom(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*ContentFromResp, error)
+	// Clear removes all the transactions from the pool
+	Clear(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DropResp, error)
 }
 
 type txnPoolOperatorClient struct {
@@ -89,6 +97,42 @@ func (x *txnPoolOperatorSubscribeClient) Recv() (*TxPoolEvent, error) {
 	return m, nil
 }
 
+func (c *txnPoolOperatorClient) DropTx(ctx context.Context, in *DropTxReq, opts ...grpc.CallOption) (*DropResp, error) {
+	out := new(DropResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/DropTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) DropAccount(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*DropResp, error) {
+	out := new(DropResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/DropAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) ContentFrom(ctx context.Context, in *AccountReq, opts ...grpc.CallOption) (*ContentFromResp, error) {
+	out := new(ContentFromResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/ContentFrom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txnPoolOperatorClient) Clear(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DropResp, error) {
+	out := new(DropResp)
+	err := c.cc.Invoke(ctx, "/v1.TxnPoolOperator/Clear", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxnPoolOperatorServer is the server API for TxnPoolOperator service.
 // All implementations must embed UnimplementedTxnPoolOperatorServer
 // for forward compatibility
@@ -99,6 +143,14 @@ type TxnPoolOperatorServer interface {
 	AddTxn(context.Context, *AddTxnReq) (*AddTxnResp, error)
 	// Subscribe subscribes for new events in the txpool
 	Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error
+	// DropTx removes the transaction, and the subsequent transactions of its sender, from the pool
+	DropTx(context.Context, *DropTxReq) (*DropResp, error)
+	// DropAccount removes all the transactions of the account from the pool
+	DropAccount(context.Context, *AccountReq) (*DropResp, error)
+	// ContentFrom returns the pending and queued transactions of the account
+	ContentFrom(context.Context, *AccountReq) (*ContentFromResp, error)
+	// Clear removes all the transactions from the pool
+	Clear(context.Context, *emptypb.Empty) (*DropResp, error)
 	mustEmbedUnimplementedTxnPoolOperatorServer()
 }
 
@@ -115,6 +167,18 @@ func (UnimplementedTxnPoolOperatorServer) AddTxn(context.Context, *AddTxnReq) (*
 func (UnimplementedTxnPoolOperatorServer) Subscribe(*SubscribeRequest, TxnPoolOperator_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedTxnPoolOperatorServer) DropTx(context.Context, *DropTxReq) (*DropResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropTx not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) DropAccount(context.Context, *AccountReq) (*DropResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DropAccount not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) ContentFrom(context.Context, *AccountReq) (*ContentFromResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContentFrom not implemented")
+}
+func (UnimplementedTxnPoolOperatorServer) Clear(context.Context, *emptypb.Empty) (*DropResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Clear not implemented")
+}
 func (UnimplementedTxnPoolOperatorServer) mustEmbedUnimplementedTxnPoolOperatorServer() {}
 
 // UnsafeTxnPoolOperatorServer may be embedded to opt out of forward compatibility for this service.
@@ -185,6 +249,78 @@ func (x *txnPoolOperatorSubscribeServer) Send(m *TxPoolEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TxnPoolOperator_DropTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DropTxReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).DropTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/DropTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).DropTx(ctx, req.(*DropTxReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_DropAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).DropAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/DropAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).DropAccount(ctx, req.(*AccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_ContentFrom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).ContentFrom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/ContentFrom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).ContentFrom(ctx, req.(*AccountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TxnPoolOperator_Clear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxnPoolOperatorServer).Clear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.TxnPoolOperator/Clear",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxnPoolOperatorServer).Clear(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TxnPoolOperator_ServiceDesc is the grpc.ServiceDesc for TxnPoolOperator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -200,6 +336,22 @@ var TxnPoolOperator_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddTxn",
 			Handler:    _TxnPoolOperator_AddTxn_Handler,
 		},
+		{
+			MethodName: "DropTx",
+			Handler:    _TxnPoolOperator_DropTx_Handler,
+		},
+		{
+			MethodName: "DropAccount",
+			Handler:    _TxnPoolOperator_DropAccount_Handler,
+		},
+		{
+			MethodName: "ContentFrom",
+			Handler:    _TxnPoolOperator_ContentFrom_Handler,
+		},
+		{
+			MethodName: "Clear",
+			Handler:    _TxnPoolOperator_Clear_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
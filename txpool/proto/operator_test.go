@@ -82,6 +82,36 @@ func TestRequestValidation(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "DropTxReq: invalid hash",
+			req: &DropTxReq{
+				Hash: "0x1234",
+			},
+			valid:    false,
+			errorMsg: "invalid DropTxReq.Hash: value does not match regex pattern",
+		},
+		{
+			name: "DropTxReq: valid hash",
+			req: &DropTxReq{
+				Hash: "0x8c1e6e1f0c2e32e4e7eb7b1f5a4ed6d6b5b5e0a5f26fe7e4d2cb2a2c4a1b3d5e",
+			},
+			valid: true,
+		},
+		{
+			name: "AccountReq: empty address",
+			req: &AccountReq{
+				Address: "",
+			},
+			valid:    false,
+			errorMsg: "invalid AccountReq.Address: value does not match regex pattern",
+		},
+		{
+			name: "AccountReq: valid address",
+			req: &AccountReq{
+				Address: "0x9FC184A287e4BB51Eef4ecA81788eA10EF3f202f",
+			},
+			valid: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,6 +1,7 @@
 package txpool
 
 import (
+	"sort"
 	"sync/atomic"
 
 	"github.com/0xPolygon/polygon-edge/types"
@@ -58,3 +59,36 @@ func (p *TxPool) GetBaseFee() uint64 {
 func (p *TxPool) SetBaseFee(header *types.Header) {
 	atomic.StoreUint64(&p.baseFee, p.store.CalculateBaseFee(header))
 }
+
+// GetAccountTxs gets pending and queued transactions of the given account, ordered by nonce
+func (p *TxPool) GetAccountTxs(addr types.Address) (promoted, enqueued []*types.Transaction) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, nil
+	}
+
+	account.promoted.lock(false)
+	account.enqueued.lock(false)
+
+	defer func() {
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	promoted = sortedByNonce(account.promoted.queue)
+	enqueued = sortedByNonce(account.enqueued.queue)
+
+	return promoted, enqueued
+}
+
+// sortedByNonce returns a copy of the given transactions sorted by nonce
+func sortedByNonce(txs []*types.Transaction) []*types.Transaction {
+	sorted := make([]*types.Transaction, len(txs))
+	copy(sorted, txs)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Nonce < sorted[j].Nonce
+	})
+
+	return sorted
+}
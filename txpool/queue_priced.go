@@ -50,6 +50,26 @@ func (q *pricedQueue) length() int {
 	return q.queue.Len()
 }
 
+// removeAccount removes the transaction of the given account from the queue
+func (q *pricedQueue) removeAccount(addr types.Address) bool {
+	kept := q.queue.txs[:0]
+
+	for _, tx := range q.queue.txs {
+		if tx.From != addr {
+			kept = append(kept, tx)
+		}
+	}
+
+	if len(kept) == len(q.queue.txs) {
+		return false
+	}
+
+	q.queue.txs = kept
+	heap.Init(q.queue)
+
+	return true
+}
+
 // transactions sorted by gas price (descending)
 type maxPriceQueue struct {
 	baseFee *big.Int
@@ -0,0 +1,59 @@
+package txpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/polygon-edge/types"
+)
+
+// senderLimiter limits the number of incoming transactions
+// per sender within each one second window
+type senderLimiter struct {
+	sync.Mutex
+
+	// maximum number of transactions per sender per second, 0 means unlimited
+	limit uint64
+
+	// start of the current window (unix seconds)
+	window int64
+
+	// number of transactions received from each sender in the current window
+	counts map[types.Address]uint64
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
+}
+
+func newSenderLimiter(limit uint64) *senderLimiter {
+	return &senderLimiter{
+		limit:  limit,
+		counts: make(map[types.Address]uint64),
+		now:    time.Now,
+	}
+}
+
+// allow registers an incoming transaction of the sender and returns false
+// if the sender already reached the limit in the current window. [thread-safe]
+func (l *senderLimiter) allow(sender types.Address) bool {
+	if l.limit == 0 {
+		return true
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if now := l.now().Unix(); now != l.window {
+		// a new window starts, the counters of the previous one are dropped
+		l.window = now
+		l.counts = make(map[types.Address]uint64)
+	}
+
+	if l.counts[sender] >= l.limit {
+		return false
+	}
+
+	l.counts[sender]++
+
+	return true
+}
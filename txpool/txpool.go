@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/0xPolygon/polygon-edge/blockchain"
 	"github.com/0xPolygon/polygon-edge/chain"
+	"github.com/0xPolygon/polygon-edge/crypto"
 	"github.com/0xPolygon/polygon-edge/forkmanager"
 	"github.com/0xPolygon/polygon-edge/network"
 	"github.com/0xPolygon/polygon-edge/state"
@@ -37,6 +39,11 @@ const (
 
 	pruningCooldown = 5000 * time.Millisecond
 
+	// misbehaviour score penalties of the peers gossiping
+	// malformed or invalid transactions
+	malformedGossipTxPenalty uint64 = 10
+	invalidGossipTxPenalty   uint64 = 1
+
 	// txPoolMetrics is a prefix used for txpool-related metrics
 	txPoolMetrics = "txpool"
 )
@@ -65,6 +72,10 @@ var (
 	ErrNonceExistsInPool       = errors.New("tx with the same nonce is already present")
 	ErrReplacementUnderpriced  = errors.New("replacement tx underpriced")
 	ErrDynamicTxNotAllowed     = errors.New("dynamic tx not allowed currently")
+	ErrSenderRateLimited       = errors.New("too many transactions from the sender per second")
+	ErrTxNotFound              = errors.New("transaction not found in the pool")
+	ErrAccountNotFound         = errors.New("account not found in the pool")
+	ErrUnprotectedTx           = errors.New("only replay-protected (EIP-155) transactions allowed")
 )
 
 // indicates origin of a transaction
@@ -99,11 +110,33 @@ type signer interface {
 	Sender(tx *types.Transaction) (types.Address, error)
 }
 
+// peerScorer penalizes the peers which gossip malformed or invalid transactions
+type peerScorer interface {
+	PenalizePeer(peerID peer.ID, penalty uint64, reason string)
+}
+
 type Config struct {
 	PriceLimit         uint64
 	MaxSlots           uint64
 	MaxAccountEnqueued uint64
 	ChainID            *big.Int
+	Ordering           OrderingPolicy
+
+	// MaxSenderTxsPerSecond is the maximum number of incoming
+	// transactions accepted per sender per second (0 for unlimited)
+	MaxSenderTxsPerSecond uint64
+
+	// RejectUnprotectedTxs rejects the legacy transactions signed without
+	// the chain ID (pre-EIP-155), which can be replayed from other chains
+	RejectUnprotectedTxs bool
+
+	// AllowUnprotectedLocalTxs exempts the transactions
+	// added through the JSON-RPC/gRPC endpoints from RejectUnprotectedTxs
+	AllowUnprotectedLocalTxs bool
+
+	// UnprotectedTxsAllowlist contains the senders exempted from RejectUnprotectedTxs,
+	// e.g. the deployers of the deterministic deployment proxies
+	UnprotectedTxsAllowlist []types.Address
 }
 
 /* All requests are passed to the main loop
@@ -152,22 +185,44 @@ type TxPool struct {
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
-	executables *pricedQueue
+	// all the primaries sorted by the ordering policy
+	executables executablesQueue
+	// executablesLock guards the executables queue, which is consumed by the block building
+	// while the transactions can be removed concurrently through the pool operator.
+	// It is always taken after the account locks
+	executablesLock sync.Mutex
+
+	// ordering policy of the executable transactions
+	ordering OrderingPolicy
 
 	// lookup map keeping track of all
 	// transactions present in the pool
 	index lookupMap
 
+	// bundles submitted to the pool, which are
+	// included into blocks atomically
+	bundles *bundleStore
+
 	// networking stack
 	topic *network.Topic
 
+	// scores the peers gossiping invalid transactions
+	peerScorer peerScorer
+
+	// limits the incoming transactions per sender
+	senderLimiter *senderLimiter
+
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// policy for the replay-unprotected (pre-EIP-155) transactions
+	rejectUnprotectedTxs     bool
+	allowUnprotectedLocalTxs bool
+	unprotectedTxsAllowlist  map[types.Address]struct{}
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	promoteReqCh chan promoteRequest
@@ -207,16 +262,28 @@ func NewTxPool(
 	network *network.Server,
 	config *Config,
 ) (*TxPool, error) {
+	ordering, err := ParseOrderingPolicy(string(config.Ordering))
+	if err != nil {
+		return nil, err
+	}
+
 	pool := &TxPool{
-		logger:      logger.Named("txpool"),
-		forks:       forks,
-		store:       store,
-		executables: newPricesQueue(0, nil),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
-		chainID:     config.ChainID,
+		logger:        logger.Named("txpool"),
+		forks:         forks,
+		store:         store,
+		executables:   newPricesQueue(0, nil),
+		ordering:      ordering,
+		accounts:      accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
+		index:         newLookupMap(),
+		bundles:       newBundleStore(),
+		senderLimiter: newSenderLimiter(config.MaxSenderTxsPerSecond),
+		gauge:         slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit:    config.PriceLimit,
+		chainID:       config.ChainID,
+
+		rejectUnprotectedTxs:     config.RejectUnprotectedTxs,
+		allowUnprotectedLocalTxs: config.AllowUnprotectedLocalTxs,
+		unprotectedTxsAllowlist:  make(map[types.Address]struct{}, len(config.UnprotectedTxsAllowlist)),
 
 		//	main loop channels
 		promoteReqCh: make(chan promoteRequest),
@@ -224,6 +291,10 @@ func NewTxPool(
 		shutdownCh:   make(chan struct{}),
 	}
 
+	for _, addr := range config.UnprotectedTxsAllowlist {
+		pool.unprotectedTxsAllowlist[addr] = struct{}{}
+	}
+
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
@@ -239,6 +310,7 @@ func NewTxPool(
 		}
 
 		pool.topic = topic
+		pool.peerScorer = network
 	}
 
 	if grpcServer != nil {
@@ -339,18 +411,25 @@ func (p *TxPool) Prepare() {
 	primaries := p.accounts.getPrimaries()
 
 	// create new executables queue with base fee and initial transactions (primaries)
-	p.executables = newPricesQueue(p.GetBaseFee(), primaries)
+	executables := newExecutablesQueue(p.ordering, p.GetBaseFee(), primaries, p.index.arrival)
+
+	p.executablesLock.Lock()
+	p.executables = executables
+	p.executablesLock.Unlock()
 }
 
-// Peek returns the best-price selected
-// transaction ready for execution.
+// Peek returns the next transaction ready for execution,
+// selected by the ordering policy (best-price by default).
 func (p *TxPool) Peek() *types.Transaction {
 	// Popping the executables queue
 	// does not remove the actual tx
 	// from the pool.
 	// The executables queue just provides
 	// insight into which account has the
-	// highest priced tx (head of promoted queue)
+	// next tx by the ordering policy (head of promoted queue)
+	p.executablesLock.Lock()
+	defer p.executablesLock.Unlock()
+
 	return p.executables.pop()
 }
 
@@ -370,6 +449,11 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 		account.promoted.unlock()
 	}()
 
+	// the transaction was removed from the pool while it was being executed
+	if head := account.promoted.peek(); head == nil || head.Hash != tx.Hash {
+		return
+	}
+
 	// pop the top most promoted tx
 	account.promoted.pop()
 
@@ -387,13 +471,21 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 
 	// update executables
 	if tx := account.promoted.peek(); tx != nil {
+		p.executablesLock.Lock()
 		p.executables.push(tx)
+		p.executablesLock.Unlock()
 	}
 }
 
 // Drop clears the entire account associated with the given transaction
 // and reverts its next (expected) nonce.
 func (p *TxPool) Drop(tx *types.Transaction) {
+	// the transaction was already removed from the pool while it was being executed,
+	// together with the account transactions and the nonce rollback
+	if _, ok := p.index.get(tx.Hash); !ok {
+		return
+	}
+
 	account := p.accounts.get(tx.From)
 	p.dropAccount(account, tx.Nonce, tx)
 }
@@ -452,6 +544,160 @@ func (p *TxPool) dropAccount(account *account, nextNonce uint64, tx *types.Trans
 	}
 }
 
+// RemoveTx removes the transaction with the given hash from the pool, together with
+// all the subsequent transactions of its sender, which can not be executed without it.
+// Returns the removed transactions
+func (p *TxPool) RemoveTx(hash types.Hash) ([]*types.Transaction, error) {
+	tx, ok := p.index.get(hash)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTxNotFound, hash)
+	}
+
+	account := p.accounts.get(tx.From)
+	if account == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, tx.From)
+	}
+
+	return p.removeAccountTxs(account, tx.Nonce), nil
+}
+
+// RemoveAccount removes all the transactions of the given account from the pool.
+// Returns the removed transactions
+func (p *TxPool) RemoveAccount(addr types.Address) ([]*types.Transaction, error) {
+	account := p.accounts.get(addr)
+	if account == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, addr)
+	}
+
+	return p.removeAccountTxs(account, 0), nil
+}
+
+// RemoveAll removes all the transactions from the pool. Returns the removed transactions
+func (p *TxPool) RemoveAll() []*types.Transaction {
+	removed := make([]*types.Transaction, 0)
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		if account, ok := value.(*account); ok {
+			removed = append(removed, p.removeAccountTxs(account, 0)...)
+		}
+
+		return true
+	})
+
+	return removed
+}
+
+// ResyncAccounts removes all the transactions of the given accounts, or of every account
+// if none is given, and sets their next expected nonce to the nonce in the current state.
+// It is used when the state changes without a new block, e.g. when the head is rewound.
+// Returns the removed transactions
+func (p *TxPool) ResyncAccounts(addrs ...types.Address) []*types.Transaction {
+	if len(addrs) == 0 {
+		p.accounts.Range(func(key, _ interface{}) bool {
+			if addr, ok := key.(types.Address); ok {
+				addrs = append(addrs, addr)
+			}
+
+			return true
+		})
+	}
+
+	stateRoot := p.store.Header().StateRoot
+	removed := make([]*types.Transaction, 0)
+
+	for _, addr := range addrs {
+		account := p.accounts.get(addr)
+		if account == nil {
+			continue
+		}
+
+		removed = append(removed, p.removeAccountTxs(account, 0)...)
+		account.setNonce(p.store.GetNonce(stateRoot, addr))
+	}
+
+	return removed
+}
+
+// removeAccountTxs removes all the promoted and enqueued transactions of the account
+// with nonce equal or greater than the given one, signals EventType_DROPPED for each
+// of them, clears their slots and metrics and rolls back the account nonce if needed
+func (p *TxPool) removeAccountTxs(account *account, fromNonce uint64) []*types.Transaction {
+	account.promoted.lock(true)
+	account.enqueued.lock(true)
+	account.nonceToTx.lock()
+
+	defer func() {
+		account.nonceToTx.unlock()
+		account.enqueued.unlock()
+		account.promoted.unlock()
+	}()
+
+	// splits the queue transactions to the kept and the removed ones
+	split := func(queue *accountQueue) (removed []*types.Transaction) {
+		all := make([]*types.Transaction, queue.length())
+		copy(all, queue.clear())
+
+		for _, tx := range all {
+			if tx.Nonce < fromNonce {
+				queue.push(tx)
+			} else {
+				removed = append(removed, tx)
+			}
+		}
+
+		return removed
+	}
+
+	removedPromoted := split(account.promoted)
+	removedEnqueued := split(account.enqueued)
+
+	// the removed promoted transactions need to be executed again
+	// by the sender, so the next expected nonce is rolled back
+	for _, tx := range removedPromoted {
+		if tx.Nonce < account.getNonce() {
+			account.setNonce(tx.Nonce)
+		}
+	}
+
+	removed := make([]*types.Transaction, 0, len(removedPromoted)+len(removedEnqueued))
+	removed = append(removed, removedPromoted...)
+	removed = append(removed, removedEnqueued...)
+
+	account.nonceToTx.remove(removed...)
+	p.index.remove(removed...)
+	p.gauge.decrease(slotsRequired(removed...))
+	p.updatePending(-1 * int64(len(removedPromoted)))
+
+	// a block can be built at the same time, so the removed primary is replaced by the
+	// current one. The primary taken by the block building is not in the queue,
+	// and it is skipped by Pop if it was removed
+	if len(removedPromoted) > 0 {
+		p.executablesLock.Lock()
+
+		if p.executables.removeAccount(removedPromoted[0].From) {
+			if primary := account.promoted.peek(); primary != nil {
+				p.executables.push(primary)
+			}
+		}
+
+		p.executablesLock.Unlock()
+	}
+
+	for _, tx := range removed {
+		p.eventManager.signalEvent(proto.EventType_DROPPED, tx.Hash)
+	}
+
+	if p.logger.IsDebug() && len(removed) > 0 {
+		p.logger.Debug("removed account txs",
+			"num", len(removed),
+			"from_nonce", fromNonce,
+			"next_nonce", account.getNonce(),
+		)
+	}
+
+	return removed
+}
+
 // Demote excludes an account from being further processed during block building
 // due to a recoverable error. If an account has been demoted too many times (maxAccountDemotions),
 // it is Dropped instead.
@@ -494,6 +740,7 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// Grab the latest state root now that the block has been inserted
 	stateRoot := p.store.Header().StateRoot
 	stateNonces := make(map[types.Address]uint64)
+	minedTxs := make(map[types.Hash]struct{})
 
 	// discover latest (next) nonces for all accounts
 	for _, header := range event.NewChain {
@@ -507,6 +754,10 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		// remove mined txs from the lookup map
 		p.index.remove(block.Transactions...)
 
+		for _, tx := range block.Transactions {
+			minedTxs[tx.Hash] = struct{}{}
+		}
+
 		// Extract latest nonces
 		for _, tx := range block.Transactions {
 			var err error
@@ -536,9 +787,13 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 		}
 	}
 
-	// update base fee
+	// update base fee and prune included or expired bundles
 	if ln := len(event.NewChain); ln > 0 {
 		p.SetBaseFee(event.NewChain[ln-1])
+
+		if pruned := p.bundles.prune(event.NewChain[ln-1].Number, minedTxs); pruned > 0 {
+			metrics.SetGauge([]string{txPoolMetrics, "bundles"}, float32(p.bundles.length()))
+		}
 	}
 
 	// reset accounts with the new state
@@ -552,7 +807,7 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 
 // validateTx ensures the transaction conforms to specific
 // constraints before entering the pool.
-func (p *TxPool) validateTx(tx *types.Transaction) error {
+func (p *TxPool) validateTx(origin txOrigin, tx *types.Transaction) error {
 	// Check the transaction type. State transactions are not expected to be added to the pool
 	if tx.Type == types.StateTx {
 		metrics.IncrCounter([]string{txPoolMetrics, "invalid_tx_type"}, 1)
@@ -599,6 +854,13 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		tx.From = from
 	}
 
+	// Check if the transaction can be replayed from other chains
+	if !crypto.IsReplayProtected(tx) && !p.isUnprotectedTxAllowed(origin, from) {
+		metrics.IncrCounter([]string{txPoolMetrics, "unprotected_txs"}, 1)
+
+		return ErrUnprotectedTx
+	}
+
 	// Grab current block number
 	currentHeader := p.store.Header()
 	currentBlockNumber := currentHeader.Number
@@ -764,6 +1026,22 @@ func (p *TxPool) pruneAccountsWithNonceHoles() {
 	)
 }
 
+// isUnprotectedTxAllowed returns true if the replay-unprotected (pre-EIP-155)
+// transaction with the given origin and sender can be added to the pool
+func (p *TxPool) isUnprotectedTxAllowed(origin txOrigin, from types.Address) bool {
+	if !p.rejectUnprotectedTxs {
+		return true
+	}
+
+	if origin == local && p.allowUnprotectedLocalTxs {
+		return true
+	}
+
+	_, ok := p.unprotectedTxsAllowlist[from]
+
+	return ok
+}
+
 // addTx is the main entry point to the pool
 // for all new transactions. If the call is
 // successful, an account is created for this address
@@ -774,10 +1052,16 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	}
 
 	// validate incoming tx
-	if err := p.validateTx(tx); err != nil {
+	if err := p.validateTx(origin, tx); err != nil {
 		return err
 	}
 
+	if !p.senderLimiter.allow(tx.From) {
+		metrics.IncrCounter([]string{txPoolMetrics, "rate_limited_txs"}, 1)
+
+		return fmt.Errorf("%w: %s", ErrSenderRateLimited, tx.From)
+	}
+
 	// add chainID to the tx - only dynamic fee tx
 	if tx.Type == types.DynamicFeeTx {
 		tx.ChainID = p.chainID
@@ -923,7 +1207,7 @@ func (p *TxPool) handlePromoteRequest(req promoteRequest) {
 
 // addGossipTx handles receiving transactions
 // gossiped by the network.
-func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
+func (p *TxPool) addGossipTx(obj interface{}, from peer.ID) {
 	if !p.sealing.Load() {
 		return
 	}
@@ -938,6 +1222,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// Verify that the gossiped transaction message is not empty
 	if raw == nil || raw.Raw == nil {
 		p.logger.Error("malformed gossip transaction message received")
+		p.penalizePeer(from, malformedGossipTxPenalty, "malformed gossip transaction message")
 
 		return
 	}
@@ -947,6 +1232,7 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 	// decode tx
 	if err := tx.UnmarshalRLP(raw.Raw.Value); err != nil {
 		p.logger.Error("failed to decode broadcast tx", "err", err)
+		p.penalizePeer(from, malformedGossipTxPenalty, "undecodable gossip transaction")
 
 		return
 	}
@@ -962,9 +1248,49 @@ func (p *TxPool) addGossipTx(obj interface{}, _ peer.ID) {
 		}
 
 		p.logger.Error("failed to add broadcast tx", "err", err, "hash", tx.Hash.String())
+
+		if isInvalidGossipTx(err) {
+			p.penalizePeer(from, invalidGossipTxPenalty, err.Error())
+		}
 	}
 }
 
+// penalizePeer increases the misbehaviour score of the peer gossiping bad transactions
+func (p *TxPool) penalizePeer(from peer.ID, penalty uint64, reason string) {
+	metrics.IncrCounter([]string{txPoolMetrics, "invalid_gossip_txs"}, 1)
+
+	if p.peerScorer != nil {
+		p.peerScorer.PenalizePeer(from, penalty, reason)
+	}
+}
+
+// isInvalidGossipTx returns true if the error means the gossiped transaction is invalid by itself.
+// Errors which depend on the local pool, state view or configuration (nonce, balance, pool capacity,
+// price limit and base fee) are not considered, since honest peers can relay such transactions too
+func isInvalidGossipTx(err error) bool {
+	for _, invalidErr := range []error{
+		ErrIntrinsicGas,
+		ErrBlockLimitExceeded,
+		ErrNegativeValue,
+		ErrExtractSignature,
+		ErrInvalidSender,
+		ErrOversizedData,
+		ErrInvalidTxType,
+		ErrTxTypeNotSupported,
+		ErrTipAboveFeeCap,
+		ErrTipVeryHigh,
+		ErrFeeCapVeryHigh,
+		ErrDynamicTxNotAllowed,
+		runtime.ErrMaxCodeSizeExceeded,
+	} {
+		if errors.Is(err, invalidErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // resetAccounts updates existing accounts with the new nonce and prunes stale transactions.
 func (p *TxPool) resetAccounts(stateNonces map[types.Address]uint64) {
 	if len(stateNonces) == 0 {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -637,6 +638,187 @@ func TestAddGossipTx(t *testing.T) {
 	})
 }
 
+type mockPeerScorer struct {
+	penalties map[peer.ID]uint64
+}
+
+func (m *mockPeerScorer) PenalizePeer(peerID peer.ID, penalty uint64, _ string) {
+	if m.penalties == nil {
+		m.penalties = make(map[peer.ID]uint64)
+	}
+
+	m.penalties[peerID] += penalty
+}
+
+func TestAddGossipTx_PenalizesPeers(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+
+	newPool := func(t *testing.T) (*TxPool, *mockPeerScorer) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		scorer := &mockPeerScorer{}
+		pool.peerScorer = scorer
+
+		pool.SetSigner(&mockSigner{})
+		pool.SetSealing(true)
+
+		return pool, scorer
+	}
+
+	gossipTx := func(tx *types.Transaction) *proto.Txn {
+		return &proto.Txn{Raw: &any.Any{Value: tx.MarshalRLP()}}
+	}
+
+	t.Run("malformed message", func(t *testing.T) {
+		t.Parallel()
+
+		pool, scorer := newPool(t)
+
+		pool.addGossipTx(&proto.Txn{}, from)
+		pool.addGossipTx(&proto.Txn{Raw: &any.Any{Value: []byte{0x1, 0x2}}}, from)
+
+		assert.Equal(t, 2*malformedGossipTxPenalty, scorer.penalties[from])
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		t.Parallel()
+
+		pool, scorer := newPool(t)
+
+		tx := newTx(addr1, 0, 1)
+		tx.Gas = 1
+
+		pool.addGossipTx(gossipTx(tx), from)
+
+		assert.Equal(t, invalidGossipTxPenalty, scorer.penalties[from])
+	})
+
+	t.Run("transaction rejected by the local state is not penalized", func(t *testing.T) {
+		t.Parallel()
+
+		pool, scorer := newPool(t)
+
+		tx := newTx(addr1, 0, 1)
+		require.NoError(t, pool.addTx(local, tx))
+
+		// already known
+		pool.addGossipTx(gossipTx(tx), from)
+
+		// nonce too low
+		pool.getOrCreateAccount(addr2).setNonce(5)
+		pool.addGossipTx(gossipTx(newTx(addr2, 1, 1)), from)
+
+		// below the local price limit
+		underpriced := newTx(addr3, 0, 1)
+		underpriced.GasPrice.SetUint64(0)
+		pool.addGossipTx(gossipTx(underpriced), from)
+
+		assert.Empty(t, scorer.penalties)
+	})
+}
+
+func TestAddTx_SenderRateLimit(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	require.NoError(t, err)
+
+	pool.SetSigner(&mockSigner{})
+
+	now := time.Unix(100, 0)
+	pool.senderLimiter = newSenderLimiter(2)
+	pool.senderLimiter.now = func() time.Time { return now }
+
+	assert.NoError(t, pool.addTx(local, newTx(addr1, 0, 1)))
+	assert.NoError(t, pool.addTx(gossip, newTx(addr1, 1, 1)))
+	assert.ErrorIs(t, pool.addTx(local, newTx(addr1, 2, 1)), ErrSenderRateLimited)
+
+	// other senders are not affected
+	assert.NoError(t, pool.addTx(local, newTx(addr2, 3, 1)))
+
+	// the limit resets in the next second
+	now = now.Add(time.Second)
+
+	assert.NoError(t, pool.addTx(local, newTx(addr1, 2, 1)))
+}
+
+func TestAddTx_UnprotectedTxs(t *testing.T) {
+	t.Parallel()
+
+	newUnprotectedTx := func(addr types.Address, nonce uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.V = big.NewInt(27)
+
+		return tx
+	}
+
+	newPool := func(t *testing.T, config func(pool *TxPool)) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		require.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+		config(pool)
+
+		return pool
+	}
+
+	t.Run("accepted if rejection is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t, func(pool *TxPool) {})
+
+		assert.NoError(t, pool.addTx(gossip, newUnprotectedTx(addr1, 0)))
+	})
+
+	t.Run("rejected if rejection is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t, func(pool *TxPool) {
+			pool.rejectUnprotectedTxs = true
+		})
+
+		assert.ErrorIs(t, pool.addTx(local, newUnprotectedTx(addr1, 0)), ErrUnprotectedTx)
+		assert.ErrorIs(t, pool.addTx(gossip, newUnprotectedTx(addr1, 0)), ErrUnprotectedTx)
+
+		// replay-protected txs are not affected
+		protectedTx := newTx(addr1, 0, 1)
+		protectedTx.V = big.NewInt(235)
+
+		assert.NoError(t, pool.addTx(local, protectedTx))
+	})
+
+	t.Run("local txs exempted", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t, func(pool *TxPool) {
+			pool.rejectUnprotectedTxs = true
+			pool.allowUnprotectedLocalTxs = true
+		})
+
+		assert.ErrorIs(t, pool.addTx(gossip, newUnprotectedTx(addr1, 0)), ErrUnprotectedTx)
+		assert.NoError(t, pool.addTx(local, newUnprotectedTx(addr1, 0)))
+	})
+
+	t.Run("allowlisted senders exempted", func(t *testing.T) {
+		t.Parallel()
+
+		pool := newPool(t, func(pool *TxPool) {
+			pool.rejectUnprotectedTxs = true
+			pool.unprotectedTxsAllowlist[addr2] = struct{}{}
+		})
+
+		assert.ErrorIs(t, pool.addTx(gossip, newUnprotectedTx(addr1, 0)), ErrUnprotectedTx)
+		assert.NoError(t, pool.addTx(gossip, newUnprotectedTx(addr2, 0)))
+	})
+}
+
 func TestDropKnownGossipTx(t *testing.T) {
 	t.Parallel()
 
@@ -1943,6 +2125,183 @@ func TestDemote(t *testing.T) {
 	})
 }
 
+func TestRemoveTxs(t *testing.T) {
+	t.Parallel()
+
+	// promotes nonces [0, 3) of the account and enqueues nonces 5 and 6
+	setupPool := func(t *testing.T) (*TxPool, []*types.Transaction) {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		txs := []*types.Transaction{
+			newTx(addr1, 0, 1),
+			newTx(addr1, 1, 1),
+			newTx(addr1, 2, 1),
+			newTx(addr1, 5, 1),
+			newTx(addr1, 6, 1),
+		}
+
+		assert.NoError(t, pool.addTx(local, txs[0]))
+
+		req := <-pool.promoteReqCh
+
+		for _, tx := range txs[1:] {
+			assert.NoError(t, pool.addTx(local, tx))
+		}
+
+		pool.handlePromoteRequest(req)
+
+		assert.Equal(t, uint64(3), pool.accounts.get(addr1).promoted.length())
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+
+		return pool, txs
+	}
+
+	t.Run("get account txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		promoted, enqueued := pool.GetAccountTxs(addr1)
+		assert.Equal(t, txs[:3], promoted)
+		assert.Equal(t, txs[3:], enqueued)
+
+		promoted, enqueued = pool.GetAccountTxs(addr2)
+		assert.Empty(t, promoted)
+		assert.Empty(t, enqueued)
+	})
+
+	t.Run("remove tx with subsequent txs", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		removed, err := pool.RemoveTx(txs[1].Hash)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, txs[1:], removed)
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(1), acc.getNonce())
+		assert.Equal(t, uint64(1), acc.promoted.length())
+		assert.Equal(t, uint64(0), acc.enqueued.length())
+		assert.Equal(t, 1, len(acc.nonceToTx.mapping))
+		assert.Equal(t, uint64(1), pool.gauge.read())
+		assert.Equal(t, int64(1), pool.pending)
+
+		for _, tx := range removed {
+			_, exists := pool.index.get(tx.Hash)
+			assert.False(t, exists)
+		}
+
+		_, err = pool.RemoveTx(txs[1].Hash)
+		assert.ErrorIs(t, err, ErrTxNotFound)
+
+		// the removed nonce can be used again
+		assert.NoError(t, pool.addTx(local, newTx(addr1, 1, 2)))
+	})
+
+	t.Run("remove account", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		removed, err := pool.RemoveAccount(addr1)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, txs, removed)
+
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+		assert.Equal(t, int64(0), pool.pending)
+
+		_, err = pool.RemoveAccount(addr2)
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+
+	t.Run("remove all", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		tx := newTx(addr2, 3, 1)
+		assert.NoError(t, pool.addTx(local, tx))
+
+		removed := pool.RemoveAll()
+		assert.ElementsMatch(t, append(txs, tx), removed)
+		assert.Equal(t, uint64(0), pool.gauge.read())
+		assert.Equal(t, uint64(0), pool.accounts.get(addr2).enqueued.length())
+	})
+
+	t.Run("resync accounts", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		// the state nonce is 0, the pool expected the nonce 3
+		removed := pool.ResyncAccounts()
+		assert.ElementsMatch(t, txs, removed)
+
+		acc := pool.accounts.get(addr1)
+		assert.Equal(t, uint64(0), acc.getNonce())
+		assert.Equal(t, uint64(0), acc.promoted.length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		assert.Empty(t, pool.ResyncAccounts(addr1, addr2))
+	})
+
+	t.Run("remove during block building", func(t *testing.T) {
+		t.Parallel()
+
+		pool, txs := setupPool(t)
+
+		tx := newTx(addr2, 0, 1)
+		assert.NoError(t, pool.addTx(local, tx))
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+		pool.Prepare()
+
+		// the primary of addr1 is being executed while both accounts are removed
+		executed := pool.Peek()
+		if executed.From != addr1 {
+			executed = pool.Peek()
+		}
+
+		assert.Equal(t, txs[0], executed)
+
+		_, err := pool.RemoveAccount(addr1)
+		assert.NoError(t, err)
+
+		_, err = pool.RemoveAccount(addr2)
+		assert.NoError(t, err)
+
+		// the removed transactions are neither popped twice nor returned for execution
+		pool.Pop(executed)
+		assert.Nil(t, pool.Peek())
+
+		assert.Equal(t, uint64(0), pool.gauge.read())
+		assert.Equal(t, int64(0), pool.pending)
+
+		pool.Drop(executed)
+		assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
+
+		// the primary of the partially removed account is kept in the queue
+		pool, txs = setupPool(t)
+		pool.Prepare()
+
+		_, err = pool.RemoveTx(txs[1].Hash)
+		assert.NoError(t, err)
+
+		executed = pool.Peek()
+		assert.Equal(t, txs[0], executed)
+
+		pool.Pop(executed)
+		assert.Nil(t, pool.Peek())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+	})
+}
+
 func Test_updateAccountSkipsCounts(t *testing.T) {
 	t.Parallel()
 
@@ -2166,7 +2525,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.Input = input
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			runtime.ErrMaxCodeSizeExceeded,
 		)
 	})
@@ -2186,7 +2545,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasPrice = new(big.Int).SetUint64(pool.GetBaseFee())
 
 		assert.NoError(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			runtime.ErrMaxCodeSizeExceeded,
 		)
 	})
@@ -2201,7 +2560,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasFeeCap = big.NewInt(1100)
 		tx.GasTipCap = big.NewInt(10)
 
-		assert.NoError(t, pool.validateTx(signTx(tx)))
+		assert.NoError(t, pool.validateTx(local, signTx(tx)))
 	})
 
 	t.Run("eip-1559 tx (gas fee cap less than base fee)", func(t *testing.T) {
@@ -2215,7 +2574,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = big.NewInt(10)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			ErrUnderpriced,
 		)
 	})
@@ -2231,7 +2590,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = big.NewInt(100000)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			ErrTipAboveFeeCap,
 		)
 	})
@@ -2250,7 +2609,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		signedTx.GasTipCap = nil
 
 		assert.ErrorIs(t,
-			pool.validateTx(signedTx),
+			pool.validateTx(local, signedTx),
 			ErrUnderpriced,
 		)
 
@@ -2262,7 +2621,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		signedTx.GasFeeCap = nil
 
 		assert.ErrorIs(t,
-			pool.validateTx(signedTx),
+			pool.validateTx(local, signedTx),
 			ErrUnderpriced,
 		)
 	})
@@ -2280,7 +2639,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasFeeCap = new(big.Int).SetBit(new(big.Int), bitLength, 1)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			ErrFeeCapVeryHigh,
 		)
 
@@ -2290,7 +2649,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = new(big.Int).SetBit(new(big.Int), bitLength, 1)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			ErrTipVeryHigh,
 		)
 	})
@@ -2307,7 +2666,7 @@ func Test_TxPool_validateTx(t *testing.T) {
 		tx.GasTipCap = big.NewInt(100000)
 
 		assert.ErrorIs(t,
-			pool.validateTx(signTx(tx)),
+			pool.validateTx(local, signTx(tx)),
 			ErrTxTypeNotSupported,
 		)
 	})
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/0xPolygon/polygon-edge/helper/keccak"
+)
+
+// Bundle is an ordered list of transactions that must be included
+// in a block atomically (all of them, in the given order, or none)
+type Bundle struct {
+	// Hash is the bundle identifier, computed from the transaction hashes
+	Hash Hash
+
+	// Txs are the bundle transactions
+	Txs []*Transaction
+
+	// MinBlock is the first block number the bundle can be included in
+	MinBlock uint64
+
+	// MaxBlock is the last block number the bundle can be included in
+	MaxBlock uint64
+}
+
+// ComputeHash computes the bundle hash as the keccak256 of the concatenated transaction hashes
+// followed by the block range. Transaction hashes need to be computed beforehand.
+func (b *Bundle) ComputeHash() *Bundle {
+	buf := make([]byte, 0, len(b.Txs)*HashLength+16)
+	for _, tx := range b.Txs {
+		buf = append(buf, tx.Hash.Bytes()...)
+	}
+
+	buf = binary.BigEndian.AppendUint64(buf, b.MinBlock)
+	buf = binary.BigEndian.AppendUint64(buf, b.MaxBlock)
+
+	b.Hash = BytesToHash(keccak.Keccak256(nil, buf))
+
+	return b
+}
+
+// IsValidFor returns true if the bundle can be included in the block with the given number
+func (b *Bundle) IsValidFor(blockNumber uint64) bool {
+	return blockNumber >= b.MinBlock && blockNumber <= b.MaxBlock
+}
+
+// IsExpired returns true if the bundle can not be included in any block after the given one
+func (b *Bundle) IsExpired(blockNumber uint64) bool {
+	return blockNumber >= b.MaxBlock
+}
+
+// Gas returns the sum of the gas limits of all the bundle transactions
+func (b *Bundle) Gas() uint64 {
+	gas := uint64(0)
+	for _, tx := range b.Txs {
+		gas += tx.Gas
+	}
+
+	return gas
+}